@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,15 +15,21 @@ import (
 
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
-	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/faizp/zenlist/backend/go-graphql/graph"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/caldav"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/calendar"
 	"github.com/faizp/zenlist/backend/go-graphql/internal/config"
 	"github.com/faizp/zenlist/backend/go-graphql/internal/db"
 	"github.com/faizp/zenlist/backend/go-graphql/internal/db/repo"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/export"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/graphql/extension"
 	"github.com/faizp/zenlist/backend/go-graphql/internal/graphql/middleware"
 	platformlogger "github.com/faizp/zenlist/backend/go-graphql/internal/platform/logger"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/rest"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/rpc"
 	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/webhook"
 	"github.com/joho/godotenv"
 	"github.com/vektah/gqlparser/v2/gqlerror"
 )
@@ -37,6 +44,9 @@ func main() {
 	}
 
 	log := platformlogger.New(cfg.AppEnv)
+	if cfg.CursorSecretGenerated {
+		log.Warn("cursor_secret_generated", "detail", "CURSOR_SECRET is unset; pagination cursors and export links stop working on restart and are not shared between instances")
+	}
 	ctx := context.Background()
 
 	pool, err := db.NewPool(ctx, db.PoolConfig{
@@ -59,8 +69,15 @@ func main() {
 	}
 
 	resolver := &graph.Resolver{Service: svc}
-	srv := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{Resolvers: resolver}))
-	srv.Use(extension.FixedComplexityLimit(200))
+	srv := handler.NewDefaultServer(graph.NewExecutableSchema(graph.Config{
+		Resolvers:  resolver,
+		Complexity: graph.NewComplexity(),
+	}))
+	srv.AroundOperations(graph.WithLoaders(svc))
+	srv.Use(&extension.QueryCost{
+		MaxComplexity: cfg.GraphQLMaxComplexity,
+		MaxDepth:      cfg.GraphQLMaxDepth,
+	})
 	srv.SetErrorPresenter(func(ctx context.Context, err error) *gqlerror.Error {
 		presented := graphql.DefaultErrorPresenter(ctx, err)
 		if presented.Extensions == nil {
@@ -75,6 +92,12 @@ func main() {
 		return presented
 	})
 
+	restHandler, err := rest.NewHandler(svc)
+	if err != nil {
+		log.Error("rest_init_failed", "error", err)
+		os.Exit(1)
+	}
+
 	mux := http.NewServeMux()
 	mux.Handle("/", playground.Handler("ZenList GraphQL", "/query"))
 	mux.Handle("/query", chain(
@@ -83,6 +106,32 @@ func main() {
 		middleware.RequestID,
 		middleware.Logging(log),
 	))
+	mux.Handle("/api/v1/", chain(
+		restHandler,
+		middleware.Timeout(cfg.RequestTimeout),
+		middleware.RequestID,
+		middleware.Logging(log),
+	))
+	mux.Handle("/calendar/", chain(
+		calendar.NewHandler(svc),
+		middleware.Timeout(cfg.RequestTimeout),
+		middleware.RequestID,
+		middleware.Logging(log),
+	))
+	mux.Handle("/export/", chain(
+		export.NewHandler(svc, cfg.ExportTimeout),
+		middleware.RequestID,
+		middleware.Logging(log),
+	))
+	if cfg.CalDAVPassword != "" {
+		mux.Handle(caldav.Prefix, chain(
+			caldav.NewHandler(svc, cfg.CalDAVPassword),
+			middleware.Timeout(cfg.RequestTimeout),
+			middleware.RequestID,
+			middleware.Logging(log),
+		))
+		mux.Handle("/.well-known/caldav", http.RedirectHandler(caldav.Prefix, http.StatusMovedPermanently))
+	}
 	mux.Handle("/healthz", healthHandler(pool, log))
 
 	httpServer := &http.Server{
@@ -102,6 +151,50 @@ func main() {
 		}
 	}()
 
+	// gRPC is opt-in and only listens when GRPC_PORT is set.
+	stopGRPC := func(context.Context) {}
+	if cfg.GRPCPort != "" {
+		grpcServer := rpc.NewGRPCServer(svc, cfg.RequestTimeout, log)
+		grpcListener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+		if err != nil {
+			log.Error("grpc_listen_failed", "error", err)
+			os.Exit(1)
+		}
+		go func() {
+			log.Info("grpc_server_starting", "addr", grpcListener.Addr().String())
+			if err := grpcServer.Serve(grpcListener); err != nil {
+				log.Error("grpc_server_failed", "error", err)
+				os.Exit(1)
+			}
+		}()
+		// Watch streams never finish on their own, so fall back to a hard
+		// stop once ctx is done.
+		stopGRPC = func(ctx context.Context) {
+			stopped := make(chan struct{})
+			go func() {
+				grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-ctx.Done():
+				grpcServer.Stop()
+			}
+		}
+	}
+
+	workerCtx, stopWorker := context.WithCancel(context.Background())
+	workerDone := make(chan struct{})
+	go func() {
+		defer close(workerDone)
+		webhook.NewWorker(store, webhook.Config{
+			PollInterval:   cfg.WebhookPollInterval,
+			RequestTimeout: cfg.WebhookTimeout,
+			MaxAttempts:    cfg.WebhookMaxAttempts,
+			AllowPrivate:   cfg.WebhookAllowPrivate,
+		}, log).Run(workerCtx)
+	}()
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, syscall.SIGTERM, syscall.SIGINT)
 	<-stop
@@ -111,6 +204,9 @@ func main() {
 	if err := httpServer.Shutdown(shutdownCtx); err != nil {
 		log.Error("server_shutdown_failed", "error", err)
 	}
+	stopGRPC(shutdownCtx)
+	stopWorker()
+	<-workerDone
 	log.Info("server_stopped")
 }
 
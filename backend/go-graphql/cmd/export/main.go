@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/config"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/repo"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/export"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	_ = godotenv.Load()
+
+	formats := make([]string, 0, len(service.ExportFormats))
+	for _, f := range service.ExportFormats {
+		formats = append(formats, string(f))
+	}
+
+	format := flag.String("format", string(service.ExportJSON), "one of: "+strings.Join(formats, ", "))
+	out := flag.String("out", "", "output file (defaults to stdout)")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config error: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+	pool, err := db.NewPool(ctx, db.PoolConfig{
+		URL:               cfg.DatabaseURL,
+		MaxConns:          cfg.DBMaxConns,
+		MinConns:          cfg.DBMinConns,
+		HealthCheckPeriod: cfg.DBHealthCheckEvery,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "db error: %v\n", err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	svc := service.New(repo.New(pool), cfg)
+	if err := svc.Bootstrap(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "bootstrap error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var w io.Writer = os.Stdout
+	var f *os.File
+	if *out != "" {
+		f, err = os.Create(*out)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "create error: %v\n", err)
+			os.Exit(1)
+		}
+		w = f
+	}
+	bw := bufio.NewWriter(w)
+
+	err = export.Write(ctx, svc, service.ExportFormat(*format), bw)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if f != nil {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			// Do not leave a truncated export behind.
+			_ = os.Remove(*out)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "export error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/config"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/repo"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/importer"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	_ = godotenv.Load()
+
+	formats := make([]string, 0, len(importer.Formats))
+	for _, f := range importer.Formats {
+		formats = append(formats, string(f))
+	}
+
+	format := flag.String("format", "", "one of: "+strings.Join(formats, ", "))
+	file := flag.String("file", "", "file to import")
+	project := flag.String("project", "", "project title when the file does not name one (defaults to the file name)")
+	dryRun := flag.Bool("dry-run", false, "report what would be imported without writing anything")
+	asJSON := flag.Bool("json", false, "print the report as JSON")
+	flag.Parse()
+
+	if *format == "" || *file == "" {
+		fmt.Fprintln(os.Stderr, "-format and -file are required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "config error: %v\n", err)
+		os.Exit(1)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "open error: %v\n", err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	projectTitle := *project
+	if projectTitle == "" {
+		base := filepath.Base(*file)
+		projectTitle = strings.TrimSuffix(base, filepath.Ext(base))
+	}
+
+	ctx := context.Background()
+	pool, err := db.NewPool(ctx, db.PoolConfig{
+		URL:               cfg.DatabaseURL,
+		MaxConns:          cfg.DBMaxConns,
+		MinConns:          cfg.DBMinConns,
+		HealthCheckPeriod: cfg.DBHealthCheckEvery,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "db error: %v\n", err)
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	svc := service.New(repo.New(pool), cfg)
+	if err := svc.Bootstrap(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "bootstrap error: %v\n", err)
+		os.Exit(1)
+	}
+
+	report, err := importer.New(svc).Import(ctx, importer.Format(*format), f, projectTitle, *dryRun)
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		_ = enc.Encode(report)
+	} else {
+		printReport(report)
+	}
+	if err != nil {
+		// Entities created before the failure are kept; re-running skips them.
+		fmt.Fprintf(os.Stderr, "import error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func printReport(r importer.Report) {
+	if r.DryRun {
+		fmt.Println("dry run: nothing was written")
+	}
+	fmt.Printf("projects:  %d created, %d reused\n", r.ProjectsCreated, r.ProjectsReused)
+	fmt.Printf("labels:    %d created, %d reused\n", r.LabelsCreated, r.LabelsReused)
+	fmt.Printf("tasks:     %d created, %d subtasks created\n", r.TasksCreated, r.SubtasksCreated)
+	fmt.Printf("skipped:   %d duplicates\n", r.DuplicatesSkipped)
+	for _, w := range r.Warnings {
+		fmt.Printf("warning: %s\n", w)
+	}
+}
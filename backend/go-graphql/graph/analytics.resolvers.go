@@ -0,0 +1,95 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func (r *analyticsResolver) Completed(ctx context.Context, obj *model.Analytics, interval *model.AnalyticsInterval) ([]*model.AnalyticsBucket, error) {
+	buckets, err := r.Service.CompletedSeries(ctx, analyticsScope(obj), analyticsInterval(interval))
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelAnalyticsBuckets(buckets), nil
+}
+
+func (r *analyticsResolver) Burndown(ctx context.Context, obj *model.Analytics, interval *model.AnalyticsInterval) ([]*model.ProjectBurndown, error) {
+	series, err := r.Service.Burndown(ctx, analyticsScope(obj), analyticsInterval(interval))
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	out := make([]*model.ProjectBurndown, 0, len(series))
+	for _, s := range series {
+		out = append(out, &model.ProjectBurndown{Project: toModelProject(s.Project), Points: toModelAnalyticsBuckets(s.Points)})
+	}
+	return out, nil
+}
+
+func (r *analyticsResolver) LeadTime(ctx context.Context, obj *model.Analytics) (*model.LeadTime, error) {
+	lead, err := r.Service.LeadTime(ctx, analyticsScope(obj))
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	out := &model.LeadTime{CompletedCount: lead.CompletedCount}
+	if lead.CompletedCount > 0 {
+		out.AverageSeconds = &lead.AverageSeconds
+		out.MedianSeconds = &lead.MedianSeconds
+	}
+	return out, nil
+}
+
+func (r *analyticsResolver) TimeInStatus(ctx context.Context, obj *model.Analytics) ([]*model.StatusTime, error) {
+	times, err := r.Service.TimeInStatus(ctx, analyticsScope(obj))
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	out := make([]*model.StatusTime, 0, len(times))
+	for _, t := range times {
+		out = append(out, &model.StatusTime{
+			Status:         model.TaskStatus(t.Status),
+			TaskCount:      t.TaskCount,
+			TotalSeconds:   t.TotalSeconds,
+			AverageSeconds: t.AverageSeconds(),
+		})
+	}
+	return out, nil
+}
+
+func (r *analyticsResolver) OverdueByPriority(ctx context.Context, obj *model.Analytics) ([]*model.OverdueRate, error) {
+	rates, err := r.Service.OverdueByPriority(ctx, analyticsScope(obj))
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelOverdueRates(rates), nil
+}
+
+func (r *analyticsResolver) OverdueByLabel(ctx context.Context, obj *model.Analytics) ([]*model.OverdueRate, error) {
+	rates, err := r.Service.OverdueByLabel(ctx, analyticsScope(obj))
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelOverdueRates(rates), nil
+}
+
+func (r *queryResolver) Analytics(ctx context.Context, from time.Time, to time.Time, projectIds []string) (*model.Analytics, error) {
+	scope, err := r.Service.Analytics(ctx, service.AnalyticsScope{From: from, To: to, ProjectIDs: projectIds})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	ids := scope.ProjectIDs
+	if ids == nil {
+		ids = []string{}
+	}
+	return &model.Analytics{From: scope.From, To: scope.To, ProjectIds: ids, Timezone: scope.Timezone}, nil
+}
+
+// Analytics returns AnalyticsResolver implementation.
+func (r *Resolver) Analytics() AnalyticsResolver { return &analyticsResolver{r} }
+
+type analyticsResolver struct{ *Resolver }
@@ -0,0 +1,81 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func (r *boardColumnResolver) Tasks(ctx context.Context, obj *model.BoardColumn, first *int, after *string, last *int, before *string) (*model.TaskConnection, error) {
+	page, err := r.Service.ListTasks(ctx, obj.Status.ProjectID, nil, service.TaskFilter{StatusID: &obj.Status.ID}, pageArgs(ctx, first, after, last, before))
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toTaskConnection(page), nil
+}
+
+func (r *mutationResolver) CreateProjectStatus(ctx context.Context, input model.CreateProjectStatusInput) (*model.ProjectStatus, error) {
+	status, err := r.Service.CreateProjectStatus(ctx, service.CreateProjectStatusInput{
+		ProjectID: input.ProjectID,
+		Name:      input.Name,
+		Category:  string(input.Category),
+		Color:     input.Color,
+		WipLimit:  input.WipLimit,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelProjectStatus(status), nil
+}
+
+func (r *mutationResolver) UpdateProjectStatus(ctx context.Context, input model.UpdateProjectStatusInput) (*model.ProjectStatus, error) {
+	var category *string
+	if input.Category != nil {
+		c := string(*input.Category)
+		category = &c
+	}
+
+	status, err := r.Service.UpdateProjectStatus(ctx, service.UpdateProjectStatusInput{
+		ID:            input.ID,
+		Name:          input.Name,
+		Category:      category,
+		Color:         input.Color,
+		ClearColor:    input.ClearColor != nil && *input.ClearColor,
+		WipLimit:      input.WipLimit,
+		ClearWipLimit: input.ClearWipLimit != nil && *input.ClearWipLimit,
+		Position:      input.Position,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelProjectStatus(status), nil
+}
+
+func (r *mutationResolver) DeleteProjectStatus(ctx context.Context, id string, moveTasksToStatusID *string) (*model.DeletePayload, error) {
+	deleted, err := r.Service.DeleteProjectStatus(ctx, id, moveTasksToStatusID)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+
+	return &model.DeletePayload{ID: deleted.ID.String(), DeletedAt: deleted.DeletedAt}, nil
+}
+
+func (r *queryResolver) Board(ctx context.Context, projectID string) (*model.Board, error) {
+	board, err := r.Service.Board(ctx, projectID)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	if board == nil {
+		return nil, nil
+	}
+	return toModelBoard(*board), nil
+}
+
+// BoardColumn returns BoardColumnResolver implementation.
+func (r *Resolver) BoardColumn() BoardColumnResolver { return &boardColumnResolver{r} }
+
+type boardColumnResolver struct{ *Resolver }
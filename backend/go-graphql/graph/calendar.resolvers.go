@@ -0,0 +1,39 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+)
+
+func (r *mutationResolver) CreateCalendarFeed(ctx context.Context, projectID *string) (*model.CalendarFeedPayload, error) {
+	created, err := r.Service.CreateCalendarFeed(ctx, projectID)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return &model.CalendarFeedPayload{Feed: toModelCalendarFeed(created.Feed), URL: created.URL}, nil
+}
+
+func (r *mutationResolver) RevokeCalendarFeed(ctx context.Context, id string) (*model.DeletePayload, error) {
+	revoked, err := r.Service.RevokeCalendarFeed(ctx, id)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+
+	return &model.DeletePayload{ID: revoked.ID.String(), DeletedAt: revoked.DeletedAt}, nil
+}
+
+func (r *queryResolver) CalendarFeeds(ctx context.Context) ([]*model.CalendarFeed, error) {
+	feeds, err := r.Service.ListCalendarFeeds(ctx)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	out := make([]*model.CalendarFeed, 0, len(feeds))
+	for _, feed := range feeds {
+		out = append(out, toModelCalendarFeed(feed))
+	}
+	return out, nil
+}
@@ -0,0 +1,41 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func (r *mutationResolver) SetCapacity(ctx context.Context, input model.SetCapacityInput) (*model.Capacity, error) {
+	capacity, err := r.Service.SetCapacity(ctx, service.SetCapacityInput{
+		WeeklyMinutes: input.WeeklyMinutes,
+		WorkDays:      toServiceWeekdays(input.WorkDays),
+		WorkdayStart:  input.WorkdayStart,
+		WorkdayEnd:    input.WorkdayEnd,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelCapacity(capacity), nil
+}
+
+func (r *queryResolver) Capacity(ctx context.Context, from time.Time, to time.Time) (*model.CapacityPlan, error) {
+	plan, err := r.Service.CapacityPlan(ctx, from, to)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelCapacityPlan(plan), nil
+}
+
+func (r *userResolver) Capacity(ctx context.Context, obj *model.User) (*model.Capacity, error) {
+	capacity, err := r.Service.Capacity(ctx)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelCapacity(capacity), nil
+}
@@ -0,0 +1,118 @@
+package graph
+
+import (
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+)
+
+// Estimated fan-out of the unpaged task lists, used to price nested selections.
+const (
+	labelsPerTaskEstimate      = 5
+	subtasksPerTaskEstimate    = 10
+	sectionsPerProjectEstimate = 10
+	statusesPerProjectEstimate = 10
+	fieldsPerProjectEstimate   = 10
+	entriesPerTaskEstimate     = 20
+	daysPerPlanEstimate        = 31
+	tasksPerDayEstimate        = 10
+	bucketsPerSeriesEstimate   = 31
+	projectsPerUserEstimate    = 10
+	tasksPerTemplateEstimate   = 20
+)
+
+// NewComplexity returns per-field cost functions. Paged fields multiply their
+// selection cost by the effective page size (the same defaults and caps the
+// service applies), and unpaged task lists by their estimated fan-out.
+func NewComplexity() ComplexityRoot {
+	var c ComplexityRoot
+
+	c.Query.Projects = func(childComplexity int, includeArchived *bool, first *int, after *string, last *int, before *string) int {
+		return 1 + childComplexity*pageCost(first, last, 20, 100)
+	}
+	c.Query.Labels = func(childComplexity int, first *int, after *string, last *int, before *string) int {
+		return 1 + childComplexity*pageCost(first, last, 50, 200)
+	}
+	c.Query.Tasks = func(childComplexity int, projectID string, parentTaskID *string, statuses []model.TaskStatus, priorities []model.TaskPriority, labelIDs []string, labelMatch *model.LabelMatch, dueBefore *time.Time, dueAfter *time.Time, startBefore *time.Time, startAfter *time.Time, completedBetween *model.TimeRange, hasDueDate *bool, updatedSince *time.Time, titleContains *string, sectionID *string, statusID *string, customFields []*model.CustomFieldFilterInput, sortByCustomField *model.CustomFieldSortInput, first *int, after *string, last *int, before *string) int {
+		return 1 + childComplexity*pageCost(first, last, 20, 100)
+	}
+	c.Query.TasksByFilter = func(childComplexity int, filterID *string, expression *string, first *int, after *string) int {
+		return 1 + childComplexity*pageCost(first, nil, 20, 100)
+	}
+	c.Query.WebhookDeliveries = func(childComplexity int, subscriptionID *string, statuses []model.WebhookDeliveryStatus, first *int, after *string, last *int, before *string) int {
+		return 1 + childComplexity*pageCost(first, last, 20, 100)
+	}
+	c.ProjectSection.Tasks = func(childComplexity int, first *int, after *string, last *int, before *string) int {
+		return 1 + childComplexity*pageCost(first, last, 20, 100)
+	}
+	c.Project.Sections = func(childComplexity int) int {
+		return 1 + childComplexity*sectionsPerProjectEstimate
+	}
+	c.Project.Statuses = func(childComplexity int) int {
+		return 1 + childComplexity*statusesPerProjectEstimate
+	}
+	c.Project.CustomFields = func(childComplexity int) int {
+		return 1 + childComplexity*fieldsPerProjectEstimate
+	}
+	c.Board.Columns = func(childComplexity int) int {
+		return 1 + childComplexity*statusesPerProjectEstimate
+	}
+	c.BoardColumn.Tasks = func(childComplexity int, first *int, after *string, last *int, before *string) int {
+		return 1 + childComplexity*pageCost(first, last, 20, 100)
+	}
+	c.Task.Labels = func(childComplexity int) int {
+		return 1 + childComplexity*labelsPerTaskEstimate
+	}
+	c.Task.Subtasks = func(childComplexity int) int {
+		return 1 + childComplexity*subtasksPerTaskEstimate
+	}
+	c.Task.CustomFields = func(childComplexity int) int {
+		return 1 + childComplexity*fieldsPerProjectEstimate
+	}
+	c.Task.TimeEntries = func(childComplexity int) int {
+		return 1 + childComplexity*entriesPerTaskEstimate
+	}
+	c.CapacityPlan.Days = func(childComplexity int) int {
+		return 1 + childComplexity*daysPerPlanEstimate
+	}
+	c.CapacityDay.Tasks = func(childComplexity int) int {
+		return 1 + childComplexity*tasksPerDayEstimate
+	}
+	c.Analytics.Completed = func(childComplexity int, interval *model.AnalyticsInterval) int {
+		return 1 + childComplexity*bucketsPerSeriesEstimate
+	}
+	c.Analytics.Burndown = func(childComplexity int, interval *model.AnalyticsInterval) int {
+		return 1 + childComplexity*projectsPerUserEstimate
+	}
+	c.ProjectBurndown.Points = func(childComplexity int) int {
+		return 1 + childComplexity*bucketsPerSeriesEstimate
+	}
+	c.Query.Templates = func(childComplexity int) int {
+		return 1 + childComplexity*projectsPerUserEstimate
+	}
+	c.Template.Tasks = func(childComplexity int) int {
+		return 1 + childComplexity*tasksPerTemplateEstimate
+	}
+	c.TemplateTask.Subtasks = func(childComplexity int) int {
+		return 1 + childComplexity*subtasksPerTaskEstimate
+	}
+	c.TemplateInstance.Tasks = func(childComplexity int) int {
+		return 1 + childComplexity*tasksPerTemplateEstimate
+	}
+
+	return c
+}
+
+func pageCost(first *int, last *int, defaultValue int, maxValue int) int {
+	size := first
+	if size == nil {
+		size = last
+	}
+	if size == nil || *size <= 0 {
+		return defaultValue
+	}
+	if *size > maxValue {
+		return maxValue
+	}
+	return *size
+}
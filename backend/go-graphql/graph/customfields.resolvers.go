@@ -0,0 +1,47 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func (r *mutationResolver) CreateCustomField(ctx context.Context, input model.CreateCustomFieldInput) (*model.CustomField, error) {
+	field, err := r.Service.CreateCustomField(ctx, service.CreateCustomFieldInput{
+		ProjectID: input.ProjectID,
+		Name:      input.Name,
+		Type:      string(input.Type),
+		Options:   input.Options,
+		Required:  input.Required != nil && *input.Required,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelCustomField(field), nil
+}
+
+func (r *mutationResolver) UpdateCustomField(ctx context.Context, input model.UpdateCustomFieldInput) (*model.CustomField, error) {
+	field, err := r.Service.UpdateCustomField(ctx, service.UpdateCustomFieldInput{
+		ID:       input.ID,
+		Name:     input.Name,
+		Options:  input.Options,
+		Required: input.Required,
+		Position: input.Position,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelCustomField(field), nil
+}
+
+func (r *mutationResolver) DeleteCustomField(ctx context.Context, id string) (*model.DeletePayload, error) {
+	deleted, err := r.Service.DeleteCustomField(ctx, id)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return &model.DeletePayload{ID: deleted.ID.String(), DeletedAt: deleted.DeletedAt}, nil
+}
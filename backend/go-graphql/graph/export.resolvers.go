@@ -0,0 +1,20 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func (r *mutationResolver) ExportData(ctx context.Context, format model.ExportFormat) (*model.ExportLink, error) {
+	link, err := r.Service.CreateExportLink(ctx, service.ExportFormat(strings.ToLower(format.String())))
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return &model.ExportLink{URL: link.URL, Format: format, ExpiresAt: link.ExpiresAt}, nil
+}
@@ -0,0 +1,74 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func (r *mutationResolver) CreateSavedFilter(ctx context.Context, input model.CreateSavedFilterInput) (*model.SavedFilter, error) {
+	saved, err := r.Service.CreateSavedFilter(ctx, service.CreateSavedFilterInput{
+		Name:       input.Name,
+		Expression: input.Expression,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelSavedFilter(saved), nil
+}
+
+func (r *mutationResolver) UpdateSavedFilter(ctx context.Context, input model.UpdateSavedFilterInput) (*model.SavedFilter, error) {
+	saved, err := r.Service.UpdateSavedFilter(ctx, service.UpdateSavedFilterInput{
+		ID:         input.ID,
+		Name:       input.Name,
+		Expression: input.Expression,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelSavedFilter(saved), nil
+}
+
+func (r *mutationResolver) DeleteSavedFilter(ctx context.Context, id string) (*model.DeletePayload, error) {
+	deleted, err := r.Service.DeleteSavedFilter(ctx, id)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+
+	return &model.DeletePayload{ID: deleted.ID.String(), DeletedAt: deleted.DeletedAt}, nil
+}
+
+func (r *queryResolver) SavedFilters(ctx context.Context) ([]*model.SavedFilter, error) {
+	filters, err := r.Service.ListSavedFilters(ctx)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	out := make([]*model.SavedFilter, 0, len(filters))
+	for _, f := range filters {
+		out = append(out, toModelSavedFilter(f))
+	}
+	return out, nil
+}
+
+func (r *queryResolver) SavedFilter(ctx context.Context, id string) (*model.SavedFilter, error) {
+	saved, err := r.Service.SavedFilter(ctx, id)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	if saved == nil {
+		return nil, nil
+	}
+	return toModelSavedFilter(*saved), nil
+}
+
+func (r *queryResolver) TasksByFilter(ctx context.Context, filterID *string, expression *string, first *int, after *string) (*model.TaskConnection, error) {
+	page, err := r.Service.TasksByFilter(ctx, filterID, expression, pageArgs(ctx, first, after, nil, nil))
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toTaskConnection(page), nil
+}
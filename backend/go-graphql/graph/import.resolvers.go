@@ -0,0 +1,27 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/importer"
+)
+
+func (r *mutationResolver) ImportData(ctx context.Context, input model.ImportDataInput) (*model.ImportReport, error) {
+	projectTitle := strings.TrimSuffix(input.File.Filename, filepath.Ext(input.File.Filename))
+	if input.ProjectTitle != nil {
+		projectTitle = *input.ProjectTitle
+	}
+	dryRun := input.DryRun != nil && *input.DryRun
+
+	report, err := importer.New(r.Service).Import(ctx, importFormats[input.Format], input.File.File, projectTitle, dryRun)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelImportReport(report), nil
+}
@@ -0,0 +1,119 @@
+package graph
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+const (
+	// loaderWait is how long a loader collects keys from sibling resolvers
+	// before it queries.
+	loaderWait     = 2 * time.Millisecond
+	loaderMaxBatch = 500
+)
+
+type loadersKey struct{}
+
+// loaders batch per-row lookups made while resolving one operation, so a
+// page of tasks costs one query per field instead of one per task.
+type loaders struct {
+	taskProgress      *batchLoader[service.TaskProgress]
+	customFieldValues *batchLoader[[]service.CustomFieldValue]
+	timeSpent         *batchLoader[int64]
+	recurrence        *batchLoader[string]
+}
+
+// WithLoaders gives every operation its own loaders.
+func WithLoaders(svc *service.Service) graphql.OperationMiddleware {
+	return func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		return next(context.WithValue(ctx, loadersKey{}, &loaders{
+			taskProgress:      newBatchLoader(svc.TaskProgressBatch),
+			customFieldValues: newBatchLoader(svc.TaskCustomFieldValuesBatch),
+			timeSpent:         newBatchLoader(svc.TaskTimeSpentBatch),
+			recurrence:        newBatchLoader(svc.TaskRecurrenceBatch),
+		}))
+	}
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	l, _ := ctx.Value(loadersKey{}).(*loaders)
+	return l
+}
+
+// batchLoader coalesces Load calls that arrive within wait of each other
+// into one fetch. It does not cache between batches.
+type batchLoader[V any] struct {
+	fetch func(ctx context.Context, keys []string) (map[string]V, error)
+	wait  time.Duration
+
+	mu    sync.Mutex
+	batch *loaderBatch[V]
+}
+
+type loaderBatch[V any] struct {
+	keys   []string
+	full   chan struct{}
+	done   chan struct{}
+	result map[string]V
+	err    error
+}
+
+func newBatchLoader[V any](fetch func(ctx context.Context, keys []string) (map[string]V, error)) *batchLoader[V] {
+	return &batchLoader[V]{fetch: fetch, wait: loaderWait}
+}
+
+// Load returns the value for key, or the zero value when fetch did not
+// return one.
+func (l *batchLoader[V]) Load(ctx context.Context, key string) (V, error) {
+	l.mu.Lock()
+	b := l.batch
+	if b == nil {
+		b = &loaderBatch[V]{full: make(chan struct{}), done: make(chan struct{})}
+		l.batch = b
+		go l.run(ctx, b)
+	}
+	b.keys = append(b.keys, key)
+	if len(b.keys) >= loaderMaxBatch {
+		l.batch = nil
+		close(b.full)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+		return b.result[key], b.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *batchLoader[V]) run(ctx context.Context, b *loaderBatch[V]) {
+	timer := time.NewTimer(l.wait)
+	select {
+	case <-timer.C:
+	case <-b.full:
+		timer.Stop()
+	}
+
+	l.mu.Lock()
+	if l.batch == b {
+		l.batch = nil
+	}
+	keys := make([]string, 0, len(b.keys))
+	seen := make(map[string]struct{}, len(b.keys))
+	for _, k := range b.keys {
+		if _, ok := seen[k]; !ok {
+			seen[k] = struct{}{}
+			keys = append(keys, k)
+		}
+	}
+	l.mu.Unlock()
+
+	b.result, b.err = l.fetch(ctx, keys)
+	close(b.done)
+}
@@ -0,0 +1,76 @@
+package graph
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+func TestBatchLoaderCoalescesConcurrentLoads(t *testing.T) {
+	var calls atomic.Int32
+	l := newBatchLoader(func(_ context.Context, keys []string) (map[string]int, error) {
+		calls.Add(1)
+		out := make(map[string]int, len(keys))
+		for _, k := range keys {
+			n, _ := strconv.Atoi(k)
+			out[k] = n * 2
+		}
+		return out, nil
+	})
+	// Generous so the test does not depend on goroutine scheduling.
+	l.wait = 200 * time.Millisecond
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Duplicate keys share one fetch slot.
+			key := strconv.Itoa(i % 10)
+			got, err := l.Load(context.Background(), key)
+			if err != nil {
+				t.Errorf("Load(%s): %v", key, err)
+				return
+			}
+			if got != (i%10)*2 {
+				t.Errorf("Load(%s): got %d, want %d", key, got, (i%10)*2)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("expected 1 fetch, got %d", n)
+	}
+
+	// A later load starts a new batch rather than reusing the old result.
+	if _, err := l.Load(context.Background(), "1"); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("expected 2 fetches, got %d", n)
+	}
+}
+
+func TestWithLoadersCreatesEveryLoader(t *testing.T) {
+	var got *loaders
+	WithLoaders(nil)(context.Background(), func(ctx context.Context) graphql.ResponseHandler {
+		got = loadersFrom(ctx)
+		return nil
+	})
+	if got == nil {
+		t.Fatal("no loaders in context")
+	}
+	v := reflect.ValueOf(got).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).IsNil() {
+			t.Errorf("loader %s is not created", v.Type().Field(i).Name)
+		}
+	}
+}
@@ -1,12 +1,14 @@
 package graph
 
 import (
-	"encoding/base64"
-	"fmt"
+	"context"
+	"strings"
 	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
 	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/importer"
 	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -56,13 +58,15 @@ func toModelUser(u sqlc.User) *model.User {
 
 func toModelProject(p sqlc.Project) *model.Project {
 	return &model.Project{
-		ID:          uuidString(p.ID),
-		UserID:      uuidString(p.UserID),
-		Title:       p.Title,
-		Description: stringPtr(p.Description),
-		Color:       stringPtr(p.Color),
-		CreatedAt:   timeValue(p.CreatedAt),
-		UpdatedAt:   timeValue(p.UpdatedAt),
+		ID:                  uuidString(p.ID),
+		UserID:              uuidString(p.UserID),
+		Title:               p.Title,
+		Description:         stringPtr(p.Description),
+		Color:               stringPtr(p.Color),
+		ArchivedAt:          timePtr(p.ArchivedAt),
+		AutoCompleteParents: p.AutoCompleteParents,
+		CreatedAt:           timeValue(p.CreatedAt),
+		UpdatedAt:           timeValue(p.UpdatedAt),
 	}
 }
 
@@ -82,71 +86,509 @@ func toModelTask(t sqlc.Task) *model.Task {
 		id := uuidString(t.ParentTaskID)
 		parentID = &id
 	}
+	var sectionID *string
+	if t.SectionID.Valid {
+		id := uuidString(t.SectionID)
+		sectionID = &id
+	}
+	var estimate *int
+	if t.EstimateMinutes != nil {
+		v := int(*t.EstimateMinutes)
+		estimate = &v
+	}
 
 	return &model.Task{
-		ID:           uuidString(t.ID),
-		UserID:       uuidString(t.UserID),
-		ProjectID:    uuidString(t.ProjectID),
-		ParentTaskID: parentID,
-		Title:        t.Title,
-		Description:  stringPtr(t.Description),
-		Status:       model.TaskStatus(t.Status),
-		Priority:     model.TaskPriority(t.Priority),
-		StartAt:      timePtr(t.StartAt),
-		DueAt:        timePtr(t.DueAt),
-		CompletedAt:  timePtr(t.CompletedAt),
-		CreatedAt:    timeValue(t.CreatedAt),
-		UpdatedAt:    timeValue(t.UpdatedAt),
+		ID:              uuidString(t.ID),
+		UserID:          uuidString(t.UserID),
+		ProjectID:       uuidString(t.ProjectID),
+		ParentTaskID:    parentID,
+		SectionID:       sectionID,
+		StatusID:        uuidString(t.StatusID),
+		BlockedReason:   t.BlockedReason,
+		Title:           t.Title,
+		Description:     stringPtr(t.Description),
+		Status:          model.TaskStatus(t.Status),
+		Priority:        model.TaskPriority(t.Priority),
+		StartAt:         timePtr(t.StartAt),
+		DueAt:           timePtr(t.DueAt),
+		CompletedAt:     timePtr(t.CompletedAt),
+		EstimateMinutes: estimate,
+		CreatedAt:       timeValue(t.CreatedAt),
+		UpdatedAt:       timeValue(t.UpdatedAt),
+	}
+}
+
+func toModelProjectSection(s sqlc.ProjectSection) *model.ProjectSection {
+	return &model.ProjectSection{
+		ID:        uuidString(s.ID),
+		ProjectID: uuidString(s.ProjectID),
+		Name:      s.Name,
+		Position:  int(s.Position),
+		CreatedAt: timeValue(s.CreatedAt),
+		UpdatedAt: timeValue(s.UpdatedAt),
+	}
+}
+
+func toModelProjectStatus(s sqlc.ProjectStatus) *model.ProjectStatus {
+	var wipLimit *int
+	if s.WipLimit != nil {
+		v := int(*s.WipLimit)
+		wipLimit = &v
+	}
+	return &model.ProjectStatus{
+		ID:        uuidString(s.ID),
+		ProjectID: uuidString(s.ProjectID),
+		Name:      s.Name,
+		Category:  model.TaskStatus(s.Category),
+		Color:     s.Color,
+		WipLimit:  wipLimit,
+		Position:  int(s.Position),
+		CreatedAt: timeValue(s.CreatedAt),
+		UpdatedAt: timeValue(s.UpdatedAt),
+	}
+}
+
+func toModelCustomField(f sqlc.CustomField) *model.CustomField {
+	return &model.CustomField{
+		ID:        uuidString(f.ID),
+		ProjectID: uuidString(f.ProjectID),
+		Name:      f.Name,
+		Type:      model.CustomFieldType(f.FieldType),
+		Options:   f.Options,
+		Required:  f.Required,
+		Position:  int(f.Position),
+		CreatedAt: timeValue(f.CreatedAt),
+		UpdatedAt: timeValue(f.UpdatedAt),
+	}
+}
+
+func toServiceCustomFieldValues(in []*model.CustomFieldValueInput) []service.CustomFieldValueInput {
+	if in == nil {
+		return nil
+	}
+	out := make([]service.CustomFieldValueInput, 0, len(in))
+	for _, v := range in {
+		out = append(out, service.CustomFieldValueInput{FieldID: v.FieldID, Value: v.Value})
+	}
+	return out
+}
+
+func toModelTimeEntry(e sqlc.TimeEntry) *model.TimeEntry {
+	end := time.Now()
+	if e.EndedAt.Valid {
+		end = e.EndedAt.Time
+	}
+	return &model.TimeEntry{
+		ID:              uuidString(e.ID),
+		TaskID:          uuidString(e.TaskID),
+		StartedAt:       timeValue(e.StartedAt),
+		EndedAt:         timePtr(e.EndedAt),
+		DurationSeconds: int(max(end.Sub(e.StartedAt.Time), 0) / time.Second),
+		Note:            e.Note,
+		CreatedAt:       timeValue(e.CreatedAt),
+		UpdatedAt:       timeValue(e.UpdatedAt),
+	}
+}
+
+func toModelTimeReport(r service.TimeReport) *model.TimeReport {
+	buckets := make([]*model.TimeReportBucket, 0, len(r.Buckets))
+	for _, b := range r.Buckets {
+		buckets = append(buckets, &model.TimeReportBucket{Key: b.Key, Name: b.Name, Seconds: int(b.Seconds)})
+	}
+	return &model.TimeReport{
+		From:         r.From,
+		To:           r.To,
+		GroupBy:      model.TimeReportGroup(r.GroupBy),
+		TotalSeconds: int(r.TotalSeconds),
+		Buckets:      buckets,
+	}
+}
+
+func toModelCapacity(c service.Capacity) *model.Capacity {
+	days := make([]model.Weekday, 0, len(c.WorkDays))
+	for _, d := range c.WorkDays {
+		days = append(days, model.Weekday(strings.ToUpper(d.String())))
+	}
+	return &model.Capacity{
+		WeeklyMinutes: c.WeeklyMinutes,
+		WorkDays:      days,
+		WorkdayStart:  service.FormatClock(c.WorkdayStart),
+		WorkdayEnd:    service.FormatClock(c.WorkdayEnd),
 	}
 }
 
+func analyticsScope(a *model.Analytics) service.AnalyticsScope {
+	return service.AnalyticsScope{From: a.From, To: a.To, ProjectIDs: a.ProjectIds, Timezone: a.Timezone}
+}
+
+func analyticsInterval(v *model.AnalyticsInterval) string {
+	if v == nil {
+		return service.AnalyticsDay
+	}
+	return string(*v)
+}
+
+func toModelAnalyticsBuckets(buckets []service.AnalyticsBucket) []*model.AnalyticsBucket {
+	out := make([]*model.AnalyticsBucket, 0, len(buckets))
+	for _, b := range buckets {
+		out = append(out, &model.AnalyticsBucket{Date: b.Date, Count: b.Count})
+	}
+	return out
+}
+
+func toModelOverdueRates(rates []service.OverdueRate) []*model.OverdueRate {
+	out := make([]*model.OverdueRate, 0, len(rates))
+	for _, r := range rates {
+		rate := &model.OverdueRate{DueCount: r.DueCount, OverdueCount: r.OverdueCount, Rate: r.Rate()}
+		if r.Priority != "" {
+			p := model.TaskPriority(r.Priority)
+			rate.Priority = &p
+		}
+		if r.Label != nil {
+			rate.Label = toModelLabel(*r.Label)
+		}
+		out = append(out, rate)
+	}
+	return out
+}
+
+func toModelSchedulePlan(p service.SchedulePlan) *model.SchedulePlan {
+	scheduled := make([]*model.ScheduledTask, 0, len(p.Scheduled))
+	for _, st := range p.Scheduled {
+		scheduled = append(scheduled, &model.ScheduledTask{Task: toModelTask(st.Task), StartAt: st.StartAt, EndAt: st.EndAt})
+	}
+	unscheduled := make([]*model.UnscheduledTask, 0, len(p.Unscheduled))
+	for _, ut := range p.Unscheduled {
+		unscheduled = append(unscheduled, &model.UnscheduledTask{Task: toModelTask(ut.Task), Reason: model.UnscheduledReason(ut.Reason)})
+	}
+	return &model.SchedulePlan{Preview: p.Preview, Scheduled: scheduled, Unscheduled: unscheduled}
+}
+
+func toModelTemplate(t service.Template) *model.Template {
+	out := &model.Template{
+		ID:        t.ID.String(),
+		Name:      t.Name,
+		Kind:      model.TemplateKind(t.Kind),
+		Tasks:     toModelTemplateTasks(t.Tasks),
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+	if t.Project != nil {
+		out.Project = &model.TemplateProject{
+			Title:               t.Project.Title,
+			Description:         t.Project.Description,
+			Color:               t.Project.Color,
+			AutoCompleteParents: t.Project.AutoCompleteParents,
+		}
+	}
+	return out
+}
+
+func toModelTemplateTasks(tasks []service.TemplateTask) []*model.TemplateTask {
+	out := make([]*model.TemplateTask, 0, len(tasks))
+	for _, t := range tasks {
+		labelIDs := t.LabelIDs
+		if labelIDs == nil {
+			labelIDs = []string{}
+		}
+		out = append(out, &model.TemplateTask{
+			Title:           t.Title,
+			Description:     t.Description,
+			Priority:        model.TaskPriority(t.Priority),
+			EstimateMinutes: t.EstimateMinutes,
+			LabelIds:        labelIDs,
+			StartAt:         toModelTemplateDate(t.StartAt),
+			DueAt:           toModelTemplateDate(t.DueAt),
+			Subtasks:        toModelTemplateTasks(t.Subtasks),
+		})
+	}
+	return out
+}
+
+func toModelTemplateDate(d *service.TemplateDate) *model.TemplateDate {
+	if d == nil {
+		return nil
+	}
+	return &model.TemplateDate{Days: d.Days, Time: service.FormatClock(d.Minute)}
+}
+
+func toServiceWeekdays(in []model.Weekday) []time.Weekday {
+	out := make([]time.Weekday, 0, len(in))
+	for _, d := range in {
+		for w := time.Sunday; w <= time.Saturday; w++ {
+			if strings.EqualFold(w.String(), string(d)) {
+				out = append(out, w)
+			}
+		}
+	}
+	return out
+}
+
+func toModelCapacityPlan(p service.CapacityPlan) *model.CapacityPlan {
+	days := make([]*model.CapacityDay, 0, len(p.Days))
+	for _, d := range p.Days {
+		tasks := make([]*model.CapacityTask, 0, len(d.Tasks))
+		for _, t := range d.Tasks {
+			tasks = append(tasks, &model.CapacityTask{Task: toModelTask(t.Task), Minutes: t.Minutes})
+		}
+		days = append(days, &model.CapacityDay{
+			Date:            d.Date,
+			CapacityMinutes: d.CapacityMinutes,
+			PlannedMinutes:  d.PlannedMinutes,
+			Overloaded:      d.Overloaded(),
+			Tasks:           tasks,
+		})
+	}
+	return &model.CapacityPlan{Capacity: toModelCapacity(p.Capacity), Days: days}
+}
+
+func toModelBoard(b service.Board) *model.Board {
+	columns := make([]*model.BoardColumn, 0, len(b.Columns))
+	for _, c := range b.Columns {
+		columns = append(columns, &model.BoardColumn{
+			Status:       toModelProjectStatus(c.Status),
+			TaskCount:    c.TaskCount,
+			OverWipLimit: c.OverWipLimit(),
+		})
+	}
+	return &model.Board{Project: toModelProject(b.Project), Columns: columns}
+}
+
+func toModelTransitionPolicy(p service.TransitionPolicy) *model.TransitionPolicy {
+	out := &model.TransitionPolicy{
+		ProjectID:    p.ProjectID.String(),
+		Restricted:   p.Restricted,
+		Transitions:  make([]*model.StatusTransition, 0, len(p.Transitions)),
+		Requirements: make([]*model.StatusRequirement, 0, len(p.Requirements)),
+	}
+	for _, t := range p.Transitions {
+		out.Transitions = append(out.Transitions, &model.StatusTransition{From: model.TaskStatus(t.From), To: model.TaskStatus(t.To)})
+	}
+	for _, r := range p.Requirements {
+		fields := make([]model.TransitionRequirement, 0, len(r.Fields))
+		for _, f := range r.Fields {
+			fields = append(fields, model.TransitionRequirement(f))
+		}
+		out.Requirements = append(out.Requirements, &model.StatusRequirement{Status: model.TaskStatus(r.Status), Fields: fields})
+	}
+	return out
+}
+
 func toProjectConnection(page service.PageResult[sqlc.Project]) *model.ProjectConnection {
-	edges := make([]*model.ProjectEdge, 0, len(page.Items))
-	for _, item := range page.Items {
-		edges = append(edges, &model.ProjectEdge{Cursor: edgeCursor(item.CreatedAt.Time, item.ID), Node: toModelProject(item)})
+	edges := make([]*model.ProjectEdge, 0, len(page.Edges))
+	for _, edge := range page.Edges {
+		edges = append(edges, &model.ProjectEdge{Cursor: edge.Cursor, Node: toModelProject(edge.Node)})
 	}
 	return &model.ProjectConnection{
-		Edges: edges,
-		PageInfo: &model.PageInfo{
-			EndCursor:   page.EndCursor,
-			HasNextPage: page.HasNextPage,
-		},
+		Edges:      edges,
+		PageInfo:   toPageInfo(page),
+		TotalCount: page.TotalCount,
 	}
 }
 
 func toLabelConnection(page service.PageResult[sqlc.Label]) *model.LabelConnection {
-	edges := make([]*model.LabelEdge, 0, len(page.Items))
-	for _, item := range page.Items {
-		edges = append(edges, &model.LabelEdge{Cursor: edgeCursor(item.CreatedAt.Time, item.ID), Node: toModelLabel(item)})
+	edges := make([]*model.LabelEdge, 0, len(page.Edges))
+	for _, edge := range page.Edges {
+		edges = append(edges, &model.LabelEdge{Cursor: edge.Cursor, Node: toModelLabel(edge.Node)})
 	}
 	return &model.LabelConnection{
-		Edges: edges,
-		PageInfo: &model.PageInfo{
-			EndCursor:   page.EndCursor,
-			HasNextPage: page.HasNextPage,
-		},
+		Edges:      edges,
+		PageInfo:   toPageInfo(page),
+		TotalCount: page.TotalCount,
 	}
 }
 
 func toTaskConnection(page service.PageResult[sqlc.Task]) *model.TaskConnection {
-	edges := make([]*model.TaskEdge, 0, len(page.Items))
-	for _, item := range page.Items {
-		edges = append(edges, &model.TaskEdge{Cursor: edgeCursor(item.CreatedAt.Time, item.ID), Node: toModelTask(item)})
+	edges := make([]*model.TaskEdge, 0, len(page.Edges))
+	for _, edge := range page.Edges {
+		edges = append(edges, &model.TaskEdge{Cursor: edge.Cursor, Node: toModelTask(edge.Node)})
 	}
 	return &model.TaskConnection{
-		Edges: edges,
-		PageInfo: &model.PageInfo{
-			EndCursor:   page.EndCursor,
-			HasNextPage: page.HasNextPage,
-		},
+		Edges:      edges,
+		PageInfo:   toPageInfo(page),
+		TotalCount: page.TotalCount,
+	}
+}
+
+func toPageInfo[T any](page service.PageResult[T]) *model.PageInfo {
+	return &model.PageInfo{
+		StartCursor:     page.StartCursor,
+		EndCursor:       page.EndCursor,
+		HasPreviousPage: page.HasPreviousPage,
+		HasNextPage:     page.HasNextPage,
 	}
 }
 
-func edgeCursor(createdAt time.Time, id pgtype.UUID) string {
-	uid := uuid.Nil
-	if id.Valid {
-		uid = uuid.UUID(id.Bytes)
+func toModelNode(node any) model.Node {
+	switch v := node.(type) {
+	case sqlc.User:
+		return toModelUser(v)
+	case sqlc.Project:
+		return toModelProject(v)
+	case sqlc.Label:
+		return toModelLabel(v)
+	case sqlc.Task:
+		return toModelTask(v)
+	case sqlc.ProjectSection:
+		return toModelProjectSection(v)
+	case sqlc.ProjectStatus:
+		return toModelProjectStatus(v)
+	case sqlc.CustomField:
+		return toModelCustomField(v)
+	case sqlc.TimeEntry:
+		return toModelTimeEntry(v)
+	case service.Template:
+		return toModelTemplate(v)
+	case sqlc.SavedFilter:
+		return toModelSavedFilter(v)
+	case sqlc.WebhookSubscription:
+		return toModelWebhookSubscription(v)
+	case sqlc.WebhookDelivery:
+		return toModelWebhookDelivery(v)
+	case sqlc.CalendarFeed:
+		return toModelCalendarFeed(v)
+	default:
+		return nil
+	}
+}
+
+// pageArgs maps Relay connection arguments, only asking the service for a total
+// when the client selected totalCount.
+func pageArgs(ctx context.Context, first *int, after *string, last *int, before *string) service.PageArgs {
+	return service.PageArgs{
+		First:     first,
+		After:     after,
+		Last:      last,
+		Before:    before,
+		WithTotal: fieldRequested(ctx, "totalCount"),
+	}
+}
+
+func fieldRequested(ctx context.Context, name string) bool {
+	for _, field := range graphql.CollectAllFields(ctx) {
+		if field == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook event types are "task.created" in the service and TASK_CREATED in
+// the schema.
+func toModelEventType(v string) model.WebhookEventType {
+	return model.WebhookEventType(strings.ToUpper(strings.ReplaceAll(v, ".", "_")))
+}
+
+func fromModelEventTypes(in []model.WebhookEventType) []string {
+	if in == nil {
+		return nil
+	}
+	out := make([]string, 0, len(in))
+	for _, v := range in {
+		out = append(out, strings.ToLower(strings.Replace(string(v), "_", ".", 1)))
+	}
+	return out
+}
+
+func toModelWebhookSubscription(s sqlc.WebhookSubscription) *model.WebhookSubscription {
+	eventTypes := make([]model.WebhookEventType, 0, len(s.EventTypes))
+	for _, v := range s.EventTypes {
+		eventTypes = append(eventTypes, toModelEventType(v))
+	}
+	return &model.WebhookSubscription{
+		ID:         uuidString(s.ID),
+		URL:        s.Url,
+		EventTypes: eventTypes,
+		Active:     s.Active,
+		CreatedAt:  timeValue(s.CreatedAt),
+		UpdatedAt:  timeValue(s.UpdatedAt),
+	}
+}
+
+func toModelWebhookDelivery(d sqlc.WebhookDelivery) *model.WebhookDelivery {
+	var nextAttemptAt *time.Time
+	if d.Status == service.DeliveryPending {
+		nextAttemptAt = timePtr(d.NextAttemptAt)
+	}
+
+	var lastStatusCode *int
+	if d.LastStatusCode != nil {
+		code := int(*d.LastStatusCode)
+		lastStatusCode = &code
+	}
+
+	return &model.WebhookDelivery{
+		ID:             uuidString(d.ID),
+		SubscriptionID: uuidString(d.SubscriptionID),
+		EventID:        uuidString(d.EventID),
+		EventType:      toModelEventType(d.EventType),
+		Status:         model.WebhookDeliveryStatus(d.Status),
+		Attempts:       int(d.Attempts),
+		NextAttemptAt:  nextAttemptAt,
+		LastStatusCode: lastStatusCode,
+		LastError:      stringPtr(d.LastError),
+		DeliveredAt:    timePtr(d.DeliveredAt),
+		CreatedAt:      timeValue(d.CreatedAt),
+		UpdatedAt:      timeValue(d.UpdatedAt),
+	}
+}
+
+func toWebhookDeliveryConnection(page service.PageResult[sqlc.WebhookDelivery]) *model.WebhookDeliveryConnection {
+	edges := make([]*model.WebhookDeliveryEdge, 0, len(page.Edges))
+	for _, edge := range page.Edges {
+		edges = append(edges, &model.WebhookDeliveryEdge{Cursor: edge.Cursor, Node: toModelWebhookDelivery(edge.Node)})
+	}
+	return &model.WebhookDeliveryConnection{
+		Edges:      edges,
+		PageInfo:   toPageInfo(page),
+		TotalCount: page.TotalCount,
+	}
+}
+
+func toModelCalendarFeed(f sqlc.CalendarFeed) *model.CalendarFeed {
+	var projectID *string
+	if f.ProjectID.Valid {
+		id := uuidString(f.ProjectID)
+		projectID = &id
+	}
+	return &model.CalendarFeed{
+		ID:        uuidString(f.ID),
+		ProjectID: projectID,
+		CreatedAt: timeValue(f.CreatedAt),
+	}
+}
+
+func toModelSavedFilter(f sqlc.SavedFilter) *model.SavedFilter {
+	return &model.SavedFilter{
+		ID:         uuidString(f.ID),
+		Name:       f.Name,
+		Expression: f.Expression,
+		CreatedAt:  timeValue(f.CreatedAt),
+		UpdatedAt:  timeValue(f.UpdatedAt),
+	}
+}
+
+var importFormats = map[model.ImportFormat]importer.Format{
+	model.ImportFormatTodoistCSV:  importer.FormatTodoistCSV,
+	model.ImportFormatTodoistJSON: importer.FormatTodoistJSON,
+	model.ImportFormatTrelloJSON:  importer.FormatTrelloJSON,
+	model.ImportFormatCSV:         importer.FormatCSV,
+}
+
+func toModelImportReport(r importer.Report) *model.ImportReport {
+	warnings := r.Warnings
+	if warnings == nil {
+		warnings = []string{}
+	}
+	return &model.ImportReport{
+		DryRun:            r.DryRun,
+		ProjectsCreated:   r.ProjectsCreated,
+		ProjectsReused:    r.ProjectsReused,
+		LabelsCreated:     r.LabelsCreated,
+		LabelsReused:      r.LabelsReused,
+		TasksCreated:      r.TasksCreated,
+		SubtasksCreated:   r.SubtasksCreated,
+		DuplicatesSkipped: r.DuplicatesSkipped,
+		Warnings:          warnings,
 	}
-	raw := fmt.Sprintf("%s|%s", createdAt.UTC().Format(time.RFC3339Nano), uid.String())
-	return base64.StdEncoding.EncodeToString([]byte(raw))
 }
@@ -0,0 +1,39 @@
+package graph
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestToModelNodeCoversEveryNodeType(t *testing.T) {
+	id := uuid.New()
+	pgID := pgtype.UUID{Bytes: id, Valid: true}
+	for _, node := range []any{
+		sqlc.User{ID: pgID},
+		sqlc.Project{ID: pgID},
+		sqlc.Label{ID: pgID},
+		sqlc.Task{ID: pgID},
+		sqlc.ProjectSection{ID: pgID},
+		sqlc.ProjectStatus{ID: pgID},
+		sqlc.CustomField{ID: pgID},
+		sqlc.TimeEntry{ID: pgID},
+		service.Template{ID: id},
+		sqlc.SavedFilter{ID: pgID},
+		sqlc.WebhookSubscription{ID: pgID},
+		sqlc.WebhookDelivery{ID: pgID},
+		sqlc.CalendarFeed{ID: pgID},
+	} {
+		got := toModelNode(node)
+		if got == nil {
+			t.Fatalf("%T does not map to a Node", node)
+		}
+		if gotID := reflect.ValueOf(got).Elem().FieldByName("ID").String(); gotID != id.String() {
+			t.Fatalf("%T: got id %q, want %s", node, gotID, id)
+		}
+	}
+}
@@ -7,16 +7,138 @@ import (
 	"io"
 	"strconv"
 	"time"
+
+	"github.com/99designs/gqlgen/graphql"
 )
 
+type Node interface {
+	IsNode()
+}
+
+// Productivity figures over [from, to) for live tasks of live projects, limited
+// to projectIds when any are given. Buckets are in the user's timezone.
+type Analytics struct {
+	From       time.Time `json:"from"`
+	To         time.Time `json:"to"`
+	ProjectIds []string  `json:"projectIds"`
+	Timezone   string    `json:"timezone"`
+	// Tasks completed per bucket; every bucket of the range is listed.
+	Completed []*AnalyticsBucket `json:"completed"`
+	// Per project, leaving out projects archived before the range.
+	Burndown []*ProjectBurndown `json:"burndown"`
+	LeadTime *LeadTime          `json:"leadTime"`
+	// Time in each status category, from recorded status changes.
+	TimeInStatus      []*StatusTime  `json:"timeInStatus"`
+	OverdueByPriority []*OverdueRate `json:"overdueByPriority"`
+	// A task counts toward each of its labels.
+	OverdueByLabel []*OverdueRate `json:"overdueByLabel"`
+}
+
+type AnalyticsBucket struct {
+	// First day of the bucket in the analytics timezone, YYYY-MM-DD.
+	Date  string `json:"date"`
+	Count int    `json:"count"`
+}
+
+type Board struct {
+	Project *Project       `json:"project"`
+	Columns []*BoardColumn `json:"columns"`
+}
+
+type BoardColumn struct {
+	Status *ProjectStatus `json:"status"`
+	// Root tasks currently in the status.
+	TaskCount int `json:"taskCount"`
+	// True when taskCount exceeds wipLimit, e.g. after the limit was lowered.
+	OverWipLimit bool `json:"overWipLimit"`
+	// Root tasks in the status, newest first.
+	Tasks *TaskConnection `json:"tasks"`
+}
+
+type CalendarFeed struct {
+	ID string `json:"id"`
+	// Null for the feed of every project.
+	ProjectID *string   `json:"projectId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (CalendarFeed) IsNode() {}
+
+type CalendarFeedPayload struct {
+	Feed *CalendarFeed `json:"feed"`
+	// Subscription URL containing the feed's secret token. It is only returned
+	// here; append ?kind=todo for VTODO entries instead of VEVENTs.
+	URL string `json:"url"`
+}
+
+// How much a user can work. weeklyMinutes is split evenly over workDays.
+type Capacity struct {
+	WeeklyMinutes int `json:"weeklyMinutes"`
+	// Monday first.
+	WorkDays []Weekday `json:"workDays"`
+	// Working hours of a work day as HH:MM in the user's timezone.
+	WorkdayStart string `json:"workdayStart"`
+	WorkdayEnd   string `json:"workdayEnd"`
+}
+
+type CapacityDay struct {
+	// Calendar day in the user's timezone, YYYY-MM-DD.
+	Date            string `json:"date"`
+	CapacityMinutes int    `json:"capacityMinutes"`
+	PlannedMinutes  int    `json:"plannedMinutes"`
+	// True when plannedMinutes exceeds capacityMinutes.
+	Overloaded bool            `json:"overloaded"`
+	Tasks      []*CapacityTask `json:"tasks"`
+}
+
+type CapacityPlan struct {
+	Capacity *Capacity `json:"capacity"`
+	// Every day of the range, in order.
+	Days []*CapacityDay `json:"days"`
+}
+
+// The part of a task's estimate planned on one day.
+type CapacityTask struct {
+	Task    *Task `json:"task"`
+	Minutes int   `json:"minutes"`
+}
+
+type CreateCustomFieldInput struct {
+	ProjectID string          `json:"projectId"`
+	Name      string          `json:"name"`
+	Type      CustomFieldType `json:"type"`
+	// Required for SELECT fields and not allowed for the others.
+	Options  []string `json:"options"`
+	Required *bool    `json:"required"`
+}
+
 type CreateLabelInput struct {
 	Name string `json:"name"`
 }
 
 type CreateProjectInput struct {
-	Title       string  `json:"title"`
-	Description *string `json:"description"`
-	Color       *string `json:"color"`
+	Title               string  `json:"title"`
+	Description         *string `json:"description"`
+	Color               *string `json:"color"`
+	AutoCompleteParents *bool   `json:"autoCompleteParents"`
+}
+
+type CreateProjectSectionInput struct {
+	ProjectID string `json:"projectId"`
+	Name      string `json:"name"`
+}
+
+type CreateProjectStatusInput struct {
+	ProjectID string     `json:"projectId"`
+	Name      string     `json:"name"`
+	Category  TaskStatus `json:"category"`
+	Color     *string    `json:"color"`
+	WipLimit  *int       `json:"wipLimit"`
+}
+
+type CreateSavedFilterInput struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
 }
 
 type CreateTaskInput struct {
@@ -29,6 +151,71 @@ type CreateTaskInput struct {
 	StartAt      *time.Time    `json:"startAt"`
 	DueAt        *time.Time    `json:"dueAt"`
 	LabelIds     []string      `json:"labelIds"`
+	SectionID    *string       `json:"sectionId"`
+	// Workflow status of the project; overrides status. Without it the task goes to the first status of the status category.
+	StatusID *string `json:"statusId"`
+	// Kept only when the task starts out BLOCKED.
+	BlockedReason *string `json:"blockedReason"`
+	// Every required custom field of the project must be given.
+	CustomFields    []*CustomFieldValueInput `json:"customFields"`
+	EstimateMinutes *int                     `json:"estimateMinutes"`
+}
+
+type CreateTimeEntryInput struct {
+	TaskID    string    `json:"taskId"`
+	StartedAt time.Time `json:"startedAt"`
+	// Must be after startedAt and not in the future.
+	EndedAt time.Time `json:"endedAt"`
+	Note    *string   `json:"note"`
+}
+
+type CreateWebhookSubscriptionInput struct {
+	URL string `json:"url"`
+	// Shared secret for the X-Zenlist-Signature HMAC. At least 16 characters.
+	Secret     string             `json:"secret"`
+	EventTypes []WebhookEventType `json:"eventTypes"`
+}
+
+// A user-defined task attribute of a project.
+type CustomField struct {
+	ID        string          `json:"id"`
+	ProjectID string          `json:"projectId"`
+	Name      string          `json:"name"`
+	Type      CustomFieldType `json:"type"`
+	// Choices of a SELECT field, in order; empty for other types.
+	Options []string `json:"options"`
+	// Required fields must be set on new tasks and cannot be cleared.
+	Required bool `json:"required"`
+	// 0-based display order within the project.
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (CustomField) IsNode() {}
+
+type CustomFieldFilterInput struct {
+	FieldID string `json:"fieldId"`
+	// Matches tasks with this value; null matches tasks without a value.
+	Value *string `json:"value"`
+}
+
+type CustomFieldSortInput struct {
+	FieldID   string         `json:"fieldId"`
+	Direction *SortDirection `json:"direction"`
+}
+
+type CustomFieldValue struct {
+	Field *CustomField `json:"field"`
+	// The value in canonical form: numbers without trailing zeros, dates as
+	// YYYY-MM-DD, checkboxes as true or false.
+	Value string `json:"value"`
+}
+
+type CustomFieldValueInput struct {
+	FieldID string `json:"fieldId"`
+	// Read according to the field's type. Null or blank clears the value.
+	Value *string `json:"value"`
 }
 
 type DeletePayload struct {
@@ -36,6 +223,37 @@ type DeletePayload struct {
 	DeletedAt time.Time `json:"deletedAt"`
 }
 
+type ExportLink struct {
+	// Download URL. It is signed, needs no other credentials and stops working at expiresAt.
+	URL       string       `json:"url"`
+	Format    ExportFormat `json:"format"`
+	ExpiresAt time.Time    `json:"expiresAt"`
+}
+
+type ImportDataInput struct {
+	File   graphql.Upload `json:"file"`
+	Format ImportFormat   `json:"format"`
+	// Project for files that do not name one (Todoist CSV), and an override for
+	// the Trello board name. Defaults to the uploaded file name.
+	ProjectTitle *string `json:"projectTitle"`
+	// Report what would be imported without writing anything.
+	DryRun *bool `json:"dryRun"`
+}
+
+type ImportReport struct {
+	DryRun          bool `json:"dryRun"`
+	ProjectsCreated int  `json:"projectsCreated"`
+	// Existing projects with the same title that tasks were added to.
+	ProjectsReused  int `json:"projectsReused"`
+	LabelsCreated   int `json:"labelsCreated"`
+	LabelsReused    int `json:"labelsReused"`
+	TasksCreated    int `json:"tasksCreated"`
+	SubtasksCreated int `json:"subtasksCreated"`
+	// Tasks skipped because a task with the same title, parent and due date already exists.
+	DuplicatesSkipped int      `json:"duplicatesSkipped"`
+	Warnings          []string `json:"warnings"`
+}
+
 type Label struct {
 	ID        string    `json:"id"`
 	UserID    string    `json:"userId"`
@@ -44,9 +262,12 @@ type Label struct {
 	UpdatedAt time.Time `json:"updatedAt"`
 }
 
+func (Label) IsNode() {}
+
 type LabelConnection struct {
-	Edges    []*LabelEdge `json:"edges"`
-	PageInfo *PageInfo    `json:"pageInfo"`
+	Edges      []*LabelEdge `json:"edges"`
+	PageInfo   *PageInfo    `json:"pageInfo"`
+	TotalCount *int         `json:"totalCount"`
 }
 
 type LabelEdge struct {
@@ -54,24 +275,67 @@ type LabelEdge struct {
 	Node   *Label `json:"node"`
 }
 
+// Creation to completion of the tasks completed in the range.
+type LeadTime struct {
+	CompletedCount int `json:"completedCount"`
+	// Null when no task was completed.
+	AverageSeconds *float64 `json:"averageSeconds"`
+	MedianSeconds  *float64 `json:"medianSeconds"`
+}
+
+// Tasks due in the range, up to now, and how many of them were completed after
+// their dueAt or are still open.
+type OverdueRate struct {
+	// Set in overdueByPriority.
+	Priority *TaskPriority `json:"priority"`
+	// Set in overdueByLabel.
+	Label        *Label `json:"label"`
+	DueCount     int    `json:"dueCount"`
+	OverdueCount int    `json:"overdueCount"`
+	// overdueCount / dueCount.
+	Rate float64 `json:"rate"`
+}
+
 type PageInfo struct {
-	EndCursor   *string `json:"endCursor"`
-	HasNextPage bool    `json:"hasNextPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	HasNextPage     bool    `json:"hasNextPage"`
 }
 
 type Project struct {
-	ID          string    `json:"id"`
-	UserID      string    `json:"userId"`
-	Title       string    `json:"title"`
-	Description *string   `json:"description"`
-	Color       *string   `json:"color"`
-	CreatedAt   time.Time `json:"createdAt"`
-	UpdatedAt   time.Time `json:"updatedAt"`
+	ID          string  `json:"id"`
+	UserID      string  `json:"userId"`
+	Title       string  `json:"title"`
+	Description *string `json:"description"`
+	Color       *string `json:"color"`
+	// Set while the project is archived. Archived projects accept no new tasks.
+	ArchivedAt *time.Time `json:"archivedAt"`
+	// When true, finishing a task's last open subtask completes the task, and
+	// reopening a subtask moves a DONE parent back to TODO.
+	AutoCompleteParents bool      `json:"autoCompleteParents"`
+	CreatedAt           time.Time `json:"createdAt"`
+	UpdatedAt           time.Time `json:"updatedAt"`
+	// Sections in display order.
+	Sections []*ProjectSection `json:"sections"`
+	// Workflow statuses (board columns) in display order.
+	Statuses []*ProjectStatus `json:"statuses"`
+	// Custom fields in display order.
+	CustomFields []*CustomField `json:"customFields"`
+}
+
+func (Project) IsNode() {}
+
+type ProjectBurndown struct {
+	Project *Project `json:"project"`
+	// Open tasks at the end of each bucket; the last one ends at the range's to.
+	Points []*AnalyticsBucket `json:"points"`
 }
 
 type ProjectConnection struct {
-	Edges    []*ProjectEdge `json:"edges"`
-	PageInfo *PageInfo      `json:"pageInfo"`
+	Edges      []*ProjectEdge `json:"edges"`
+	PageInfo   *PageInfo      `json:"pageInfo"`
+	TotalCount *int           `json:"totalCount"`
 }
 
 type ProjectEdge struct {
@@ -79,27 +343,160 @@ type ProjectEdge struct {
 	Node   *Project `json:"node"`
 }
 
+// A named, ordered group of root tasks within a project.
+type ProjectSection struct {
+	ID        string `json:"id"`
+	ProjectID string `json:"projectId"`
+	Name      string `json:"name"`
+	// 0-based display order within the project.
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+	// Root tasks filed under this section, newest first.
+	Tasks *TaskConnection `json:"tasks"`
+}
+
+func (ProjectSection) IsNode() {}
+
+// A workflow status of a project, shown as a board column. category is the
+// TaskStatus reported for tasks in it.
+type ProjectStatus struct {
+	ID        string     `json:"id"`
+	ProjectID string     `json:"projectId"`
+	Name      string     `json:"name"`
+	Category  TaskStatus `json:"category"`
+	Color     *string    `json:"color"`
+	// Maximum number of root tasks in the status, or null for no limit.
+	WipLimit *int `json:"wipLimit"`
+	// 0-based display order within the project.
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (ProjectStatus) IsNode() {}
+
+type QuickAddTaskPayload struct {
+	Task *Task `json:"task"`
+	// Labels created because createLabels was set.
+	CreatedLabels []*Label `json:"createdLabels"`
+}
+
+// A named filter expression, e.g. "priority <= P2 AND label:urgent AND due < +3d".
+// See tasksByFilter for the syntax.
+type SavedFilter struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Expression string    `json:"expression"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+func (SavedFilter) IsNode() {}
+
+type SchedulePlan struct {
+	Preview bool `json:"preview"`
+	// In order of startAt.
+	Scheduled   []*ScheduledTask   `json:"scheduled"`
+	Unscheduled []*UnscheduledTask `json:"unscheduled"`
+}
+
+type ScheduledTask struct {
+	Task    *Task     `json:"task"`
+	StartAt time.Time `json:"startAt"`
+	// startAt plus the task's estimate.
+	EndAt time.Time `json:"endAt"`
+}
+
+type SetCapacityInput struct {
+	WeeklyMinutes int `json:"weeklyMinutes"`
+	// Required unless weeklyMinutes is 0.
+	WorkDays []Weekday `json:"workDays"`
+	// HH:MM; the current working hours are kept when omitted.
+	WorkdayStart *string `json:"workdayStart"`
+	// HH:MM, after workdayStart; 24:00 is the end of the day.
+	WorkdayEnd *string `json:"workdayEnd"`
+}
+
+type SetTransitionPolicyInput struct {
+	ProjectID string `json:"projectId"`
+	// Allowed moves. Omit or leave empty to allow every move.
+	Transitions  []*StatusTransitionInput  `json:"transitions"`
+	Requirements []*StatusRequirementInput `json:"requirements"`
+}
+
+// Fields a task must have set to enter status.
+type StatusRequirement struct {
+	Status TaskStatus              `json:"status"`
+	Fields []TransitionRequirement `json:"fields"`
+}
+
+type StatusRequirementInput struct {
+	Status TaskStatus              `json:"status"`
+	Fields []TransitionRequirement `json:"fields"`
+}
+
+type StatusTime struct {
+	Status TaskStatus `json:"status"`
+	// Tasks that spent time in the status within the range.
+	TaskCount      int     `json:"taskCount"`
+	TotalSeconds   float64 `json:"totalSeconds"`
+	AverageSeconds float64 `json:"averageSeconds"`
+}
+
+type StatusTransition struct {
+	From TaskStatus `json:"from"`
+	To   TaskStatus `json:"to"`
+}
+
+type StatusTransitionInput struct {
+	From TaskStatus `json:"from"`
+	To   TaskStatus `json:"to"`
+}
+
 type Task struct {
-	ID           string       `json:"id"`
-	UserID       string       `json:"userId"`
-	ProjectID    string       `json:"projectId"`
-	ParentTaskID *string      `json:"parentTaskId"`
-	Title        string       `json:"title"`
-	Description  *string      `json:"description"`
-	Status       TaskStatus   `json:"status"`
-	Priority     TaskPriority `json:"priority"`
-	StartAt      *time.Time   `json:"startAt"`
-	DueAt        *time.Time   `json:"dueAt"`
-	CompletedAt  *time.Time   `json:"completedAt"`
-	CreatedAt    time.Time    `json:"createdAt"`
-	UpdatedAt    time.Time    `json:"updatedAt"`
-	Labels       []*Label     `json:"labels"`
-	Subtasks     []*Task      `json:"subtasks"`
+	ID           string  `json:"id"`
+	UserID       string  `json:"userId"`
+	ProjectID    string  `json:"projectId"`
+	ParentTaskID *string `json:"parentTaskId"`
+	Title        string  `json:"title"`
+	Description  *string `json:"description"`
+	// Category of the task's workflow status.
+	Status TaskStatus `json:"status"`
+	// Workflow status (board column) of the project the task is in.
+	StatusID string `json:"statusId"`
+	// Why the task is blocked; only set while status is BLOCKED.
+	BlockedReason *string      `json:"blockedReason"`
+	Priority      TaskPriority `json:"priority"`
+	// Expected effort in minutes.
+	EstimateMinutes *int       `json:"estimateMinutes"`
+	StartAt         *time.Time `json:"startAt"`
+	DueAt           *time.Time `json:"dueAt"`
+	CompletedAt     *time.Time `json:"completedAt"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+	// Section of the project the task is filed under; always null for subtasks.
+	SectionID *string `json:"sectionId"`
+	// RFC 5545 RRULE, e.g. FREQ=MONTHLY, or null for one-off tasks.
+	Recurrence *string  `json:"recurrence"`
+	Labels     []*Label `json:"labels"`
+	Subtasks   []*Task  `json:"subtasks"`
+	// Completion of the task's live subtasks.
+	Progress *TaskProgress `json:"progress"`
+	// Values of the custom fields set on the task, in field order.
+	CustomFields []*CustomFieldValue `json:"customFields"`
+	// Seconds tracked on the task and its live subtasks, running timers included.
+	TimeSpent int `json:"timeSpent"`
+	// The task's own time entries, latest first.
+	TimeEntries []*TimeEntry `json:"timeEntries"`
 }
 
+func (Task) IsNode() {}
+
 type TaskConnection struct {
-	Edges    []*TaskEdge `json:"edges"`
-	PageInfo *PageInfo   `json:"pageInfo"`
+	Edges      []*TaskEdge `json:"edges"`
+	PageInfo   *PageInfo   `json:"pageInfo"`
+	TotalCount *int        `json:"totalCount"`
 }
 
 type TaskEdge struct {
@@ -107,6 +504,126 @@ type TaskEdge struct {
 	Node   *Task  `json:"node"`
 }
 
+type TaskProgress struct {
+	Total int `json:"total"`
+	Done  int `json:"done"`
+	// done as a whole percentage of total, rounded down; 0 without subtasks.
+	Percent int `json:"percent"`
+}
+
+type Template struct {
+	ID   string       `json:"id"`
+	Name string       `json:"name"`
+	Kind TemplateKind `json:"kind"`
+	// Set for PROJECT templates.
+	Project *TemplateProject `json:"project"`
+	// Top-level tasks in creation order; a TASK template has one.
+	Tasks     []*TemplateTask `json:"tasks"`
+	CreatedAt time.Time       `json:"createdAt"`
+	UpdatedAt time.Time       `json:"updatedAt"`
+}
+
+func (Template) IsNode() {}
+
+// A date relative to the day a template is instantiated on.
+type TemplateDate struct {
+	// Days after the start date.
+	Days int `json:"days"`
+	// Time of day as HH:MM in the user's timezone.
+	Time string `json:"time"`
+}
+
+type TemplateInstance struct {
+	// The new project, or the project a TASK template was added to.
+	Project *Project `json:"project"`
+	// Every task created, parents before their subtasks.
+	Tasks []*Task `json:"tasks"`
+}
+
+type TemplateProject struct {
+	Title               string  `json:"title"`
+	Description         *string `json:"description"`
+	Color               *string `json:"color"`
+	AutoCompleteParents bool    `json:"autoCompleteParents"`
+}
+
+type TemplateTask struct {
+	Title           string       `json:"title"`
+	Description     *string      `json:"description"`
+	Priority        TaskPriority `json:"priority"`
+	EstimateMinutes *int         `json:"estimateMinutes"`
+	// Labels that have been deleted since are skipped when instantiating.
+	LabelIds []string        `json:"labelIds"`
+	StartAt  *TemplateDate   `json:"startAt"`
+	DueAt    *TemplateDate   `json:"dueAt"`
+	Subtasks []*TemplateTask `json:"subtasks"`
+}
+
+// Time tracked on a task. An entry without endedAt is a running timer.
+type TimeEntry struct {
+	ID        string     `json:"id"`
+	TaskID    string     `json:"taskId"`
+	StartedAt time.Time  `json:"startedAt"`
+	EndedAt   *time.Time `json:"endedAt"`
+	// Length of the entry in seconds; running timers count up to now.
+	DurationSeconds int       `json:"durationSeconds"`
+	Note            *string   `json:"note"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+func (TimeEntry) IsNode() {}
+
+type TimeRange struct {
+	From *time.Time `json:"from"`
+	To   *time.Time `json:"to"`
+}
+
+type TimeReport struct {
+	From    time.Time       `json:"from"`
+	To      time.Time       `json:"to"`
+	GroupBy TimeReportGroup `json:"groupBy"`
+	// Tracked seconds in the range; time on a task with several labels counts once.
+	TotalSeconds int `json:"totalSeconds"`
+	// PROJECT and LABEL buckets are ordered by time, largest first. DAY lists
+	// every day of the range in order.
+	Buckets []*TimeReportBucket `json:"buckets"`
+}
+
+type TimeReportBucket struct {
+	// Project or label ID, or the day as YYYY-MM-DD. Null for time on unlabelled tasks.
+	Key     *string `json:"key"`
+	Name    string  `json:"name"`
+	Seconds int     `json:"seconds"`
+}
+
+// How a project's tasks may move between status categories. Moves between
+// workflow statuses of the same category are always allowed. New tasks are held
+// to the requirements of the status they start in.
+type TransitionPolicy struct {
+	ProjectID string `json:"projectId"`
+	// False when every move is allowed; transitions then lists all of them.
+	Restricted bool `json:"restricted"`
+	// Allowed from -> to moves.
+	Transitions  []*StatusTransition  `json:"transitions"`
+	Requirements []*StatusRequirement `json:"requirements"`
+}
+
+type UnscheduledTask struct {
+	Task   *Task             `json:"task"`
+	Reason UnscheduledReason `json:"reason"`
+}
+
+type UpdateCustomFieldInput struct {
+	ID   string  `json:"id"`
+	Name *string `json:"name"`
+	// Replaces a SELECT field's options. Options still used by tasks cannot be removed.
+	Options  []string `json:"options"`
+	Required *bool    `json:"required"`
+	// New position; other fields shift to make room. Out-of-range values are clamped.
+	Position *int `json:"position"`
+}
+
 type UpdateLabelInput struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
@@ -117,6 +634,34 @@ type UpdateProjectInput struct {
 	Title       string  `json:"title"`
 	Description *string `json:"description"`
 	Color       *string `json:"color"`
+	// Omit to keep the current setting.
+	AutoCompleteParents *bool `json:"autoCompleteParents"`
+}
+
+type UpdateProjectSectionInput struct {
+	ID   string  `json:"id"`
+	Name *string `json:"name"`
+	// New position; other sections shift to make room. Out-of-range values are clamped.
+	Position *int `json:"position"`
+}
+
+type UpdateProjectStatusInput struct {
+	ID   string  `json:"id"`
+	Name *string `json:"name"`
+	// Changing the category re-categorises the tasks already in the status.
+	Category      *TaskStatus `json:"category"`
+	Color         *string     `json:"color"`
+	ClearColor    *bool       `json:"clearColor"`
+	WipLimit      *int        `json:"wipLimit"`
+	ClearWipLimit *bool       `json:"clearWipLimit"`
+	// New position; other statuses shift to make room. Out-of-range values are clamped.
+	Position *int `json:"position"`
+}
+
+type UpdateSavedFilterInput struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
 }
 
 type UpdateTaskInput struct {
@@ -128,6 +673,41 @@ type UpdateTaskInput struct {
 	StartAt     *time.Time    `json:"startAt"`
 	DueAt       *time.Time    `json:"dueAt"`
 	LabelIds    []string      `json:"labelIds"`
+	SectionID   *string       `json:"sectionId"`
+	// Removes the task from its section; takes precedence over sectionId.
+	ClearSection *bool `json:"clearSection"`
+	// Moves the task to a workflow status of its project; overrides status. A
+	// status alone moves the task to the first workflow status of that category.
+	StatusID *string `json:"statusId"`
+	// Cleared automatically when the task leaves BLOCKED.
+	BlockedReason *string `json:"blockedReason"`
+	// Sets or clears the listed custom fields; others are left unchanged.
+	CustomFields    []*CustomFieldValueInput `json:"customFields"`
+	EstimateMinutes *int                     `json:"estimateMinutes"`
+	// Removes the estimate; takes precedence over estimateMinutes.
+	ClearEstimate *bool `json:"clearEstimate"`
+	// Replaces the RRULE; an empty rule stops the task repeating.
+	Recurrence *string `json:"recurrence"`
+	// Stops the task repeating; takes precedence over recurrence.
+	ClearRecurrence *bool `json:"clearRecurrence"`
+}
+
+type UpdateTimeEntryInput struct {
+	ID string `json:"id"`
+	// Moves the entry to another task.
+	TaskID    *string    `json:"taskId"`
+	StartedAt *time.Time `json:"startedAt"`
+	// Setting this on a running timer stops it.
+	EndedAt *time.Time `json:"endedAt"`
+	Note    *string    `json:"note"`
+}
+
+type UpdateWebhookSubscriptionInput struct {
+	ID         string             `json:"id"`
+	URL        *string            `json:"url"`
+	Secret     *string            `json:"secret"`
+	EventTypes []WebhookEventType `json:"eventTypes"`
+	Active     *bool              `json:"active"`
 }
 
 type UpsertMeInput struct {
@@ -145,96 +725,740 @@ type User struct {
 	AvatarURL *string   `json:"avatarUrl"`
 	CreatedAt time.Time `json:"createdAt"`
 	UpdatedAt time.Time `json:"updatedAt"`
+	// Defaults to 40 hours over Monday to Friday, 09:00 to 17:00.
+	Capacity *Capacity `json:"capacity"`
 }
 
-type TaskPriority string
+func (User) IsNode() {}
+
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	SubscriptionID string                `json:"subscriptionId"`
+	EventID        string                `json:"eventId"`
+	EventType      WebhookEventType      `json:"eventType"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int                   `json:"attempts"`
+	// When the next attempt is due. Null once the delivery is DELIVERED or DEAD.
+	NextAttemptAt  *time.Time `json:"nextAttemptAt"`
+	LastStatusCode *int       `json:"lastStatusCode"`
+	LastError      *string    `json:"lastError"`
+	DeliveredAt    *time.Time `json:"deliveredAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+func (WebhookDelivery) IsNode() {}
+
+type WebhookDeliveryConnection struct {
+	Edges      []*WebhookDeliveryEdge `json:"edges"`
+	PageInfo   *PageInfo              `json:"pageInfo"`
+	TotalCount *int                   `json:"totalCount"`
+}
+
+type WebhookDeliveryEdge struct {
+	Cursor string           `json:"cursor"`
+	Node   *WebhookDelivery `json:"node"`
+}
+
+type WebhookSubscription struct {
+	ID  string `json:"id"`
+	URL string `json:"url"`
+	// Events delivered to this URL. Empty means every event.
+	EventTypes []WebhookEventType `json:"eventTypes"`
+	Active     bool               `json:"active"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	UpdatedAt  time.Time          `json:"updatedAt"`
+}
+
+func (WebhookSubscription) IsNode() {}
+
+type AnalyticsInterval string
 
 const (
-	TaskPriorityP1 TaskPriority = "P1"
-	TaskPriorityP2 TaskPriority = "P2"
-	TaskPriorityP3 TaskPriority = "P3"
-	TaskPriorityP4 TaskPriority = "P4"
-	TaskPriorityP5 TaskPriority = "P5"
+	AnalyticsIntervalDay AnalyticsInterval = "DAY"
+	// Weeks start on Monday.
+	AnalyticsIntervalWeek AnalyticsInterval = "WEEK"
 )
 
-var AllTaskPriority = []TaskPriority{
-	TaskPriorityP1,
-	TaskPriorityP2,
-	TaskPriorityP3,
-	TaskPriorityP4,
-	TaskPriorityP5,
+var AllAnalyticsInterval = []AnalyticsInterval{
+	AnalyticsIntervalDay,
+	AnalyticsIntervalWeek,
 }
 
-func (e TaskPriority) IsValid() bool {
+func (e AnalyticsInterval) IsValid() bool {
 	switch e {
-	case TaskPriorityP1, TaskPriorityP2, TaskPriorityP3, TaskPriorityP4, TaskPriorityP5:
+	case AnalyticsIntervalDay, AnalyticsIntervalWeek:
 		return true
 	}
 	return false
 }
 
-func (e TaskPriority) String() string {
+func (e AnalyticsInterval) String() string {
 	return string(e)
 }
 
-func (e *TaskPriority) UnmarshalGQL(v interface{}) error {
+func (e *AnalyticsInterval) UnmarshalGQL(v interface{}) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = TaskPriority(str)
+	*e = AnalyticsInterval(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid TaskPriority", str)
+		return fmt.Errorf("%s is not a valid AnalyticsInterval", str)
 	}
 	return nil
 }
 
-func (e TaskPriority) MarshalGQL(w io.Writer) {
+func (e AnalyticsInterval) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
 
-type TaskStatus string
+type CustomFieldType string
 
 const (
-	TaskStatusTodo       TaskStatus = "TODO"
-	TaskStatusInProgress TaskStatus = "IN_PROGRESS"
-	TaskStatusBlocked    TaskStatus = "BLOCKED"
-	TaskStatusDone       TaskStatus = "DONE"
+	CustomFieldTypeText   CustomFieldType = "TEXT"
+	CustomFieldTypeNumber CustomFieldType = "NUMBER"
+	// A calendar date written YYYY-MM-DD.
+	CustomFieldTypeDate CustomFieldType = "DATE"
+	// One of the field's options.
+	CustomFieldTypeSelect CustomFieldType = "SELECT"
+	// An http or https URL.
+	CustomFieldTypeURL CustomFieldType = "URL"
+	// true or false.
+	CustomFieldTypeCheckbox CustomFieldType = "CHECKBOX"
 )
 
-var AllTaskStatus = []TaskStatus{
-	TaskStatusTodo,
-	TaskStatusInProgress,
-	TaskStatusBlocked,
-	TaskStatusDone,
+var AllCustomFieldType = []CustomFieldType{
+	CustomFieldTypeText,
+	CustomFieldTypeNumber,
+	CustomFieldTypeDate,
+	CustomFieldTypeSelect,
+	CustomFieldTypeURL,
+	CustomFieldTypeCheckbox,
 }
 
-func (e TaskStatus) IsValid() bool {
+func (e CustomFieldType) IsValid() bool {
 	switch e {
-	case TaskStatusTodo, TaskStatusInProgress, TaskStatusBlocked, TaskStatusDone:
+	case CustomFieldTypeText, CustomFieldTypeNumber, CustomFieldTypeDate, CustomFieldTypeSelect, CustomFieldTypeURL, CustomFieldTypeCheckbox:
 		return true
 	}
 	return false
 }
 
-func (e TaskStatus) String() string {
+func (e CustomFieldType) String() string {
 	return string(e)
 }
 
-func (e *TaskStatus) UnmarshalGQL(v interface{}) error {
+func (e *CustomFieldType) UnmarshalGQL(v interface{}) error {
 	str, ok := v.(string)
 	if !ok {
 		return fmt.Errorf("enums must be strings")
 	}
 
-	*e = TaskStatus(str)
+	*e = CustomFieldType(str)
 	if !e.IsValid() {
-		return fmt.Errorf("%s is not a valid TaskStatus", str)
+		return fmt.Errorf("%s is not a valid CustomFieldType", str)
 	}
 	return nil
 }
 
-func (e TaskStatus) MarshalGQL(w io.Writer) {
+func (e CustomFieldType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ExportFormat string
+
+const (
+	// One versioned JSON document.
+	ExportFormatJSON ExportFormat = "JSON"
+	// A zip with a CSV file per entity.
+	ExportFormatCSV ExportFormat = "CSV"
+	// A Markdown checklist per project.
+	ExportFormatMarkdown ExportFormat = "MARKDOWN"
+)
+
+var AllExportFormat = []ExportFormat{
+	ExportFormatJSON,
+	ExportFormatCSV,
+	ExportFormatMarkdown,
+}
+
+func (e ExportFormat) IsValid() bool {
+	switch e {
+	case ExportFormatJSON, ExportFormatCSV, ExportFormatMarkdown:
+		return true
+	}
+	return false
+}
+
+func (e ExportFormat) String() string {
+	return string(e)
+}
+
+func (e *ExportFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ExportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ExportFormat", str)
+	}
+	return nil
+}
+
+func (e ExportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type ImportFormat string
+
+const (
+	ImportFormatTodoistCSV  ImportFormat = "TODOIST_CSV"
+	ImportFormatTodoistJSON ImportFormat = "TODOIST_JSON"
+	ImportFormatTrelloJSON  ImportFormat = "TRELLO_JSON"
+	// Generic CSV; see the README for the columns.
+	ImportFormatCSV ImportFormat = "CSV"
+)
+
+var AllImportFormat = []ImportFormat{
+	ImportFormatTodoistCSV,
+	ImportFormatTodoistJSON,
+	ImportFormatTrelloJSON,
+	ImportFormatCSV,
+}
+
+func (e ImportFormat) IsValid() bool {
+	switch e {
+	case ImportFormatTodoistCSV, ImportFormatTodoistJSON, ImportFormatTrelloJSON, ImportFormatCSV:
+		return true
+	}
+	return false
+}
+
+func (e ImportFormat) String() string {
+	return string(e)
+}
+
+func (e *ImportFormat) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ImportFormat(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ImportFormat", str)
+	}
+	return nil
+}
+
+func (e ImportFormat) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type LabelMatch string
+
+const (
+	// Tasks with at least one of the labels.
+	LabelMatchAny LabelMatch = "ANY"
+	// Tasks with every one of the labels.
+	LabelMatchAll LabelMatch = "ALL"
+)
+
+var AllLabelMatch = []LabelMatch{
+	LabelMatchAny,
+	LabelMatchAll,
+}
+
+func (e LabelMatch) IsValid() bool {
+	switch e {
+	case LabelMatchAny, LabelMatchAll:
+		return true
+	}
+	return false
+}
+
+func (e LabelMatch) String() string {
+	return string(e)
+}
+
+func (e *LabelMatch) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LabelMatch(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LabelMatch", str)
+	}
+	return nil
+}
+
+func (e LabelMatch) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type SortDirection string
+
+const (
+	SortDirectionAsc  SortDirection = "ASC"
+	SortDirectionDesc SortDirection = "DESC"
+)
+
+var AllSortDirection = []SortDirection{
+	SortDirectionAsc,
+	SortDirectionDesc,
+}
+
+func (e SortDirection) IsValid() bool {
+	switch e {
+	case SortDirectionAsc, SortDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e SortDirection) String() string {
+	return string(e)
+}
+
+func (e *SortDirection) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SortDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SortDirection", str)
+	}
+	return nil
+}
+
+func (e SortDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TaskPriority string
+
+const (
+	TaskPriorityP1 TaskPriority = "P1"
+	TaskPriorityP2 TaskPriority = "P2"
+	TaskPriorityP3 TaskPriority = "P3"
+	TaskPriorityP4 TaskPriority = "P4"
+	TaskPriorityP5 TaskPriority = "P5"
+)
+
+var AllTaskPriority = []TaskPriority{
+	TaskPriorityP1,
+	TaskPriorityP2,
+	TaskPriorityP3,
+	TaskPriorityP4,
+	TaskPriorityP5,
+}
+
+func (e TaskPriority) IsValid() bool {
+	switch e {
+	case TaskPriorityP1, TaskPriorityP2, TaskPriorityP3, TaskPriorityP4, TaskPriorityP5:
+		return true
+	}
+	return false
+}
+
+func (e TaskPriority) String() string {
+	return string(e)
+}
+
+func (e *TaskPriority) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TaskPriority(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TaskPriority", str)
+	}
+	return nil
+}
+
+func (e TaskPriority) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TaskStatus string
+
+const (
+	TaskStatusTodo       TaskStatus = "TODO"
+	TaskStatusInProgress TaskStatus = "IN_PROGRESS"
+	TaskStatusBlocked    TaskStatus = "BLOCKED"
+	TaskStatusDone       TaskStatus = "DONE"
+)
+
+var AllTaskStatus = []TaskStatus{
+	TaskStatusTodo,
+	TaskStatusInProgress,
+	TaskStatusBlocked,
+	TaskStatusDone,
+}
+
+func (e TaskStatus) IsValid() bool {
+	switch e {
+	case TaskStatusTodo, TaskStatusInProgress, TaskStatusBlocked, TaskStatusDone:
+		return true
+	}
+	return false
+}
+
+func (e TaskStatus) String() string {
+	return string(e)
+}
+
+func (e *TaskStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TaskStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TaskStatus", str)
+	}
+	return nil
+}
+
+func (e TaskStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TemplateKind string
+
+const (
+	// A project with its tasks.
+	TemplateKindProject TemplateKind = "PROJECT"
+	// One task with its subtasks.
+	TemplateKindTask TemplateKind = "TASK"
+)
+
+var AllTemplateKind = []TemplateKind{
+	TemplateKindProject,
+	TemplateKindTask,
+}
+
+func (e TemplateKind) IsValid() bool {
+	switch e {
+	case TemplateKindProject, TemplateKindTask:
+		return true
+	}
+	return false
+}
+
+func (e TemplateKind) String() string {
+	return string(e)
+}
+
+func (e *TemplateKind) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TemplateKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TemplateKind", str)
+	}
+	return nil
+}
+
+func (e TemplateKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TimeReportGroup string
+
+const (
+	TimeReportGroupProject TimeReportGroup = "PROJECT"
+	TimeReportGroupLabel   TimeReportGroup = "LABEL"
+	// Calendar days in the user's timezone.
+	TimeReportGroupDay TimeReportGroup = "DAY"
+)
+
+var AllTimeReportGroup = []TimeReportGroup{
+	TimeReportGroupProject,
+	TimeReportGroupLabel,
+	TimeReportGroupDay,
+}
+
+func (e TimeReportGroup) IsValid() bool {
+	switch e {
+	case TimeReportGroupProject, TimeReportGroupLabel, TimeReportGroupDay:
+		return true
+	}
+	return false
+}
+
+func (e TimeReportGroup) String() string {
+	return string(e)
+}
+
+func (e *TimeReportGroup) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TimeReportGroup(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TimeReportGroup", str)
+	}
+	return nil
+}
+
+func (e TimeReportGroup) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type TransitionRequirement string
+
+const (
+	// Task.blockedReason; only valid for BLOCKED.
+	TransitionRequirementBlockedReason TransitionRequirement = "BLOCKED_REASON"
+	TransitionRequirementDescription   TransitionRequirement = "DESCRIPTION"
+	TransitionRequirementStartAt       TransitionRequirement = "START_AT"
+	TransitionRequirementDueAt         TransitionRequirement = "DUE_AT"
+)
+
+var AllTransitionRequirement = []TransitionRequirement{
+	TransitionRequirementBlockedReason,
+	TransitionRequirementDescription,
+	TransitionRequirementStartAt,
+	TransitionRequirementDueAt,
+}
+
+func (e TransitionRequirement) IsValid() bool {
+	switch e {
+	case TransitionRequirementBlockedReason, TransitionRequirementDescription, TransitionRequirementStartAt, TransitionRequirementDueAt:
+		return true
+	}
+	return false
+}
+
+func (e TransitionRequirement) String() string {
+	return string(e)
+}
+
+func (e *TransitionRequirement) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TransitionRequirement(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TransitionRequirement", str)
+	}
+	return nil
+}
+
+func (e TransitionRequirement) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type UnscheduledReason string
+
+const (
+	// The task has no estimateMinutes.
+	UnscheduledReasonNoEstimate UnscheduledReason = "NO_ESTIMATE"
+	// No free working time in the range holds the estimate.
+	UnscheduledReasonNoFreeSlot UnscheduledReason = "NO_FREE_SLOT"
+	// Free time exists, but none that ends by the task's dueAt.
+	UnscheduledReasonWouldMissDue UnscheduledReason = "WOULD_MISS_DUE"
+)
+
+var AllUnscheduledReason = []UnscheduledReason{
+	UnscheduledReasonNoEstimate,
+	UnscheduledReasonNoFreeSlot,
+	UnscheduledReasonWouldMissDue,
+}
+
+func (e UnscheduledReason) IsValid() bool {
+	switch e {
+	case UnscheduledReasonNoEstimate, UnscheduledReasonNoFreeSlot, UnscheduledReasonWouldMissDue:
+		return true
+	}
+	return false
+}
+
+func (e UnscheduledReason) String() string {
+	return string(e)
+}
+
+func (e *UnscheduledReason) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = UnscheduledReason(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid UnscheduledReason", str)
+	}
+	return nil
+}
+
+func (e UnscheduledReason) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusDelivered WebhookDeliveryStatus = "DELIVERED"
+	WebhookDeliveryStatusDead      WebhookDeliveryStatus = "DEAD"
+)
+
+var AllWebhookDeliveryStatus = []WebhookDeliveryStatus{
+	WebhookDeliveryStatusPending,
+	WebhookDeliveryStatusDelivered,
+	WebhookDeliveryStatusDead,
+}
+
+func (e WebhookDeliveryStatus) IsValid() bool {
+	switch e {
+	case WebhookDeliveryStatusPending, WebhookDeliveryStatusDelivered, WebhookDeliveryStatusDead:
+		return true
+	}
+	return false
+}
+
+func (e WebhookDeliveryStatus) String() string {
+	return string(e)
+}
+
+func (e *WebhookDeliveryStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookDeliveryStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookDeliveryStatus", str)
+	}
+	return nil
+}
+
+func (e WebhookDeliveryStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type WebhookEventType string
+
+const (
+	WebhookEventTypeTaskCreated    WebhookEventType = "TASK_CREATED"
+	WebhookEventTypeTaskUpdated    WebhookEventType = "TASK_UPDATED"
+	WebhookEventTypeTaskDeleted    WebhookEventType = "TASK_DELETED"
+	WebhookEventTypeProjectCreated WebhookEventType = "PROJECT_CREATED"
+	WebhookEventTypeProjectUpdated WebhookEventType = "PROJECT_UPDATED"
+	WebhookEventTypeProjectDeleted WebhookEventType = "PROJECT_DELETED"
+	WebhookEventTypeLabelCreated   WebhookEventType = "LABEL_CREATED"
+	WebhookEventTypeLabelUpdated   WebhookEventType = "LABEL_UPDATED"
+	WebhookEventTypeLabelDeleted   WebhookEventType = "LABEL_DELETED"
+)
+
+var AllWebhookEventType = []WebhookEventType{
+	WebhookEventTypeTaskCreated,
+	WebhookEventTypeTaskUpdated,
+	WebhookEventTypeTaskDeleted,
+	WebhookEventTypeProjectCreated,
+	WebhookEventTypeProjectUpdated,
+	WebhookEventTypeProjectDeleted,
+	WebhookEventTypeLabelCreated,
+	WebhookEventTypeLabelUpdated,
+	WebhookEventTypeLabelDeleted,
+}
+
+func (e WebhookEventType) IsValid() bool {
+	switch e {
+	case WebhookEventTypeTaskCreated, WebhookEventTypeTaskUpdated, WebhookEventTypeTaskDeleted, WebhookEventTypeProjectCreated, WebhookEventTypeProjectUpdated, WebhookEventTypeProjectDeleted, WebhookEventTypeLabelCreated, WebhookEventTypeLabelUpdated, WebhookEventTypeLabelDeleted:
+		return true
+	}
+	return false
+}
+
+func (e WebhookEventType) String() string {
+	return string(e)
+}
+
+func (e *WebhookEventType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = WebhookEventType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid WebhookEventType", str)
+	}
+	return nil
+}
+
+func (e WebhookEventType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type Weekday string
+
+const (
+	WeekdayMonday    Weekday = "MONDAY"
+	WeekdayTuesday   Weekday = "TUESDAY"
+	WeekdayWednesday Weekday = "WEDNESDAY"
+	WeekdayThursday  Weekday = "THURSDAY"
+	WeekdayFriday    Weekday = "FRIDAY"
+	WeekdaySaturday  Weekday = "SATURDAY"
+	WeekdaySunday    Weekday = "SUNDAY"
+)
+
+var AllWeekday = []Weekday{
+	WeekdayMonday,
+	WeekdayTuesday,
+	WeekdayWednesday,
+	WeekdayThursday,
+	WeekdayFriday,
+	WeekdaySaturday,
+	WeekdaySunday,
+}
+
+func (e Weekday) IsValid() bool {
+	switch e {
+	case WeekdayMonday, WeekdayTuesday, WeekdayWednesday, WeekdayThursday, WeekdayFriday, WeekdaySaturday, WeekdaySunday:
+		return true
+	}
+	return false
+}
+
+func (e Weekday) String() string {
+	return string(e)
+}
+
+func (e *Weekday) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Weekday(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Weekday", str)
+	}
+	return nil
+}
+
+func (e Weekday) MarshalGQL(w io.Writer) {
 	fmt.Fprint(w, strconv.Quote(e.String()))
 }
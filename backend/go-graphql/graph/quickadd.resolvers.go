@@ -0,0 +1,27 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func (r *mutationResolver) QuickAddTask(ctx context.Context, text string, projectID *string, createLabels *bool) (*model.QuickAddTaskPayload, error) {
+	result, err := r.Service.QuickAddTask(ctx, service.QuickAddTaskInput{
+		Text:         text,
+		ProjectID:    projectID,
+		CreateLabels: createLabels != nil && *createLabels,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	created := make([]*model.Label, 0, len(result.CreatedLabels))
+	for _, label := range result.CreatedLabels {
+		created = append(created, toModelLabel(label))
+	}
+	return &model.QuickAddTaskPayload{Task: toModelTask(result.Task), CreatedLabels: created}, nil
+}
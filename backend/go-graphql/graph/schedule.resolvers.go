@@ -0,0 +1,25 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func (r *mutationResolver) AutoSchedule(ctx context.Context, from time.Time, to time.Time, projectIds []string, preview *bool) (*model.SchedulePlan, error) {
+	plan, err := r.Service.AutoSchedule(ctx, service.AutoScheduleInput{
+		From:       from,
+		To:         to,
+		ProjectIDs: projectIds,
+		Preview:    preview != nil && *preview,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelSchedulePlan(plan), nil
+}
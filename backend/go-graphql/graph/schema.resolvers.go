@@ -5,6 +5,7 @@ package graph
 
 import (
 	"context"
+	"time"
 
 	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
 	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
@@ -25,9 +26,10 @@ func (r *mutationResolver) UpsertMe(ctx context.Context, input model.UpsertMeInp
 
 func (r *mutationResolver) CreateProject(ctx context.Context, input model.CreateProjectInput) (*model.Project, error) {
 	project, err := r.Service.CreateProject(ctx, service.CreateProjectInput{
-		Title:       input.Title,
-		Description: input.Description,
-		Color:       input.Color,
+		Title:               input.Title,
+		Description:         input.Description,
+		Color:               input.Color,
+		AutoCompleteParents: input.AutoCompleteParents != nil && *input.AutoCompleteParents,
 	})
 	if err != nil {
 		return nil, asGraphQLError(err)
@@ -37,10 +39,11 @@ func (r *mutationResolver) CreateProject(ctx context.Context, input model.Create
 
 func (r *mutationResolver) UpdateProject(ctx context.Context, input model.UpdateProjectInput) (*model.Project, error) {
 	project, err := r.Service.UpdateProject(ctx, service.UpdateProjectInput{
-		ID:          input.ID,
-		Title:       input.Title,
-		Description: input.Description,
-		Color:       input.Color,
+		ID:                  input.ID,
+		Title:               input.Title,
+		Description:         input.Description,
+		Color:               input.Color,
+		AutoCompleteParents: input.AutoCompleteParents,
 	})
 	if err != nil {
 		return nil, asGraphQLError(err)
@@ -57,6 +60,22 @@ func (r *mutationResolver) DeleteProject(ctx context.Context, id string) (*model
 	return &model.DeletePayload{ID: deleted.ID.String(), DeletedAt: deleted.DeletedAt}, nil
 }
 
+func (r *mutationResolver) ArchiveProject(ctx context.Context, id string) (*model.Project, error) {
+	project, err := r.Service.ArchiveProject(ctx, id)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelProject(project), nil
+}
+
+func (r *mutationResolver) UnarchiveProject(ctx context.Context, id string) (*model.Project, error) {
+	project, err := r.Service.UnarchiveProject(ctx, id)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelProject(project), nil
+}
+
 func (r *mutationResolver) CreateLabel(ctx context.Context, input model.CreateLabelInput) (*model.Label, error) {
 	label, err := r.Service.CreateLabel(ctx, service.CreateLabelInput{Name: input.Name})
 	if err != nil {
@@ -93,15 +112,20 @@ func (r *mutationResolver) CreateTask(ctx context.Context, input model.CreateTas
 	}
 
 	task, err := r.Service.CreateTask(ctx, service.CreateTaskInput{
-		ProjectID:    input.ProjectID,
-		ParentTaskID: input.ParentTaskID,
-		Title:        input.Title,
-		Description:  input.Description,
-		Status:       status,
-		Priority:     priority,
-		StartAt:      input.StartAt,
-		DueAt:        input.DueAt,
-		LabelIDs:     input.LabelIds,
+		ProjectID:       input.ProjectID,
+		ParentTaskID:    input.ParentTaskID,
+		Title:           input.Title,
+		Description:     input.Description,
+		Status:          status,
+		Priority:        priority,
+		StartAt:         input.StartAt,
+		DueAt:           input.DueAt,
+		LabelIDs:        input.LabelIds,
+		SectionID:       input.SectionID,
+		StatusID:        input.StatusID,
+		BlockedReason:   input.BlockedReason,
+		EstimateMinutes: input.EstimateMinutes,
+		CustomFields:    toServiceCustomFieldValues(input.CustomFields),
 	})
 	if err != nil {
 		return nil, asGraphQLError(err)
@@ -123,14 +147,23 @@ func (r *mutationResolver) UpdateTask(ctx context.Context, input model.UpdateTas
 	}
 
 	task, err := r.Service.UpdateTask(ctx, service.UpdateTaskInput{
-		ID:          input.ID,
-		Title:       input.Title,
-		Description: input.Description,
-		Status:      status,
-		Priority:    priority,
-		StartAt:     input.StartAt,
-		DueAt:       input.DueAt,
-		LabelIDs:    input.LabelIds,
+		ID:              input.ID,
+		Title:           input.Title,
+		Description:     input.Description,
+		Status:          status,
+		Priority:        priority,
+		StartAt:         input.StartAt,
+		DueAt:           input.DueAt,
+		SectionID:       input.SectionID,
+		ClearSection:    input.ClearSection != nil && *input.ClearSection,
+		StatusID:        input.StatusID,
+		BlockedReason:   input.BlockedReason,
+		EstimateMinutes: input.EstimateMinutes,
+		ClearEstimate:   input.ClearEstimate != nil && *input.ClearEstimate,
+		Recurrence:      input.Recurrence,
+		ClearRecurrence: input.ClearRecurrence != nil && *input.ClearRecurrence,
+		LabelIDs:        input.LabelIds,
+		CustomFields:    toServiceCustomFieldValues(input.CustomFields),
 	})
 	if err != nil {
 		return nil, asGraphQLError(err)
@@ -147,6 +180,50 @@ func (r *mutationResolver) DeleteTask(ctx context.Context, id string) (*model.De
 	return &model.DeletePayload{ID: deleted.ID.String(), DeletedAt: deleted.DeletedAt}, nil
 }
 
+func (r *projectResolver) Sections(ctx context.Context, obj *model.Project) ([]*model.ProjectSection, error) {
+	sections, err := r.Service.ProjectSections(ctx, obj.ID)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	out := make([]*model.ProjectSection, 0, len(sections))
+	for _, section := range sections {
+		out = append(out, toModelProjectSection(section))
+	}
+	return out, nil
+}
+
+func (r *projectResolver) Statuses(ctx context.Context, obj *model.Project) ([]*model.ProjectStatus, error) {
+	statuses, err := r.Service.ProjectStatuses(ctx, obj.ID)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	out := make([]*model.ProjectStatus, 0, len(statuses))
+	for _, status := range statuses {
+		out = append(out, toModelProjectStatus(status))
+	}
+	return out, nil
+}
+
+func (r *projectResolver) CustomFields(ctx context.Context, obj *model.Project) ([]*model.CustomField, error) {
+	fields, err := r.Service.ProjectCustomFields(ctx, obj.ID)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	out := make([]*model.CustomField, 0, len(fields))
+	for _, field := range fields {
+		out = append(out, toModelCustomField(field))
+	}
+	return out, nil
+}
+
+func (r *queryResolver) Node(ctx context.Context, id string) (model.Node, error) {
+	node, err := r.Service.Node(ctx, id)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelNode(node), nil
+}
+
 func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
 	user, err := r.Service.Me(ctx)
 	if err != nil {
@@ -155,12 +232,8 @@ func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
 	return toModelUser(user), nil
 }
 
-func (r *queryResolver) Projects(ctx context.Context, first *int, after *string) (*model.ProjectConnection, error) {
-	limit := 20
-	if first != nil {
-		limit = *first
-	}
-	page, err := r.Service.ListProjects(ctx, limit, after)
+func (r *queryResolver) Projects(ctx context.Context, includeArchived *bool, first *int, after *string, last *int, before *string) (*model.ProjectConnection, error) {
+	page, err := r.Service.ListProjects(ctx, includeArchived != nil && *includeArchived, pageArgs(ctx, first, after, last, before))
 	if err != nil {
 		return nil, asGraphQLError(err)
 	}
@@ -178,24 +251,15 @@ func (r *queryResolver) Project(ctx context.Context, id string) (*model.Project,
 	return toModelProject(*project), nil
 }
 
-func (r *queryResolver) Labels(ctx context.Context, first *int, after *string) (*model.LabelConnection, error) {
-	limit := 50
-	if first != nil {
-		limit = *first
-	}
-	page, err := r.Service.ListLabels(ctx, limit, after)
+func (r *queryResolver) Labels(ctx context.Context, first *int, after *string, last *int, before *string) (*model.LabelConnection, error) {
+	page, err := r.Service.ListLabels(ctx, pageArgs(ctx, first, after, last, before))
 	if err != nil {
 		return nil, asGraphQLError(err)
 	}
 	return toLabelConnection(page), nil
 }
 
-func (r *queryResolver) Tasks(ctx context.Context, projectID string, parentTaskID *string, statuses []model.TaskStatus, priorities []model.TaskPriority, first *int, after *string) (*model.TaskConnection, error) {
-	limit := 20
-	if first != nil {
-		limit = *first
-	}
-
+func (r *queryResolver) Tasks(ctx context.Context, projectID string, parentTaskID *string, statuses []model.TaskStatus, priorities []model.TaskPriority, labelIds []string, labelMatch *model.LabelMatch, dueBefore *time.Time, dueAfter *time.Time, startBefore *time.Time, startAfter *time.Time, completedBetween *model.TimeRange, hasDueDate *bool, updatedSince *time.Time, titleContains *string, sectionID *string, statusID *string, customFields []*model.CustomFieldFilterInput, sortByCustomField *model.CustomFieldSortInput, first *int, after *string, last *int, before *string) (*model.TaskConnection, error) {
 	statusFilters := make([]string, 0, len(statuses))
 	for _, s := range statuses {
 		statusFilters = append(statusFilters, string(s))
@@ -205,7 +269,36 @@ func (r *queryResolver) Tasks(ctx context.Context, projectID string, parentTaskI
 		priorityFilters = append(priorityFilters, string(p))
 	}
 
-	page, err := r.Service.ListTasks(ctx, projectID, parentTaskID, statusFilters, priorityFilters, limit, after)
+	filter := service.TaskFilter{
+		Statuses:       statusFilters,
+		Priorities:     priorityFilters,
+		LabelIDs:       labelIds,
+		MatchAllLabels: labelMatch != nil && *labelMatch == model.LabelMatchAll,
+		DueBefore:      dueBefore,
+		DueAfter:       dueAfter,
+		StartBefore:    startBefore,
+		StartAfter:     startAfter,
+		HasDueDate:     hasDueDate,
+		UpdatedSince:   updatedSince,
+		TitleContains:  titleContains,
+		SectionID:      sectionID,
+		StatusID:       statusID,
+	}
+	for _, cf := range customFields {
+		filter.CustomFields = append(filter.CustomFields, service.CustomFieldFilter{FieldID: cf.FieldID, Value: cf.Value})
+	}
+	if sortByCustomField != nil {
+		filter.SortByCustomField = &service.CustomFieldSort{
+			FieldID:    sortByCustomField.FieldID,
+			Descending: sortByCustomField.Direction != nil && *sortByCustomField.Direction == model.SortDirectionDesc,
+		}
+	}
+	if completedBetween != nil {
+		filter.CompletedFrom = completedBetween.From
+		filter.CompletedTo = completedBetween.To
+	}
+
+	page, err := r.Service.ListTasks(ctx, projectID, parentTaskID, filter, pageArgs(ctx, first, after, last, before))
 	if err != nil {
 		return nil, asGraphQLError(err)
 	}
@@ -223,6 +316,27 @@ func (r *queryResolver) Task(ctx context.Context, id string) (*model.Task, error
 	return toModelTask(*task), nil
 }
 
+func (r *taskResolver) Recurrence(ctx context.Context, obj *model.Task) (*string, error) {
+	var rule string
+	if l := loadersFrom(ctx); l != nil {
+		v, err := l.recurrence.Load(ctx, obj.ID)
+		if err != nil {
+			return nil, asGraphQLError(err)
+		}
+		rule = v
+	} else {
+		batch, err := r.Service.TaskRecurrenceBatch(ctx, []string{obj.ID})
+		if err != nil {
+			return nil, asGraphQLError(err)
+		}
+		rule = batch[obj.ID]
+	}
+	if rule == "" {
+		return nil, nil
+	}
+	return &rule, nil
+}
+
 func (r *taskResolver) Labels(ctx context.Context, obj *model.Task) ([]*model.Label, error) {
 	labels, err := r.Service.LabelsForTask(ctx, obj.ID)
 	if err != nil {
@@ -247,15 +361,93 @@ func (r *taskResolver) Subtasks(ctx context.Context, obj *model.Task) ([]*model.
 	return out, nil
 }
 
+func (r *taskResolver) Progress(ctx context.Context, obj *model.Task) (*model.TaskProgress, error) {
+	var progress service.TaskProgress
+	if l := loadersFrom(ctx); l != nil {
+		p, err := l.taskProgress.Load(ctx, obj.ID)
+		if err != nil {
+			return nil, asGraphQLError(err)
+		}
+		progress = p
+	} else {
+		batch, err := r.Service.TaskProgressBatch(ctx, []string{obj.ID})
+		if err != nil {
+			return nil, asGraphQLError(err)
+		}
+		progress = batch[obj.ID]
+	}
+	return &model.TaskProgress{Total: progress.Total, Done: progress.Done, Percent: progress.Percent()}, nil
+}
+
+func (r *taskResolver) CustomFields(ctx context.Context, obj *model.Task) ([]*model.CustomFieldValue, error) {
+	var values []service.CustomFieldValue
+	if l := loadersFrom(ctx); l != nil {
+		v, err := l.customFieldValues.Load(ctx, obj.ID)
+		if err != nil {
+			return nil, asGraphQLError(err)
+		}
+		values = v
+	} else {
+		batch, err := r.Service.TaskCustomFieldValuesBatch(ctx, []string{obj.ID})
+		if err != nil {
+			return nil, asGraphQLError(err)
+		}
+		values = batch[obj.ID]
+	}
+	out := make([]*model.CustomFieldValue, 0, len(values))
+	for _, v := range values {
+		out = append(out, &model.CustomFieldValue{Field: toModelCustomField(v.Field), Value: v.Value})
+	}
+	return out, nil
+}
+
+func (r *taskResolver) TimeSpent(ctx context.Context, obj *model.Task) (int, error) {
+	var seconds int64
+	if l := loadersFrom(ctx); l != nil {
+		v, err := l.timeSpent.Load(ctx, obj.ID)
+		if err != nil {
+			return 0, asGraphQLError(err)
+		}
+		seconds = v
+	} else {
+		batch, err := r.Service.TaskTimeSpentBatch(ctx, []string{obj.ID})
+		if err != nil {
+			return 0, asGraphQLError(err)
+		}
+		seconds = batch[obj.ID]
+	}
+	return int(seconds), nil
+}
+
+func (r *taskResolver) TimeEntries(ctx context.Context, obj *model.Task) ([]*model.TimeEntry, error) {
+	entries, err := r.Service.TaskTimeEntries(ctx, obj.ID)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	out := make([]*model.TimeEntry, 0, len(entries))
+	for _, entry := range entries {
+		out = append(out, toModelTimeEntry(entry))
+	}
+	return out, nil
+}
+
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
+// Project returns ProjectResolver implementation.
+func (r *Resolver) Project() ProjectResolver { return &projectResolver{r} }
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
 // Task returns TaskResolver implementation.
 func (r *Resolver) Task() TaskResolver { return &taskResolver{r} }
 
+// User returns UserResolver implementation.
+func (r *Resolver) User() UserResolver { return &userResolver{r} }
+
 type mutationResolver struct{ *Resolver }
+type projectResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type taskResolver struct{ *Resolver }
+type userResolver struct{ *Resolver }
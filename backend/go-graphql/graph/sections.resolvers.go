@@ -0,0 +1,56 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func (r *mutationResolver) CreateProjectSection(ctx context.Context, input model.CreateProjectSectionInput) (*model.ProjectSection, error) {
+	section, err := r.Service.CreateProjectSection(ctx, service.CreateProjectSectionInput{
+		ProjectID: input.ProjectID,
+		Name:      input.Name,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelProjectSection(section), nil
+}
+
+func (r *mutationResolver) UpdateProjectSection(ctx context.Context, input model.UpdateProjectSectionInput) (*model.ProjectSection, error) {
+	section, err := r.Service.UpdateProjectSection(ctx, service.UpdateProjectSectionInput{
+		ID:       input.ID,
+		Name:     input.Name,
+		Position: input.Position,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelProjectSection(section), nil
+}
+
+func (r *mutationResolver) DeleteProjectSection(ctx context.Context, id string, moveTasksToSectionID *string) (*model.DeletePayload, error) {
+	deleted, err := r.Service.DeleteProjectSection(ctx, id, moveTasksToSectionID)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+
+	return &model.DeletePayload{ID: deleted.ID.String(), DeletedAt: deleted.DeletedAt}, nil
+}
+
+func (r *projectSectionResolver) Tasks(ctx context.Context, obj *model.ProjectSection, first *int, after *string, last *int, before *string) (*model.TaskConnection, error) {
+	page, err := r.Service.ListTasks(ctx, obj.ProjectID, nil, service.TaskFilter{SectionID: &obj.ID}, pageArgs(ctx, first, after, last, before))
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toTaskConnection(page), nil
+}
+
+// ProjectSection returns ProjectSectionResolver implementation.
+func (r *Resolver) ProjectSection() ProjectSectionResolver { return &projectSectionResolver{r} }
+
+type projectSectionResolver struct{ *Resolver }
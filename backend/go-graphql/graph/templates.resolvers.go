@@ -0,0 +1,69 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func (r *mutationResolver) SaveAsTemplate(ctx context.Context, projectID *string, taskID *string, name *string) (*model.Template, error) {
+	tmpl, err := r.Service.SaveAsTemplate(ctx, service.SaveTemplateInput{ProjectID: projectID, TaskID: taskID, Name: name})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelTemplate(tmpl), nil
+}
+
+func (r *mutationResolver) InstantiateTemplate(ctx context.Context, templateID string, startDate time.Time, title *string, projectID *string) (*model.TemplateInstance, error) {
+	instance, err := r.Service.InstantiateTemplate(ctx, service.InstantiateTemplateInput{
+		TemplateID: templateID,
+		StartDate:  startDate,
+		Title:      title,
+		ProjectID:  projectID,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	tasks := make([]*model.Task, 0, len(instance.Tasks))
+	for _, t := range instance.Tasks {
+		tasks = append(tasks, toModelTask(t))
+	}
+	return &model.TemplateInstance{Project: toModelProject(instance.Project), Tasks: tasks}, nil
+}
+
+func (r *mutationResolver) DeleteTemplate(ctx context.Context, id string) (*model.DeletePayload, error) {
+	deleted, err := r.Service.DeleteTemplate(ctx, id)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+
+	return &model.DeletePayload{ID: deleted.ID.String(), DeletedAt: deleted.DeletedAt}, nil
+}
+
+func (r *queryResolver) Templates(ctx context.Context) ([]*model.Template, error) {
+	templates, err := r.Service.Templates(ctx)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	out := make([]*model.Template, 0, len(templates))
+	for _, t := range templates {
+		out = append(out, toModelTemplate(t))
+	}
+	return out, nil
+}
+
+func (r *queryResolver) Template(ctx context.Context, id string) (*model.Template, error) {
+	tmpl, err := r.Service.Template(ctx, id)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	if tmpl == nil {
+		return nil, nil
+	}
+	return toModelTemplate(*tmpl), nil
+}
@@ -0,0 +1,82 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func (r *mutationResolver) StartTimer(ctx context.Context, taskID string, note *string) (*model.TimeEntry, error) {
+	entry, err := r.Service.StartTimer(ctx, taskID, note)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelTimeEntry(entry), nil
+}
+
+func (r *mutationResolver) StopTimer(ctx context.Context) (*model.TimeEntry, error) {
+	entry, err := r.Service.StopTimer(ctx)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelTimeEntry(entry), nil
+}
+
+func (r *mutationResolver) CreateTimeEntry(ctx context.Context, input model.CreateTimeEntryInput) (*model.TimeEntry, error) {
+	entry, err := r.Service.CreateTimeEntry(ctx, service.CreateTimeEntryInput{
+		TaskID:    input.TaskID,
+		StartedAt: input.StartedAt,
+		EndedAt:   input.EndedAt,
+		Note:      input.Note,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelTimeEntry(entry), nil
+}
+
+func (r *mutationResolver) UpdateTimeEntry(ctx context.Context, input model.UpdateTimeEntryInput) (*model.TimeEntry, error) {
+	entry, err := r.Service.UpdateTimeEntry(ctx, service.UpdateTimeEntryInput{
+		ID:        input.ID,
+		TaskID:    input.TaskID,
+		StartedAt: input.StartedAt,
+		EndedAt:   input.EndedAt,
+		Note:      input.Note,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelTimeEntry(entry), nil
+}
+
+func (r *mutationResolver) DeleteTimeEntry(ctx context.Context, id string) (*model.DeletePayload, error) {
+	deleted, err := r.Service.DeleteTimeEntry(ctx, id)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return &model.DeletePayload{ID: deleted.ID.String(), DeletedAt: deleted.DeletedAt}, nil
+}
+
+func (r *queryResolver) RunningTimer(ctx context.Context) (*model.TimeEntry, error) {
+	entry, err := r.Service.RunningTimer(ctx)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+	return toModelTimeEntry(*entry), nil
+}
+
+func (r *queryResolver) TimeReport(ctx context.Context, from time.Time, to time.Time, groupBy model.TimeReportGroup) (*model.TimeReport, error) {
+	report, err := r.Service.TimeReport(ctx, from, to, string(groupBy))
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelTimeReport(report), nil
+}
@@ -0,0 +1,42 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func (r *mutationResolver) SetTransitionPolicy(ctx context.Context, input model.SetTransitionPolicyInput) (*model.TransitionPolicy, error) {
+	in := service.SetTransitionPolicyInput{ProjectID: input.ProjectID}
+	for _, t := range input.Transitions {
+		in.Transitions = append(in.Transitions, service.StatusTransition{From: string(t.From), To: string(t.To)})
+	}
+	for _, req := range input.Requirements {
+		fields := make([]string, 0, len(req.Fields))
+		for _, f := range req.Fields {
+			fields = append(fields, string(f))
+		}
+		in.Requirements = append(in.Requirements, service.StatusRequirement{Status: string(req.Status), Fields: fields})
+	}
+
+	policy, err := r.Service.SetTransitionPolicy(ctx, in)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelTransitionPolicy(policy), nil
+}
+
+func (r *queryResolver) TransitionPolicy(ctx context.Context, projectID string) (*model.TransitionPolicy, error) {
+	policy, err := r.Service.TransitionPolicy(ctx, projectID)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	if policy == nil {
+		return nil, nil
+	}
+	return toModelTransitionPolicy(*policy), nil
+}
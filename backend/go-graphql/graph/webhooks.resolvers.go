@@ -0,0 +1,79 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"github.com/faizp/zenlist/backend/go-graphql/graph/model"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func (r *mutationResolver) CreateWebhookSubscription(ctx context.Context, input model.CreateWebhookSubscriptionInput) (*model.WebhookSubscription, error) {
+	sub, err := r.Service.CreateWebhookSubscription(ctx, service.CreateWebhookSubscriptionInput{
+		URL:        input.URL,
+		Secret:     input.Secret,
+		EventTypes: fromModelEventTypes(input.EventTypes),
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelWebhookSubscription(sub), nil
+}
+
+func (r *mutationResolver) UpdateWebhookSubscription(ctx context.Context, input model.UpdateWebhookSubscriptionInput) (*model.WebhookSubscription, error) {
+	sub, err := r.Service.UpdateWebhookSubscription(ctx, service.UpdateWebhookSubscriptionInput{
+		ID:         input.ID,
+		URL:        input.URL,
+		Secret:     input.Secret,
+		EventTypes: fromModelEventTypes(input.EventTypes),
+		Active:     input.Active,
+	})
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelWebhookSubscription(sub), nil
+}
+
+func (r *mutationResolver) DeleteWebhookSubscription(ctx context.Context, id string) (*model.DeletePayload, error) {
+	deleted, err := r.Service.DeleteWebhookSubscription(ctx, id)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+
+	return &model.DeletePayload{ID: deleted.ID.String(), DeletedAt: deleted.DeletedAt}, nil
+}
+
+func (r *mutationResolver) RetryWebhookDelivery(ctx context.Context, id string) (*model.WebhookDelivery, error) {
+	delivery, err := r.Service.RetryWebhookDelivery(ctx, id)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toModelWebhookDelivery(delivery), nil
+}
+
+func (r *queryResolver) WebhookSubscriptions(ctx context.Context) ([]*model.WebhookSubscription, error) {
+	subs, err := r.Service.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	out := make([]*model.WebhookSubscription, 0, len(subs))
+	for _, sub := range subs {
+		out = append(out, toModelWebhookSubscription(sub))
+	}
+	return out, nil
+}
+
+func (r *queryResolver) WebhookDeliveries(ctx context.Context, subscriptionID *string, statuses []model.WebhookDeliveryStatus, first *int, after *string, last *int, before *string) (*model.WebhookDeliveryConnection, error) {
+	statusFilters := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		statusFilters = append(statusFilters, string(s))
+	}
+
+	page, err := r.Service.ListWebhookDeliveries(ctx, subscriptionID, statusFilters, pageArgs(ctx, first, after, last, before))
+	if err != nil {
+		return nil, asGraphQLError(err)
+	}
+	return toWebhookDeliveryConnection(page), nil
+}
@@ -0,0 +1,167 @@
+// Package backup writes and restores whole-database archives: a gzipped tar
+// holding manifest.json and one CSV file per table, produced with Postgres
+// COPY. The manifest records the migration version the data was taken at
+// and a SHA-256 checksum of every file.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// FormatVersion is the archive layout. Restore refuses newer layouts.
+const FormatVersion = 1
+
+const manifestName = "manifest.json"
+
+type Manifest struct {
+	FormatVersion int       `json:"formatVersion"`
+	SchemaVersion uint      `json:"schemaVersion"`
+	CreatedAt     time.Time `json:"createdAt"`
+	Tables        []Table   `json:"tables"`
+}
+
+// Table describes one table's CSV file. Columns are in file order.
+type Table struct {
+	Name    string   `json:"name"`
+	File    string   `json:"file"`
+	Columns []string `json:"columns"`
+	Rows    int64    `json:"rows"`
+	Bytes   int64    `json:"bytes"`
+	SHA256  string   `json:"sha256"`
+}
+
+// writeArchive tars the manifest and the table files it lists, which must
+// already be in dir.
+func writeArchive(w io.Writer, m Manifest, dir string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: manifestName, Mode: 0o644, Size: int64(len(manifest)), ModTime: m.CreatedAt}); err != nil {
+		return err
+	}
+	if _, err := tw.Write(manifest); err != nil {
+		return err
+	}
+
+	for _, t := range m.Tables {
+		if err := addFile(tw, filepath.Join(dir, t.File), t.File, t.Bytes, m.CreatedAt); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+func addFile(tw *tar.Writer, src, name string, size int64, modTime time.Time) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: size, ModTime: modTime}); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// extractArchive unpacks r into dir and checks every file against the
+// manifest. Unknown entries, missing files and checksum mismatches are
+// errors, so nothing from a damaged archive reaches the database.
+func extractArchive(r io.Reader, dir string) (Manifest, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("not a backup archive: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != manifestName {
+		return Manifest{}, errors.New("not a backup archive: manifest.json must be the first entry")
+	}
+	var m Manifest
+	if err := json.NewDecoder(tr).Decode(&m); err != nil {
+		return Manifest{}, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if m.FormatVersion < 1 || m.FormatVersion > FormatVersion {
+		return Manifest{}, fmt.Errorf("archive format %d is not supported (this build reads up to %d)", m.FormatVersion, FormatVersion)
+	}
+
+	expected := make(map[string]Table, len(m.Tables))
+	for _, t := range m.Tables {
+		if t.File != path.Base(t.File) || t.File == "." || t.File == manifestName {
+			return Manifest{}, fmt.Errorf("invalid file name %q in manifest", t.File)
+		}
+		expected[t.File] = t
+	}
+
+	seen := map[string]bool{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Manifest{}, fmt.Errorf("read archive: %w", err)
+		}
+		t, ok := expected[hdr.Name]
+		if !ok || seen[hdr.Name] || hdr.Typeflag != tar.TypeReg {
+			return Manifest{}, fmt.Errorf("unexpected archive entry %q", hdr.Name)
+		}
+		seen[hdr.Name] = true
+		if err := extractFile(tr, filepath.Join(dir, t.File), t); err != nil {
+			return Manifest{}, err
+		}
+	}
+	for _, t := range m.Tables {
+		if !seen[t.File] {
+			return Manifest{}, fmt.Errorf("archive is missing %s", t.File)
+		}
+	}
+	return m, nil
+}
+
+func extractFile(r io.Reader, dst string, t Table) error {
+	f, err := os.OpenFile(dst, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sum, n, err := copyHashed(f, r)
+	if err != nil {
+		return fmt.Errorf("extract %s: %w", t.File, err)
+	}
+	if n != t.Bytes || sum != t.SHA256 {
+		return fmt.Errorf("checksum mismatch for %s", t.File)
+	}
+	return f.Close()
+}
+
+// copyHashed copies r to w and returns the hex SHA-256 and length of what
+// was copied.
+func copyHashed(w io.Writer, r io.Reader) (string, int64, error) {
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(w, h), r)
+	return hex.EncodeToString(h.Sum(nil)), n, err
+}
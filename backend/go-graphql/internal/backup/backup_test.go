@@ -0,0 +1,187 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeTestArchive(t *testing.T, files map[string]string) (Manifest, []byte) {
+	t.Helper()
+	dir := t.TempDir()
+	m := Manifest{FormatVersion: FormatVersion, SchemaVersion: 3, CreatedAt: time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)}
+	for _, name := range []string{"projects", "users"} {
+		content, ok := files[name]
+		if !ok {
+			continue
+		}
+		file := name + ".csv"
+		f, err := os.Create(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum, n, err := copyHashed(f, strings.NewReader(content))
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		m.Tables = append(m.Tables, Table{Name: name, File: file, Columns: []string{"id"}, Rows: 1, Bytes: n, SHA256: sum})
+	}
+
+	var buf bytes.Buffer
+	if err := writeArchive(&buf, m, dir); err != nil {
+		t.Fatalf("write archive: %v", err)
+	}
+	return m, buf.Bytes()
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	want, archive := writeTestArchive(t, map[string]string{"users": "id\n1\n", "projects": "id\n2\n"})
+
+	dir := t.TempDir()
+	got, err := extractArchive(bytes.NewReader(archive), dir)
+	if err != nil {
+		t.Fatalf("extract: %v", err)
+	}
+	if got.SchemaVersion != want.SchemaVersion || len(got.Tables) != 2 {
+		t.Fatalf("unexpected manifest: %+v", got)
+	}
+	body, err := os.ReadFile(filepath.Join(dir, "users.csv"))
+	if err != nil || string(body) != "id\n1\n" {
+		t.Fatalf("users.csv: %q, %v", body, err)
+	}
+}
+
+func TestExtractRejectsTampering(t *testing.T) {
+	m, _ := writeTestArchive(t, map[string]string{"users": "id\n1\n"})
+
+	// Same manifest, different content of the same length.
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	manifest := []byte(`{"formatVersion":1,"schemaVersion":3,"tables":[{"name":"users","file":"users.csv","columns":["id"],"rows":1,"bytes":` +
+		"5" + `,"sha256":"` + m.Tables[0].SHA256 + `"}]}`)
+	_ = tw.WriteHeader(&tar.Header{Name: manifestName, Mode: 0o644, Size: int64(len(manifest))})
+	_, _ = tw.Write(manifest)
+	_ = tw.WriteHeader(&tar.Header{Name: "users.csv", Mode: 0o644, Size: 5})
+	_, _ = tw.Write([]byte("id\n9\n"))
+	tw.Close()
+	gz.Close()
+
+	_, err := extractArchive(bytes.NewReader(buf.Bytes()), t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "checksum mismatch") {
+		t.Fatalf("expected a checksum mismatch, got %v", err)
+	}
+
+	if _, err := extractArchive(strings.NewReader("not gzip"), t.TempDir()); err == nil {
+		t.Fatalf("expected an error for a non-archive")
+	}
+}
+
+func TestExtractRejectsUnsafeNames(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	manifest := []byte(`{"formatVersion":1,"schemaVersion":3,"tables":[{"name":"users","file":"../users.csv"}]}`)
+	_ = tw.WriteHeader(&tar.Header{Name: manifestName, Mode: 0o644, Size: int64(len(manifest))})
+	_, _ = tw.Write(manifest)
+	tw.Close()
+	gz.Close()
+
+	if _, err := extractArchive(bytes.NewReader(buf.Bytes()), t.TempDir()); err == nil {
+		t.Fatalf("expected an error for a path outside the archive root")
+	}
+}
+
+func TestCheckCompatible(t *testing.T) {
+	available := []uint{1, 2, 3}
+	m := Manifest{SchemaVersion: 2}
+
+	tests := []struct {
+		name       string
+		m          Manifest
+		current    uint
+		hasVersion bool
+		dirty      bool
+		wantErr    bool
+	}{
+		{name: "fresh database", m: m},
+		{name: "older database", m: m, current: 1, hasVersion: true},
+		{name: "same version", m: m, current: 2, hasVersion: true},
+		{name: "newer database", m: m, current: 3, hasVersion: true, wantErr: true},
+		{name: "dirty database", m: m, current: 1, hasVersion: true, dirty: true, wantErr: true},
+		{name: "archive from a newer release", m: Manifest{SchemaVersion: 4}, wantErr: true},
+		{name: "unknown version", m: Manifest{SchemaVersion: 0}, wantErr: true},
+	}
+	for _, tc := range tests {
+		err := checkCompatible(tc.m, available, tc.current, tc.hasVersion, tc.dirty)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: got %v, wantErr %v", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestCompareSchema(t *testing.T) {
+	m := Manifest{Tables: []Table{{Name: "users", Columns: []string{"id", "name"}}}}
+	if err := compareSchema(m, map[string][]string{"users": {"name", "id"}}); err != nil {
+		t.Fatalf("column order should not matter: %v", err)
+	}
+	if err := compareSchema(m, map[string][]string{"users": {"id"}}); err == nil {
+		t.Fatalf("expected an error for a missing column")
+	}
+	if err := compareSchema(m, map[string][]string{"users": {"id", "name"}, "tasks": {"id"}}); err == nil {
+		t.Fatalf("expected an error for an extra table")
+	}
+}
+
+func TestLoadOrder(t *testing.T) {
+	deps := map[string][]string{
+		"projects":    {"users"},
+		"tasks":       {"users", "projects", "tasks"},
+		"task_labels": {"tasks", "labels"},
+		"labels":      {"users"},
+	}
+	order, err := loadOrder([]string{"labels", "projects", "task_labels", "tasks", "users"}, deps)
+	if err != nil {
+		t.Fatalf("load order: %v", err)
+	}
+	pos := map[string]int{}
+	for i, name := range order {
+		pos[name] = i
+	}
+	for child, parents := range deps {
+		for _, p := range parents {
+			if p != child && pos[p] > pos[child] {
+				t.Errorf("%s must load before %s: %v", p, child, order)
+			}
+		}
+	}
+
+	if _, err := loadOrder([]string{"a", "b"}, map[string][]string{"a": {"b"}, "b": {"a"}}); err == nil {
+		t.Fatalf("expected an error for a cycle")
+	}
+}
+
+func TestMigrationVersions(t *testing.T) {
+	versions, err := MigrationVersions("../../migrations")
+	if err != nil {
+		t.Fatalf("read migrations: %v", err)
+	}
+	if len(versions) == 0 || versions[0] != 1 || !slices.IsSorted(versions) {
+		t.Fatalf("unexpected versions: %v", versions)
+	}
+}
+
+func TestCopySQL(t *testing.T) {
+	got := copySQL("tasks", []string{"id", "title"}, "TO STDOUT")
+	want := `COPY "public"."tasks" ("id", "title") TO STDOUT WITH (FORMAT csv, HEADER true)`
+	if got != want {
+		t.Fatalf("got %s", got)
+	}
+}
@@ -0,0 +1,430 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// migrationsTable is golang-migrate's bookkeeping table. It is not archived;
+// its content is the manifest's SchemaVersion.
+const migrationsTable = "schema_migrations"
+
+// Backup archives every table in the public schema to w. All tables are
+// read in one repeatable-read transaction, so the archive is a consistent
+// snapshot even while the API is writing. Table files are spooled to a
+// temporary directory because tar needs each file's size up front.
+func Backup(ctx context.Context, pool *pgxpool.Pool, w io.Writer) (Manifest, error) {
+	dir, err := os.MkdirTemp("", "zenlist-backup-")
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	m := Manifest{FormatVersion: FormatVersion, CreatedAt: time.Now().UTC().Truncate(time.Second)}
+
+	var dirty bool
+	err = tx.QueryRow(ctx, "SELECT version, dirty FROM "+migrationsTable+" LIMIT 1").Scan(&m.SchemaVersion, &dirty)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("read schema version: %w", err)
+	}
+	if dirty {
+		return Manifest{}, fmt.Errorf("schema version %d is dirty; fix the failed migration before backing up", m.SchemaVersion)
+	}
+
+	columns, err := tableColumns(ctx, tx)
+	if err != nil {
+		return Manifest{}, err
+	}
+	for _, name := range sortedKeys(columns) {
+		t, err := copyTableOut(ctx, tx, dir, name, columns[name])
+		if err != nil {
+			return Manifest{}, err
+		}
+		m.Tables = append(m.Tables, t)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return Manifest{}, err
+	}
+	return m, writeArchive(w, m, dir)
+}
+
+func copyTableOut(ctx context.Context, tx pgx.Tx, dir, name string, columns []string) (Table, error) {
+	t := Table{Name: name, File: name + ".csv", Columns: columns}
+	f, err := os.Create(filepath.Join(dir, t.File))
+	if err != nil {
+		return Table{}, err
+	}
+	defer f.Close()
+
+	pr, pw := io.Pipe()
+	type result struct {
+		sum string
+		n   int64
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		sum, n, err := copyHashed(f, pr)
+		pr.CloseWithError(err)
+		done <- result{sum, n, err}
+	}()
+
+	tag, err := tx.Conn().PgConn().CopyTo(ctx, pw, copySQL(name, columns, "TO STDOUT"))
+	pw.CloseWithError(err)
+	res := <-done
+	if err != nil {
+		return Table{}, fmt.Errorf("copy %s: %w", name, err)
+	}
+	if res.err != nil {
+		return Table{}, fmt.Errorf("write %s: %w", t.File, res.err)
+	}
+
+	t.Rows, t.Bytes, t.SHA256 = tag.RowsAffected(), res.n, res.sum
+	return t, f.Close()
+}
+
+// Migrator is the part of *migrate.Migrate that Restore uses.
+type Migrator interface {
+	Version() (uint, bool, error)
+	Migrate(version uint) error
+}
+
+// Restore loads an archive into an empty database. It verifies the archive,
+// migrates the database to the archived schema version and loads every
+// table in one transaction, parents before children. available lists the
+// migration versions this build ships (see MigrationVersions).
+//
+// The database is left at the archived version; run the normal migrations
+// afterwards to bring it up to date.
+func Restore(ctx context.Context, pool *pgxpool.Pool, r io.Reader, migrator Migrator, available []uint) (Manifest, error) {
+	dir, err := os.MkdirTemp("", "zenlist-restore-")
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	m, err := extractArchive(r, dir)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	current, dirty, err := migrator.Version()
+	hasVersion := true
+	if errors.Is(err, migrate.ErrNilVersion) {
+		hasVersion, err = false, nil
+	}
+	if err != nil {
+		return Manifest{}, fmt.Errorf("read schema version: %w", err)
+	}
+	if err := checkCompatible(m, available, current, hasVersion, dirty); err != nil {
+		return Manifest{}, err
+	}
+	if err := checkEmpty(ctx, pool); err != nil {
+		return Manifest{}, err
+	}
+
+	if err := migrator.Migrate(m.SchemaVersion); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return Manifest{}, fmt.Errorf("migrate to version %d: %w", m.SchemaVersion, err)
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return Manifest{}, err
+	}
+	defer tx.Rollback(ctx)
+
+	columns, err := tableColumns(ctx, tx)
+	if err != nil {
+		return Manifest{}, err
+	}
+	if err := compareSchema(m, columns); err != nil {
+		return Manifest{}, err
+	}
+	deps, err := foreignKeys(ctx, tx)
+	if err != nil {
+		return Manifest{}, err
+	}
+	order, err := loadOrder(sortedKeys(columns), deps)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	tables := make(map[string]Table, len(m.Tables))
+	for _, t := range m.Tables {
+		tables[t.Name] = t
+	}
+	for _, name := range order {
+		if err := copyTableIn(ctx, tx, dir, tables[name]); err != nil {
+			return Manifest{}, err
+		}
+	}
+	if err := resetSequences(ctx, tx); err != nil {
+		return Manifest{}, err
+	}
+	return m, tx.Commit(ctx)
+}
+
+func copyTableIn(ctx context.Context, tx pgx.Tx, dir string, t Table) error {
+	f, err := os.Open(filepath.Join(dir, t.File))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	tag, err := tx.Conn().PgConn().CopyFrom(ctx, f, copySQL(t.Name, t.Columns, "FROM STDIN"))
+	if err != nil {
+		return fmt.Errorf("restore %s: %w", t.Name, err)
+	}
+	if tag.RowsAffected() != t.Rows {
+		return fmt.Errorf("restore %s: loaded %d rows, manifest lists %d", t.Name, tag.RowsAffected(), t.Rows)
+	}
+	return nil
+}
+
+func copySQL(table string, columns []string, direction string) string {
+	quoted := make([]string, 0, len(columns))
+	for _, c := range columns {
+		quoted = append(quoted, pgx.Identifier{c}.Sanitize())
+	}
+	return fmt.Sprintf("COPY %s (%s) %s WITH (FORMAT csv, HEADER true)",
+		pgx.Identifier{"public", table}.Sanitize(), strings.Join(quoted, ", "), direction)
+}
+
+// checkCompatible refuses archives this build cannot restore faithfully.
+func checkCompatible(m Manifest, available []uint, current uint, hasVersion, dirty bool) error {
+	if len(available) == 0 {
+		return errors.New("no migrations found")
+	}
+	latest := slices.Max(available)
+	switch {
+	case m.SchemaVersion > latest:
+		return fmt.Errorf("archive is at schema version %d but this build only knows up to %d; restore with a newer release", m.SchemaVersion, latest)
+	case !slices.Contains(available, m.SchemaVersion):
+		return fmt.Errorf("archive schema version %d does not match any migration", m.SchemaVersion)
+	case dirty:
+		return fmt.Errorf("database schema version %d is dirty", current)
+	case hasVersion && current > m.SchemaVersion:
+		return fmt.Errorf("database is already at schema version %d, newer than the archive's %d; restore into an empty database", current, m.SchemaVersion)
+	}
+	return nil
+}
+
+// compareSchema checks that the migrated database has exactly the archived
+// tables and columns.
+func compareSchema(m Manifest, columns map[string][]string) error {
+	if len(m.Tables) != len(columns) {
+		return fmt.Errorf("archive has %d tables, database has %d", len(m.Tables), len(columns))
+	}
+	for _, t := range m.Tables {
+		cols, ok := columns[t.Name]
+		if !ok {
+			return fmt.Errorf("table %s is not in the database", t.Name)
+		}
+		want, got := slices.Sorted(slices.Values(t.Columns)), slices.Sorted(slices.Values(cols))
+		if !slices.Equal(want, got) {
+			return fmt.Errorf("table %s columns differ: archive has %v, database has %v", t.Name, want, got)
+		}
+	}
+	return nil
+}
+
+// loadOrder sorts tables so every table comes after the tables it
+// references. Self-references are ignored; COPY checks them at the end of
+// the statement.
+func loadOrder(tables []string, deps map[string][]string) ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(tables))
+	order := make([]string, 0, len(tables))
+
+	var visit func(string) error
+	visit = func(t string) error {
+		switch state[t] {
+		case done:
+			return nil
+		case visiting:
+			return fmt.Errorf("foreign keys form a cycle through %s", t)
+		}
+		state[t] = visiting
+		parents := slices.Sorted(slices.Values(deps[t]))
+		for _, p := range parents {
+			if p != t {
+				if err := visit(p); err != nil {
+					return err
+				}
+			}
+		}
+		state[t] = done
+		order = append(order, t)
+		return nil
+	}
+	for _, t := range tables {
+		if err := visit(t); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+func tableColumns(ctx context.Context, tx pgx.Tx) (map[string][]string, error) {
+	rows, err := tx.Query(ctx, `
+SELECT c.table_name, c.column_name
+FROM information_schema.columns c
+JOIN information_schema.tables t
+  ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+WHERE c.table_schema = 'public'
+  AND t.table_type = 'BASE TABLE'
+  AND c.table_name <> $1
+  AND c.is_generated = 'NEVER'
+ORDER BY c.table_name, c.ordinal_position`, migrationsTable)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	defer rows.Close()
+
+	columns := map[string][]string{}
+	for rows.Next() {
+		var table, column string
+		if err := rows.Scan(&table, &column); err != nil {
+			return nil, err
+		}
+		columns[table] = append(columns[table], column)
+	}
+	return columns, rows.Err()
+}
+
+func foreignKeys(ctx context.Context, tx pgx.Tx) (map[string][]string, error) {
+	rows, err := tx.Query(ctx, `
+SELECT child.relname, parent.relname
+FROM pg_constraint c
+JOIN pg_class child ON child.oid = c.conrelid
+JOIN pg_class parent ON parent.oid = c.confrelid
+JOIN pg_namespace n ON n.oid = child.relnamespace
+WHERE c.contype = 'f'
+  AND n.nspname = 'public'`)
+	if err != nil {
+		return nil, fmt.Errorf("list foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	deps := map[string][]string{}
+	for rows.Next() {
+		var child, parent string
+		if err := rows.Scan(&child, &parent); err != nil {
+			return nil, err
+		}
+		deps[child] = append(deps[child], parent)
+	}
+	return deps, rows.Err()
+}
+
+// checkEmpty refuses databases that already hold data in any table.
+func checkEmpty(ctx context.Context, pool *pgxpool.Pool) error {
+	tx, err := pool.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	columns, err := tableColumns(ctx, tx)
+	if err != nil {
+		return err
+	}
+	for _, name := range sortedKeys(columns) {
+		var exists bool
+		err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM "+pgx.Identifier{"public", name}.Sanitize()+")").Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("check %s: %w", name, err)
+		}
+		if exists {
+			return fmt.Errorf("table %s is not empty; restore needs an empty database", name)
+		}
+	}
+	return nil
+}
+
+// resetSequences moves serial and identity sequences past the restored
+// rows. Current tables use UUID keys; this keeps later ones safe.
+func resetSequences(ctx context.Context, tx pgx.Tx) error {
+	rows, err := tx.Query(ctx, `
+SELECT c.relname, a.attname, pg_get_serial_sequence(quote_ident(c.relname), a.attname)
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_attribute a ON a.attrelid = c.oid
+WHERE n.nspname = 'public'
+  AND c.relkind = 'r'
+  AND a.attnum > 0
+  AND NOT a.attisdropped
+  AND pg_get_serial_sequence(quote_ident(c.relname), a.attname) IS NOT NULL`)
+	if err != nil {
+		return fmt.Errorf("list sequences: %w", err)
+	}
+	type seq struct{ table, column, name string }
+	var seqs []seq
+	for rows.Next() {
+		var s seq
+		if err := rows.Scan(&s.table, &s.column, &s.name); err != nil {
+			rows.Close()
+			return err
+		}
+		seqs = append(seqs, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, s := range seqs {
+		_, err := tx.Exec(ctx, fmt.Sprintf("SELECT setval($1, COALESCE(MAX(%s), 0) + 1, false) FROM %s",
+			pgx.Identifier{s.column}.Sanitize(), pgx.Identifier{"public", s.table}.Sanitize()), s.name)
+		if err != nil {
+			return fmt.Errorf("reset sequence %s: %w", s.name, err)
+		}
+	}
+	return nil
+}
+
+// MigrationVersions lists the up-migration versions in dir.
+func MigrationVersions(dir string) ([]uint, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var versions []uint
+	for _, e := range entries {
+		mig, err := source.Parse(e.Name())
+		if err != nil || mig.Direction != source.Up {
+			continue
+		}
+		versions = append(versions, mig.Version)
+	}
+	slices.Sort(versions)
+	return versions, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	return slices.Sorted(maps.Keys(m))
+}
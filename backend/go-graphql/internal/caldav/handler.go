@@ -0,0 +1,561 @@
+// Package caldav exposes projects as CalDAV (RFC 4791) calendar collections
+// of VTODOs so reminder apps can sync tasks both ways.
+package caldav
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/calendar"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/google/uuid"
+)
+
+// Prefix is where the handler is mounted.
+const Prefix = "/caldav/"
+
+const (
+	principalPath = Prefix + "principal/"
+	homePath      = Prefix + "calendars/"
+	objectSuffix  = ".ics"
+	maxBodyBytes  = 1 << 20
+	allowMethods  = "OPTIONS, GET, HEAD, PUT, DELETE, PROPFIND, REPORT"
+	contentType   = "text/calendar; charset=utf-8; component=VTODO"
+)
+
+type resourceKind int
+
+const (
+	kindRoot resourceKind = iota
+	kindPrincipal
+	kindHome
+	kindCollection
+	kindObject
+)
+
+type resource struct {
+	kind      resourceKind
+	projectID string
+	taskID    string
+}
+
+// Handler serves the CalDAV tree:
+//
+//	/caldav/                                  service root
+//	/caldav/principal/                        the (single) user
+//	/caldav/calendars/                        calendar home
+//	/caldav/calendars/{projectId}/            one VTODO collection per project
+//	/caldav/calendars/{projectId}/{taskId}.ics
+//
+// Clients authenticate with HTTP Basic auth; any user name is accepted and
+// the password must match the configured one. A PUT to an existing task goes
+// through Service.UpdateTask; a PUT to an unknown one in a project creates
+// the task with that ID through Service.CreateTask.
+type Handler struct {
+	svc      *service.Service
+	password string
+}
+
+func NewHandler(svc *service.Service, password string) *Handler {
+	return &Handler{svc: svc, password: password}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if _, pass, ok := r.BasicAuth(); !ok || subtle.ConstantTimeCompare([]byte(pass), []byte(h.password)) != 1 {
+		w.Header().Set("WWW-Authenticate", `Basic realm="ZenList", charset="UTF-8"`)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	res, ok := parsePath(r.URL.Path)
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.Header().Set("DAV", "1, 3, calendar-access")
+		w.Header().Set("Allow", allowMethods)
+		w.WriteHeader(http.StatusOK)
+	case "PROPFIND":
+		h.propfind(w, r, res)
+	case "REPORT":
+		h.report(w, r, res)
+	case http.MethodGet, http.MethodHead:
+		h.get(w, r, res)
+	case http.MethodPut:
+		h.put(w, r, res)
+	case http.MethodDelete:
+		h.delete(w, r, res)
+	default:
+		w.Header().Set("Allow", allowMethods)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *Handler) propfind(w http.ResponseWriter, r *http.Request, res resource) {
+	req, err := readPropfind(io.LimitReader(r.Body, maxBodyBytes))
+	if err != nil {
+		http.Error(w, "invalid PROPFIND body", http.StatusBadRequest)
+		return
+	}
+	// Depth: infinity is treated as 1; nothing here is nested deeper.
+	children := r.Header.Get("Depth") != "0"
+	ctx := r.Context()
+
+	var responses []response
+	switch res.kind {
+	case kindRoot:
+		responses = append(responses, newResponse(Prefix, []prop{
+			{propResourceType, "<d:collection/>"},
+			{propDisplayName, "ZenList"},
+			{propCurrentUserPrincipal, hrefXML(principalPath)},
+		}, req))
+	case kindPrincipal:
+		user, err := h.svc.Me(ctx)
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		responses = append(responses, newResponse(principalPath, []prop{
+			{propResourceType, "<d:principal/>"},
+			{propDisplayName, escape(user.Name)},
+			{propCurrentUserPrincipal, hrefXML(principalPath)},
+			{propPrincipalURL, hrefXML(principalPath)},
+			{propCalendarHomeSet, hrefXML(homePath)},
+			{propCalendarUserAddressSet, hrefXML("mailto:" + user.Email)},
+		}, req))
+	case kindHome:
+		responses = append(responses, newResponse(homePath, []prop{
+			{propResourceType, "<d:collection/>"},
+			{propDisplayName, "Projects"},
+			{propOwner, hrefXML(principalPath)},
+			{propCurrentUserPrincipal, hrefXML(principalPath)},
+		}, req))
+		if children {
+			collections, err := h.svc.CalDAVCollections(ctx)
+			if err != nil {
+				writeServiceError(w, err)
+				return
+			}
+			for _, c := range collections {
+				responses = append(responses, newResponse(collectionPath(c.ID.Bytes), collectionProps(c), req))
+			}
+		}
+	case kindCollection:
+		collections, err := h.svc.CalDAVCollections(ctx)
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		var found bool
+		for _, c := range collections {
+			if uuid.UUID(c.ID.Bytes).String() == res.projectID {
+				responses = append(responses, newResponse(collectionPath(c.ID.Bytes), collectionProps(c), req))
+				found = true
+			}
+		}
+		if !found {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		if children {
+			data, err := h.svc.CalDAVProject(ctx, res.projectID)
+			if err != nil {
+				writeServiceError(w, err)
+				return
+			}
+			for _, task := range data.Tasks {
+				resp, err := objectResponse(data, task, req)
+				if err != nil {
+					writeServiceError(w, err)
+					return
+				}
+				responses = append(responses, resp)
+			}
+		}
+	case kindObject:
+		data, err := h.svc.CalDAVTask(ctx, res.projectID, res.taskID)
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		resp, err := objectResponse(data, data.Tasks[0], req)
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		responses = append(responses, resp)
+	}
+
+	writeMultistatus(w, responses)
+}
+
+// report answers calendar-query and calendar-multiget against a collection.
+// sync-collection is not offered, so clients fall back to ctag polling.
+func (h *Handler) report(w http.ResponseWriter, r *http.Request, res resource) {
+	if res.kind != kindCollection {
+		writePrecondition(w, http.StatusForbidden, "d:supported-report")
+		return
+	}
+
+	var body reportBody
+	if err := xml.NewDecoder(io.LimitReader(r.Body, maxBodyBytes)).Decode(&body); err != nil {
+		http.Error(w, "invalid REPORT body", http.StatusBadRequest)
+		return
+	}
+	if body.XMLName.Space != nsCalDAV || (body.XMLName.Local != "calendar-query" && body.XMLName.Local != "calendar-multiget") {
+		writePrecondition(w, http.StatusForbidden, "d:supported-report")
+		return
+	}
+
+	data, err := h.svc.CalDAVProject(r.Context(), res.projectID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	req := body.props()
+	var responses []response
+	if body.XMLName.Local == "calendar-query" {
+		if body.wantsTodos() {
+			for _, task := range data.Tasks {
+				resp, err := objectResponse(data, task, req)
+				if err != nil {
+					writeServiceError(w, err)
+					return
+				}
+				responses = append(responses, resp)
+			}
+		}
+		writeMultistatus(w, responses)
+		return
+	}
+
+	byID := make(map[string]sqlc.Task, len(data.Tasks))
+	for _, task := range data.Tasks {
+		byID[uuid.UUID(task.ID.Bytes).String()] = task
+	}
+	for _, href := range body.Hrefs {
+		target, ok := parseHref(href)
+		task, exists := byID[target.taskID]
+		if !ok || target.kind != kindObject || target.projectID != res.projectID || !exists {
+			responses = append(responses, response{href: href, status: http.StatusNotFound})
+			continue
+		}
+		resp, err := objectResponse(data, task, req)
+		if err != nil {
+			writeServiceError(w, err)
+			return
+		}
+		responses = append(responses, resp)
+	}
+	writeMultistatus(w, responses)
+}
+
+func (h *Handler) get(w http.ResponseWriter, r *http.Request, res resource) {
+	if res.kind != kindObject {
+		w.Header().Set("Allow", "OPTIONS, PROPFIND, REPORT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	data, err := h.svc.CalDAVTask(r.Context(), res.projectID, res.taskID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	body, err := renderObject(data, data.Tasks[0])
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	etag := calendar.ETag(body)
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Last-Modified", data.Tasks[0].UpdatedAt.Time.UTC().Format(http.TimeFormat))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+func (h *Handler) put(w http.ResponseWriter, r *http.Request, res resource) {
+	if res.kind != kindObject {
+		w.Header().Set("Allow", "OPTIONS, PROPFIND, REPORT")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	data, err := h.svc.CalDAVTask(r.Context(), res.projectID, res.taskID)
+	if err != nil {
+		if service.IsAppErrorCode(err, service.CodeNotFound) {
+			h.create(w, r, res, payload)
+			return
+		}
+		writeServiceError(w, err)
+		return
+	}
+	task := data.Tasks[0]
+
+	current, err := renderObject(data, task)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	if !preconditionsHold(r, calendar.ETag(current)) {
+		http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+		return
+	}
+
+	todo, err := calendar.ParseTodo(bytes.NewReader(payload), calendar.UserLocation(data.User.Timezone))
+	if err != nil || (todo.UID != "" && todo.UID != calendar.TaskUID(task.ID)) {
+		writePrecondition(w, http.StatusForbidden, "c:valid-calendar-data")
+		return
+	}
+
+	in := todo.UpdateInput(task)
+	expected := task.UpdatedAt.Time
+	in.ExpectedUpdatedAt = &expected
+	if _, err := h.svc.UpdateTask(r.Context(), in); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	// The stored object is not byte-for-byte what the client sent, so no ETag
+	// is returned and the client re-fetches it (RFC 4791 5.3.4).
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// create stores a PUT to an unknown object as a new task with the ID from
+// the path. If-None-Match: * always holds here; If-Match never does.
+func (h *Handler) create(w http.ResponseWriter, r *http.Request, res resource, payload []byte) {
+	if r.Header.Get("If-Match") != "" {
+		http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+		return
+	}
+
+	user, err := h.svc.Me(r.Context())
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	todo, err := calendar.ParseTodo(bytes.NewReader(payload), calendar.UserLocation(user.Timezone))
+	if err != nil {
+		writePrecondition(w, http.StatusForbidden, "c:valid-calendar-data")
+		return
+	}
+	if _, err := h.svc.CreateTask(r.Context(), todo.CreateInput(res.projectID, res.taskID)); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+
+	data, err := h.svc.CalDAVTask(r.Context(), res.projectID, res.taskID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	body, err := renderObject(data, data.Tasks[0])
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	// The ETag is that of the stored object, so the client can send it in
+	// If-Match on its next write without re-fetching first.
+	w.Header().Set("ETag", calendar.ETag(body))
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request, res resource) {
+	if res.kind != kindObject {
+		http.Error(w, "projects cannot be deleted over CalDAV", http.StatusForbidden)
+		return
+	}
+
+	data, err := h.svc.CalDAVTask(r.Context(), res.projectID, res.taskID)
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	current, err := renderObject(data, data.Tasks[0])
+	if err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	if !preconditionsHold(r, calendar.ETag(current)) {
+		http.Error(w, "precondition failed", http.StatusPreconditionFailed)
+		return
+	}
+
+	if _, err := h.svc.DeleteTask(r.Context(), res.taskID); err != nil {
+		writeServiceError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// preconditionsHold evaluates If-Match and If-None-Match against an existing
+// resource.
+func preconditionsHold(r *http.Request, etag string) bool {
+	if m := r.Header.Get("If-Match"); m != "" && !etagMatches(m, etag) {
+		return false
+	}
+	if m := r.Header.Get("If-None-Match"); m != "" && etagMatches(m, etag) {
+		return false
+	}
+	return true
+}
+
+func collectionProps(c sqlc.ListProjectSyncStatesRow) []prop {
+	ctag := strconv.FormatInt(c.LastModified.Time.UnixMicro(), 36) + "-" + strconv.FormatInt(c.TaskCount, 36)
+	props := []prop{
+		{propResourceType, "<d:collection/><c:calendar/>"},
+		{propDisplayName, escape(c.Title)},
+		{propOwner, hrefXML(principalPath)},
+		{propCurrentUserPrincipal, hrefXML(principalPath)},
+		{propSupportedComponents, `<c:comp name="VTODO"/>`},
+		{propSupportedReportSet, "<d:supported-report><d:report><c:calendar-query/></d:report></d:supported-report>" +
+			"<d:supported-report><d:report><c:calendar-multiget/></d:report></d:supported-report>"},
+		{propCurrentUserPrivileges, "<d:privilege><d:read/></d:privilege>" +
+			"<d:privilege><d:write-content/></d:privilege>" +
+			"<d:privilege><d:bind/></d:privilege>" +
+			"<d:privilege><d:unbind/></d:privilege>"},
+		{propGetCTag, escape(ctag)},
+	}
+	if c.Description != nil {
+		props = append(props, prop{propCalendarDescription, escape(*c.Description)})
+	}
+	if c.Color != nil {
+		props = append(props, prop{propCalendarColor, escape(*c.Color)})
+	}
+	return props
+}
+
+func objectResponse(data service.CalendarData, task sqlc.Task, req propRequest) (response, error) {
+	body, err := renderObject(data, task)
+	if err != nil {
+		return response{}, err
+	}
+	return newResponse(objectPath(task), []prop{
+		{propResourceType, ""},
+		{propGetETag, escape(calendar.ETag(body))},
+		{propGetContentType, contentType},
+		{propGetContentLength, strconv.Itoa(len(body))},
+		{propGetLastModified, task.UpdatedAt.Time.UTC().Format(http.TimeFormat)},
+		{propCurrentUserPrivileges, "<d:privilege><d:read/></d:privilege><d:privilege><d:write-content/></d:privilege>"},
+		{propCalendarData, escape(string(body))},
+	}, req), nil
+}
+
+func renderObject(data service.CalendarData, task sqlc.Task) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := calendar.RenderObject(&buf, data.User, task, data.Labels[uuid.UUID(task.ID.Bytes)]); err != nil {
+		return nil, service.NewInternal("failed to render task", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func collectionPath(projectID [16]byte) string {
+	return homePath + uuid.UUID(projectID).String() + "/"
+}
+
+func objectPath(task sqlc.Task) string {
+	return collectionPath(task.ProjectID.Bytes) + uuid.UUID(task.ID.Bytes).String() + objectSuffix
+}
+
+// parsePath maps a request path onto a resource. IDs must be UUIDs and are
+// returned in canonical form.
+func parsePath(p string) (resource, bool) {
+	rest, ok := strings.CutPrefix(p, strings.TrimSuffix(Prefix, "/"))
+	if !ok {
+		return resource{}, false
+	}
+	var segments []string
+	for _, s := range strings.Split(rest, "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+
+	switch {
+	case len(segments) == 0:
+		return resource{kind: kindRoot}, true
+	case len(segments) == 1 && segments[0] == "principal":
+		return resource{kind: kindPrincipal}, true
+	case segments[0] != "calendars" || len(segments) > 3:
+		return resource{}, false
+	case len(segments) == 1:
+		return resource{kind: kindHome}, true
+	}
+
+	projectID, err := uuid.Parse(segments[1])
+	if err != nil {
+		return resource{}, false
+	}
+	if len(segments) == 2 {
+		return resource{kind: kindCollection, projectID: projectID.String()}, true
+	}
+
+	taskID, err := uuid.Parse(strings.TrimSuffix(segments[2], objectSuffix))
+	if err != nil || !strings.HasSuffix(segments[2], objectSuffix) {
+		return resource{}, false
+	}
+	return resource{kind: kindObject, projectID: projectID.String(), taskID: taskID.String()}, true
+}
+
+// parseHref resolves a DAV:href, which may be a path or an absolute URL.
+func parseHref(href string) (resource, bool) {
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return resource{}, false
+	}
+	return parsePath(u.Path)
+}
+
+// etagMatches reports whether an If-Match / If-None-Match header value
+// matches etag. Weak validators compare by their opaque part.
+func etagMatches(header string, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func writeServiceError(w http.ResponseWriter, err error) {
+	var appErr *service.AppError
+	if !errors.As(err, &appErr) {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	switch appErr.Code {
+	case service.CodeNotFound:
+		http.Error(w, appErr.Message, http.StatusNotFound)
+	case service.CodeBadUserInput:
+		http.Error(w, appErr.Message, http.StatusBadRequest)
+	case service.CodeConflict:
+		// A concurrent change, or a new object whose ID is already taken.
+		http.Error(w, appErr.Message, http.StatusPreconditionFailed)
+	default:
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}
+}
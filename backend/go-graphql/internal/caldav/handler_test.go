@@ -0,0 +1,101 @@
+package caldav
+
+import (
+	"encoding/xml"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func TestParsePath(t *testing.T) {
+	projectID := "6f1c1b7e-2f64-4a53-9a7e-2d4a6c1f0b11"
+	taskID := "0b6d3f2a-8f51-4bde-9b31-7c4a2e9d5f00"
+
+	tests := []struct {
+		path string
+		want resource
+		ok   bool
+	}{
+		{path: "/caldav/", want: resource{kind: kindRoot}, ok: true},
+		{path: "/caldav", want: resource{kind: kindRoot}, ok: true},
+		{path: "/caldav/principal/", want: resource{kind: kindPrincipal}, ok: true},
+		{path: "/caldav/calendars/", want: resource{kind: kindHome}, ok: true},
+		{path: "/caldav/calendars/" + projectID + "/", want: resource{kind: kindCollection, projectID: projectID}, ok: true},
+		{
+			path: "/caldav/calendars/" + strings.ToUpper(projectID) + "/" + taskID + ".ics",
+			want: resource{kind: kindObject, projectID: projectID, taskID: taskID},
+			ok:   true,
+		},
+		{path: "/caldav/calendars/not-a-uuid/"},
+		{path: "/caldav/calendars/" + projectID + "/" + taskID},
+		{path: "/caldav/other/"},
+		{path: "/api/v1/tasks"},
+	}
+	for _, tc := range tests {
+		got, ok := parsePath(tc.path)
+		if ok != tc.ok || got != tc.want {
+			t.Fatalf("parsePath(%q): got %+v, %v want %+v, %v", tc.path, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestEtagMatches(t *testing.T) {
+	etag := `"abc"`
+	if !etagMatches(`"x", "abc"`, etag) || !etagMatches("*", etag) || !etagMatches(`W/"abc"`, etag) {
+		t.Fatalf("expected match")
+	}
+	if etagMatches(`"abd"`, etag) {
+		t.Fatalf("unexpected match")
+	}
+}
+
+func TestAuthRequired(t *testing.T) {
+	h := NewHandler(&service.Service{}, "correct-horse-battery")
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest("PROPFIND", "/caldav/", nil)
+	req.SetBasicAuth("user", "wrong-password")
+	h.ServeHTTP(rec, req)
+	if rec.Code != 401 || rec.Header().Get("WWW-Authenticate") == "" {
+		t.Fatalf("expected 401 with a challenge, got %d", rec.Code)
+	}
+}
+
+func TestPropfindRoot(t *testing.T) {
+	h := NewHandler(&service.Service{}, "correct-horse-battery")
+	rec := httptest.NewRecorder()
+	body := `<?xml version="1.0"?><d:propfind xmlns:d="DAV:"><d:prop><d:current-user-principal/><d:getetag/><x:foo xmlns:x="urn:x"/></d:prop></d:propfind>`
+	req := httptest.NewRequest("PROPFIND", "/caldav/", strings.NewReader(body))
+	req.SetBasicAuth("user", "correct-horse-battery")
+	req.Header.Set("Depth", "0")
+	h.ServeHTTP(rec, req)
+	if rec.Code != 207 {
+		t.Fatalf("expected 207, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	var ms struct {
+		Responses []struct {
+			Href      string `xml:"href"`
+			Propstats []struct {
+				Status string `xml:"status"`
+				Prop   struct {
+					Inner string `xml:",innerxml"`
+				} `xml:"prop"`
+			} `xml:"propstat"`
+		} `xml:"response"`
+	}
+	if err := xml.Unmarshal(rec.Body.Bytes(), &ms); err != nil {
+		t.Fatalf("decode multistatus: %v\n%s", err, rec.Body.String())
+	}
+	if len(ms.Responses) != 1 || ms.Responses[0].Href != "/caldav/" || len(ms.Responses[0].Propstats) != 2 {
+		t.Fatalf("unexpected multistatus: %s", rec.Body.String())
+	}
+	ok, missing := ms.Responses[0].Propstats[0], ms.Responses[0].Propstats[1]
+	if !strings.Contains(ok.Status, "200") || !strings.Contains(ok.Prop.Inner, principalPath) {
+		t.Fatalf("unexpected found propstat: %+v", ok)
+	}
+	if !strings.Contains(missing.Status, "404") || !strings.Contains(missing.Prop.Inner, "getetag") || !strings.Contains(missing.Prop.Inner, `xmlns:x="urn:x"`) {
+		t.Fatalf("unexpected missing propstat: %+v", missing)
+	}
+}
@@ -0,0 +1,259 @@
+package caldav
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	nsDAV    = "DAV:"
+	nsCalDAV = "urn:ietf:params:xml:ns:caldav"
+	nsCS     = "http://calendarserver.org/ns/"
+	nsApple  = "http://apple.com/ns/ical/"
+)
+
+var prefixes = map[string]string{
+	nsDAV:    "d",
+	nsCalDAV: "c",
+	nsCS:     "cs",
+	nsApple:  "ic",
+}
+
+var (
+	propResourceType           = xml.Name{Space: nsDAV, Local: "resourcetype"}
+	propDisplayName            = xml.Name{Space: nsDAV, Local: "displayname"}
+	propOwner                  = xml.Name{Space: nsDAV, Local: "owner"}
+	propCurrentUserPrincipal   = xml.Name{Space: nsDAV, Local: "current-user-principal"}
+	propPrincipalURL           = xml.Name{Space: nsDAV, Local: "principal-URL"}
+	propCurrentUserPrivileges  = xml.Name{Space: nsDAV, Local: "current-user-privilege-set"}
+	propSupportedReportSet     = xml.Name{Space: nsDAV, Local: "supported-report-set"}
+	propGetETag                = xml.Name{Space: nsDAV, Local: "getetag"}
+	propGetContentType         = xml.Name{Space: nsDAV, Local: "getcontenttype"}
+	propGetContentLength       = xml.Name{Space: nsDAV, Local: "getcontentlength"}
+	propGetLastModified        = xml.Name{Space: nsDAV, Local: "getlastmodified"}
+	propCalendarHomeSet        = xml.Name{Space: nsCalDAV, Local: "calendar-home-set"}
+	propCalendarUserAddressSet = xml.Name{Space: nsCalDAV, Local: "calendar-user-address-set"}
+	propCalendarDescription    = xml.Name{Space: nsCalDAV, Local: "calendar-description"}
+	propSupportedComponents    = xml.Name{Space: nsCalDAV, Local: "supported-calendar-component-set"}
+	propCalendarData           = xml.Name{Space: nsCalDAV, Local: "calendar-data"}
+	propGetCTag                = xml.Name{Space: nsCS, Local: "getctag"}
+	propCalendarColor          = xml.Name{Space: nsApple, Local: "calendar-color"}
+)
+
+// prop is a WebDAV property whose value is already-serialised inner XML.
+type prop struct {
+	name  xml.Name
+	value string
+}
+
+// propRequest is what a PROPFIND or REPORT asked for. An empty names list
+// with neither flag set is treated as allprop (RFC 4918 9.1).
+type propRequest struct {
+	allProp  bool
+	propName bool
+	names    []xml.Name
+}
+
+// propNames collects the element names inside a DAV:prop.
+type propNames []xml.Name
+
+func (p *propNames) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			*p = append(*p, t.Name)
+			if err := d.Skip(); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+type propfindBody struct {
+	XMLName  xml.Name   `xml:"DAV: propfind"`
+	AllProp  *struct{}  `xml:"DAV: allprop"`
+	PropName *struct{}  `xml:"DAV: propname"`
+	Prop     *propNames `xml:"DAV: prop"`
+}
+
+type compFilter struct {
+	Name  string       `xml:"name,attr"`
+	Comps []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+type reportBody struct {
+	XMLName xml.Name
+	Prop    *propNames `xml:"DAV: prop"`
+	Hrefs   []string   `xml:"DAV: href"`
+	Filter  *struct {
+		Comp compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+	} `xml:"urn:ietf:params:xml:ns:caldav filter"`
+}
+
+func (b reportBody) props() propRequest {
+	if b.Prop == nil {
+		return propRequest{allProp: true}
+	}
+	return propRequest{names: *b.Prop}
+}
+
+// wantsTodos reports whether a calendar-query filter can match a VTODO.
+// Narrower filters (time ranges, property filters) are not applied; RFC 4791
+// clients re-check what they receive, so returning extra objects is harmless.
+func (b reportBody) wantsTodos() bool {
+	if b.Filter == nil || len(b.Filter.Comp.Comps) == 0 {
+		return true
+	}
+	for _, c := range b.Filter.Comp.Comps {
+		if strings.EqualFold(c.Name, "VTODO") {
+			return true
+		}
+	}
+	return false
+}
+
+func readPropfind(r io.Reader) (propRequest, error) {
+	var body propfindBody
+	if err := xml.NewDecoder(r).Decode(&body); err != nil {
+		if errors.Is(err, io.EOF) {
+			return propRequest{allProp: true}, nil
+		}
+		return propRequest{}, err
+	}
+	switch {
+	case body.PropName != nil:
+		return propRequest{propName: true}, nil
+	case body.Prop != nil:
+		return propRequest{names: *body.Prop}, nil
+	default:
+		return propRequest{allProp: true}, nil
+	}
+}
+
+// response is one DAV:response in a multistatus. status is set instead of
+// props for hrefs that could not be resolved.
+type response struct {
+	href    string
+	found   []prop
+	missing []xml.Name
+	status  int
+}
+
+// newResponse selects the requested properties from all. calendar-data is
+// only returned when asked for by name (RFC 4791 9.6).
+func newResponse(href string, all []prop, req propRequest) response {
+	resp := response{href: href}
+	switch {
+	case req.propName:
+		for _, p := range all {
+			resp.found = append(resp.found, prop{name: p.name})
+		}
+	case req.allProp || len(req.names) == 0:
+		for _, p := range all {
+			if p.name != propCalendarData {
+				resp.found = append(resp.found, p)
+			}
+		}
+	default:
+		for _, name := range req.names {
+			if p, ok := findProp(all, name); ok {
+				resp.found = append(resp.found, p)
+			} else {
+				resp.missing = append(resp.missing, name)
+			}
+		}
+	}
+	return resp
+}
+
+func findProp(props []prop, name xml.Name) (prop, bool) {
+	for _, p := range props {
+		if p.name == name {
+			return p, true
+		}
+	}
+	return prop{}, false
+}
+
+func writeMultistatus(w http.ResponseWriter, responses []response) {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<d:multistatus xmlns:d="DAV:" xmlns:c="` + nsCalDAV + `" xmlns:cs="` + nsCS + `" xmlns:ic="` + nsApple + `">`)
+	for _, resp := range responses {
+		b.WriteString("<d:response><d:href>" + escape(resp.href) + "</d:href>")
+		if resp.status != 0 {
+			b.WriteString("<d:status>" + statusLine(resp.status) + "</d:status>")
+		}
+		writePropstat(&b, resp.found, http.StatusOK)
+		missing := make([]prop, 0, len(resp.missing))
+		for _, name := range resp.missing {
+			missing = append(missing, prop{name: name})
+		}
+		writePropstat(&b, missing, http.StatusNotFound)
+		b.WriteString("</d:response>")
+	}
+	b.WriteString("</d:multistatus>")
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(http.StatusMultiStatus)
+	_, _ = io.WriteString(w, b.String())
+}
+
+func writePropstat(b *strings.Builder, props []prop, status int) {
+	if len(props) == 0 {
+		return
+	}
+	b.WriteString("<d:propstat><d:prop>")
+	for _, p := range props {
+		writeElement(b, p.name, p.value)
+	}
+	b.WriteString("</d:prop><d:status>" + statusLine(status) + "</d:status></d:propstat>")
+}
+
+func writeElement(b *strings.Builder, name xml.Name, inner string) {
+	tag, open := name.Local, name.Local
+	if prefix, ok := prefixes[name.Space]; ok {
+		tag = prefix + ":" + name.Local
+		open = tag
+	} else if name.Space != "" {
+		tag = "x:" + name.Local
+		open = tag + ` xmlns:x="` + escape(name.Space) + `"`
+	}
+	if inner == "" {
+		b.WriteString("<" + open + "/>")
+		return
+	}
+	b.WriteString("<" + open + ">" + inner + "</" + tag + ">")
+}
+
+// writePrecondition reports a failed WebDAV/CalDAV precondition (RFC 4918
+// 16) such as "c:valid-calendar-data".
+func writePrecondition(w http.ResponseWriter, status int, condition string) {
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, xml.Header+`<d:error xmlns:d="DAV:" xmlns:c="`+nsCalDAV+`"><`+condition+`/></d:error>`)
+}
+
+func hrefXML(href string) string {
+	return "<d:href>" + escape(href) + "</d:href>"
+}
+
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func statusLine(code int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", code, http.StatusText(code))
+}
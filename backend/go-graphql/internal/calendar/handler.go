@@ -0,0 +1,79 @@
+package calendar
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+const feedSuffix = ".ics"
+
+// Handler serves GET /calendar/{token}.ics. The token in the path is the only
+// credential, so the feed can be subscribed to from any calendar app.
+type Handler struct {
+	svc *service.Service
+	mux *http.ServeMux
+}
+
+func NewHandler(svc *service.Service) *Handler {
+	h := &Handler{svc: svc, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /calendar/{file}", h.feed)
+	h.mux.HandleFunc("/calendar/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) feed(w http.ResponseWriter, r *http.Request) {
+	file := r.PathValue("file")
+	if !strings.HasSuffix(file, feedSuffix) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	kind := Kind(r.URL.Query().Get("kind"))
+	switch kind {
+	case "":
+		kind = KindEvent
+	case KindEvent, KindTodo:
+	default:
+		http.Error(w, "kind must be event or todo", http.StatusBadRequest)
+		return
+	}
+
+	data, err := h.svc.CalendarFeed(r.Context(), strings.TrimSuffix(file, feedSuffix))
+	if err != nil {
+		if service.IsAppErrorCode(err, service.CodeNotFound) {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	var body bytes.Buffer
+	if err := Render(&body, data, kind); err != nil {
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	// Calendar clients poll; a content hash lets unchanged feeds return 304.
+	etag := ETag(body.Bytes())
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="zenlist.ics"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body.Bytes())
+}
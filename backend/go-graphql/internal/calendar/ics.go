@@ -0,0 +1,277 @@
+// Package calendar renders tasks as an RFC 5545 iCalendar feed.
+package calendar
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Kind selects which component each task becomes. Most calendar apps only
+// show VEVENTs; task-aware clients (Apple Reminders, Thunderbird) read VTODOs.
+type Kind string
+
+const (
+	KindEvent Kind = "event"
+	KindTodo  Kind = "todo"
+)
+
+const (
+	prodID       = "-//ZenList//Tasks//EN"
+	uidDomain    = "zenlist"
+	refreshEvery = "PT15M"
+	maxLineLen   = 75
+	localLayout  = "20060102T150405"
+	utcLayout    = "20060102T150405Z"
+)
+
+var todoStatuses = map[string]string{
+	"TODO":        "NEEDS-ACTION",
+	"IN_PROGRESS": "IN-PROCESS",
+	"BLOCKED":     "NEEDS-ACTION",
+	"DONE":        "COMPLETED",
+}
+
+var statusLabels = map[string]string{
+	"TODO":        "To do",
+	"IN_PROGRESS": "In progress",
+	"BLOCKED":     "Blocked",
+	"DONE":        "Done",
+}
+
+// iCalendar priorities run 1 (highest) to 9 (lowest).
+var priorities = map[string]int{
+	"P1": 1,
+	"P2": 3,
+	"P3": 5,
+	"P4": 7,
+	"P5": 9,
+}
+
+// Render writes data as a VCALENDAR. Times are expressed in the user's
+// timezone, with a matching VTIMEZONE, unless that zone is UTC or unknown.
+func Render(w io.Writer, data service.CalendarData, kind Kind) error {
+	loc := UserLocation(data.User.Timezone)
+
+	name := "ZenList"
+	if data.Project != nil {
+		name = data.Project.Title
+	}
+
+	cw := &contentWriter{w: w}
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:" + prodID)
+	cw.line("CALSCALE:GREGORIAN")
+	cw.line("METHOD:PUBLISH")
+	cw.line("NAME:" + escapeText(name))
+	cw.line("X-WR-CALNAME:" + escapeText(name))
+	cw.line("X-WR-TIMEZONE:" + loc.String())
+	cw.line("REFRESH-INTERVAL;VALUE=DURATION:" + refreshEvery)
+	cw.line("X-PUBLISHED-TTL:" + refreshEvery)
+	writeComponents(cw, data, kind, loc)
+	cw.line("END:VCALENDAR")
+	return cw.err
+}
+
+// RenderObject writes one task as a CalDAV calendar object resource: a
+// VCALENDAR with a single VTODO and no METHOD (RFC 4791 4.1).
+func RenderObject(w io.Writer, user sqlc.User, task sqlc.Task, labels []string) error {
+	data := service.CalendarData{
+		User:   user,
+		Tasks:  []sqlc.Task{task},
+		Labels: map[uuid.UUID][]string{uuid.UUID(task.ID.Bytes): labels},
+	}
+
+	cw := &contentWriter{w: w}
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:" + prodID)
+	cw.line("CALSCALE:GREGORIAN")
+	writeComponents(cw, data, KindTodo, UserLocation(user.Timezone))
+	cw.line("END:VCALENDAR")
+	return cw.err
+}
+
+// UserLocation loads a users.timezone value, falling back to UTC for names
+// the runtime does not know.
+func UserLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ETag returns a strong entity tag for a rendered body.
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+func writeComponents(cw *contentWriter, data service.CalendarData, kind Kind, loc *time.Location) {
+	tz := newTimeFormatter(loc)
+	if from, to, ok := taskTimeRange(data.Tasks); ok && !tz.utc {
+		writeTimezone(cw, loc, from, to)
+	}
+
+	for _, task := range data.Tasks {
+		labels := data.Labels[uuid.UUID(task.ID.Bytes)]
+		if kind == KindTodo {
+			writeTodo(cw, task, labels, tz)
+		} else {
+			writeEvent(cw, task, labels, tz)
+		}
+	}
+}
+
+func writeEvent(cw *contentWriter, t sqlc.Task, labels []string, tz timeFormatter) {
+	start, due := timePtr(t.StartAt), timePtr(t.DueAt)
+	if start == nil {
+		start = due
+	}
+
+	cw.line("BEGIN:VEVENT")
+	writeCommon(cw, t, labels)
+	cw.line(tz.property("DTSTART", *start))
+	// Without DTEND the event is a point in time at DTSTART (RFC 5545 3.6.1).
+	if due != nil && due.After(*start) {
+		cw.line(tz.property("DTEND", *due))
+	}
+	cw.line("TRANSP:TRANSPARENT")
+
+	description := fmt.Sprintf("Status: %s\nPriority: %s", statusLabels[t.Status], t.Priority)
+	if t.Description != nil && strings.TrimSpace(*t.Description) != "" {
+		description = *t.Description + "\n\n" + description
+	}
+	cw.line("DESCRIPTION:" + escapeText(description))
+	cw.line("END:VEVENT")
+}
+
+func writeTodo(cw *contentWriter, t sqlc.Task, labels []string, tz timeFormatter) {
+	cw.line("BEGIN:VTODO")
+	writeCommon(cw, t, labels)
+	if start := timePtr(t.StartAt); start != nil {
+		cw.line(tz.property("DTSTART", *start))
+	}
+	if due := timePtr(t.DueAt); due != nil {
+		cw.line(tz.property("DUE", *due))
+	}
+	cw.line("STATUS:" + todoStatuses[t.Status])
+	if completed := timePtr(t.CompletedAt); completed != nil {
+		cw.line("COMPLETED:" + completed.UTC().Format(utcLayout))
+		cw.line("PERCENT-COMPLETE:100")
+	}
+	if t.ParentTaskID.Valid {
+		cw.line("RELATED-TO:" + TaskUID(t.ParentTaskID))
+	}
+	if t.Description != nil && strings.TrimSpace(*t.Description) != "" {
+		cw.line("DESCRIPTION:" + escapeText(*t.Description))
+	}
+	cw.line("END:VTODO")
+}
+
+func writeCommon(cw *contentWriter, t sqlc.Task, labels []string) {
+	cw.line("UID:" + TaskUID(t.ID))
+	cw.line("DTSTAMP:" + t.UpdatedAt.Time.UTC().Format(utcLayout))
+	cw.line("CREATED:" + t.CreatedAt.Time.UTC().Format(utcLayout))
+	cw.line("LAST-MODIFIED:" + t.UpdatedAt.Time.UTC().Format(utcLayout))
+	cw.line("SUMMARY:" + escapeText(t.Title))
+	cw.line(fmt.Sprintf("PRIORITY:%d", priorities[t.Priority]))
+	if len(labels) > 0 {
+		escaped := make([]string, 0, len(labels))
+		for _, l := range labels {
+			escaped = append(escaped, escapeText(l))
+		}
+		cw.line("CATEGORIES:" + strings.Join(escaped, ","))
+	}
+}
+
+// TaskUID is the iCalendar UID of a task.
+func TaskUID(id pgtype.UUID) string {
+	return uuid.UUID(id.Bytes).String() + "@" + uidDomain
+}
+
+func timePtr(v pgtype.Timestamptz) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	t := v.Time
+	return &t
+}
+
+// timeFormatter renders DATE-TIME properties either as UTC ("...Z") or as
+// local time with a TZID parameter.
+type timeFormatter struct {
+	loc *time.Location
+	utc bool
+}
+
+func newTimeFormatter(loc *time.Location) timeFormatter {
+	return timeFormatter{loc: loc, utc: loc == time.UTC || loc.String() == "UTC"}
+}
+
+func (f timeFormatter) property(name string, t time.Time) string {
+	if f.utc {
+		return name + ":" + t.UTC().Format(utcLayout)
+	}
+	return name + ";TZID=" + f.loc.String() + ":" + t.In(f.loc).Format(localLayout)
+}
+
+// escapeText escapes a TEXT value (RFC 5545 3.3.11).
+func escapeText(s string) string {
+	r := strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	)
+	return r.Replace(s)
+}
+
+// contentWriter writes CRLF-terminated content lines, folding them at 75
+// octets without splitting UTF-8 sequences (RFC 5545 3.1).
+type contentWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (cw *contentWriter) line(s string) {
+	if cw.err != nil {
+		return
+	}
+	_, cw.err = io.WriteString(cw.w, fold(s)+"\r\n")
+}
+
+func fold(s string) string {
+	if len(s) <= maxLineLen {
+		return s
+	}
+
+	var b strings.Builder
+	limit := maxLineLen
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		// Continuation lines start with a space, which counts toward the limit.
+		limit = maxLineLen - 1
+	}
+	b.WriteString(s)
+	return b.String()
+}
@@ -0,0 +1,133 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestEscapeText(t *testing.T) {
+	got := escapeText("a\\b;c,d\ne\r\nf")
+	want := `a\\b\;c\,d\ne\nf`
+	if got != want {
+		t.Fatalf("got %q want %q", got, want)
+	}
+}
+
+func TestFold(t *testing.T) {
+	line := "SUMMARY:" + strings.Repeat("é", 80)
+	folded := fold(line)
+	for i, part := range strings.Split(folded, "\r\n") {
+		if len(part) > maxLineLen {
+			t.Fatalf("line %d is %d octets", i, len(part))
+		}
+		if i > 0 && !strings.HasPrefix(part, " ") {
+			t.Fatalf("continuation line %d does not start with a space", i)
+		}
+		if !utf8.ValidString(part) {
+			t.Fatalf("line %d splits a UTF-8 sequence: %q", i, part)
+		}
+	}
+	if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != line {
+		t.Fatalf("unfolding changed the line")
+	}
+}
+
+func TestZoneTransitions(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("tzdata unavailable")
+	}
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, loc)
+	to := time.Date(2024, 12, 31, 0, 0, 0, 0, loc)
+
+	got := zoneTransitions(loc, from, to)
+	if len(got) != 3 {
+		t.Fatalf("got %d transitions, want 3", len(got))
+	}
+	spring, fall := got[1], got[2]
+	if !spring.dst || spring.offsetFrom != -5*3600 || spring.offsetTo != -4*3600 {
+		t.Fatalf("unexpected spring transition: %+v", spring)
+	}
+	if want := time.Date(2024, 3, 10, 7, 0, 0, 0, time.UTC); !spring.at.Equal(want) {
+		t.Fatalf("spring transition at %s, want %s", spring.at.UTC(), want)
+	}
+	if fall.dst || fall.offsetTo != -5*3600 {
+		t.Fatalf("unexpected fall transition: %+v", fall)
+	}
+	if formatOffset(spring.offsetFrom) != "-0500" || formatOffset(19800) != "+0530" {
+		t.Fatalf("unexpected offset formatting")
+	}
+}
+
+func TestRender(t *testing.T) {
+	taskID := uuid.New()
+	start := time.Date(2024, 7, 1, 13, 0, 0, 0, time.UTC)
+	due := start.Add(2 * time.Hour)
+	desc := "Bring notes; slides"
+	data := service.CalendarData{
+		User:    sqlc.User{Timezone: "Europe/Berlin"},
+		Project: &sqlc.Project{Title: "Launch, v2"},
+		Tasks: []sqlc.Task{{
+			ID:          pgtype.UUID{Bytes: taskID, Valid: true},
+			Title:       "Review plan",
+			Description: &desc,
+			Status:      "DONE",
+			Priority:    "P2",
+			StartAt:     pgtype.Timestamptz{Time: start, Valid: true},
+			DueAt:       pgtype.Timestamptz{Time: due, Valid: true},
+			CompletedAt: pgtype.Timestamptz{Time: due, Valid: true},
+			CreatedAt:   pgtype.Timestamptz{Time: start, Valid: true},
+			UpdatedAt:   pgtype.Timestamptz{Time: due, Valid: true},
+		}},
+		Labels: map[uuid.UUID][]string{taskID: {"work", "q3"}},
+	}
+
+	var event strings.Builder
+	if err := Render(&event, data, KindEvent); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	for _, want := range []string{
+		"X-WR-CALNAME:Launch\\, v2\r\n",
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n",
+		"BEGIN:VEVENT\r\nUID:" + taskID.String() + "@zenlist\r\n",
+		"DTSTART;TZID=Europe/Berlin:20240701T150000\r\n",
+		"DTEND;TZID=Europe/Berlin:20240701T170000\r\n",
+		"PRIORITY:3\r\n",
+		"CATEGORIES:work,q3\r\n",
+		"DESCRIPTION:Bring notes\\; slides\\n\\nStatus: Done\\nPriority: P2\r\n",
+	} {
+		if !strings.Contains(event.String(), want) {
+			t.Fatalf("event feed missing %q:\n%s", want, event.String())
+		}
+	}
+	if !strings.HasSuffix(event.String(), "END:VCALENDAR\r\n") {
+		t.Fatalf("feed not terminated")
+	}
+
+	data.User.Timezone = "UTC"
+	var todo strings.Builder
+	if err := Render(&todo, data, KindTodo); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	for _, want := range []string{
+		"BEGIN:VTODO\r\n",
+		"DTSTART:20240701T130000Z\r\n",
+		"DUE:20240701T150000Z\r\n",
+		"STATUS:COMPLETED\r\n",
+		"COMPLETED:20240701T150000Z\r\n",
+	} {
+		if !strings.Contains(todo.String(), want) {
+			t.Fatalf("todo feed missing %q:\n%s", want, todo.String())
+		}
+	}
+	if strings.Contains(todo.String(), "VTIMEZONE") {
+		t.Fatalf("UTC feed should not include a VTIMEZONE")
+	}
+}
@@ -0,0 +1,292 @@
+package calendar
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/google/uuid"
+)
+
+// Todo is the part of a VTODO that maps onto a task. Labels (CATEGORIES) and
+// the parent (RELATED-TO) are rendered but not written back: labels are
+// referenced by ID and UpdateTask cannot move a task to another parent.
+type Todo struct {
+	UID         string
+	Summary     string
+	Description *string
+	Status      string
+	Priority    int
+	Start       *time.Time
+	Due         *time.Time
+	Completed   *time.Time
+}
+
+// ParseTodo reads the first VTODO in an iCalendar object. Floating times, and
+// TZIDs the runtime does not know, are read in loc.
+func ParseTodo(r io.Reader, loc *time.Location) (Todo, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return Todo{}, err
+	}
+
+	var (
+		todo  Todo
+		found bool
+		depth int
+	)
+	for _, raw := range lines {
+		name, params, value, err := parseContentLine(raw)
+		if err != nil {
+			return Todo{}, err
+		}
+
+		switch {
+		case name == "BEGIN":
+			if found && depth == 0 {
+				continue
+			}
+			if depth > 0 {
+				depth++
+			} else if strings.EqualFold(value, "VTODO") {
+				found = true
+				depth = 1
+			}
+			continue
+		case name == "END":
+			if depth > 0 {
+				depth--
+			}
+			continue
+		case depth != 1:
+			// Outside the VTODO, or inside a nested VALARM.
+			continue
+		}
+
+		switch name {
+		case "UID":
+			todo.UID = value
+		case "SUMMARY":
+			todo.Summary = unescapeText(value)
+		case "DESCRIPTION":
+			desc := unescapeText(value)
+			todo.Description = &desc
+		case "STATUS":
+			todo.Status = strings.ToUpper(value)
+		case "PRIORITY":
+			p, err := strconv.Atoi(value)
+			if err != nil || p < 0 || p > 9 {
+				return Todo{}, fmt.Errorf("invalid PRIORITY %q", value)
+			}
+			todo.Priority = p
+		case "DTSTART", "DUE", "COMPLETED":
+			t, err := parseDateTime(value, params, loc)
+			if err != nil {
+				return Todo{}, fmt.Errorf("invalid %s: %w", name, err)
+			}
+			switch name {
+			case "DTSTART":
+				todo.Start = &t
+			case "DUE":
+				todo.Due = &t
+			default:
+				todo.Completed = &t
+			}
+		}
+	}
+
+	if !found {
+		return Todo{}, errors.New("no VTODO component")
+	}
+	return todo, nil
+}
+
+// UpdateInput maps the VTODO onto an update of existing. Absent dates clear
+// the task's dates; a NEEDS-ACTION status keeps TODO, IN_PROGRESS and BLOCKED
+// as they are, since most reminder apps only distinguish done from not done.
+func (t Todo) UpdateInput(existing sqlc.Task) service.UpdateTaskInput {
+	in := service.UpdateTaskInput{
+		ID:           uuid.UUID(existing.ID.Bytes).String(),
+		ClearStartAt: t.Start == nil,
+		ClearDueAt:   t.Due == nil,
+		StartAt:      t.Start,
+		DueAt:        t.Due,
+	}
+
+	if summary := strings.TrimSpace(t.Summary); summary != "" {
+		in.Title = &summary
+	}
+
+	if t.Description != nil {
+		in.Description = t.Description
+	} else if existing.Description != nil {
+		empty := ""
+		in.Description = &empty
+	}
+
+	status := t.status(existing.Status)
+	in.Status = &status
+
+	priority := priorityFromICal(t.Priority)
+	in.Priority = &priority
+	return in
+}
+
+// CreateInput maps the VTODO onto a new task with the given ID in projectID.
+// The client's UID is not kept; the task gets its own, like every other task.
+func (t Todo) CreateInput(projectID string, taskID string) service.CreateTaskInput {
+	return service.CreateTaskInput{
+		ID:          &taskID,
+		ProjectID:   projectID,
+		Title:       t.Summary,
+		Description: t.Description,
+		Status:      t.status("TODO"),
+		Priority:    priorityFromICal(t.Priority),
+		StartAt:     t.Start,
+		DueAt:       t.Due,
+	}
+}
+
+// status maps the VTODO status onto a task status, starting from current.
+func (t Todo) status(current string) string {
+	switch t.Status {
+	case "COMPLETED":
+		return "DONE"
+	case "IN-PROCESS":
+		return "IN_PROGRESS"
+	case "NEEDS-ACTION":
+		if current == "DONE" {
+			return "TODO"
+		}
+	case "":
+		if t.Completed != nil {
+			return "DONE"
+		}
+		if current == "DONE" {
+			return "TODO"
+		}
+	}
+	return current
+}
+
+// priorityFromICal inverts the priorities map; 0 ("undefined") becomes the
+// default P3.
+func priorityFromICal(p int) string {
+	switch {
+	case p == 0:
+		return "P3"
+	case p <= 2:
+		return "P1"
+	case p <= 4:
+		return "P2"
+	case p == 5:
+		return "P3"
+	case p <= 7:
+		return "P4"
+	default:
+		return "P5"
+	}
+}
+
+// unfold joins folded content lines (RFC 5545 3.1), accepting LF as well as
+// CRLF line endings.
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), 1<<20)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// parseContentLine splits "NAME;PARAM=VALUE:value", honouring quoted
+// parameter values that may contain ':' or ';'.
+func parseContentLine(line string) (string, map[string]string, string, error) {
+	var (
+		parts   []string
+		start   int
+		inQuote bool
+		colon   = -1
+	)
+	for i := 0; i < len(line) && colon < 0; i++ {
+		switch line[i] {
+		case '"':
+			inQuote = !inQuote
+		case ';':
+			if !inQuote {
+				parts = append(parts, line[start:i])
+				start = i + 1
+			}
+		case ':':
+			if !inQuote {
+				parts = append(parts, line[start:i])
+				colon = i
+			}
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", fmt.Errorf("malformed content line %q", line)
+	}
+
+	params := make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		key, value, _ := strings.Cut(p, "=")
+		params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], nil
+}
+
+func parseDateTime(value string, params map[string]string, loc *time.Location) (time.Time, error) {
+	if strings.EqualFold(params["VALUE"], "DATE") || len(value) == len("20060102") {
+		return time.ParseInLocation("20060102", value, loc)
+	}
+	if strings.HasSuffix(value, "Z") {
+		return time.Parse(utcLayout, value)
+	}
+	if tzid, ok := params["TZID"]; ok {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	return time.ParseInLocation(localLayout, value, loc)
+}
+
+func unescapeText(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package calendar
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestParseTodo(t *testing.T) {
+	body := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VTODO",
+		"UID:abc@zenlist",
+		"SUMMARY:Call the\\, bank",
+		"DESCRIPTION:line one\\nline",
+		"  two",
+		"STATUS:COMPLETED",
+		"PRIORITY:1",
+		`DUE;TZID="Europe/Berlin":20240701T170000`,
+		"DTSTART;VALUE=DATE:20240701",
+		"COMPLETED:20240701T160000Z",
+		"BEGIN:VALARM",
+		"DESCRIPTION:ignored",
+		"END:VALARM",
+		"END:VTODO",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	todo, err := ParseTodo(strings.NewReader(body), time.UTC)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if todo.UID != "abc@zenlist" || todo.Summary != "Call the, bank" || todo.Status != "COMPLETED" || todo.Priority != 1 {
+		t.Fatalf("unexpected todo: %+v", todo)
+	}
+	if todo.Description == nil || *todo.Description != "line one\nline two" {
+		t.Fatalf("unexpected description: %v", todo.Description)
+	}
+	if want := time.Date(2024, 7, 1, 15, 0, 0, 0, time.UTC); todo.Due == nil || !todo.Due.Equal(want) {
+		t.Fatalf("due: got %v want %s", todo.Due, want)
+	}
+	if want := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC); todo.Start == nil || !todo.Start.Equal(want) {
+		t.Fatalf("start: got %v want %s", todo.Start, want)
+	}
+
+	if _, err := ParseTodo(strings.NewReader("BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"), time.UTC); err == nil {
+		t.Fatalf("expected an error for a calendar without VTODO")
+	}
+}
+
+func TestTodoUpdateInput(t *testing.T) {
+	desc := "old"
+	existing := sqlc.Task{
+		ID:          pgtype.UUID{Valid: true},
+		Status:      "BLOCKED",
+		Priority:    "P2",
+		Description: &desc,
+	}
+
+	in := Todo{Summary: " Renamed ", Status: "NEEDS-ACTION", Priority: 9}.UpdateInput(existing)
+	if *in.Title != "Renamed" || *in.Status != "BLOCKED" || *in.Priority != "P5" {
+		t.Fatalf("unexpected update: %+v", in)
+	}
+	if !in.ClearStartAt || !in.ClearDueAt || in.Description == nil || *in.Description != "" {
+		t.Fatalf("absent fields should clear the task's values: %+v", in)
+	}
+
+	completed := time.Now()
+	in = Todo{Completed: &completed}.UpdateInput(existing)
+	if *in.Status != "DONE" || *in.Priority != "P3" {
+		t.Fatalf("unexpected update: %+v", in)
+	}
+
+	existing.Status = "DONE"
+	in = Todo{Status: "NEEDS-ACTION"}.UpdateInput(existing)
+	if *in.Status != "TODO" {
+		t.Fatalf("reopening a task should set TODO, got %s", *in.Status)
+	}
+}
+
+func TestTodoCreateInput(t *testing.T) {
+	due := time.Date(2024, 7, 2, 17, 0, 0, 0, time.UTC)
+	in := Todo{UID: "client-uid", Summary: "Buy milk", Status: "IN-PROCESS", Priority: 1, Due: &due}.CreateInput("project", "task")
+	if in.ID == nil || *in.ID != "task" || in.ProjectID != "project" {
+		t.Fatalf("unexpected ids: %+v", in)
+	}
+	if in.Title != "Buy milk" || in.Status != "IN_PROGRESS" || in.Priority != "P1" || in.DueAt != &due {
+		t.Fatalf("unexpected create: %+v", in)
+	}
+
+	completed := time.Now()
+	if in := (Todo{Summary: "Done", Completed: &completed}).CreateInput("p", "t"); in.Status != "DONE" || in.Priority != "P3" {
+		t.Fatalf("unexpected create: %+v", in)
+	}
+	if in := (Todo{Summary: "New", Status: "NEEDS-ACTION"}).CreateInput("p", "t"); in.Status != "TODO" {
+		t.Fatalf("a new open task should be TODO, got %s", in.Status)
+	}
+}
+
+func TestPriorityRoundTrip(t *testing.T) {
+	for p, v := range priorities {
+		if got := priorityFromICal(v); got != p {
+			t.Fatalf("priority %s: got %s", p, got)
+		}
+	}
+}
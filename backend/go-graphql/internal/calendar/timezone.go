@@ -0,0 +1,116 @@
+package calendar
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+)
+
+// tzMargin widens the VTIMEZONE window past the first and last task so
+// clients expanding nearby dates still find the right offset.
+const tzMargin = 365 * 24 * time.Hour
+
+// transition is one change of UTC offset in a zone.
+type transition struct {
+	at         time.Time
+	offsetFrom int
+	offsetTo   int
+	name       string
+	dst        bool
+}
+
+// writeTimezone emits a VTIMEZONE for loc covering [from, to]. Go does not
+// expose a zone's rules, so transitions are found by probing the offset and
+// each one becomes its own STANDARD/DAYLIGHT sub-component without RRULEs.
+func writeTimezone(cw *contentWriter, loc *time.Location, from, to time.Time) {
+	cw.line("BEGIN:VTIMEZONE")
+	cw.line("TZID:" + loc.String())
+	for _, tr := range zoneTransitions(loc, from.Add(-tzMargin), to.Add(tzMargin)) {
+		component := "STANDARD"
+		if tr.dst {
+			component = "DAYLIGHT"
+		}
+		cw.line("BEGIN:" + component)
+		// DTSTART is the onset in the wall-clock time in effect before it.
+		onset := tr.at.In(time.FixedZone("", tr.offsetFrom))
+		cw.line("DTSTART:" + onset.Format(localLayout))
+		cw.line("TZOFFSETFROM:" + formatOffset(tr.offsetFrom))
+		cw.line("TZOFFSETTO:" + formatOffset(tr.offsetTo))
+		if tr.name != "" {
+			cw.line("TZNAME:" + escapeText(tr.name))
+		}
+		cw.line("END:" + component)
+	}
+	cw.line("END:VTIMEZONE")
+}
+
+// zoneTransitions lists the offset in effect at from, followed by every
+// change of offset up to to. The first entry always exists so the VTIMEZONE
+// has at least one observance, as RFC 5545 requires.
+func zoneTransitions(loc *time.Location, from, to time.Time) []transition {
+	start := from.In(loc)
+	name, offset := start.Zone()
+	out := []transition{{at: start, offsetFrom: offset, offsetTo: offset, name: name, dst: start.IsDST()}}
+
+	const step = 24 * time.Hour
+	prev := start
+	for t := start.Add(step); !prev.After(to); t = t.Add(step) {
+		_, prevOffset := prev.Zone()
+		_, curOffset := t.In(loc).Zone()
+		if curOffset != prevOffset {
+			at := findTransition(loc, prev, t)
+			name, offset := at.Zone()
+			out = append(out, transition{at: at, offsetFrom: prevOffset, offsetTo: offset, name: name, dst: at.IsDST()})
+		}
+		prev = t.In(loc)
+	}
+	return out
+}
+
+// findTransition binary-searches (lo, hi] for the first second at which the
+// offset differs from the one at lo.
+func findTransition(loc *time.Location, lo, hi time.Time) time.Time {
+	_, loOffset := lo.In(loc).Zone()
+	for hi.Sub(lo) > time.Second {
+		mid := lo.Add(hi.Sub(lo) / 2).Truncate(time.Second)
+		if _, offset := mid.In(loc).Zone(); offset == loOffset {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return hi.In(loc)
+}
+
+func formatOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	h, m, s := seconds/3600, seconds/60%60, seconds%60
+	if s != 0 {
+		return fmt.Sprintf("%c%02d%02d%02d", sign, h, m, s)
+	}
+	return fmt.Sprintf("%c%02d%02d", sign, h, m)
+}
+
+// taskTimeRange returns the earliest and latest start/due times in tasks. ok
+// is false when no task has either.
+func taskTimeRange(tasks []sqlc.Task) (from time.Time, to time.Time, ok bool) {
+	for _, t := range tasks {
+		for _, v := range []*time.Time{timePtr(t.StartAt), timePtr(t.DueAt)} {
+			if v == nil {
+				continue
+			}
+			if from.IsZero() || v.Before(from) {
+				from = *v
+			}
+			if to.IsZero() || v.After(to) {
+				to = *v
+			}
+		}
+	}
+	return from, to, !from.IsZero()
+}
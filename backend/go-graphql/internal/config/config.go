@@ -1,7 +1,11 @@
 package config
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"net/url"
 	"os"
 	"strconv"
 	"strings"
@@ -10,39 +14,80 @@ import (
 
 // Config contains runtime configuration for the API process.
 type Config struct {
-	AppEnv             string
-	HTTPPort           string
-	DatabaseURL        string
-	DefaultUserName    string
-	DefaultUserEmail   string
-	DefaultUserTZ      string
-	DefaultUserAvatar  string
-	RequestTimeout     time.Duration
-	QueryTimeout       time.Duration
-	DBMaxConns         int32
-	DBMinConns         int32
-	DBHealthCheckEvery time.Duration
+	AppEnv               string
+	HTTPPort             string
+	PublicBaseURL        string
+	GRPCPort             string
+	DatabaseURL          string
+	DefaultUserName      string
+	DefaultUserEmail     string
+	DefaultUserTZ        string
+	DefaultUserAvatar    string
+	RequestTimeout       time.Duration
+	QueryTimeout         time.Duration
+	DBMaxConns           int32
+	DBMinConns           int32
+	DBHealthCheckEvery   time.Duration
+	GraphQLMaxComplexity int
+	GraphQLMaxDepth      int
+	CursorSecret         string
+	// CursorSecretGenerated is set when CURSOR_SECRET is unset and a random
+	// per-process secret is used instead.
+	CursorSecretGenerated bool
+	WebhookPollInterval   time.Duration
+	WebhookTimeout        time.Duration
+	WebhookMaxAttempts    int
+	// WebhookAllowPrivate lets webhooks target loopback and private
+	// addresses, for local development.
+	WebhookAllowPrivate bool
+	CalDAVPassword      string
+	ExportLinkTTL       time.Duration
+	ExportTimeout       time.Duration
 }
 
 func Load() (Config, error) {
 	cfg := Config{
-		AppEnv:             getEnv("APP_ENV", "development"),
-		HTTPPort:           getEnv("HTTP_PORT", "8080"),
-		DatabaseURL:        getEnv("DATABASE_URL", ""),
-		DefaultUserName:    getEnv("DEFAULT_USER_NAME", "ZenList User"),
-		DefaultUserEmail:   getEnv("DEFAULT_USER_EMAIL", "user@zenlist.local"),
-		DefaultUserTZ:      getEnv("DEFAULT_USER_TIMEZONE", "UTC"),
-		DefaultUserAvatar:  getEnv("DEFAULT_USER_AVATAR_URL", ""),
-		RequestTimeout:     getDuration("REQUEST_TIMEOUT", 20*time.Second),
-		QueryTimeout:       getDuration("QUERY_TIMEOUT", 3*time.Second),
-		DBMaxConns:         int32(getInt("DB_MAX_CONNS", 20)),
-		DBMinConns:         int32(getInt("DB_MIN_CONNS", 2)),
-		DBHealthCheckEvery: getDuration("DB_HEALTHCHECK_PERIOD", 30*time.Second),
+		AppEnv:               getEnv("APP_ENV", "development"),
+		HTTPPort:             getEnv("HTTP_PORT", "8080"),
+		GRPCPort:             getEnv("GRPC_PORT", ""),
+		PublicBaseURL:        strings.TrimRight(getEnv("PUBLIC_BASE_URL", "http://localhost:8080"), "/"),
+		DatabaseURL:          getEnv("DATABASE_URL", ""),
+		DefaultUserName:      getEnv("DEFAULT_USER_NAME", "ZenList User"),
+		DefaultUserEmail:     getEnv("DEFAULT_USER_EMAIL", "user@zenlist.local"),
+		DefaultUserTZ:        getEnv("DEFAULT_USER_TIMEZONE", "UTC"),
+		DefaultUserAvatar:    getEnv("DEFAULT_USER_AVATAR_URL", ""),
+		RequestTimeout:       getDuration("REQUEST_TIMEOUT", 20*time.Second),
+		QueryTimeout:         getDuration("QUERY_TIMEOUT", 3*time.Second),
+		DBMaxConns:           int32(getInt("DB_MAX_CONNS", 20)),
+		DBMinConns:           int32(getInt("DB_MIN_CONNS", 2)),
+		DBHealthCheckEvery:   getDuration("DB_HEALTHCHECK_PERIOD", 30*time.Second),
+		GraphQLMaxComplexity: getInt("GRAPHQL_MAX_COMPLEXITY", 50000),
+		GraphQLMaxDepth:      getInt("GRAPHQL_MAX_DEPTH", 10),
+		CursorSecret:         getEnv("CURSOR_SECRET", ""),
+		WebhookPollInterval:  getDuration("WEBHOOK_POLL_INTERVAL", 2*time.Second),
+		WebhookTimeout:       getDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		WebhookMaxAttempts:   getInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		WebhookAllowPrivate:  getBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false),
+		CalDAVPassword:       getEnv("CALDAV_PASSWORD", ""),
+		ExportLinkTTL:        getDuration("EXPORT_LINK_TTL", 15*time.Minute),
+		ExportTimeout:        getDuration("EXPORT_TIMEOUT", 10*time.Minute),
 	}
 
 	if strings.TrimSpace(cfg.DatabaseURL) == "" {
 		return Config{}, errors.New("DATABASE_URL is required")
 	}
+	if cfg.GRPCPort != "" {
+		port, err := strconv.Atoi(cfg.GRPCPort)
+		if err != nil || port < 1 || port > 65535 {
+			return Config{}, errors.New("GRPC_PORT must be a port number, or empty to disable gRPC")
+		}
+		if httpPort, err := strconv.Atoi(cfg.HTTPPort); err == nil && httpPort == port {
+			return Config{}, errors.New("GRPC_PORT must differ from HTTP_PORT")
+		}
+	}
+	if u, err := url.Parse(cfg.PublicBaseURL); err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return Config{}, errors.New("PUBLIC_BASE_URL must be an absolute http or https URL")
+	}
 	if strings.TrimSpace(cfg.DefaultUserEmail) == "" {
 		return Config{}, errors.New("DEFAULT_USER_EMAIL is required")
 	}
@@ -64,6 +109,44 @@ func Load() (Config, error) {
 	if cfg.DBMinConns < 0 || cfg.DBMinConns > cfg.DBMaxConns {
 		return Config{}, errors.New("DB_MIN_CONNS must be between 0 and DB_MAX_CONNS")
 	}
+	if cfg.GraphQLMaxComplexity < 1 {
+		return Config{}, errors.New("GRAPHQL_MAX_COMPLEXITY must be >= 1")
+	}
+	if cfg.GraphQLMaxDepth < 1 {
+		return Config{}, errors.New("GRAPHQL_MAX_DEPTH must be >= 1")
+	}
+	if cfg.CursorSecret == "" {
+		secret := make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return Config{}, fmt.Errorf("generate cursor secret: %w", err)
+		}
+		cfg.CursorSecret = hex.EncodeToString(secret)
+		cfg.CursorSecretGenerated = true
+	}
+	if len(cfg.CursorSecret) < 32 {
+		return Config{}, errors.New("CURSOR_SECRET must be empty or at least 32 characters")
+	}
+
+	if cfg.WebhookPollInterval <= 0 {
+		return Config{}, errors.New("WEBHOOK_POLL_INTERVAL must be positive")
+	}
+	if cfg.WebhookTimeout <= 0 {
+		return Config{}, errors.New("WEBHOOK_TIMEOUT must be positive")
+	}
+	if cfg.WebhookMaxAttempts < 1 {
+		return Config{}, errors.New("WEBHOOK_MAX_ATTEMPTS must be >= 1")
+	}
+
+	if cfg.CalDAVPassword != "" && len(cfg.CalDAVPassword) < 12 {
+		return Config{}, errors.New("CALDAV_PASSWORD must be empty or at least 12 characters")
+	}
+
+	if cfg.ExportLinkTTL <= 0 {
+		return Config{}, errors.New("EXPORT_LINK_TTL must be positive")
+	}
+	if cfg.ExportTimeout <= 0 {
+		return Config{}, errors.New("EXPORT_TIMEOUT must be positive")
+	}
 
 	return cfg, nil
 }
@@ -88,6 +171,18 @@ func getDuration(key string, def time.Duration) time.Duration {
 	return d
 }
 
+func getBool(key string, def bool) bool {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return def
+	}
+	return b
+}
+
 func getInt(key string, def int) int {
 	v := strings.TrimSpace(os.Getenv(key))
 	if v == "" {
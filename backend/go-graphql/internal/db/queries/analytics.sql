@@ -0,0 +1,136 @@
+-- name: CompletedTaskSeries :many
+-- Tasks completed per bucket. Analytics queries cover the live tasks of the
+-- user's live projects, optionally limited to project_ids. Buckets are local
+-- days or weeks (bucket is 'day' or 'week'; weeks start on Monday) in the tz
+-- time zone, and every bucket overlapping [range_from, range_to) is listed.
+WITH buckets AS (
+  SELECT generate_series(
+    date_trunc(sqlc.arg(bucket)::text, sqlc.arg(range_from)::timestamptz AT TIME ZONE sqlc.arg(tz)::text),
+    (sqlc.arg(range_to)::timestamptz AT TIME ZONE sqlc.arg(tz)::text) - INTERVAL '1 microsecond',
+    ('1 ' || sqlc.arg(bucket)::text)::interval
+  ) AS bucket_start
+),
+completed AS (
+  SELECT date_trunc(sqlc.arg(bucket)::text, t.completed_at AT TIME ZONE sqlc.arg(tz)::text) AS bucket_start
+  FROM tasks t
+  JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+  WHERE t.user_id = sqlc.arg(user_id)
+    AND t.deleted_at IS NULL
+    AND t.status = 'DONE'
+    AND t.completed_at >= sqlc.arg(range_from)::timestamptz
+    AND t.completed_at < sqlc.arg(range_to)::timestamptz
+    AND (cardinality(sqlc.arg(project_ids)::uuid[]) = 0 OR t.project_id = ANY(sqlc.arg(project_ids)::uuid[]))
+)
+SELECT b.bucket_start::date AS bucket, COUNT(c.bucket_start) AS task_count
+FROM buckets b
+LEFT JOIN completed c ON c.bucket_start = b.bucket_start
+GROUP BY b.bucket_start
+ORDER BY b.bucket_start;
+
+-- name: ProjectBurndown :many
+-- Open tasks of each project at the end of each bucket (or at range_to for
+-- the last one). A task is open from its creation until its completion.
+-- Projects archived before the range are left out.
+WITH buckets AS (
+  SELECT
+    bucket_start,
+    LEAST((bucket_start + ('1 ' || sqlc.arg(bucket)::text)::interval) AT TIME ZONE sqlc.arg(tz)::text, sqlc.arg(range_to)::timestamptz) AS bucket_end
+  FROM generate_series(
+    date_trunc(sqlc.arg(bucket)::text, sqlc.arg(range_from)::timestamptz AT TIME ZONE sqlc.arg(tz)::text),
+    (sqlc.arg(range_to)::timestamptz AT TIME ZONE sqlc.arg(tz)::text) - INTERVAL '1 microsecond',
+    ('1 ' || sqlc.arg(bucket)::text)::interval
+  ) AS bucket_start
+)
+SELECT
+  sqlc.embed(p),
+  b.bucket_start::date AS bucket,
+  COUNT(t.id) AS task_count
+FROM projects p
+CROSS JOIN buckets b
+LEFT JOIN tasks t
+  ON t.project_id = p.id
+  AND t.deleted_at IS NULL
+  AND t.created_at < b.bucket_end
+  AND (t.completed_at IS NULL OR t.completed_at >= b.bucket_end)
+WHERE p.user_id = sqlc.arg(user_id)
+  AND p.deleted_at IS NULL
+  AND (p.archived_at IS NULL OR p.archived_at >= sqlc.arg(range_from)::timestamptz)
+  AND (cardinality(sqlc.arg(project_ids)::uuid[]) = 0 OR p.id = ANY(sqlc.arg(project_ids)::uuid[]))
+GROUP BY p.id, b.bucket_start
+ORDER BY p.created_at, p.id, b.bucket_start;
+
+-- name: LeadTimeStats :one
+-- Lead time (created_at to completed_at) of tasks completed in the range;
+-- both figures are 0 when there are none.
+SELECT
+  COUNT(*) AS completed_count,
+  COALESCE(AVG(EXTRACT(EPOCH FROM t.completed_at - t.created_at)), 0)::float8 AS average_seconds,
+  COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM t.completed_at - t.created_at)), 0)::float8 AS median_seconds
+FROM tasks t
+JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+WHERE t.user_id = sqlc.arg(user_id)
+  AND t.deleted_at IS NULL
+  AND t.status = 'DONE'
+  AND t.completed_at >= sqlc.arg(range_from)::timestamptz
+  AND t.completed_at < sqlc.arg(range_to)::timestamptz
+  AND (cardinality(sqlc.arg(project_ids)::uuid[]) = 0 OR t.project_id = ANY(sqlc.arg(project_ids)::uuid[]));
+
+-- name: TimeInStatus :many
+-- Time spent in each status category within the range. A task stays in a
+-- status until its next change, or until now.
+WITH spans AS (
+  SELECT
+    c.task_id,
+    c.status,
+    c.changed_at AS started_at,
+    COALESCE(LEAD(c.changed_at) OVER (PARTITION BY c.task_id ORDER BY c.changed_at, c.id), NOW()) AS ended_at
+  FROM task_status_changes c
+  JOIN tasks t ON t.id = c.task_id AND t.deleted_at IS NULL
+  JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+  WHERE c.user_id = sqlc.arg(user_id)
+    AND (cardinality(sqlc.arg(project_ids)::uuid[]) = 0 OR t.project_id = ANY(sqlc.arg(project_ids)::uuid[]))
+)
+SELECT
+  status,
+  COUNT(DISTINCT task_id) AS task_count,
+  SUM(EXTRACT(EPOCH FROM LEAST(ended_at, sqlc.arg(range_to)::timestamptz) - GREATEST(started_at, sqlc.arg(range_from)::timestamptz)))::float8 AS total_seconds
+FROM spans
+WHERE started_at < sqlc.arg(range_to)::timestamptz
+  AND ended_at > sqlc.arg(range_from)::timestamptz
+GROUP BY status
+ORDER BY status;
+
+-- name: OverdueByPriority :many
+-- Tasks due in the range, up to now, and how many of them were completed
+-- late or are still open.
+SELECT
+  t.priority,
+  COUNT(*) AS due_count,
+  COUNT(*) FILTER (WHERE t.completed_at IS NULL OR t.completed_at > t.due_at) AS overdue_count
+FROM tasks t
+JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+WHERE t.user_id = sqlc.arg(user_id)
+  AND t.deleted_at IS NULL
+  AND t.due_at >= sqlc.arg(range_from)::timestamptz
+  AND t.due_at < LEAST(sqlc.arg(range_to)::timestamptz, NOW())
+  AND (cardinality(sqlc.arg(project_ids)::uuid[]) = 0 OR t.project_id = ANY(sqlc.arg(project_ids)::uuid[]))
+GROUP BY t.priority
+ORDER BY t.priority;
+
+-- name: OverdueByLabel :many
+-- OverdueByPriority per label; a task counts toward each of its labels.
+SELECT
+  sqlc.embed(l),
+  COUNT(*) AS due_count,
+  COUNT(*) FILTER (WHERE t.completed_at IS NULL OR t.completed_at > t.due_at) AS overdue_count
+FROM tasks t
+JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+JOIN task_labels tl ON tl.task_id = t.id
+JOIN labels l ON l.id = tl.label_id AND l.deleted_at IS NULL
+WHERE t.user_id = sqlc.arg(user_id)
+  AND t.deleted_at IS NULL
+  AND t.due_at >= sqlc.arg(range_from)::timestamptz
+  AND t.due_at < LEAST(sqlc.arg(range_to)::timestamptz, NOW())
+  AND (cardinality(sqlc.arg(project_ids)::uuid[]) = 0 OR t.project_id = ANY(sqlc.arg(project_ids)::uuid[]))
+GROUP BY l.id
+ORDER BY l.name, l.id;
@@ -0,0 +1,85 @@
+-- name: CreateCalendarFeed :one
+INSERT INTO calendar_feeds (user_id, project_id, token_hash)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, project_id, token_hash, created_at, revoked_at;
+
+-- name: ListCalendarFeeds :many
+SELECT id, user_id, project_id, token_hash, created_at, revoked_at
+FROM calendar_feeds
+WHERE user_id = $1
+  AND revoked_at IS NULL
+ORDER BY created_at DESC, id DESC;
+
+-- name: GetCalendarFeedByID :one
+SELECT id, user_id, project_id, token_hash, created_at, revoked_at
+FROM calendar_feeds
+WHERE id = $1
+  AND user_id = $2
+  AND revoked_at IS NULL
+LIMIT 1;
+
+-- name: GetCalendarFeedByTokenHash :one
+SELECT id, user_id, project_id, token_hash, created_at, revoked_at
+FROM calendar_feeds
+WHERE token_hash = $1
+  AND revoked_at IS NULL
+LIMIT 1;
+
+-- name: RevokeCalendarFeed :one
+UPDATE calendar_feeds
+SET revoked_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND revoked_at IS NULL
+RETURNING id, revoked_at;
+
+-- name: ListScheduledTasks :many
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM tasks
+WHERE user_id = $1
+  AND deleted_at IS NULL
+  AND (start_at IS NOT NULL OR due_at IS NOT NULL)
+  AND (NOT $2::boolean OR project_id = $3::uuid)
+ORDER BY COALESCE(due_at, start_at) DESC, id DESC
+LIMIT $4;
+
+-- name: ListLabelNamesByTaskIDs :many
+SELECT tl.task_id, l.name
+FROM task_labels tl
+JOIN labels l ON l.id = tl.label_id
+WHERE tl.task_id = ANY($1::uuid[])
+  AND l.user_id = $2
+  AND l.deleted_at IS NULL
+ORDER BY l.name;
+
+-- name: ListProjectSyncStates :many
+-- last_modified moves whenever a rendered task could change: soft deletes
+-- bump tasks.updated_at, label renames show up in CATEGORIES and the user's
+-- timezone is used for every date.
+SELECT
+  p.id,
+  p.title,
+  p.description,
+  p.color,
+  COUNT(t.id) FILTER (WHERE t.deleted_at IS NULL)::bigint AS task_count,
+  GREATEST(
+    p.updated_at,
+    MAX(t.updated_at),
+    (SELECT MAX(l.updated_at) FROM labels l WHERE l.user_id = p.user_id),
+    (SELECT u.updated_at FROM users u WHERE u.id = p.user_id)
+  )::timestamptz AS last_modified
+FROM projects p
+LEFT JOIN tasks t ON t.project_id = p.id
+WHERE p.user_id = $1
+  AND p.deleted_at IS NULL
+GROUP BY p.id
+ORDER BY p.created_at, p.id;
+
+-- name: ListProjectTasks :many
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM tasks
+WHERE user_id = $1
+  AND project_id = $2
+  AND deleted_at IS NULL
+ORDER BY created_at, id
+LIMIT $3;
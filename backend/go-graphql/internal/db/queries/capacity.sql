@@ -0,0 +1,68 @@
+-- name: GetUserCapacity :one
+SELECT user_id, weekly_minutes, work_days, updated_at, workday_start, workday_end
+FROM user_capacity
+WHERE user_id = $1;
+
+-- name: UpsertUserCapacity :one
+INSERT INTO user_capacity (user_id, weekly_minutes, work_days, workday_start, workday_end)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id) DO UPDATE
+SET
+  weekly_minutes = EXCLUDED.weekly_minutes,
+  work_days = EXCLUDED.work_days,
+  workday_start = EXCLUDED.workday_start,
+  workday_end = EXCLUDED.workday_end,
+  updated_at = NOW()
+RETURNING user_id, weekly_minutes, work_days, updated_at, workday_start, workday_end;
+
+-- name: ListEstimatedTasksInRange :many
+-- Open, estimated tasks of live, unarchived projects whose schedule
+-- (start_at to due_at, either alone) overlaps [range_from, range_to).
+SELECT t.id, t.user_id, t.project_id, t.parent_task_id, t.title, t.description, t.status, t.priority, t.start_at, t.due_at, t.completed_at, t.created_at, t.updated_at, t.deleted_at, t.section_id, t.status_id, t.blocked_reason, t.estimate_minutes
+FROM tasks t
+JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL AND p.archived_at IS NULL
+WHERE t.user_id = sqlc.arg(user_id)
+  AND t.deleted_at IS NULL
+  AND t.estimate_minutes IS NOT NULL
+  AND t.status <> 'DONE'
+  AND COALESCE(t.start_at, t.due_at) < sqlc.arg(range_to)::timestamptz
+  AND COALESCE(t.due_at, t.start_at) >= sqlc.arg(range_from)::timestamptz
+ORDER BY COALESCE(t.start_at, t.due_at), t.id;
+
+-- name: ListUnscheduledTasks :many
+-- Open tasks without a start of live, unarchived projects, optionally limited
+-- to project_ids.
+SELECT t.id, t.user_id, t.project_id, t.parent_task_id, t.title, t.description, t.status, t.priority, t.start_at, t.due_at, t.completed_at, t.created_at, t.updated_at, t.deleted_at, t.section_id, t.status_id, t.blocked_reason, t.estimate_minutes
+FROM tasks t
+JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL AND p.archived_at IS NULL
+WHERE t.user_id = sqlc.arg(user_id)
+  AND t.deleted_at IS NULL
+  AND t.start_at IS NULL
+  AND t.status <> 'DONE'
+  AND (cardinality(sqlc.arg(project_ids)::uuid[]) = 0 OR t.project_id = ANY(sqlc.arg(project_ids)::uuid[]))
+ORDER BY t.due_at NULLS LAST, t.priority, t.created_at, t.id;
+
+-- name: ListScheduledTasksInRange :many
+-- Open tasks of live, unarchived projects that start before range_to and,
+-- taking default_minutes for tasks without an estimate, end after range_from.
+SELECT t.id, t.user_id, t.project_id, t.parent_task_id, t.title, t.description, t.status, t.priority, t.start_at, t.due_at, t.completed_at, t.created_at, t.updated_at, t.deleted_at, t.section_id, t.status_id, t.blocked_reason, t.estimate_minutes
+FROM tasks t
+JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL AND p.archived_at IS NULL
+WHERE t.user_id = sqlc.arg(user_id)
+  AND t.deleted_at IS NULL
+  AND t.status <> 'DONE'
+  AND t.start_at < sqlc.arg(range_to)::timestamptz
+  AND t.start_at + make_interval(mins => COALESCE(t.estimate_minutes, sqlc.arg(default_minutes)::int)) > sqlc.arg(range_from)::timestamptz
+ORDER BY t.start_at, t.id;
+
+-- name: ScheduleTask :one
+-- Sets the start of a task that is still unscheduled.
+UPDATE tasks
+SET
+  start_at = $3,
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+  AND start_at IS NULL
+RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes;
@@ -0,0 +1,122 @@
+-- name: CreateCustomField :one
+-- New fields go last.
+INSERT INTO custom_fields (user_id, project_id, name, field_type, options, required, position)
+SELECT $1, $2, $3, $4, $5, $6, COALESCE(MAX(position) + 1, 0)
+FROM custom_fields
+WHERE project_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, name, field_type, options, required, position, created_at, updated_at, deleted_at;
+
+-- name: GetCustomFieldByID :one
+SELECT id, user_id, project_id, name, field_type, options, required, position, created_at, updated_at, deleted_at
+FROM custom_fields
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: ListCustomFields :many
+SELECT id, user_id, project_id, name, field_type, options, required, position, created_at, updated_at, deleted_at
+FROM custom_fields
+WHERE project_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY position, id;
+
+-- name: CountCustomFields :one
+SELECT COUNT(*)
+FROM custom_fields
+WHERE project_id = $1
+  AND deleted_at IS NULL;
+
+-- name: UpdateCustomField :one
+UPDATE custom_fields
+SET
+  name = $3,
+  options = $4,
+  required = $5,
+  position = $6,
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, name, field_type, options, required, position, created_at, updated_at, deleted_at;
+
+-- name: ShiftCustomFields :exec
+-- Moves the fields with position in [from_position, to_position] by delta
+-- (+1 or -1) to open or close a gap.
+UPDATE custom_fields
+SET
+  position = position + sqlc.arg(delta)::int,
+  updated_at = NOW()
+WHERE project_id = sqlc.arg(project_id)
+  AND deleted_at IS NULL
+  AND position BETWEEN sqlc.arg(from_position)::int AND sqlc.arg(to_position)::int;
+
+-- name: SoftDeleteCustomField :one
+UPDATE custom_fields
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, project_id, position, deleted_at;
+
+-- name: SoftDeleteCustomFieldsByProject :execrows
+UPDATE custom_fields
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE project_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: LockCustomFields :exec
+-- Serialises position changes within one project for the rest of the
+-- transaction.
+SELECT pg_advisory_xact_lock(hashtextextended('custom_fields:' || sqlc.arg(project_id)::uuid::text, 0));
+
+-- name: CountLiveTasksWithValueOutside :one
+-- Counts live tasks whose value for a SELECT field is not one of options.
+SELECT COUNT(*)
+FROM task_custom_field_values v
+JOIN tasks t ON t.id = v.task_id
+WHERE v.field_id = sqlc.arg(field_id)
+  AND t.deleted_at IS NULL
+  AND v.value <> ALL(sqlc.arg(options)::text[]);
+
+-- name: DeleteCustomFieldValuesOutside :exec
+DELETE FROM task_custom_field_values
+WHERE field_id = sqlc.arg(field_id)
+  AND value <> ALL(sqlc.arg(options)::text[]);
+
+-- name: DeleteCustomFieldValues :exec
+DELETE FROM task_custom_field_values
+WHERE field_id = $1;
+
+-- name: ListTaskCustomFieldValues :many
+-- Values of live fields for several tasks, in field order.
+SELECT v.task_id, v.value, sqlc.embed(f)
+FROM task_custom_field_values v
+JOIN custom_fields f ON f.id = v.field_id
+WHERE v.task_id = ANY(sqlc.arg(task_ids)::uuid[])
+  AND f.user_id = sqlc.arg(user_id)
+  AND f.deleted_at IS NULL
+ORDER BY v.task_id, f.position, f.id;
+
+-- name: UpsertTaskCustomFieldValue :exec
+INSERT INTO task_custom_field_values (task_id, field_id, value, number_value, date_value, bool_value)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (task_id, field_id) DO UPDATE
+SET
+  value = EXCLUDED.value,
+  number_value = EXCLUDED.number_value,
+  date_value = EXCLUDED.date_value,
+  bool_value = EXCLUDED.bool_value,
+  updated_at = NOW();
+
+-- name: DeleteTaskCustomFieldValue :exec
+DELETE FROM task_custom_field_values
+WHERE task_id = $1
+  AND field_id = $2;
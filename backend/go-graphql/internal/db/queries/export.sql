@@ -0,0 +1,63 @@
+-- name: ExportProjects :many
+-- The Export queries page by id so a full export streams in bounded batches
+-- without holding a transaction open; the nil UUID starts from the beginning.
+SELECT id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents
+FROM projects
+WHERE user_id = $1
+  AND deleted_at IS NULL
+  AND id > $2
+ORDER BY id
+LIMIT $3;
+
+-- name: ExportLabels :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at
+FROM labels
+WHERE user_id = $1
+  AND deleted_at IS NULL
+  AND id > $2
+ORDER BY id
+LIMIT $3;
+
+-- name: ExportTasks :many
+SELECT t.id, t.user_id, t.project_id, t.parent_task_id, t.title, t.description, t.status, t.priority, t.start_at, t.due_at, t.completed_at, t.created_at, t.updated_at, t.deleted_at, t.section_id, t.status_id, t.blocked_reason, t.estimate_minutes
+FROM tasks t
+JOIN projects p ON p.id = t.project_id
+WHERE t.user_id = $1
+  AND t.deleted_at IS NULL
+  AND p.deleted_at IS NULL
+  AND t.id > $2
+ORDER BY t.id
+LIMIT $3;
+
+-- name: ExportTaskLabels :many
+SELECT tl.task_id, tl.label_id
+FROM task_labels tl
+JOIN tasks t ON t.id = tl.task_id
+JOIN projects p ON p.id = t.project_id
+JOIN labels l ON l.id = tl.label_id
+WHERE t.user_id = sqlc.arg(user_id)
+  AND t.deleted_at IS NULL
+  AND p.deleted_at IS NULL
+  AND l.deleted_at IS NULL
+  AND (tl.task_id, tl.label_id) > (sqlc.arg(after_task_id)::uuid, sqlc.arg(after_label_id)::uuid)
+ORDER BY tl.task_id, tl.label_id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ExportRootTasks :many
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM tasks
+WHERE user_id = sqlc.arg(user_id)
+  AND project_id = sqlc.arg(project_id)
+  AND parent_task_id IS NULL
+  AND deleted_at IS NULL
+  AND (created_at, id) > (sqlc.arg(after_created_at)::timestamptz, sqlc.arg(after_id)::uuid)
+ORDER BY created_at, id
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListSubtasksByParentIDs :many
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM tasks
+WHERE user_id = $1
+  AND parent_task_id = ANY($2::uuid[])
+  AND deleted_at IS NULL
+ORDER BY parent_task_id, created_at, id;
@@ -31,6 +31,24 @@ WHERE user_id = $1
 ORDER BY created_at DESC, id DESC
 LIMIT $5;
 
+-- name: ListLabelsBefore :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at
+FROM labels
+WHERE user_id = $1
+  AND deleted_at IS NULL
+  AND (
+    NOT $2::boolean
+    OR (created_at, id) > ($3::timestamptz, $4::uuid)
+  )
+ORDER BY created_at ASC, id ASC
+LIMIT $5;
+
+-- name: CountLabels :one
+SELECT COUNT(*)
+FROM labels
+WHERE user_id = $1
+  AND deleted_at IS NULL;
+
 -- name: UpdateLabel :one
 UPDATE labels
 SET
@@ -63,3 +81,12 @@ WHERE tl.task_id = $1
   AND l.user_id = $2
   AND l.deleted_at IS NULL
 ORDER BY l.created_at DESC, l.id DESC;
+
+-- name: GetLabelsByNames :many
+-- names must be lower-cased; matching is case-insensitive like the unique
+-- index.
+SELECT id, user_id, name, created_at, updated_at, deleted_at
+FROM labels
+WHERE user_id = sqlc.arg(user_id)
+  AND LOWER(name) = ANY(sqlc.arg(names)::text[])
+  AND deleted_at IS NULL;
@@ -1,10 +1,10 @@
 -- name: CreateProject :one
-INSERT INTO projects (user_id, title, description, color)
-VALUES ($1, $2, $3, $4)
-RETURNING id, user_id, title, description, color, created_at, updated_at, deleted_at;
+INSERT INTO projects (user_id, title, description, color, auto_complete_parents)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents;
 
 -- name: GetProjectByID :one
-SELECT id, user_id, title, description, color, created_at, updated_at, deleted_at
+SELECT id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents
 FROM projects
 WHERE id = $1
   AND user_id = $2
@@ -12,10 +12,11 @@ WHERE id = $1
 LIMIT 1;
 
 -- name: ListProjects :many
-SELECT id, user_id, title, description, color, created_at, updated_at, deleted_at
+SELECT id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents
 FROM projects
 WHERE user_id = $1
   AND deleted_at IS NULL
+  AND ($6::boolean OR archived_at IS NULL)
   AND (
     NOT $2::boolean
     OR (created_at, id) < ($3::timestamptz, $4::uuid)
@@ -23,17 +24,60 @@ WHERE user_id = $1
 ORDER BY created_at DESC, id DESC
 LIMIT $5;
 
+-- name: ListProjectsBefore :many
+SELECT id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents
+FROM projects
+WHERE user_id = $1
+  AND deleted_at IS NULL
+  AND ($6::boolean OR archived_at IS NULL)
+  AND (
+    NOT $2::boolean
+    OR (created_at, id) > ($3::timestamptz, $4::uuid)
+  )
+ORDER BY created_at ASC, id ASC
+LIMIT $5;
+
+-- name: CountProjects :one
+SELECT COUNT(*)
+FROM projects
+WHERE user_id = $1
+  AND deleted_at IS NULL
+  AND ($2::boolean OR archived_at IS NULL);
+
 -- name: UpdateProject :one
+-- A NULL auto_complete_parents keeps the current setting.
+UPDATE projects
+SET
+  title = sqlc.arg(title),
+  description = sqlc.narg(description),
+  color = sqlc.narg(color),
+  auto_complete_parents = COALESCE(sqlc.narg(auto_complete_parents)::boolean, auto_complete_parents),
+  updated_at = NOW()
+WHERE id = sqlc.arg(id)
+  AND user_id = sqlc.arg(user_id)
+  AND deleted_at IS NULL
+RETURNING id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents;
+
+-- name: ArchiveProject :one
+-- Archiving an archived project keeps the original archived_at.
+UPDATE projects
+SET
+  archived_at = COALESCE(archived_at, NOW()),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents;
+
+-- name: UnarchiveProject :one
 UPDATE projects
 SET
-  title = $3,
-  description = $4,
-  color = $5,
+  archived_at = NULL,
   updated_at = NOW()
 WHERE id = $1
   AND user_id = $2
   AND deleted_at IS NULL
-RETURNING id, user_id, title, description, color, created_at, updated_at, deleted_at;
+RETURNING id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents;
 
 -- name: SoftDeleteProject :one
 UPDATE projects
@@ -44,3 +88,10 @@ WHERE id = $1
   AND user_id = $2
   AND deleted_at IS NULL
 RETURNING id, deleted_at;
+
+-- name: ListProjectTitles :many
+SELECT id, title
+FROM projects
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY created_at, id;
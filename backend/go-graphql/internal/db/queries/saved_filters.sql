@@ -0,0 +1,40 @@
+-- name: CreateSavedFilter :one
+INSERT INTO saved_filters (user_id, name, expression)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, name, expression, created_at, updated_at, deleted_at;
+
+-- name: GetSavedFilterByID :one
+SELECT id, user_id, name, expression, created_at, updated_at, deleted_at
+FROM saved_filters
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: ListSavedFilters :many
+SELECT id, user_id, name, expression, created_at, updated_at, deleted_at
+FROM saved_filters
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY LOWER(name), id;
+
+-- name: UpdateSavedFilter :one
+UPDATE saved_filters
+SET
+  name = $3,
+  expression = $4,
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, name, expression, created_at, updated_at, deleted_at;
+
+-- name: SoftDeleteSavedFilter :one
+UPDATE saved_filters
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, deleted_at;
@@ -0,0 +1,76 @@
+-- name: CreateProjectSection :one
+-- New sections go last.
+INSERT INTO project_sections (user_id, project_id, name, position)
+SELECT $1, $2, $3, COALESCE(MAX(position) + 1, 0)
+FROM project_sections
+WHERE project_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, name, position, created_at, updated_at, deleted_at;
+
+-- name: GetProjectSectionByID :one
+SELECT id, user_id, project_id, name, position, created_at, updated_at, deleted_at
+FROM project_sections
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: ListProjectSections :many
+SELECT id, user_id, project_id, name, position, created_at, updated_at, deleted_at
+FROM project_sections
+WHERE project_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY position, id;
+
+-- name: CountProjectSections :one
+SELECT COUNT(*)
+FROM project_sections
+WHERE project_id = $1
+  AND deleted_at IS NULL;
+
+-- name: UpdateProjectSection :one
+UPDATE project_sections
+SET
+  name = $3,
+  position = $4,
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, name, position, created_at, updated_at, deleted_at;
+
+-- name: ShiftProjectSections :exec
+-- Moves the sections with position in [from_position, to_position] by delta
+-- (+1 or -1) to open or close a gap.
+UPDATE project_sections
+SET
+  position = position + sqlc.arg(delta)::int,
+  updated_at = NOW()
+WHERE project_id = sqlc.arg(project_id)
+  AND deleted_at IS NULL
+  AND position BETWEEN sqlc.arg(from_position)::int AND sqlc.arg(to_position)::int;
+
+-- name: SoftDeleteProjectSection :one
+UPDATE project_sections
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, project_id, position, deleted_at;
+
+-- name: SoftDeleteSectionsByProject :execrows
+UPDATE project_sections
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE project_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: LockProjectSections :exec
+-- Serialises position changes within one project for the rest of the
+-- transaction.
+SELECT pg_advisory_xact_lock(hashtextextended('project_sections:' || sqlc.arg(project_id)::uuid::text, 0));
@@ -0,0 +1,113 @@
+-- name: CreateProjectStatus :one
+-- New statuses go last.
+INSERT INTO project_statuses (user_id, project_id, name, category, color, wip_limit, position)
+SELECT $1, $2, $3, $4, $5, $6, COALESCE(MAX(position) + 1, 0)
+FROM project_statuses
+WHERE project_id = $2
+  AND user_id = $1
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, name, category, color, wip_limit, position, created_at, updated_at, deleted_at;
+
+-- name: CreateDefaultProjectStatuses :many
+INSERT INTO project_statuses (user_id, project_id, name, category, position)
+SELECT $1, $2, d.name, d.category, d.position
+FROM (
+  VALUES
+    ('To Do', 'TODO', 0),
+    ('In Progress', 'IN_PROGRESS', 1),
+    ('Blocked', 'BLOCKED', 2),
+    ('Done', 'DONE', 3)
+) AS d (name, category, position)
+RETURNING id, user_id, project_id, name, category, color, wip_limit, position, created_at, updated_at, deleted_at;
+
+-- name: GetProjectStatusByID :one
+SELECT id, user_id, project_id, name, category, color, wip_limit, position, created_at, updated_at, deleted_at
+FROM project_statuses
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetProjectStatusForUpdate :one
+-- Locks the status row so concurrent moves into it see each other's WIP.
+SELECT id, user_id, project_id, name, category, color, wip_limit, position, created_at, updated_at, deleted_at
+FROM project_statuses
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+FOR UPDATE;
+
+-- name: FirstProjectStatusInCategory :one
+SELECT id, user_id, project_id, name, category, color, wip_limit, position, created_at, updated_at, deleted_at
+FROM project_statuses
+WHERE project_id = $1
+  AND user_id = $2
+  AND category = $3
+  AND deleted_at IS NULL
+ORDER BY position, id
+LIMIT 1;
+
+-- name: ListProjectStatuses :many
+SELECT id, user_id, project_id, name, category, color, wip_limit, position, created_at, updated_at, deleted_at
+FROM project_statuses
+WHERE project_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY position, id;
+
+-- name: CountProjectStatuses :one
+SELECT COUNT(*)
+FROM project_statuses
+WHERE project_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: UpdateProjectStatus :one
+UPDATE project_statuses
+SET
+  name = $3,
+  category = $4,
+  color = $5,
+  wip_limit = $6,
+  position = $7,
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, name, category, color, wip_limit, position, created_at, updated_at, deleted_at;
+
+-- name: ShiftProjectStatuses :exec
+-- Moves the statuses with position in [from_position, to_position] by delta
+-- (+1 or -1) to open or close a gap.
+UPDATE project_statuses
+SET
+  position = position + sqlc.arg(delta)::int,
+  updated_at = NOW()
+WHERE project_id = sqlc.arg(project_id)
+  AND user_id = sqlc.arg(user_id)
+  AND deleted_at IS NULL
+  AND position BETWEEN sqlc.arg(from_position)::int AND sqlc.arg(to_position)::int;
+
+-- name: SoftDeleteProjectStatus :one
+UPDATE project_statuses
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, project_id, position, deleted_at;
+
+-- name: SoftDeleteStatusesByProject :execrows
+UPDATE project_statuses
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE project_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL;
+
+-- name: LockProjectStatuses :exec
+-- Serialises position changes within one project for the rest of the
+-- transaction.
+SELECT pg_advisory_xact_lock(hashtextextended('project_statuses:' || sqlc.arg(project_id)::uuid::text, 0));
@@ -0,0 +1,22 @@
+-- name: UpsertTaskRecurrence :one
+INSERT INTO task_recurrences (task_id, rule)
+VALUES ($1, $2)
+ON CONFLICT (task_id) DO UPDATE
+SET rule = EXCLUDED.rule,
+    updated_at = NOW()
+RETURNING task_id, rule, created_at, updated_at;
+
+-- name: ListTaskRecurrences :many
+SELECT r.task_id, r.rule, r.created_at, r.updated_at
+FROM task_recurrences r
+JOIN tasks t ON t.id = r.task_id
+WHERE r.task_id = ANY(sqlc.arg(task_ids)::uuid[])
+  AND t.user_id = sqlc.arg(user_id)
+  AND t.deleted_at IS NULL;
+
+-- name: DeleteTaskRecurrence :exec
+DELETE FROM task_recurrences r
+USING tasks t
+WHERE r.task_id = sqlc.arg(task_id)
+  AND t.id = r.task_id
+  AND t.user_id = sqlc.arg(user_id);
@@ -1,5 +1,7 @@
 -- name: CreateTask :one
+-- A null id gets a random one; CalDAV clients pick their own.
 INSERT INTO tasks (
+  id,
   user_id,
   project_id,
   parent_task_id,
@@ -9,13 +11,17 @@ INSERT INTO tasks (
   priority,
   start_at,
   due_at,
-  completed_at
+  completed_at,
+  section_id,
+  status_id,
+  blocked_reason,
+  estimate_minutes
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at;
+VALUES (COALESCE(sqlc.narg(id)::uuid, gen_random_uuid()), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes;
 
 -- name: GetTaskByID :one
-SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
 FROM tasks
 WHERE id = $1
   AND user_id = $2
@@ -23,39 +29,211 @@ WHERE id = $1
 LIMIT 1;
 
 -- name: ListRootTasks :many
-SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
 FROM tasks
-WHERE user_id = $1
-  AND project_id = $2
+WHERE user_id = sqlc.arg(user_id)
+  AND project_id = sqlc.arg(project_id)
   AND parent_task_id IS NULL
   AND deleted_at IS NULL
-  AND (cardinality($3::text[]) = 0 OR status = ANY($3::text[]))
-  AND (cardinality($4::text[]) = 0 OR priority = ANY($4::text[]))
+  AND (cardinality(sqlc.arg(statuses)::text[]) = 0 OR status = ANY(sqlc.arg(statuses)::text[]))
+  AND (cardinality(sqlc.arg(priorities)::text[]) = 0 OR priority = ANY(sqlc.arg(priorities)::text[]))
+  AND (
+    cardinality(sqlc.arg(label_ids)::uuid[]) = 0
+    OR (
+      SELECT COUNT(*)
+      FROM task_labels tl
+      WHERE tl.task_id = tasks.id
+        AND tl.label_id = ANY(sqlc.arg(label_ids)::uuid[])
+    ) >= CASE WHEN sqlc.arg(match_all_labels)::boolean THEN cardinality(sqlc.arg(label_ids)::uuid[]) ELSE 1 END
+  )
+  AND (sqlc.narg(due_before)::timestamptz IS NULL OR due_at < sqlc.narg(due_before)::timestamptz)
+  AND (sqlc.narg(due_after)::timestamptz IS NULL OR due_at >= sqlc.narg(due_after)::timestamptz)
+  AND (sqlc.narg(start_before)::timestamptz IS NULL OR start_at < sqlc.narg(start_before)::timestamptz)
+  AND (sqlc.narg(start_after)::timestamptz IS NULL OR start_at >= sqlc.narg(start_after)::timestamptz)
+  AND (sqlc.narg(completed_from)::timestamptz IS NULL OR completed_at >= sqlc.narg(completed_from)::timestamptz)
+  AND (sqlc.narg(completed_to)::timestamptz IS NULL OR completed_at < sqlc.narg(completed_to)::timestamptz)
+  AND (sqlc.narg(has_due_date)::boolean IS NULL OR (due_at IS NOT NULL) = sqlc.narg(has_due_date)::boolean)
+  AND (sqlc.narg(updated_since)::timestamptz IS NULL OR updated_at >= sqlc.narg(updated_since)::timestamptz)
+  AND (sqlc.narg(title_pattern)::text IS NULL OR title ILIKE sqlc.narg(title_pattern)::text)
+  AND (sqlc.narg(section_id)::uuid IS NULL OR section_id = sqlc.narg(section_id)::uuid)
+  AND (sqlc.narg(status_id)::uuid IS NULL OR status_id = sqlc.narg(status_id)::uuid)
   AND (
-    NOT $5::boolean
-    OR (created_at, id) < ($6::timestamptz, $7::uuid)
+    NOT sqlc.arg(use_cursor)::boolean
+    OR (created_at, id) < (sqlc.arg(cursor_created_at)::timestamptz, sqlc.arg(cursor_id)::uuid)
   )
 ORDER BY created_at DESC, id DESC
-LIMIT $8;
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListRootTasksBefore :many
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM tasks
+WHERE user_id = sqlc.arg(user_id)
+  AND project_id = sqlc.arg(project_id)
+  AND parent_task_id IS NULL
+  AND deleted_at IS NULL
+  AND (cardinality(sqlc.arg(statuses)::text[]) = 0 OR status = ANY(sqlc.arg(statuses)::text[]))
+  AND (cardinality(sqlc.arg(priorities)::text[]) = 0 OR priority = ANY(sqlc.arg(priorities)::text[]))
+  AND (
+    cardinality(sqlc.arg(label_ids)::uuid[]) = 0
+    OR (
+      SELECT COUNT(*)
+      FROM task_labels tl
+      WHERE tl.task_id = tasks.id
+        AND tl.label_id = ANY(sqlc.arg(label_ids)::uuid[])
+    ) >= CASE WHEN sqlc.arg(match_all_labels)::boolean THEN cardinality(sqlc.arg(label_ids)::uuid[]) ELSE 1 END
+  )
+  AND (sqlc.narg(due_before)::timestamptz IS NULL OR due_at < sqlc.narg(due_before)::timestamptz)
+  AND (sqlc.narg(due_after)::timestamptz IS NULL OR due_at >= sqlc.narg(due_after)::timestamptz)
+  AND (sqlc.narg(start_before)::timestamptz IS NULL OR start_at < sqlc.narg(start_before)::timestamptz)
+  AND (sqlc.narg(start_after)::timestamptz IS NULL OR start_at >= sqlc.narg(start_after)::timestamptz)
+  AND (sqlc.narg(completed_from)::timestamptz IS NULL OR completed_at >= sqlc.narg(completed_from)::timestamptz)
+  AND (sqlc.narg(completed_to)::timestamptz IS NULL OR completed_at < sqlc.narg(completed_to)::timestamptz)
+  AND (sqlc.narg(has_due_date)::boolean IS NULL OR (due_at IS NOT NULL) = sqlc.narg(has_due_date)::boolean)
+  AND (sqlc.narg(updated_since)::timestamptz IS NULL OR updated_at >= sqlc.narg(updated_since)::timestamptz)
+  AND (sqlc.narg(title_pattern)::text IS NULL OR title ILIKE sqlc.narg(title_pattern)::text)
+  AND (sqlc.narg(section_id)::uuid IS NULL OR section_id = sqlc.narg(section_id)::uuid)
+  AND (sqlc.narg(status_id)::uuid IS NULL OR status_id = sqlc.narg(status_id)::uuid)
+  AND (
+    NOT sqlc.arg(use_cursor)::boolean
+    OR (created_at, id) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.arg(cursor_id)::uuid)
+  )
+ORDER BY created_at ASC, id ASC
+LIMIT sqlc.arg(row_limit);
+
+-- name: CountRootTasks :one
+SELECT COUNT(*)
+FROM tasks
+WHERE user_id = sqlc.arg(user_id)
+  AND project_id = sqlc.arg(project_id)
+  AND parent_task_id IS NULL
+  AND deleted_at IS NULL
+  AND (cardinality(sqlc.arg(statuses)::text[]) = 0 OR status = ANY(sqlc.arg(statuses)::text[]))
+  AND (cardinality(sqlc.arg(priorities)::text[]) = 0 OR priority = ANY(sqlc.arg(priorities)::text[]))
+  AND (
+    cardinality(sqlc.arg(label_ids)::uuid[]) = 0
+    OR (
+      SELECT COUNT(*)
+      FROM task_labels tl
+      WHERE tl.task_id = tasks.id
+        AND tl.label_id = ANY(sqlc.arg(label_ids)::uuid[])
+    ) >= CASE WHEN sqlc.arg(match_all_labels)::boolean THEN cardinality(sqlc.arg(label_ids)::uuid[]) ELSE 1 END
+  )
+  AND (sqlc.narg(due_before)::timestamptz IS NULL OR due_at < sqlc.narg(due_before)::timestamptz)
+  AND (sqlc.narg(due_after)::timestamptz IS NULL OR due_at >= sqlc.narg(due_after)::timestamptz)
+  AND (sqlc.narg(start_before)::timestamptz IS NULL OR start_at < sqlc.narg(start_before)::timestamptz)
+  AND (sqlc.narg(start_after)::timestamptz IS NULL OR start_at >= sqlc.narg(start_after)::timestamptz)
+  AND (sqlc.narg(completed_from)::timestamptz IS NULL OR completed_at >= sqlc.narg(completed_from)::timestamptz)
+  AND (sqlc.narg(completed_to)::timestamptz IS NULL OR completed_at < sqlc.narg(completed_to)::timestamptz)
+  AND (sqlc.narg(has_due_date)::boolean IS NULL OR (due_at IS NOT NULL) = sqlc.narg(has_due_date)::boolean)
+  AND (sqlc.narg(updated_since)::timestamptz IS NULL OR updated_at >= sqlc.narg(updated_since)::timestamptz)
+  AND (sqlc.narg(title_pattern)::text IS NULL OR title ILIKE sqlc.narg(title_pattern)::text)
+  AND (sqlc.narg(section_id)::uuid IS NULL OR section_id = sqlc.narg(section_id)::uuid)
+  AND (sqlc.narg(status_id)::uuid IS NULL OR status_id = sqlc.narg(status_id)::uuid);
 
 -- name: ListSubtasks :many
-SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
 FROM tasks
-WHERE user_id = $1
-  AND project_id = $2
-  AND parent_task_id = $3
+WHERE user_id = sqlc.arg(user_id)
+  AND project_id = sqlc.arg(project_id)
+  AND parent_task_id = sqlc.arg(parent_task_id)
   AND deleted_at IS NULL
-  AND (cardinality($4::text[]) = 0 OR status = ANY($4::text[]))
-  AND (cardinality($5::text[]) = 0 OR priority = ANY($5::text[]))
+  AND (cardinality(sqlc.arg(statuses)::text[]) = 0 OR status = ANY(sqlc.arg(statuses)::text[]))
+  AND (cardinality(sqlc.arg(priorities)::text[]) = 0 OR priority = ANY(sqlc.arg(priorities)::text[]))
+  AND (
+    cardinality(sqlc.arg(label_ids)::uuid[]) = 0
+    OR (
+      SELECT COUNT(*)
+      FROM task_labels tl
+      WHERE tl.task_id = tasks.id
+        AND tl.label_id = ANY(sqlc.arg(label_ids)::uuid[])
+    ) >= CASE WHEN sqlc.arg(match_all_labels)::boolean THEN cardinality(sqlc.arg(label_ids)::uuid[]) ELSE 1 END
+  )
+  AND (sqlc.narg(due_before)::timestamptz IS NULL OR due_at < sqlc.narg(due_before)::timestamptz)
+  AND (sqlc.narg(due_after)::timestamptz IS NULL OR due_at >= sqlc.narg(due_after)::timestamptz)
+  AND (sqlc.narg(start_before)::timestamptz IS NULL OR start_at < sqlc.narg(start_before)::timestamptz)
+  AND (sqlc.narg(start_after)::timestamptz IS NULL OR start_at >= sqlc.narg(start_after)::timestamptz)
+  AND (sqlc.narg(completed_from)::timestamptz IS NULL OR completed_at >= sqlc.narg(completed_from)::timestamptz)
+  AND (sqlc.narg(completed_to)::timestamptz IS NULL OR completed_at < sqlc.narg(completed_to)::timestamptz)
+  AND (sqlc.narg(has_due_date)::boolean IS NULL OR (due_at IS NOT NULL) = sqlc.narg(has_due_date)::boolean)
+  AND (sqlc.narg(updated_since)::timestamptz IS NULL OR updated_at >= sqlc.narg(updated_since)::timestamptz)
+  AND (sqlc.narg(title_pattern)::text IS NULL OR title ILIKE sqlc.narg(title_pattern)::text)
+  AND (sqlc.narg(section_id)::uuid IS NULL OR section_id = sqlc.narg(section_id)::uuid)
+  AND (sqlc.narg(status_id)::uuid IS NULL OR status_id = sqlc.narg(status_id)::uuid)
   AND (
-    NOT $6::boolean
-    OR (created_at, id) < ($7::timestamptz, $8::uuid)
+    NOT sqlc.arg(use_cursor)::boolean
+    OR (created_at, id) < (sqlc.arg(cursor_created_at)::timestamptz, sqlc.arg(cursor_id)::uuid)
   )
 ORDER BY created_at DESC, id DESC
-LIMIT $9;
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListSubtasksBefore :many
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM tasks
+WHERE user_id = sqlc.arg(user_id)
+  AND project_id = sqlc.arg(project_id)
+  AND parent_task_id = sqlc.arg(parent_task_id)
+  AND deleted_at IS NULL
+  AND (cardinality(sqlc.arg(statuses)::text[]) = 0 OR status = ANY(sqlc.arg(statuses)::text[]))
+  AND (cardinality(sqlc.arg(priorities)::text[]) = 0 OR priority = ANY(sqlc.arg(priorities)::text[]))
+  AND (
+    cardinality(sqlc.arg(label_ids)::uuid[]) = 0
+    OR (
+      SELECT COUNT(*)
+      FROM task_labels tl
+      WHERE tl.task_id = tasks.id
+        AND tl.label_id = ANY(sqlc.arg(label_ids)::uuid[])
+    ) >= CASE WHEN sqlc.arg(match_all_labels)::boolean THEN cardinality(sqlc.arg(label_ids)::uuid[]) ELSE 1 END
+  )
+  AND (sqlc.narg(due_before)::timestamptz IS NULL OR due_at < sqlc.narg(due_before)::timestamptz)
+  AND (sqlc.narg(due_after)::timestamptz IS NULL OR due_at >= sqlc.narg(due_after)::timestamptz)
+  AND (sqlc.narg(start_before)::timestamptz IS NULL OR start_at < sqlc.narg(start_before)::timestamptz)
+  AND (sqlc.narg(start_after)::timestamptz IS NULL OR start_at >= sqlc.narg(start_after)::timestamptz)
+  AND (sqlc.narg(completed_from)::timestamptz IS NULL OR completed_at >= sqlc.narg(completed_from)::timestamptz)
+  AND (sqlc.narg(completed_to)::timestamptz IS NULL OR completed_at < sqlc.narg(completed_to)::timestamptz)
+  AND (sqlc.narg(has_due_date)::boolean IS NULL OR (due_at IS NOT NULL) = sqlc.narg(has_due_date)::boolean)
+  AND (sqlc.narg(updated_since)::timestamptz IS NULL OR updated_at >= sqlc.narg(updated_since)::timestamptz)
+  AND (sqlc.narg(title_pattern)::text IS NULL OR title ILIKE sqlc.narg(title_pattern)::text)
+  AND (sqlc.narg(section_id)::uuid IS NULL OR section_id = sqlc.narg(section_id)::uuid)
+  AND (sqlc.narg(status_id)::uuid IS NULL OR status_id = sqlc.narg(status_id)::uuid)
+  AND (
+    NOT sqlc.arg(use_cursor)::boolean
+    OR (created_at, id) > (sqlc.arg(cursor_created_at)::timestamptz, sqlc.arg(cursor_id)::uuid)
+  )
+ORDER BY created_at ASC, id ASC
+LIMIT sqlc.arg(row_limit);
+
+-- name: CountSubtasks :one
+SELECT COUNT(*)
+FROM tasks
+WHERE user_id = sqlc.arg(user_id)
+  AND project_id = sqlc.arg(project_id)
+  AND parent_task_id = sqlc.arg(parent_task_id)
+  AND deleted_at IS NULL
+  AND (cardinality(sqlc.arg(statuses)::text[]) = 0 OR status = ANY(sqlc.arg(statuses)::text[]))
+  AND (cardinality(sqlc.arg(priorities)::text[]) = 0 OR priority = ANY(sqlc.arg(priorities)::text[]))
+  AND (
+    cardinality(sqlc.arg(label_ids)::uuid[]) = 0
+    OR (
+      SELECT COUNT(*)
+      FROM task_labels tl
+      WHERE tl.task_id = tasks.id
+        AND tl.label_id = ANY(sqlc.arg(label_ids)::uuid[])
+    ) >= CASE WHEN sqlc.arg(match_all_labels)::boolean THEN cardinality(sqlc.arg(label_ids)::uuid[]) ELSE 1 END
+  )
+  AND (sqlc.narg(due_before)::timestamptz IS NULL OR due_at < sqlc.narg(due_before)::timestamptz)
+  AND (sqlc.narg(due_after)::timestamptz IS NULL OR due_at >= sqlc.narg(due_after)::timestamptz)
+  AND (sqlc.narg(start_before)::timestamptz IS NULL OR start_at < sqlc.narg(start_before)::timestamptz)
+  AND (sqlc.narg(start_after)::timestamptz IS NULL OR start_at >= sqlc.narg(start_after)::timestamptz)
+  AND (sqlc.narg(completed_from)::timestamptz IS NULL OR completed_at >= sqlc.narg(completed_from)::timestamptz)
+  AND (sqlc.narg(completed_to)::timestamptz IS NULL OR completed_at < sqlc.narg(completed_to)::timestamptz)
+  AND (sqlc.narg(has_due_date)::boolean IS NULL OR (due_at IS NOT NULL) = sqlc.narg(has_due_date)::boolean)
+  AND (sqlc.narg(updated_since)::timestamptz IS NULL OR updated_at >= sqlc.narg(updated_since)::timestamptz)
+  AND (sqlc.narg(title_pattern)::text IS NULL OR title ILIKE sqlc.narg(title_pattern)::text)
+  AND (sqlc.narg(section_id)::uuid IS NULL OR section_id = sqlc.narg(section_id)::uuid)
+  AND (sqlc.narg(status_id)::uuid IS NULL OR status_id = sqlc.narg(status_id)::uuid);
 
 -- name: ListSubtasksByParentID :many
-SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
 FROM tasks
 WHERE user_id = $1
   AND parent_task_id = $2
@@ -72,11 +250,15 @@ SET
   start_at = $7,
   due_at = $8,
   completed_at = $9,
+  section_id = $10,
+  status_id = $11,
+  blocked_reason = $12,
+  estimate_minutes = $13,
   updated_at = NOW()
 WHERE id = $1
   AND user_id = $2
   AND deleted_at IS NULL
-RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at;
+RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes;
 
 -- name: SoftDeleteTask :one
 UPDATE tasks
@@ -86,22 +268,138 @@ SET
 WHERE id = $1
   AND user_id = $2
   AND deleted_at IS NULL
-RETURNING id, deleted_at;
+RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes;
 
--- name: SoftDeleteDirectSubtasks :execrows
+-- name: SoftDeleteDirectSubtasks :many
 UPDATE tasks
 SET
   deleted_at = NOW(),
   updated_at = NOW()
 WHERE parent_task_id = $1
   AND user_id = $2
-  AND deleted_at IS NULL;
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes;
 
--- name: SoftDeleteTasksByProject :execrows
+-- name: SoftDeleteTasksByProject :many
 UPDATE tasks
 SET
   deleted_at = NOW(),
   updated_at = NOW()
 WHERE project_id = $1
   AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes;
+
+-- name: MoveTasksToSection :many
+-- Re-homes the tasks of a section being deleted; a NULL new_section_id
+-- leaves them unsectioned.
+UPDATE tasks
+SET
+  section_id = sqlc.narg(new_section_id),
+  updated_at = NOW()
+WHERE section_id = sqlc.arg(section_id)
+  AND user_id = sqlc.arg(user_id)
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes;
+
+-- name: CountRootTasksByStatus :many
+-- Board column counts: live root tasks of a project per workflow status.
+SELECT status_id, COUNT(*) AS task_count
+FROM tasks
+WHERE project_id = $1
+  AND user_id = $2
+  AND parent_task_id IS NULL
+  AND deleted_at IS NULL
+GROUP BY status_id;
+
+-- name: CountRootTasksInStatus :one
+SELECT COUNT(*)
+FROM tasks
+WHERE status_id = $1
+  AND user_id = $2
+  AND parent_task_id IS NULL
+  AND deleted_at IS NULL;
+
+-- name: MoveTasksToStatus :many
+-- Re-homes the live tasks of a status being deleted and brings their category
+-- and completion time in line with the new status, recording the status
+-- change. Returns the moved tasks.
+WITH moved AS (
+  UPDATE tasks t
+  SET
+    status_id = sqlc.arg(new_status_id),
+    status = sqlc.arg(category)::text,
+    completed_at = CASE
+      WHEN sqlc.arg(category)::text = 'DONE' THEN COALESCE(t.completed_at, NOW())
+      ELSE NULL
+    END,
+    blocked_reason = CASE WHEN sqlc.arg(category)::text = 'BLOCKED' THEN t.blocked_reason ELSE NULL END,
+    updated_at = NOW()
+  WHERE t.status_id = sqlc.arg(status_id)
+    AND t.user_id = sqlc.arg(user_id)
+    AND t.deleted_at IS NULL
+  RETURNING t.id, t.user_id, t.project_id, t.parent_task_id, t.title, t.description, t.status, t.priority, t.start_at, t.due_at, t.completed_at, t.created_at, t.updated_at, t.deleted_at, t.section_id, t.status_id, t.blocked_reason, t.estimate_minutes
+), logged AS (
+  INSERT INTO task_status_changes (task_id, user_id, status)
+  SELECT id, user_id, status FROM moved
+)
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM moved;
+
+-- name: SetTaskCategoryForStatus :many
+-- Applies a status's new category to the tasks already in it, recording the
+-- status change. Returns the changed tasks.
+WITH changed AS (
+  UPDATE tasks t
+  SET
+    status = sqlc.arg(category)::text,
+    completed_at = CASE
+      WHEN sqlc.arg(category)::text = 'DONE' THEN COALESCE(t.completed_at, NOW())
+      ELSE NULL
+    END,
+    blocked_reason = CASE WHEN sqlc.arg(category)::text = 'BLOCKED' THEN t.blocked_reason ELSE NULL END,
+    updated_at = NOW()
+  WHERE t.status_id = sqlc.arg(status_id)
+    AND t.user_id = sqlc.arg(user_id)
+    AND t.status <> sqlc.arg(category)::text
+    AND t.deleted_at IS NULL
+  RETURNING t.id, t.user_id, t.project_id, t.parent_task_id, t.title, t.description, t.status, t.priority, t.start_at, t.due_at, t.completed_at, t.created_at, t.updated_at, t.deleted_at, t.section_id, t.status_id, t.blocked_reason, t.estimate_minutes
+), logged AS (
+  INSERT INTO task_status_changes (task_id, user_id, status)
+  SELECT id, user_id, status FROM changed
+)
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM changed;
+
+-- name: SubtaskProgress :many
+-- Live subtask counts for a batch of parent tasks; parents without subtasks
+-- are absent.
+SELECT
+  parent_task_id,
+  COUNT(*) AS total,
+  COUNT(*) FILTER (WHERE status = 'DONE') AS done
+FROM tasks
+WHERE user_id = sqlc.arg(user_id)
+  AND parent_task_id = ANY(sqlc.arg(parent_ids)::uuid[])
+  AND deleted_at IS NULL
+GROUP BY parent_task_id;
+
+-- name: CountOpenSubtasks :one
+SELECT COUNT(*)
+FROM tasks
+WHERE parent_task_id = $1
+  AND user_id = $2
+  AND status <> 'DONE'
   AND deleted_at IS NULL;
+
+-- name: LockTask :exec
+-- Serialises concurrent subtask roll-ups onto the same parent.
+SELECT id
+FROM tasks
+WHERE id = $1
+  AND user_id = $2
+FOR UPDATE;
+
+-- name: InsertTaskStatusChange :exec
+INSERT INTO task_status_changes (task_id, user_id, status)
+VALUES ($1, $2, $3);
@@ -0,0 +1,29 @@
+-- name: CreateTemplate :one
+INSERT INTO templates (user_id, name, kind, content)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, name, kind, content, created_at, updated_at, deleted_at;
+
+-- name: GetTemplateByID :one
+SELECT id, user_id, name, kind, content, created_at, updated_at, deleted_at
+FROM templates
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: ListTemplates :many
+SELECT id, user_id, name, kind, content, created_at, updated_at, deleted_at
+FROM templates
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY LOWER(name), id;
+
+-- name: SoftDeleteTemplate :one
+UPDATE templates
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, deleted_at;
@@ -0,0 +1,100 @@
+-- name: StartTimeEntry :one
+INSERT INTO time_entries (user_id, task_id, started_at, note)
+VALUES ($1, $2, NOW(), $3)
+RETURNING id, user_id, task_id, started_at, ended_at, note, created_at, updated_at, deleted_at;
+
+-- name: CreateTimeEntry :one
+INSERT INTO time_entries (user_id, task_id, started_at, ended_at, note)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, task_id, started_at, ended_at, note, created_at, updated_at, deleted_at;
+
+-- name: GetTimeEntryByID :one
+SELECT id, user_id, task_id, started_at, ended_at, note, created_at, updated_at, deleted_at
+FROM time_entries
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: GetRunningTimeEntry :one
+SELECT id, user_id, task_id, started_at, ended_at, note, created_at, updated_at, deleted_at
+FROM time_entries
+WHERE user_id = $1
+  AND ended_at IS NULL
+  AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: StopRunningTimeEntry :one
+UPDATE time_entries
+SET
+  ended_at = GREATEST(NOW(), started_at),
+  updated_at = NOW()
+WHERE user_id = $1
+  AND ended_at IS NULL
+  AND deleted_at IS NULL
+RETURNING id, user_id, task_id, started_at, ended_at, note, created_at, updated_at, deleted_at;
+
+-- name: UpdateTimeEntry :one
+UPDATE time_entries
+SET
+  task_id = $3,
+  started_at = $4,
+  ended_at = $5,
+  note = $6,
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, task_id, started_at, ended_at, note, created_at, updated_at, deleted_at;
+
+-- name: SoftDeleteTimeEntry :one
+UPDATE time_entries
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, deleted_at;
+
+-- name: ListTimeEntriesByTask :many
+SELECT id, user_id, task_id, started_at, ended_at, note, created_at, updated_at, deleted_at
+FROM time_entries
+WHERE task_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY started_at DESC, id DESC;
+
+-- name: TaskTimeSpent :many
+-- Seconds tracked on each task and its live subtasks; running timers count
+-- up to now.
+SELECT
+  t.id AS task_id,
+  COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(e.ended_at, NOW()) - e.started_at)), 0)::bigint AS seconds
+FROM tasks t
+JOIN tasks c ON (c.id = t.id OR c.parent_task_id = t.id) AND c.deleted_at IS NULL
+JOIN time_entries e ON e.task_id = c.id AND e.deleted_at IS NULL
+WHERE t.id = ANY(sqlc.arg(task_ids)::uuid[])
+  AND t.user_id = sqlc.arg(user_id)
+GROUP BY t.id;
+
+-- name: ListTimeEntriesForReport :many
+-- Entries of live tasks overlapping [range_from, range_to).
+SELECT e.task_id, t.project_id, p.title AS project_title, e.started_at, COALESCE(e.ended_at, NOW())::timestamptz AS ended_at
+FROM time_entries e
+JOIN tasks t ON t.id = e.task_id AND t.deleted_at IS NULL
+JOIN projects p ON p.id = t.project_id
+WHERE e.user_id = sqlc.arg(user_id)
+  AND e.deleted_at IS NULL
+  AND e.started_at < sqlc.arg(range_to)::timestamptz
+  AND COALESCE(e.ended_at, NOW()) > sqlc.arg(range_from)::timestamptz
+ORDER BY e.started_at;
+
+-- name: ListLabelsForTasks :many
+SELECT tl.task_id, l.id, l.name
+FROM task_labels tl
+JOIN labels l ON l.id = tl.label_id
+WHERE tl.task_id = ANY(sqlc.arg(task_ids)::uuid[])
+  AND l.user_id = sqlc.arg(user_id)
+  AND l.deleted_at IS NULL
+ORDER BY l.name, l.id;
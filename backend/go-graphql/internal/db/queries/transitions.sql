@@ -0,0 +1,31 @@
+-- name: ListStatusTransitions :many
+SELECT from_status, to_status
+FROM project_status_transitions
+WHERE project_id = $1
+  AND user_id = $2
+ORDER BY from_status, to_status;
+
+-- name: ListStatusRequirements :many
+SELECT status, field
+FROM project_status_requirements
+WHERE project_id = $1
+  AND user_id = $2
+ORDER BY status, field;
+
+-- name: DeleteStatusTransitions :exec
+DELETE FROM project_status_transitions
+WHERE project_id = $1
+  AND user_id = $2;
+
+-- name: DeleteStatusRequirements :exec
+DELETE FROM project_status_requirements
+WHERE project_id = $1
+  AND user_id = $2;
+
+-- name: InsertStatusTransitions :exec
+INSERT INTO project_status_transitions (project_id, user_id, from_status, to_status)
+SELECT sqlc.arg(project_id)::uuid, sqlc.arg(user_id)::uuid, unnest(sqlc.arg(from_statuses)::text[]), unnest(sqlc.arg(to_statuses)::text[]);
+
+-- name: InsertStatusRequirements :exec
+INSERT INTO project_status_requirements (project_id, user_id, status, field)
+SELECT sqlc.arg(project_id)::uuid, sqlc.arg(user_id)::uuid, unnest(sqlc.arg(statuses)::text[]), unnest(sqlc.arg(fields)::text[]);
@@ -0,0 +1,194 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (user_id, url, secret, event_types)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, url, secret, event_types, active, created_at, updated_at, deleted_at;
+
+-- name: GetWebhookSubscriptionByID :one
+SELECT id, user_id, url, secret, event_types, active, created_at, updated_at, deleted_at
+FROM webhook_subscriptions
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+LIMIT 1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT id, user_id, url, secret, event_types, active, created_at, updated_at, deleted_at
+FROM webhook_subscriptions
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY created_at DESC, id DESC;
+
+-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET
+  url = $3,
+  secret = $4,
+  event_types = $5,
+  active = $6,
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, url, secret, event_types, active, created_at, updated_at, deleted_at;
+
+-- name: SoftDeleteWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET
+  active = FALSE,
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, deleted_at;
+
+-- name: InsertOutboxEvent :exec
+INSERT INTO outbox_events (user_id, event_type, payload)
+VALUES ($1, $2, $3);
+
+-- name: ClaimOutboxEvents :many
+SELECT id, user_id, event_type, payload, created_at, dispatched_at
+FROM outbox_events
+WHERE dispatched_at IS NULL
+ORDER BY created_at, id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (user_id, subscription_id, event_id, event_type)
+SELECT s.user_id, s.id, sqlc.arg(event_id)::uuid, sqlc.arg(event_type)::text
+FROM webhook_subscriptions s
+WHERE s.user_id = sqlc.arg(user_id)::uuid
+  AND s.active
+  AND s.deleted_at IS NULL
+  AND (
+    cardinality(s.event_types) = 0
+    OR sqlc.arg(event_type)::text = ANY(s.event_types)
+  )
+ON CONFLICT (subscription_id, event_id) DO NOTHING;
+
+-- name: MarkOutboxEventDispatched :exec
+UPDATE outbox_events
+SET dispatched_at = NOW()
+WHERE id = $1;
+
+-- name: ClaimDueWebhookDeliveries :many
+-- Pushes next_attempt_at forward by the lease so a crashed worker's claims
+-- become due again instead of being lost. Deliveries of deleted or inactive
+-- subscriptions are never claimed.
+UPDATE webhook_deliveries d
+SET
+  next_attempt_at = NOW() + sqlc.arg(lease)::interval,
+  updated_at = NOW()
+FROM webhook_subscriptions s, outbox_events e
+WHERE d.id IN (
+    SELECT pd.id
+    FROM webhook_deliveries pd
+    JOIN webhook_subscriptions ps ON ps.id = pd.subscription_id
+    WHERE pd.status = 'PENDING'
+      AND pd.next_attempt_at <= NOW()
+      AND ps.active
+      AND ps.deleted_at IS NULL
+    ORDER BY pd.next_attempt_at
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE OF pd SKIP LOCKED
+  )
+  AND s.id = d.subscription_id
+  AND s.active
+  AND s.deleted_at IS NULL
+  AND e.id = d.event_id
+RETURNING d.id, d.attempts, d.event_type, d.event_id, e.payload, e.created_at AS event_created_at, s.url, s.secret;
+
+-- name: MarkWebhookDeliverySucceeded :exec
+UPDATE webhook_deliveries
+SET
+  status = 'DELIVERED',
+  attempts = attempts + 1,
+  last_status_code = $2,
+  last_error = NULL,
+  delivered_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1;
+
+-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+SET
+  status = $2,
+  attempts = attempts + 1,
+  next_attempt_at = $3,
+  last_status_code = $4,
+  last_error = $5,
+  updated_at = NOW()
+WHERE id = $1;
+
+-- name: CancelPendingWebhookDeliveries :execrows
+-- Dead-letters what a subscription still has queued when it is deleted or
+-- deactivated.
+UPDATE webhook_deliveries
+SET
+  status = 'DEAD',
+  last_error = sqlc.arg(reason)::text,
+  updated_at = NOW()
+WHERE subscription_id = sqlc.arg(subscription_id)
+  AND user_id = sqlc.arg(user_id)
+  AND status = 'PENDING';
+
+-- name: RetryWebhookDelivery :one
+UPDATE webhook_deliveries
+SET
+  status = 'PENDING',
+  attempts = 0,
+  next_attempt_at = NOW(),
+  updated_at = NOW()
+WHERE webhook_deliveries.id = $1
+  AND webhook_deliveries.user_id = $2
+  AND webhook_deliveries.status = 'DEAD'
+  AND EXISTS (
+    SELECT 1
+    FROM webhook_subscriptions s
+    WHERE s.id = webhook_deliveries.subscription_id
+      AND s.user_id = $2
+      AND s.active
+      AND s.deleted_at IS NULL
+  )
+RETURNING id, user_id, subscription_id, event_id, event_type, status, attempts, next_attempt_at, last_status_code, last_error, delivered_at, created_at, updated_at;
+
+-- name: GetWebhookDeliveryByID :one
+SELECT id, user_id, subscription_id, event_id, event_type, status, attempts, next_attempt_at, last_status_code, last_error, delivered_at, created_at, updated_at
+FROM webhook_deliveries
+WHERE id = $1
+  AND user_id = $2
+LIMIT 1;
+
+-- name: ListWebhookDeliveries :many
+SELECT id, user_id, subscription_id, event_id, event_type, status, attempts, next_attempt_at, last_status_code, last_error, delivered_at, created_at, updated_at
+FROM webhook_deliveries
+WHERE user_id = $1
+  AND ($2::boolean = FALSE OR subscription_id = $3::uuid)
+  AND (cardinality($4::text[]) = 0 OR status = ANY($4::text[]))
+  AND (
+    NOT $5::boolean
+    OR (created_at, id) < ($6::timestamptz, $7::uuid)
+  )
+ORDER BY created_at DESC, id DESC
+LIMIT $8;
+
+-- name: ListWebhookDeliveriesBefore :many
+SELECT id, user_id, subscription_id, event_id, event_type, status, attempts, next_attempt_at, last_status_code, last_error, delivered_at, created_at, updated_at
+FROM webhook_deliveries
+WHERE user_id = $1
+  AND ($2::boolean = FALSE OR subscription_id = $3::uuid)
+  AND (cardinality($4::text[]) = 0 OR status = ANY($4::text[]))
+  AND (
+    NOT $5::boolean
+    OR (created_at, id) > ($6::timestamptz, $7::uuid)
+  )
+ORDER BY created_at ASC, id ASC
+LIMIT $8;
+
+-- name: CountWebhookDeliveries :one
+SELECT COUNT(*)
+FROM webhook_deliveries
+WHERE user_id = $1
+  AND ($2::boolean = FALSE OR subscription_id = $3::uuid)
+  AND (cardinality($4::text[]) = 0 OR status = ANY($4::text[]));
@@ -0,0 +1,399 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: analytics.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const completedTaskSeries = `-- name: CompletedTaskSeries :many
+WITH buckets AS (
+  SELECT generate_series(
+    date_trunc($1::text, $3::timestamptz AT TIME ZONE $2::text),
+    ($4::timestamptz AT TIME ZONE $2::text) - INTERVAL '1 microsecond',
+    ('1 ' || $1::text)::interval
+  ) AS bucket_start
+),
+completed AS (
+  SELECT date_trunc($1::text, t.completed_at AT TIME ZONE $2::text) AS bucket_start
+  FROM tasks t
+  JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+  WHERE t.user_id = $5
+    AND t.deleted_at IS NULL
+    AND t.status = 'DONE'
+    AND t.completed_at >= $3::timestamptz
+    AND t.completed_at < $4::timestamptz
+    AND (cardinality($6::uuid[]) = 0 OR t.project_id = ANY($6::uuid[]))
+)
+SELECT b.bucket_start::date AS bucket, COUNT(c.bucket_start) AS task_count
+FROM buckets b
+LEFT JOIN completed c ON c.bucket_start = b.bucket_start
+GROUP BY b.bucket_start
+ORDER BY b.bucket_start
+`
+
+type CompletedTaskSeriesParams struct {
+	Bucket     string             `json:"bucket"`
+	Tz         string             `json:"tz"`
+	RangeFrom  pgtype.Timestamptz `json:"range_from"`
+	RangeTo    pgtype.Timestamptz `json:"range_to"`
+	UserID     pgtype.UUID        `json:"user_id"`
+	ProjectIds []pgtype.UUID      `json:"project_ids"`
+}
+
+type CompletedTaskSeriesRow struct {
+	Bucket    pgtype.Date `json:"bucket"`
+	TaskCount int64       `json:"task_count"`
+}
+
+// Tasks completed per bucket. Analytics queries cover the live tasks of the
+// user's live projects, optionally limited to project_ids. Buckets are local
+// days or weeks (bucket is 'day' or 'week'; weeks start on Monday) in the tz
+// time zone, and every bucket overlapping [range_from, range_to) is listed.
+func (q *Queries) CompletedTaskSeries(ctx context.Context, arg CompletedTaskSeriesParams) ([]CompletedTaskSeriesRow, error) {
+	rows, err := q.db.Query(ctx, completedTaskSeries,
+		arg.Bucket,
+		arg.Tz,
+		arg.RangeFrom,
+		arg.RangeTo,
+		arg.UserID,
+		arg.ProjectIds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CompletedTaskSeriesRow{}
+	for rows.Next() {
+		var i CompletedTaskSeriesRow
+		if err := rows.Scan(&i.Bucket, &i.TaskCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const leadTimeStats = `-- name: LeadTimeStats :one
+SELECT
+  COUNT(*) AS completed_count,
+  COALESCE(AVG(EXTRACT(EPOCH FROM t.completed_at - t.created_at)), 0)::float8 AS average_seconds,
+  COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM t.completed_at - t.created_at)), 0)::float8 AS median_seconds
+FROM tasks t
+JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+WHERE t.user_id = $1
+  AND t.deleted_at IS NULL
+  AND t.status = 'DONE'
+  AND t.completed_at >= $2::timestamptz
+  AND t.completed_at < $3::timestamptz
+  AND (cardinality($4::uuid[]) = 0 OR t.project_id = ANY($4::uuid[]))
+`
+
+type LeadTimeStatsParams struct {
+	UserID     pgtype.UUID        `json:"user_id"`
+	RangeFrom  pgtype.Timestamptz `json:"range_from"`
+	RangeTo    pgtype.Timestamptz `json:"range_to"`
+	ProjectIds []pgtype.UUID      `json:"project_ids"`
+}
+
+type LeadTimeStatsRow struct {
+	CompletedCount int64   `json:"completed_count"`
+	AverageSeconds float64 `json:"average_seconds"`
+	MedianSeconds  float64 `json:"median_seconds"`
+}
+
+// Lead time (created_at to completed_at) of tasks completed in the range;
+// both figures are 0 when there are none.
+func (q *Queries) LeadTimeStats(ctx context.Context, arg LeadTimeStatsParams) (LeadTimeStatsRow, error) {
+	row := q.db.QueryRow(ctx, leadTimeStats,
+		arg.UserID,
+		arg.RangeFrom,
+		arg.RangeTo,
+		arg.ProjectIds,
+	)
+	var i LeadTimeStatsRow
+	err := row.Scan(&i.CompletedCount, &i.AverageSeconds, &i.MedianSeconds)
+	return i, err
+}
+
+const overdueByLabel = `-- name: OverdueByLabel :many
+SELECT
+  l.id, l.user_id, l.name, l.created_at, l.updated_at, l.deleted_at,
+  COUNT(*) AS due_count,
+  COUNT(*) FILTER (WHERE t.completed_at IS NULL OR t.completed_at > t.due_at) AS overdue_count
+FROM tasks t
+JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+JOIN task_labels tl ON tl.task_id = t.id
+JOIN labels l ON l.id = tl.label_id AND l.deleted_at IS NULL
+WHERE t.user_id = $1
+  AND t.deleted_at IS NULL
+  AND t.due_at >= $2::timestamptz
+  AND t.due_at < LEAST($3::timestamptz, NOW())
+  AND (cardinality($4::uuid[]) = 0 OR t.project_id = ANY($4::uuid[]))
+GROUP BY l.id
+ORDER BY l.name, l.id
+`
+
+type OverdueByLabelParams struct {
+	UserID     pgtype.UUID        `json:"user_id"`
+	RangeFrom  pgtype.Timestamptz `json:"range_from"`
+	RangeTo    pgtype.Timestamptz `json:"range_to"`
+	ProjectIds []pgtype.UUID      `json:"project_ids"`
+}
+
+type OverdueByLabelRow struct {
+	Label        Label `json:"label"`
+	DueCount     int64 `json:"due_count"`
+	OverdueCount int64 `json:"overdue_count"`
+}
+
+// OverdueByPriority per label; a task counts toward each of its labels.
+func (q *Queries) OverdueByLabel(ctx context.Context, arg OverdueByLabelParams) ([]OverdueByLabelRow, error) {
+	rows, err := q.db.Query(ctx, overdueByLabel,
+		arg.UserID,
+		arg.RangeFrom,
+		arg.RangeTo,
+		arg.ProjectIds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OverdueByLabelRow{}
+	for rows.Next() {
+		var i OverdueByLabelRow
+		if err := rows.Scan(
+			&i.Label.ID,
+			&i.Label.UserID,
+			&i.Label.Name,
+			&i.Label.CreatedAt,
+			&i.Label.UpdatedAt,
+			&i.Label.DeletedAt,
+			&i.DueCount,
+			&i.OverdueCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const overdueByPriority = `-- name: OverdueByPriority :many
+SELECT
+  t.priority,
+  COUNT(*) AS due_count,
+  COUNT(*) FILTER (WHERE t.completed_at IS NULL OR t.completed_at > t.due_at) AS overdue_count
+FROM tasks t
+JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+WHERE t.user_id = $1
+  AND t.deleted_at IS NULL
+  AND t.due_at >= $2::timestamptz
+  AND t.due_at < LEAST($3::timestamptz, NOW())
+  AND (cardinality($4::uuid[]) = 0 OR t.project_id = ANY($4::uuid[]))
+GROUP BY t.priority
+ORDER BY t.priority
+`
+
+type OverdueByPriorityParams struct {
+	UserID     pgtype.UUID        `json:"user_id"`
+	RangeFrom  pgtype.Timestamptz `json:"range_from"`
+	RangeTo    pgtype.Timestamptz `json:"range_to"`
+	ProjectIds []pgtype.UUID      `json:"project_ids"`
+}
+
+type OverdueByPriorityRow struct {
+	Priority     string `json:"priority"`
+	DueCount     int64  `json:"due_count"`
+	OverdueCount int64  `json:"overdue_count"`
+}
+
+// Tasks due in the range, up to now, and how many of them were completed
+// late or are still open.
+func (q *Queries) OverdueByPriority(ctx context.Context, arg OverdueByPriorityParams) ([]OverdueByPriorityRow, error) {
+	rows, err := q.db.Query(ctx, overdueByPriority,
+		arg.UserID,
+		arg.RangeFrom,
+		arg.RangeTo,
+		arg.ProjectIds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OverdueByPriorityRow{}
+	for rows.Next() {
+		var i OverdueByPriorityRow
+		if err := rows.Scan(&i.Priority, &i.DueCount, &i.OverdueCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const projectBurndown = `-- name: ProjectBurndown :many
+WITH buckets AS (
+  SELECT
+    bucket_start,
+    LEAST((bucket_start + ('1 ' || $5::text)::interval) AT TIME ZONE $4::text, $6::timestamptz) AS bucket_end
+  FROM generate_series(
+    date_trunc($5::text, $2::timestamptz AT TIME ZONE $4::text),
+    ($6::timestamptz AT TIME ZONE $4::text) - INTERVAL '1 microsecond',
+    ('1 ' || $5::text)::interval
+  ) AS bucket_start
+)
+SELECT
+  p.id, p.user_id, p.title, p.description, p.color, p.created_at, p.updated_at, p.deleted_at, p.archived_at, p.auto_complete_parents,
+  b.bucket_start::date AS bucket,
+  COUNT(t.id) AS task_count
+FROM projects p
+CROSS JOIN buckets b
+LEFT JOIN tasks t
+  ON t.project_id = p.id
+  AND t.deleted_at IS NULL
+  AND t.created_at < b.bucket_end
+  AND (t.completed_at IS NULL OR t.completed_at >= b.bucket_end)
+WHERE p.user_id = $1
+  AND p.deleted_at IS NULL
+  AND (p.archived_at IS NULL OR p.archived_at >= $2::timestamptz)
+  AND (cardinality($3::uuid[]) = 0 OR p.id = ANY($3::uuid[]))
+GROUP BY p.id, b.bucket_start
+ORDER BY p.created_at, p.id, b.bucket_start
+`
+
+type ProjectBurndownParams struct {
+	UserID     pgtype.UUID        `json:"user_id"`
+	RangeFrom  pgtype.Timestamptz `json:"range_from"`
+	ProjectIds []pgtype.UUID      `json:"project_ids"`
+	Tz         string             `json:"tz"`
+	Bucket     string             `json:"bucket"`
+	RangeTo    pgtype.Timestamptz `json:"range_to"`
+}
+
+type ProjectBurndownRow struct {
+	Project   Project     `json:"project"`
+	Bucket    pgtype.Date `json:"bucket"`
+	TaskCount int64       `json:"task_count"`
+}
+
+// Open tasks of each project at the end of each bucket (or at range_to for
+// the last one). A task is open from its creation until its completion.
+// Projects archived before the range are left out.
+func (q *Queries) ProjectBurndown(ctx context.Context, arg ProjectBurndownParams) ([]ProjectBurndownRow, error) {
+	rows, err := q.db.Query(ctx, projectBurndown,
+		arg.UserID,
+		arg.RangeFrom,
+		arg.ProjectIds,
+		arg.Tz,
+		arg.Bucket,
+		arg.RangeTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProjectBurndownRow{}
+	for rows.Next() {
+		var i ProjectBurndownRow
+		if err := rows.Scan(
+			&i.Project.ID,
+			&i.Project.UserID,
+			&i.Project.Title,
+			&i.Project.Description,
+			&i.Project.Color,
+			&i.Project.CreatedAt,
+			&i.Project.UpdatedAt,
+			&i.Project.DeletedAt,
+			&i.Project.ArchivedAt,
+			&i.Project.AutoCompleteParents,
+			&i.Bucket,
+			&i.TaskCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const timeInStatus = `-- name: TimeInStatus :many
+WITH spans AS (
+  SELECT
+    c.task_id,
+    c.status,
+    c.changed_at AS started_at,
+    COALESCE(LEAD(c.changed_at) OVER (PARTITION BY c.task_id ORDER BY c.changed_at, c.id), NOW()) AS ended_at
+  FROM task_status_changes c
+  JOIN tasks t ON t.id = c.task_id AND t.deleted_at IS NULL
+  JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+  WHERE c.user_id = $3
+    AND (cardinality($4::uuid[]) = 0 OR t.project_id = ANY($4::uuid[]))
+)
+SELECT
+  status,
+  COUNT(DISTINCT task_id) AS task_count,
+  SUM(EXTRACT(EPOCH FROM LEAST(ended_at, $1::timestamptz) - GREATEST(started_at, $2::timestamptz)))::float8 AS total_seconds
+FROM spans
+WHERE started_at < $1::timestamptz
+  AND ended_at > $2::timestamptz
+GROUP BY status
+ORDER BY status
+`
+
+type TimeInStatusParams struct {
+	RangeTo    pgtype.Timestamptz `json:"range_to"`
+	RangeFrom  pgtype.Timestamptz `json:"range_from"`
+	UserID     pgtype.UUID        `json:"user_id"`
+	ProjectIds []pgtype.UUID      `json:"project_ids"`
+}
+
+type TimeInStatusRow struct {
+	Status       string  `json:"status"`
+	TaskCount    int64   `json:"task_count"`
+	TotalSeconds float64 `json:"total_seconds"`
+}
+
+// Time spent in each status category within the range. A task stays in a
+// status until its next change, or until now.
+func (q *Queries) TimeInStatus(ctx context.Context, arg TimeInStatusParams) ([]TimeInStatusRow, error) {
+	rows, err := q.db.Query(ctx, timeInStatus,
+		arg.RangeTo,
+		arg.RangeFrom,
+		arg.UserID,
+		arg.ProjectIds,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TimeInStatusRow{}
+	for rows.Next() {
+		var i TimeInStatusRow
+		if err := rows.Scan(&i.Status, &i.TaskCount, &i.TotalSeconds); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,366 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: calendar.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createCalendarFeed = `-- name: CreateCalendarFeed :one
+INSERT INTO calendar_feeds (user_id, project_id, token_hash)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, project_id, token_hash, created_at, revoked_at
+`
+
+type CreateCalendarFeedParams struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	ProjectID pgtype.UUID `json:"project_id"`
+	TokenHash []byte      `json:"token_hash"`
+}
+
+func (q *Queries) CreateCalendarFeed(ctx context.Context, arg CreateCalendarFeedParams) (CalendarFeed, error) {
+	row := q.db.QueryRow(ctx, createCalendarFeed, arg.UserID, arg.ProjectID, arg.TokenHash)
+	var i CalendarFeed
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.TokenHash,
+		&i.CreatedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getCalendarFeedByID = `-- name: GetCalendarFeedByID :one
+SELECT id, user_id, project_id, token_hash, created_at, revoked_at
+FROM calendar_feeds
+WHERE id = $1
+  AND user_id = $2
+  AND revoked_at IS NULL
+LIMIT 1
+`
+
+type GetCalendarFeedByIDParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetCalendarFeedByID(ctx context.Context, arg GetCalendarFeedByIDParams) (CalendarFeed, error) {
+	row := q.db.QueryRow(ctx, getCalendarFeedByID, arg.ID, arg.UserID)
+	var i CalendarFeed
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.TokenHash,
+		&i.CreatedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const getCalendarFeedByTokenHash = `-- name: GetCalendarFeedByTokenHash :one
+SELECT id, user_id, project_id, token_hash, created_at, revoked_at
+FROM calendar_feeds
+WHERE token_hash = $1
+  AND revoked_at IS NULL
+LIMIT 1
+`
+
+func (q *Queries) GetCalendarFeedByTokenHash(ctx context.Context, tokenHash []byte) (CalendarFeed, error) {
+	row := q.db.QueryRow(ctx, getCalendarFeedByTokenHash, tokenHash)
+	var i CalendarFeed
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.TokenHash,
+		&i.CreatedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
+const listCalendarFeeds = `-- name: ListCalendarFeeds :many
+SELECT id, user_id, project_id, token_hash, created_at, revoked_at
+FROM calendar_feeds
+WHERE user_id = $1
+  AND revoked_at IS NULL
+ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) ListCalendarFeeds(ctx context.Context, userID pgtype.UUID) ([]CalendarFeed, error) {
+	rows, err := q.db.Query(ctx, listCalendarFeeds, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CalendarFeed{}
+	for rows.Next() {
+		var i CalendarFeed
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.TokenHash,
+			&i.CreatedAt,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listLabelNamesByTaskIDs = `-- name: ListLabelNamesByTaskIDs :many
+SELECT tl.task_id, l.name
+FROM task_labels tl
+JOIN labels l ON l.id = tl.label_id
+WHERE tl.task_id = ANY($1::uuid[])
+  AND l.user_id = $2
+  AND l.deleted_at IS NULL
+ORDER BY l.name
+`
+
+type ListLabelNamesByTaskIDsParams struct {
+	Column1 []pgtype.UUID `json:"column_1"`
+	UserID  pgtype.UUID   `json:"user_id"`
+}
+
+type ListLabelNamesByTaskIDsRow struct {
+	TaskID pgtype.UUID `json:"task_id"`
+	Name   string      `json:"name"`
+}
+
+func (q *Queries) ListLabelNamesByTaskIDs(ctx context.Context, arg ListLabelNamesByTaskIDsParams) ([]ListLabelNamesByTaskIDsRow, error) {
+	rows, err := q.db.Query(ctx, listLabelNamesByTaskIDs, arg.Column1, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListLabelNamesByTaskIDsRow{}
+	for rows.Next() {
+		var i ListLabelNamesByTaskIDsRow
+		if err := rows.Scan(&i.TaskID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectSyncStates = `-- name: ListProjectSyncStates :many
+SELECT
+  p.id,
+  p.title,
+  p.description,
+  p.color,
+  COUNT(t.id) FILTER (WHERE t.deleted_at IS NULL)::bigint AS task_count,
+  GREATEST(
+    p.updated_at,
+    MAX(t.updated_at),
+    (SELECT MAX(l.updated_at) FROM labels l WHERE l.user_id = p.user_id),
+    (SELECT u.updated_at FROM users u WHERE u.id = p.user_id)
+  )::timestamptz AS last_modified
+FROM projects p
+LEFT JOIN tasks t ON t.project_id = p.id
+WHERE p.user_id = $1
+  AND p.deleted_at IS NULL
+GROUP BY p.id
+ORDER BY p.created_at, p.id
+`
+
+type ListProjectSyncStatesRow struct {
+	ID           pgtype.UUID        `json:"id"`
+	Title        string             `json:"title"`
+	Description  *string            `json:"description"`
+	Color        *string            `json:"color"`
+	TaskCount    int64              `json:"task_count"`
+	LastModified pgtype.Timestamptz `json:"last_modified"`
+}
+
+// last_modified moves whenever a rendered task could change: soft deletes
+// bump tasks.updated_at, label renames show up in CATEGORIES and the user's
+// timezone is used for every date.
+func (q *Queries) ListProjectSyncStates(ctx context.Context, userID pgtype.UUID) ([]ListProjectSyncStatesRow, error) {
+	rows, err := q.db.Query(ctx, listProjectSyncStates, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProjectSyncStatesRow{}
+	for rows.Next() {
+		var i ListProjectSyncStatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Description,
+			&i.Color,
+			&i.TaskCount,
+			&i.LastModified,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectTasks = `-- name: ListProjectTasks :many
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM tasks
+WHERE user_id = $1
+  AND project_id = $2
+  AND deleted_at IS NULL
+ORDER BY created_at, id
+LIMIT $3
+`
+
+type ListProjectTasksParams struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	ProjectID pgtype.UUID `json:"project_id"`
+	Limit     int32       `json:"limit"`
+}
+
+func (q *Queries) ListProjectTasks(ctx context.Context, arg ListProjectTasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listProjectTasks, arg.UserID, arg.ProjectID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScheduledTasks = `-- name: ListScheduledTasks :many
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM tasks
+WHERE user_id = $1
+  AND deleted_at IS NULL
+  AND (start_at IS NOT NULL OR due_at IS NOT NULL)
+  AND (NOT $2::boolean OR project_id = $3::uuid)
+ORDER BY COALESCE(due_at, start_at) DESC, id DESC
+LIMIT $4
+`
+
+type ListScheduledTasksParams struct {
+	UserID  pgtype.UUID `json:"user_id"`
+	Column2 bool        `json:"column_2"`
+	Column3 pgtype.UUID `json:"column_3"`
+	Limit   int32       `json:"limit"`
+}
+
+func (q *Queries) ListScheduledTasks(ctx context.Context, arg ListScheduledTasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listScheduledTasks,
+		arg.UserID,
+		arg.Column2,
+		arg.Column3,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeCalendarFeed = `-- name: RevokeCalendarFeed :one
+UPDATE calendar_feeds
+SET revoked_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND revoked_at IS NULL
+RETURNING id, revoked_at
+`
+
+type RevokeCalendarFeedParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type RevokeCalendarFeedRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+}
+
+func (q *Queries) RevokeCalendarFeed(ctx context.Context, arg RevokeCalendarFeedParams) (RevokeCalendarFeedRow, error) {
+	row := q.db.QueryRow(ctx, revokeCalendarFeed, arg.ID, arg.UserID)
+	var i RevokeCalendarFeedRow
+	err := row.Scan(&i.ID, &i.RevokedAt)
+	return i, err
+}
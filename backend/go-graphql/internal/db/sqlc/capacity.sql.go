@@ -0,0 +1,301 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: capacity.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getUserCapacity = `-- name: GetUserCapacity :one
+SELECT user_id, weekly_minutes, work_days, updated_at, workday_start, workday_end
+FROM user_capacity
+WHERE user_id = $1
+`
+
+func (q *Queries) GetUserCapacity(ctx context.Context, userID pgtype.UUID) (UserCapacity, error) {
+	row := q.db.QueryRow(ctx, getUserCapacity, userID)
+	var i UserCapacity
+	err := row.Scan(
+		&i.UserID,
+		&i.WeeklyMinutes,
+		&i.WorkDays,
+		&i.UpdatedAt,
+		&i.WorkdayStart,
+		&i.WorkdayEnd,
+	)
+	return i, err
+}
+
+const listEstimatedTasksInRange = `-- name: ListEstimatedTasksInRange :many
+SELECT t.id, t.user_id, t.project_id, t.parent_task_id, t.title, t.description, t.status, t.priority, t.start_at, t.due_at, t.completed_at, t.created_at, t.updated_at, t.deleted_at, t.section_id, t.status_id, t.blocked_reason, t.estimate_minutes
+FROM tasks t
+JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL AND p.archived_at IS NULL
+WHERE t.user_id = $1
+  AND t.deleted_at IS NULL
+  AND t.estimate_minutes IS NOT NULL
+  AND t.status <> 'DONE'
+  AND COALESCE(t.start_at, t.due_at) < $2::timestamptz
+  AND COALESCE(t.due_at, t.start_at) >= $3::timestamptz
+ORDER BY COALESCE(t.start_at, t.due_at), t.id
+`
+
+type ListEstimatedTasksInRangeParams struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	RangeTo   pgtype.Timestamptz `json:"range_to"`
+	RangeFrom pgtype.Timestamptz `json:"range_from"`
+}
+
+// Open, estimated tasks of live, unarchived projects whose schedule
+// (start_at to due_at, either alone) overlaps [range_from, range_to).
+func (q *Queries) ListEstimatedTasksInRange(ctx context.Context, arg ListEstimatedTasksInRangeParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listEstimatedTasksInRange, arg.UserID, arg.RangeTo, arg.RangeFrom)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listScheduledTasksInRange = `-- name: ListScheduledTasksInRange :many
+SELECT t.id, t.user_id, t.project_id, t.parent_task_id, t.title, t.description, t.status, t.priority, t.start_at, t.due_at, t.completed_at, t.created_at, t.updated_at, t.deleted_at, t.section_id, t.status_id, t.blocked_reason, t.estimate_minutes
+FROM tasks t
+JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL AND p.archived_at IS NULL
+WHERE t.user_id = $1
+  AND t.deleted_at IS NULL
+  AND t.status <> 'DONE'
+  AND t.start_at < $2::timestamptz
+  AND t.start_at + make_interval(mins => COALESCE(t.estimate_minutes, $3::int)) > $4::timestamptz
+ORDER BY t.start_at, t.id
+`
+
+type ListScheduledTasksInRangeParams struct {
+	UserID         pgtype.UUID        `json:"user_id"`
+	RangeTo        pgtype.Timestamptz `json:"range_to"`
+	DefaultMinutes int32              `json:"default_minutes"`
+	RangeFrom      pgtype.Timestamptz `json:"range_from"`
+}
+
+// Open tasks of live, unarchived projects that start before range_to and,
+// taking default_minutes for tasks without an estimate, end after range_from.
+func (q *Queries) ListScheduledTasksInRange(ctx context.Context, arg ListScheduledTasksInRangeParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listScheduledTasksInRange,
+		arg.UserID,
+		arg.RangeTo,
+		arg.DefaultMinutes,
+		arg.RangeFrom,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listUnscheduledTasks = `-- name: ListUnscheduledTasks :many
+SELECT t.id, t.user_id, t.project_id, t.parent_task_id, t.title, t.description, t.status, t.priority, t.start_at, t.due_at, t.completed_at, t.created_at, t.updated_at, t.deleted_at, t.section_id, t.status_id, t.blocked_reason, t.estimate_minutes
+FROM tasks t
+JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL AND p.archived_at IS NULL
+WHERE t.user_id = $1
+  AND t.deleted_at IS NULL
+  AND t.start_at IS NULL
+  AND t.status <> 'DONE'
+  AND (cardinality($2::uuid[]) = 0 OR t.project_id = ANY($2::uuid[]))
+ORDER BY t.due_at NULLS LAST, t.priority, t.created_at, t.id
+`
+
+type ListUnscheduledTasksParams struct {
+	UserID     pgtype.UUID   `json:"user_id"`
+	ProjectIds []pgtype.UUID `json:"project_ids"`
+}
+
+// Open tasks without a start of live, unarchived projects, optionally limited
+// to project_ids.
+func (q *Queries) ListUnscheduledTasks(ctx context.Context, arg ListUnscheduledTasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listUnscheduledTasks, arg.UserID, arg.ProjectIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const scheduleTask = `-- name: ScheduleTask :one
+UPDATE tasks
+SET
+  start_at = $3,
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+  AND start_at IS NULL
+RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+`
+
+type ScheduleTaskParams struct {
+	ID      pgtype.UUID        `json:"id"`
+	UserID  pgtype.UUID        `json:"user_id"`
+	StartAt pgtype.Timestamptz `json:"start_at"`
+}
+
+// Sets the start of a task that is still unscheduled.
+func (q *Queries) ScheduleTask(ctx context.Context, arg ScheduleTaskParams) (Task, error) {
+	row := q.db.QueryRow(ctx, scheduleTask, arg.ID, arg.UserID, arg.StartAt)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.ParentTaskID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.Priority,
+		&i.StartAt,
+		&i.DueAt,
+		&i.CompletedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SectionID,
+		&i.StatusID,
+		&i.BlockedReason,
+		&i.EstimateMinutes,
+	)
+	return i, err
+}
+
+const upsertUserCapacity = `-- name: UpsertUserCapacity :one
+INSERT INTO user_capacity (user_id, weekly_minutes, work_days, workday_start, workday_end)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id) DO UPDATE
+SET
+  weekly_minutes = EXCLUDED.weekly_minutes,
+  work_days = EXCLUDED.work_days,
+  workday_start = EXCLUDED.workday_start,
+  workday_end = EXCLUDED.workday_end,
+  updated_at = NOW()
+RETURNING user_id, weekly_minutes, work_days, updated_at, workday_start, workday_end
+`
+
+type UpsertUserCapacityParams struct {
+	UserID        pgtype.UUID `json:"user_id"`
+	WeeklyMinutes int32       `json:"weekly_minutes"`
+	WorkDays      []int16     `json:"work_days"`
+	WorkdayStart  int16       `json:"workday_start"`
+	WorkdayEnd    int16       `json:"workday_end"`
+}
+
+func (q *Queries) UpsertUserCapacity(ctx context.Context, arg UpsertUserCapacityParams) (UserCapacity, error) {
+	row := q.db.QueryRow(ctx, upsertUserCapacity,
+		arg.UserID,
+		arg.WeeklyMinutes,
+		arg.WorkDays,
+		arg.WorkdayStart,
+		arg.WorkdayEnd,
+	)
+	var i UserCapacity
+	err := row.Scan(
+		&i.UserID,
+		&i.WeeklyMinutes,
+		&i.WorkDays,
+		&i.UpdatedAt,
+		&i.WorkdayStart,
+		&i.WorkdayEnd,
+	)
+	return i, err
+}
@@ -0,0 +1,450 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: custom_fields.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countCustomFields = `-- name: CountCustomFields :one
+SELECT COUNT(*)
+FROM custom_fields
+WHERE project_id = $1
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) CountCustomFields(ctx context.Context, projectID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countCustomFields, projectID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countLiveTasksWithValueOutside = `-- name: CountLiveTasksWithValueOutside :one
+SELECT COUNT(*)
+FROM task_custom_field_values v
+JOIN tasks t ON t.id = v.task_id
+WHERE v.field_id = $1
+  AND t.deleted_at IS NULL
+  AND v.value <> ALL($2::text[])
+`
+
+type CountLiveTasksWithValueOutsideParams struct {
+	FieldID pgtype.UUID `json:"field_id"`
+	Options []string    `json:"options"`
+}
+
+// Counts live tasks whose value for a SELECT field is not one of options.
+func (q *Queries) CountLiveTasksWithValueOutside(ctx context.Context, arg CountLiveTasksWithValueOutsideParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countLiveTasksWithValueOutside, arg.FieldID, arg.Options)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createCustomField = `-- name: CreateCustomField :one
+INSERT INTO custom_fields (user_id, project_id, name, field_type, options, required, position)
+SELECT $1, $2, $3, $4, $5, $6, COALESCE(MAX(position) + 1, 0)
+FROM custom_fields
+WHERE project_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, name, field_type, options, required, position, created_at, updated_at, deleted_at
+`
+
+type CreateCustomFieldParams struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	ProjectID pgtype.UUID `json:"project_id"`
+	Name      string      `json:"name"`
+	FieldType string      `json:"field_type"`
+	Options   []string    `json:"options"`
+	Required  bool        `json:"required"`
+}
+
+// New fields go last.
+func (q *Queries) CreateCustomField(ctx context.Context, arg CreateCustomFieldParams) (CustomField, error) {
+	row := q.db.QueryRow(ctx, createCustomField,
+		arg.UserID,
+		arg.ProjectID,
+		arg.Name,
+		arg.FieldType,
+		arg.Options,
+		arg.Required,
+	)
+	var i CustomField
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.Name,
+		&i.FieldType,
+		&i.Options,
+		&i.Required,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const deleteCustomFieldValues = `-- name: DeleteCustomFieldValues :exec
+DELETE FROM task_custom_field_values
+WHERE field_id = $1
+`
+
+func (q *Queries) DeleteCustomFieldValues(ctx context.Context, fieldID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, deleteCustomFieldValues, fieldID)
+	return err
+}
+
+const deleteCustomFieldValuesOutside = `-- name: DeleteCustomFieldValuesOutside :exec
+DELETE FROM task_custom_field_values
+WHERE field_id = $1
+  AND value <> ALL($2::text[])
+`
+
+type DeleteCustomFieldValuesOutsideParams struct {
+	FieldID pgtype.UUID `json:"field_id"`
+	Options []string    `json:"options"`
+}
+
+func (q *Queries) DeleteCustomFieldValuesOutside(ctx context.Context, arg DeleteCustomFieldValuesOutsideParams) error {
+	_, err := q.db.Exec(ctx, deleteCustomFieldValuesOutside, arg.FieldID, arg.Options)
+	return err
+}
+
+const deleteTaskCustomFieldValue = `-- name: DeleteTaskCustomFieldValue :exec
+DELETE FROM task_custom_field_values
+WHERE task_id = $1
+  AND field_id = $2
+`
+
+type DeleteTaskCustomFieldValueParams struct {
+	TaskID  pgtype.UUID `json:"task_id"`
+	FieldID pgtype.UUID `json:"field_id"`
+}
+
+func (q *Queries) DeleteTaskCustomFieldValue(ctx context.Context, arg DeleteTaskCustomFieldValueParams) error {
+	_, err := q.db.Exec(ctx, deleteTaskCustomFieldValue, arg.TaskID, arg.FieldID)
+	return err
+}
+
+const getCustomFieldByID = `-- name: GetCustomFieldByID :one
+SELECT id, user_id, project_id, name, field_type, options, required, position, created_at, updated_at, deleted_at
+FROM custom_fields
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+LIMIT 1
+`
+
+type GetCustomFieldByIDParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetCustomFieldByID(ctx context.Context, arg GetCustomFieldByIDParams) (CustomField, error) {
+	row := q.db.QueryRow(ctx, getCustomFieldByID, arg.ID, arg.UserID)
+	var i CustomField
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.Name,
+		&i.FieldType,
+		&i.Options,
+		&i.Required,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const listCustomFields = `-- name: ListCustomFields :many
+SELECT id, user_id, project_id, name, field_type, options, required, position, created_at, updated_at, deleted_at
+FROM custom_fields
+WHERE project_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY position, id
+`
+
+type ListCustomFieldsParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) ListCustomFields(ctx context.Context, arg ListCustomFieldsParams) ([]CustomField, error) {
+	rows, err := q.db.Query(ctx, listCustomFields, arg.ProjectID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CustomField{}
+	for rows.Next() {
+		var i CustomField
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.Name,
+			&i.FieldType,
+			&i.Options,
+			&i.Required,
+			&i.Position,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTaskCustomFieldValues = `-- name: ListTaskCustomFieldValues :many
+SELECT v.task_id, v.value, f.id, f.user_id, f.project_id, f.name, f.field_type, f.options, f.required, f.position, f.created_at, f.updated_at, f.deleted_at
+FROM task_custom_field_values v
+JOIN custom_fields f ON f.id = v.field_id
+WHERE v.task_id = ANY($1::uuid[])
+  AND f.user_id = $2
+  AND f.deleted_at IS NULL
+ORDER BY v.task_id, f.position, f.id
+`
+
+type ListTaskCustomFieldValuesParams struct {
+	TaskIds []pgtype.UUID `json:"task_ids"`
+	UserID  pgtype.UUID   `json:"user_id"`
+}
+
+type ListTaskCustomFieldValuesRow struct {
+	TaskID      pgtype.UUID `json:"task_id"`
+	Value       string      `json:"value"`
+	CustomField CustomField `json:"custom_field"`
+}
+
+// Values of live fields for several tasks, in field order.
+func (q *Queries) ListTaskCustomFieldValues(ctx context.Context, arg ListTaskCustomFieldValuesParams) ([]ListTaskCustomFieldValuesRow, error) {
+	rows, err := q.db.Query(ctx, listTaskCustomFieldValues, arg.TaskIds, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTaskCustomFieldValuesRow{}
+	for rows.Next() {
+		var i ListTaskCustomFieldValuesRow
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.Value,
+			&i.CustomField.ID,
+			&i.CustomField.UserID,
+			&i.CustomField.ProjectID,
+			&i.CustomField.Name,
+			&i.CustomField.FieldType,
+			&i.CustomField.Options,
+			&i.CustomField.Required,
+			&i.CustomField.Position,
+			&i.CustomField.CreatedAt,
+			&i.CustomField.UpdatedAt,
+			&i.CustomField.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockCustomFields = `-- name: LockCustomFields :exec
+SELECT pg_advisory_xact_lock(hashtextextended('custom_fields:' || $1::uuid::text, 0))
+`
+
+// Serialises position changes within one project for the rest of the
+// transaction.
+func (q *Queries) LockCustomFields(ctx context.Context, projectID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, lockCustomFields, projectID)
+	return err
+}
+
+const shiftCustomFields = `-- name: ShiftCustomFields :exec
+UPDATE custom_fields
+SET
+  position = position + $1::int,
+  updated_at = NOW()
+WHERE project_id = $2
+  AND deleted_at IS NULL
+  AND position BETWEEN $3::int AND $4::int
+`
+
+type ShiftCustomFieldsParams struct {
+	Delta        int32       `json:"delta"`
+	ProjectID    pgtype.UUID `json:"project_id"`
+	FromPosition int32       `json:"from_position"`
+	ToPosition   int32       `json:"to_position"`
+}
+
+// Moves the fields with position in [from_position, to_position] by delta
+// (+1 or -1) to open or close a gap.
+func (q *Queries) ShiftCustomFields(ctx context.Context, arg ShiftCustomFieldsParams) error {
+	_, err := q.db.Exec(ctx, shiftCustomFields,
+		arg.Delta,
+		arg.ProjectID,
+		arg.FromPosition,
+		arg.ToPosition,
+	)
+	return err
+}
+
+const softDeleteCustomField = `-- name: SoftDeleteCustomField :one
+UPDATE custom_fields
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, project_id, position, deleted_at
+`
+
+type SoftDeleteCustomFieldParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type SoftDeleteCustomFieldRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	ProjectID pgtype.UUID        `json:"project_id"`
+	Position  int32              `json:"position"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+func (q *Queries) SoftDeleteCustomField(ctx context.Context, arg SoftDeleteCustomFieldParams) (SoftDeleteCustomFieldRow, error) {
+	row := q.db.QueryRow(ctx, softDeleteCustomField, arg.ID, arg.UserID)
+	var i SoftDeleteCustomFieldRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Position,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const softDeleteCustomFieldsByProject = `-- name: SoftDeleteCustomFieldsByProject :execrows
+UPDATE custom_fields
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE project_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+`
+
+type SoftDeleteCustomFieldsByProjectParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) SoftDeleteCustomFieldsByProject(ctx context.Context, arg SoftDeleteCustomFieldsByProjectParams) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteCustomFieldsByProject, arg.ProjectID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateCustomField = `-- name: UpdateCustomField :one
+UPDATE custom_fields
+SET
+  name = $3,
+  options = $4,
+  required = $5,
+  position = $6,
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, name, field_type, options, required, position, created_at, updated_at, deleted_at
+`
+
+type UpdateCustomFieldParams struct {
+	ID       pgtype.UUID `json:"id"`
+	UserID   pgtype.UUID `json:"user_id"`
+	Name     string      `json:"name"`
+	Options  []string    `json:"options"`
+	Required bool        `json:"required"`
+	Position int32       `json:"position"`
+}
+
+func (q *Queries) UpdateCustomField(ctx context.Context, arg UpdateCustomFieldParams) (CustomField, error) {
+	row := q.db.QueryRow(ctx, updateCustomField,
+		arg.ID,
+		arg.UserID,
+		arg.Name,
+		arg.Options,
+		arg.Required,
+		arg.Position,
+	)
+	var i CustomField
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.Name,
+		&i.FieldType,
+		&i.Options,
+		&i.Required,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const upsertTaskCustomFieldValue = `-- name: UpsertTaskCustomFieldValue :exec
+INSERT INTO task_custom_field_values (task_id, field_id, value, number_value, date_value, bool_value)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (task_id, field_id) DO UPDATE
+SET
+  value = EXCLUDED.value,
+  number_value = EXCLUDED.number_value,
+  date_value = EXCLUDED.date_value,
+  bool_value = EXCLUDED.bool_value,
+  updated_at = NOW()
+`
+
+type UpsertTaskCustomFieldValueParams struct {
+	TaskID      pgtype.UUID `json:"task_id"`
+	FieldID     pgtype.UUID `json:"field_id"`
+	Value       string      `json:"value"`
+	NumberValue *float64    `json:"number_value"`
+	DateValue   pgtype.Date `json:"date_value"`
+	BoolValue   *bool       `json:"bool_value"`
+}
+
+func (q *Queries) UpsertTaskCustomFieldValue(ctx context.Context, arg UpsertTaskCustomFieldValueParams) error {
+	_, err := q.db.Exec(ctx, upsertTaskCustomFieldValue,
+		arg.TaskID,
+		arg.FieldID,
+		arg.Value,
+		arg.NumberValue,
+		arg.DateValue,
+		arg.BoolValue,
+	)
+	return err
+}
@@ -0,0 +1,326 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: export.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const exportLabels = `-- name: ExportLabels :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at
+FROM labels
+WHERE user_id = $1
+  AND deleted_at IS NULL
+  AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ExportLabelsParams struct {
+	UserID pgtype.UUID `json:"user_id"`
+	ID     pgtype.UUID `json:"id"`
+	Limit  int32       `json:"limit"`
+}
+
+func (q *Queries) ExportLabels(ctx context.Context, arg ExportLabelsParams) ([]Label, error) {
+	rows, err := q.db.Query(ctx, exportLabels, arg.UserID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Label{}
+	for rows.Next() {
+		var i Label
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportProjects = `-- name: ExportProjects :many
+SELECT id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents
+FROM projects
+WHERE user_id = $1
+  AND deleted_at IS NULL
+  AND id > $2
+ORDER BY id
+LIMIT $3
+`
+
+type ExportProjectsParams struct {
+	UserID pgtype.UUID `json:"user_id"`
+	ID     pgtype.UUID `json:"id"`
+	Limit  int32       `json:"limit"`
+}
+
+// The Export queries page by id so a full export streams in bounded batches
+// without holding a transaction open; the nil UUID starts from the beginning.
+func (q *Queries) ExportProjects(ctx context.Context, arg ExportProjectsParams) ([]Project, error) {
+	rows, err := q.db.Query(ctx, exportProjects, arg.UserID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Project{}
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Description,
+			&i.Color,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.AutoCompleteParents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportRootTasks = `-- name: ExportRootTasks :many
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM tasks
+WHERE user_id = $1
+  AND project_id = $2
+  AND parent_task_id IS NULL
+  AND deleted_at IS NULL
+  AND (created_at, id) > ($3::timestamptz, $4::uuid)
+ORDER BY created_at, id
+LIMIT $5
+`
+
+type ExportRootTasksParams struct {
+	UserID         pgtype.UUID        `json:"user_id"`
+	ProjectID      pgtype.UUID        `json:"project_id"`
+	AfterCreatedAt pgtype.Timestamptz `json:"after_created_at"`
+	AfterID        pgtype.UUID        `json:"after_id"`
+	RowLimit       int32              `json:"row_limit"`
+}
+
+func (q *Queries) ExportRootTasks(ctx context.Context, arg ExportRootTasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, exportRootTasks,
+		arg.UserID,
+		arg.ProjectID,
+		arg.AfterCreatedAt,
+		arg.AfterID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportTaskLabels = `-- name: ExportTaskLabels :many
+SELECT tl.task_id, tl.label_id
+FROM task_labels tl
+JOIN tasks t ON t.id = tl.task_id
+JOIN projects p ON p.id = t.project_id
+JOIN labels l ON l.id = tl.label_id
+WHERE t.user_id = $1
+  AND t.deleted_at IS NULL
+  AND p.deleted_at IS NULL
+  AND l.deleted_at IS NULL
+  AND (tl.task_id, tl.label_id) > ($2::uuid, $3::uuid)
+ORDER BY tl.task_id, tl.label_id
+LIMIT $4
+`
+
+type ExportTaskLabelsParams struct {
+	UserID       pgtype.UUID `json:"user_id"`
+	AfterTaskID  pgtype.UUID `json:"after_task_id"`
+	AfterLabelID pgtype.UUID `json:"after_label_id"`
+	RowLimit     int32       `json:"row_limit"`
+}
+
+func (q *Queries) ExportTaskLabels(ctx context.Context, arg ExportTaskLabelsParams) ([]TaskLabel, error) {
+	rows, err := q.db.Query(ctx, exportTaskLabels,
+		arg.UserID,
+		arg.AfterTaskID,
+		arg.AfterLabelID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TaskLabel{}
+	for rows.Next() {
+		var i TaskLabel
+		if err := rows.Scan(&i.TaskID, &i.LabelID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const exportTasks = `-- name: ExportTasks :many
+SELECT t.id, t.user_id, t.project_id, t.parent_task_id, t.title, t.description, t.status, t.priority, t.start_at, t.due_at, t.completed_at, t.created_at, t.updated_at, t.deleted_at, t.section_id, t.status_id, t.blocked_reason, t.estimate_minutes
+FROM tasks t
+JOIN projects p ON p.id = t.project_id
+WHERE t.user_id = $1
+  AND t.deleted_at IS NULL
+  AND p.deleted_at IS NULL
+  AND t.id > $2
+ORDER BY t.id
+LIMIT $3
+`
+
+type ExportTasksParams struct {
+	UserID pgtype.UUID `json:"user_id"`
+	ID     pgtype.UUID `json:"id"`
+	Limit  int32       `json:"limit"`
+}
+
+func (q *Queries) ExportTasks(ctx context.Context, arg ExportTasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, exportTasks, arg.UserID, arg.ID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubtasksByParentIDs = `-- name: ListSubtasksByParentIDs :many
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM tasks
+WHERE user_id = $1
+  AND parent_task_id = ANY($2::uuid[])
+  AND deleted_at IS NULL
+ORDER BY parent_task_id, created_at, id
+`
+
+type ListSubtasksByParentIDsParams struct {
+	UserID  pgtype.UUID   `json:"user_id"`
+	Column2 []pgtype.UUID `json:"column_2"`
+}
+
+func (q *Queries) ListSubtasksByParentIDs(ctx context.Context, arg ListSubtasksByParentIDsParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listSubtasksByParentIDs, arg.UserID, arg.Column2)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
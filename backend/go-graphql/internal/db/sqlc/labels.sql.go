@@ -11,6 +11,20 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countLabels = `-- name: CountLabels :one
+SELECT COUNT(*)
+FROM labels
+WHERE user_id = $1
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) CountLabels(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countLabels, userID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createLabel = `-- name: CreateLabel :one
 INSERT INTO labels (user_id, name)
 VALUES ($1, $2)
@@ -118,6 +132,48 @@ func (q *Queries) GetLabelsByIDs(ctx context.Context, arg GetLabelsByIDsParams)
 	return items, nil
 }
 
+const getLabelsByNames = `-- name: GetLabelsByNames :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at
+FROM labels
+WHERE user_id = $1
+  AND LOWER(name) = ANY($2::text[])
+  AND deleted_at IS NULL
+`
+
+type GetLabelsByNamesParams struct {
+	UserID pgtype.UUID `json:"user_id"`
+	Names  []string    `json:"names"`
+}
+
+// names must be lower-cased; matching is case-insensitive like the unique
+// index.
+func (q *Queries) GetLabelsByNames(ctx context.Context, arg GetLabelsByNamesParams) ([]Label, error) {
+	rows, err := q.db.Query(ctx, getLabelsByNames, arg.UserID, arg.Names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Label{}
+	for rows.Next() {
+		var i Label
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listLabels = `-- name: ListLabels :many
 SELECT id, user_id, name, created_at, updated_at, deleted_at
 FROM labels
@@ -172,6 +228,60 @@ func (q *Queries) ListLabels(ctx context.Context, arg ListLabelsParams) ([]Label
 	return items, nil
 }
 
+const listLabelsBefore = `-- name: ListLabelsBefore :many
+SELECT id, user_id, name, created_at, updated_at, deleted_at
+FROM labels
+WHERE user_id = $1
+  AND deleted_at IS NULL
+  AND (
+    NOT $2::boolean
+    OR (created_at, id) > ($3::timestamptz, $4::uuid)
+  )
+ORDER BY created_at ASC, id ASC
+LIMIT $5
+`
+
+type ListLabelsBeforeParams struct {
+	UserID  pgtype.UUID        `json:"user_id"`
+	Column2 bool               `json:"column_2"`
+	Column3 pgtype.Timestamptz `json:"column_3"`
+	Column4 pgtype.UUID        `json:"column_4"`
+	Limit   int32              `json:"limit"`
+}
+
+func (q *Queries) ListLabelsBefore(ctx context.Context, arg ListLabelsBeforeParams) ([]Label, error) {
+	rows, err := q.db.Query(ctx, listLabelsBefore,
+		arg.UserID,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Label{}
+	for rows.Next() {
+		var i Label
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listLabelsByTaskID = `-- name: ListLabelsByTaskID :many
 SELECT l.id, l.user_id, l.name, l.created_at, l.updated_at, l.deleted_at
 FROM labels l
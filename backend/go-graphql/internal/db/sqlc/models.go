@@ -8,41 +8,140 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type Label struct {
+type CalendarFeed struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	ProjectID pgtype.UUID        `json:"project_id"`
+	TokenHash []byte             `json:"token_hash"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type CustomField struct {
 	ID        pgtype.UUID        `json:"id"`
 	UserID    pgtype.UUID        `json:"user_id"`
+	ProjectID pgtype.UUID        `json:"project_id"`
 	Name      string             `json:"name"`
+	FieldType string             `json:"field_type"`
+	Options   []string           `json:"options"`
+	Required  bool               `json:"required"`
+	Position  int32              `json:"position"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 }
 
-type Project struct {
-	ID          pgtype.UUID        `json:"id"`
-	UserID      pgtype.UUID        `json:"user_id"`
-	Title       string             `json:"title"`
-	Description *string            `json:"description"`
-	Color       *string            `json:"color"`
-	CreatedAt   pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+type Label struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 }
 
-type Task struct {
+type OutboxEvent struct {
 	ID           pgtype.UUID        `json:"id"`
 	UserID       pgtype.UUID        `json:"user_id"`
-	ProjectID    pgtype.UUID        `json:"project_id"`
-	ParentTaskID pgtype.UUID        `json:"parent_task_id"`
-	Title        string             `json:"title"`
-	Description  *string            `json:"description"`
-	Status       string             `json:"status"`
-	Priority     string             `json:"priority"`
-	StartAt      pgtype.Timestamptz `json:"start_at"`
-	DueAt        pgtype.Timestamptz `json:"due_at"`
-	CompletedAt  pgtype.Timestamptz `json:"completed_at"`
+	EventType    string             `json:"event_type"`
+	Payload      []byte             `json:"payload"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
-	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
-	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
+	DispatchedAt pgtype.Timestamptz `json:"dispatched_at"`
+}
+
+type Project struct {
+	ID                  pgtype.UUID        `json:"id"`
+	UserID              pgtype.UUID        `json:"user_id"`
+	Title               string             `json:"title"`
+	Description         *string            `json:"description"`
+	Color               *string            `json:"color"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt           pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt           pgtype.Timestamptz `json:"deleted_at"`
+	ArchivedAt          pgtype.Timestamptz `json:"archived_at"`
+	AutoCompleteParents bool               `json:"auto_complete_parents"`
+}
+
+type ProjectSection struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	ProjectID pgtype.UUID        `json:"project_id"`
+	Name      string             `json:"name"`
+	Position  int32              `json:"position"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type ProjectStatus struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	ProjectID pgtype.UUID        `json:"project_id"`
+	Name      string             `json:"name"`
+	Category  string             `json:"category"`
+	Color     *string            `json:"color"`
+	WipLimit  *int32             `json:"wip_limit"`
+	Position  int32              `json:"position"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type ProjectStatusRequirement struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	ProjectID pgtype.UUID        `json:"project_id"`
+	Status    string             `json:"status"`
+	Field     string             `json:"field"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type ProjectStatusTransition struct {
+	UserID     pgtype.UUID        `json:"user_id"`
+	ProjectID  pgtype.UUID        `json:"project_id"`
+	FromStatus string             `json:"from_status"`
+	ToStatus   string             `json:"to_status"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type SavedFilter struct {
+	ID         pgtype.UUID        `json:"id"`
+	UserID     pgtype.UUID        `json:"user_id"`
+	Name       string             `json:"name"`
+	Expression string             `json:"expression"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt  pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type Task struct {
+	ID              pgtype.UUID        `json:"id"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	ProjectID       pgtype.UUID        `json:"project_id"`
+	ParentTaskID    pgtype.UUID        `json:"parent_task_id"`
+	Title           string             `json:"title"`
+	Description     *string            `json:"description"`
+	Status          string             `json:"status"`
+	Priority        string             `json:"priority"`
+	StartAt         pgtype.Timestamptz `json:"start_at"`
+	DueAt           pgtype.Timestamptz `json:"due_at"`
+	CompletedAt     pgtype.Timestamptz `json:"completed_at"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
+	SectionID       pgtype.UUID        `json:"section_id"`
+	StatusID        pgtype.UUID        `json:"status_id"`
+	BlockedReason   *string            `json:"blocked_reason"`
+	EstimateMinutes *int32             `json:"estimate_minutes"`
+}
+
+type TaskCustomFieldValue struct {
+	TaskID      pgtype.UUID        `json:"task_id"`
+	FieldID     pgtype.UUID        `json:"field_id"`
+	Value       string             `json:"value"`
+	NumberValue *float64           `json:"number_value"`
+	DateValue   pgtype.Date        `json:"date_value"`
+	BoolValue   *bool              `json:"bool_value"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
 }
 
 type TaskLabel struct {
@@ -50,6 +149,44 @@ type TaskLabel struct {
 	LabelID pgtype.UUID `json:"label_id"`
 }
 
+type TaskRecurrence struct {
+	TaskID    pgtype.UUID        `json:"task_id"`
+	Rule      string             `json:"rule"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type TaskStatusChange struct {
+	ID        pgtype.UUID        `json:"id"`
+	TaskID    pgtype.UUID        `json:"task_id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Status    string             `json:"status"`
+	ChangedAt pgtype.Timestamptz `json:"changed_at"`
+}
+
+type Template struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	Name      string             `json:"name"`
+	Kind      string             `json:"kind"`
+	Content   []byte             `json:"content"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+type TimeEntry struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	TaskID    pgtype.UUID        `json:"task_id"`
+	StartedAt pgtype.Timestamptz `json:"started_at"`
+	EndedAt   pgtype.Timestamptz `json:"ended_at"`
+	Note      *string            `json:"note"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
 type User struct {
 	ID        pgtype.UUID        `json:"id"`
 	Name      string             `json:"name"`
@@ -60,3 +197,40 @@ type User struct {
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 }
+
+type UserCapacity struct {
+	UserID        pgtype.UUID        `json:"user_id"`
+	WeeklyMinutes int32              `json:"weekly_minutes"`
+	WorkDays      []int16            `json:"work_days"`
+	UpdatedAt     pgtype.Timestamptz `json:"updated_at"`
+	WorkdayStart  int16              `json:"workday_start"`
+	WorkdayEnd    int16              `json:"workday_end"`
+}
+
+type WebhookDelivery struct {
+	ID             pgtype.UUID        `json:"id"`
+	UserID         pgtype.UUID        `json:"user_id"`
+	SubscriptionID pgtype.UUID        `json:"subscription_id"`
+	EventID        pgtype.UUID        `json:"event_id"`
+	EventType      string             `json:"event_type"`
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	NextAttemptAt  pgtype.Timestamptz `json:"next_attempt_at"`
+	LastStatusCode *int32             `json:"last_status_code"`
+	LastError      *string            `json:"last_error"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type WebhookSubscription struct {
+	ID         pgtype.UUID        `json:"id"`
+	UserID     pgtype.UUID        `json:"user_id"`
+	Url        string             `json:"url"`
+	Secret     string             `json:"secret"`
+	EventTypes []string           `json:"event_types"`
+	Active     bool               `json:"active"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt  pgtype.Timestamptz `json:"deleted_at"`
+}
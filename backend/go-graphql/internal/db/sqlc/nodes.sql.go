@@ -0,0 +1,109 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: nodes.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getNodeType = `-- name: GetNodeType :one
+SELECT node_type
+FROM (
+  SELECT 'Project'::text AS node_type
+  FROM projects
+  WHERE projects.id = $1
+    AND projects.user_id = $2
+    AND projects.deleted_at IS NULL
+  UNION ALL
+  SELECT 'Label'::text
+  FROM labels
+  WHERE labels.id = $1
+    AND labels.user_id = $2
+    AND labels.deleted_at IS NULL
+  UNION ALL
+  SELECT 'Task'::text
+  FROM tasks
+  WHERE tasks.id = $1
+    AND tasks.user_id = $2
+    AND tasks.deleted_at IS NULL
+  UNION ALL
+  SELECT 'ProjectSection'::text
+  FROM project_sections
+  WHERE project_sections.id = $1
+    AND project_sections.user_id = $2
+    AND project_sections.deleted_at IS NULL
+  UNION ALL
+  SELECT 'ProjectStatus'::text
+  FROM project_statuses
+  WHERE project_statuses.id = $1
+    AND project_statuses.user_id = $2
+    AND project_statuses.deleted_at IS NULL
+  UNION ALL
+  SELECT 'CustomField'::text
+  FROM custom_fields
+  WHERE custom_fields.id = $1
+    AND custom_fields.user_id = $2
+    AND custom_fields.deleted_at IS NULL
+  UNION ALL
+  -- Deleting a task leaves its time entries in place, so they go with it here.
+  SELECT 'TimeEntry'::text
+  FROM time_entries
+  JOIN tasks ON tasks.id = time_entries.task_id AND tasks.deleted_at IS NULL
+  WHERE time_entries.id = $1
+    AND time_entries.user_id = $2
+    AND time_entries.deleted_at IS NULL
+  UNION ALL
+  SELECT 'Template'::text
+  FROM templates
+  WHERE templates.id = $1
+    AND templates.user_id = $2
+    AND templates.deleted_at IS NULL
+  UNION ALL
+  SELECT 'SavedFilter'::text
+  FROM saved_filters
+  WHERE saved_filters.id = $1
+    AND saved_filters.user_id = $2
+    AND saved_filters.deleted_at IS NULL
+  UNION ALL
+  SELECT 'WebhookSubscription'::text
+  FROM webhook_subscriptions
+  WHERE webhook_subscriptions.id = $1
+    AND webhook_subscriptions.user_id = $2
+    AND webhook_subscriptions.deleted_at IS NULL
+  UNION ALL
+  SELECT 'WebhookDelivery'::text
+  FROM webhook_deliveries
+  WHERE webhook_deliveries.id = $1
+    AND webhook_deliveries.user_id = $2
+  UNION ALL
+  SELECT 'CalendarFeed'::text
+  FROM calendar_feeds
+  WHERE calendar_feeds.id = $1
+    AND calendar_feeds.user_id = $2
+    AND calendar_feeds.revoked_at IS NULL
+  UNION ALL
+  SELECT 'User'::text
+  FROM users
+  WHERE users.id = $1
+    AND users.id = $2
+    AND users.deleted_at IS NULL
+) nodes
+LIMIT 1
+`
+
+type GetNodeTypeParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetNodeType(ctx context.Context, arg GetNodeTypeParams) (string, error) {
+	row := q.db.QueryRow(ctx, getNodeType, arg.ID, arg.UserID)
+	var node_type string
+	err := row.Scan(&node_type)
+	return node_type, err
+}
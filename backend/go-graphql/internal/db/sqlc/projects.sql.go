@@ -11,17 +11,73 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const archiveProject = `-- name: ArchiveProject :one
+UPDATE projects
+SET
+  archived_at = COALESCE(archived_at, NOW()),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents
+`
+
+type ArchiveProjectParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+// Archiving an archived project keeps the original archived_at.
+func (q *Queries) ArchiveProject(ctx context.Context, arg ArchiveProjectParams) (Project, error) {
+	row := q.db.QueryRow(ctx, archiveProject, arg.ID, arg.UserID)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.Color,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.AutoCompleteParents,
+	)
+	return i, err
+}
+
+const countProjects = `-- name: CountProjects :one
+SELECT COUNT(*)
+FROM projects
+WHERE user_id = $1
+  AND deleted_at IS NULL
+  AND ($2::boolean OR archived_at IS NULL)
+`
+
+type CountProjectsParams struct {
+	UserID  pgtype.UUID `json:"user_id"`
+	Column2 bool        `json:"column_2"`
+}
+
+func (q *Queries) CountProjects(ctx context.Context, arg CountProjectsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countProjects, arg.UserID, arg.Column2)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createProject = `-- name: CreateProject :one
-INSERT INTO projects (user_id, title, description, color)
-VALUES ($1, $2, $3, $4)
-RETURNING id, user_id, title, description, color, created_at, updated_at, deleted_at
+INSERT INTO projects (user_id, title, description, color, auto_complete_parents)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents
 `
 
 type CreateProjectParams struct {
-	UserID      pgtype.UUID `json:"user_id"`
-	Title       string      `json:"title"`
-	Description *string     `json:"description"`
-	Color       *string     `json:"color"`
+	UserID              pgtype.UUID `json:"user_id"`
+	Title               string      `json:"title"`
+	Description         *string     `json:"description"`
+	Color               *string     `json:"color"`
+	AutoCompleteParents bool        `json:"auto_complete_parents"`
 }
 
 func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error) {
@@ -30,6 +86,7 @@ func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (P
 		arg.Title,
 		arg.Description,
 		arg.Color,
+		arg.AutoCompleteParents,
 	)
 	var i Project
 	err := row.Scan(
@@ -41,12 +98,14 @@ func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (P
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.AutoCompleteParents,
 	)
 	return i, err
 }
 
 const getProjectByID = `-- name: GetProjectByID :one
-SELECT id, user_id, title, description, color, created_at, updated_at, deleted_at
+SELECT id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents
 FROM projects
 WHERE id = $1
   AND user_id = $2
@@ -71,15 +130,51 @@ func (q *Queries) GetProjectByID(ctx context.Context, arg GetProjectByIDParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.AutoCompleteParents,
 	)
 	return i, err
 }
 
+const listProjectTitles = `-- name: ListProjectTitles :many
+SELECT id, title
+FROM projects
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY created_at, id
+`
+
+type ListProjectTitlesRow struct {
+	ID    pgtype.UUID `json:"id"`
+	Title string      `json:"title"`
+}
+
+func (q *Queries) ListProjectTitles(ctx context.Context, userID pgtype.UUID) ([]ListProjectTitlesRow, error) {
+	rows, err := q.db.Query(ctx, listProjectTitles, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProjectTitlesRow{}
+	for rows.Next() {
+		var i ListProjectTitlesRow
+		if err := rows.Scan(&i.ID, &i.Title); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listProjects = `-- name: ListProjects :many
-SELECT id, user_id, title, description, color, created_at, updated_at, deleted_at
+SELECT id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents
 FROM projects
 WHERE user_id = $1
   AND deleted_at IS NULL
+  AND ($6::boolean OR archived_at IS NULL)
   AND (
     NOT $2::boolean
     OR (created_at, id) < ($3::timestamptz, $4::uuid)
@@ -94,6 +189,7 @@ type ListProjectsParams struct {
 	Column3 pgtype.Timestamptz `json:"column_3"`
 	Column4 pgtype.UUID        `json:"column_4"`
 	Limit   int32              `json:"limit"`
+	Column6 bool               `json:"column_6"`
 }
 
 func (q *Queries) ListProjects(ctx context.Context, arg ListProjectsParams) ([]Project, error) {
@@ -103,6 +199,68 @@ func (q *Queries) ListProjects(ctx context.Context, arg ListProjectsParams) ([]P
 		arg.Column3,
 		arg.Column4,
 		arg.Limit,
+		arg.Column6,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Project{}
+	for rows.Next() {
+		var i Project
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Title,
+			&i.Description,
+			&i.Color,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.AutoCompleteParents,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectsBefore = `-- name: ListProjectsBefore :many
+SELECT id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents
+FROM projects
+WHERE user_id = $1
+  AND deleted_at IS NULL
+  AND ($6::boolean OR archived_at IS NULL)
+  AND (
+    NOT $2::boolean
+    OR (created_at, id) > ($3::timestamptz, $4::uuid)
+  )
+ORDER BY created_at ASC, id ASC
+LIMIT $5
+`
+
+type ListProjectsBeforeParams struct {
+	UserID  pgtype.UUID        `json:"user_id"`
+	Column2 bool               `json:"column_2"`
+	Column3 pgtype.Timestamptz `json:"column_3"`
+	Column4 pgtype.UUID        `json:"column_4"`
+	Limit   int32              `json:"limit"`
+	Column6 bool               `json:"column_6"`
+}
+
+func (q *Queries) ListProjectsBefore(ctx context.Context, arg ListProjectsBeforeParams) ([]Project, error) {
+	rows, err := q.db.Query(ctx, listProjectsBefore,
+		arg.UserID,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Limit,
+		arg.Column6,
 	)
 	if err != nil {
 		return nil, err
@@ -120,6 +278,8 @@ func (q *Queries) ListProjects(ctx context.Context, arg ListProjectsParams) ([]P
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.ArchivedAt,
+			&i.AutoCompleteParents,
 		); err != nil {
 			return nil, err
 		}
@@ -159,34 +319,72 @@ func (q *Queries) SoftDeleteProject(ctx context.Context, arg SoftDeleteProjectPa
 	return i, err
 }
 
-const updateProject = `-- name: UpdateProject :one
+const unarchiveProject = `-- name: UnarchiveProject :one
 UPDATE projects
 SET
-  title = $3,
-  description = $4,
-  color = $5,
+  archived_at = NULL,
   updated_at = NOW()
 WHERE id = $1
   AND user_id = $2
   AND deleted_at IS NULL
-RETURNING id, user_id, title, description, color, created_at, updated_at, deleted_at
+RETURNING id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents
+`
+
+type UnarchiveProjectParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) UnarchiveProject(ctx context.Context, arg UnarchiveProjectParams) (Project, error) {
+	row := q.db.QueryRow(ctx, unarchiveProject, arg.ID, arg.UserID)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Title,
+		&i.Description,
+		&i.Color,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.AutoCompleteParents,
+	)
+	return i, err
+}
+
+const updateProject = `-- name: UpdateProject :one
+UPDATE projects
+SET
+  title = $1,
+  description = $2,
+  color = $3,
+  auto_complete_parents = COALESCE($4::boolean, auto_complete_parents),
+  updated_at = NOW()
+WHERE id = $5
+  AND user_id = $6
+  AND deleted_at IS NULL
+RETURNING id, user_id, title, description, color, created_at, updated_at, deleted_at, archived_at, auto_complete_parents
 `
 
 type UpdateProjectParams struct {
-	ID          pgtype.UUID `json:"id"`
-	UserID      pgtype.UUID `json:"user_id"`
-	Title       string      `json:"title"`
-	Description *string     `json:"description"`
-	Color       *string     `json:"color"`
+	Title               string      `json:"title"`
+	Description         *string     `json:"description"`
+	Color               *string     `json:"color"`
+	AutoCompleteParents *bool       `json:"auto_complete_parents"`
+	ID                  pgtype.UUID `json:"id"`
+	UserID              pgtype.UUID `json:"user_id"`
 }
 
+// A NULL auto_complete_parents keeps the current setting.
 func (q *Queries) UpdateProject(ctx context.Context, arg UpdateProjectParams) (Project, error) {
 	row := q.db.QueryRow(ctx, updateProject,
-		arg.ID,
-		arg.UserID,
 		arg.Title,
 		arg.Description,
 		arg.Color,
+		arg.AutoCompleteParents,
+		arg.ID,
+		arg.UserID,
 	)
 	var i Project
 	err := row.Scan(
@@ -198,6 +396,8 @@ func (q *Queries) UpdateProject(ctx context.Context, arg UpdateProjectParams) (P
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.ArchivedAt,
+		&i.AutoCompleteParents,
 	)
 	return i, err
 }
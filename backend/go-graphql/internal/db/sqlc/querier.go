@@ -11,33 +11,233 @@ import (
 )
 
 type Querier interface {
+	// Archiving an archived project keeps the original archived_at.
+	ArchiveProject(ctx context.Context, arg ArchiveProjectParams) (Project, error)
+	// Dead-letters what a subscription still has queued when it is deleted or
+	// deactivated.
+	CancelPendingWebhookDeliveries(ctx context.Context, arg CancelPendingWebhookDeliveriesParams) (int64, error)
+	// Pushes next_attempt_at forward by the lease so a crashed worker's claims
+	// become due again instead of being lost. Deliveries of deleted or inactive
+	// subscriptions are never claimed.
+	ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]ClaimDueWebhookDeliveriesRow, error)
+	ClaimOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error)
+	// Tasks completed per bucket. Analytics queries cover the live tasks of the
+	// user's live projects, optionally limited to project_ids. Buckets are local
+	// days or weeks (bucket is 'day' or 'week'; weeks start on Monday) in the tz
+	// time zone, and every bucket overlapping [range_from, range_to) is listed.
+	CompletedTaskSeries(ctx context.Context, arg CompletedTaskSeriesParams) ([]CompletedTaskSeriesRow, error)
+	CountCustomFields(ctx context.Context, projectID pgtype.UUID) (int64, error)
+	CountLabels(ctx context.Context, userID pgtype.UUID) (int64, error)
+	// Counts live tasks whose value for a SELECT field is not one of options.
+	CountLiveTasksWithValueOutside(ctx context.Context, arg CountLiveTasksWithValueOutsideParams) (int64, error)
+	CountOpenSubtasks(ctx context.Context, arg CountOpenSubtasksParams) (int64, error)
+	CountProjectSections(ctx context.Context, projectID pgtype.UUID) (int64, error)
+	CountProjectStatuses(ctx context.Context, arg CountProjectStatusesParams) (int64, error)
+	CountProjects(ctx context.Context, arg CountProjectsParams) (int64, error)
+	CountRootTasks(ctx context.Context, arg CountRootTasksParams) (int64, error)
+	// Board column counts: live root tasks of a project per workflow status.
+	CountRootTasksByStatus(ctx context.Context, arg CountRootTasksByStatusParams) ([]CountRootTasksByStatusRow, error)
+	CountRootTasksInStatus(ctx context.Context, arg CountRootTasksInStatusParams) (int64, error)
+	CountSubtasks(ctx context.Context, arg CountSubtasksParams) (int64, error)
+	CountWebhookDeliveries(ctx context.Context, arg CountWebhookDeliveriesParams) (int64, error)
+	CreateCalendarFeed(ctx context.Context, arg CreateCalendarFeedParams) (CalendarFeed, error)
+	// New fields go last.
+	CreateCustomField(ctx context.Context, arg CreateCustomFieldParams) (CustomField, error)
+	CreateDefaultProjectStatuses(ctx context.Context, arg CreateDefaultProjectStatusesParams) ([]ProjectStatus, error)
 	CreateLabel(ctx context.Context, arg CreateLabelParams) (Label, error)
 	CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error)
+	// New sections go last.
+	CreateProjectSection(ctx context.Context, arg CreateProjectSectionParams) (ProjectSection, error)
+	// New statuses go last.
+	CreateProjectStatus(ctx context.Context, arg CreateProjectStatusParams) (ProjectStatus, error)
+	CreateSavedFilter(ctx context.Context, arg CreateSavedFilterParams) (SavedFilter, error)
+	// A null id gets a random one; CalDAV clients pick their own.
 	CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error)
+	CreateTemplate(ctx context.Context, arg CreateTemplateParams) (Template, error)
+	CreateTimeEntry(ctx context.Context, arg CreateTimeEntryParams) (TimeEntry, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
+	DeleteCustomFieldValues(ctx context.Context, fieldID pgtype.UUID) error
+	DeleteCustomFieldValuesOutside(ctx context.Context, arg DeleteCustomFieldValuesOutsideParams) error
+	DeleteStatusRequirements(ctx context.Context, arg DeleteStatusRequirementsParams) error
+	DeleteStatusTransitions(ctx context.Context, arg DeleteStatusTransitionsParams) error
+	DeleteTaskCustomFieldValue(ctx context.Context, arg DeleteTaskCustomFieldValueParams) error
 	DeleteTaskLabelsByLabelID(ctx context.Context, labelID pgtype.UUID) (int64, error)
 	DeleteTaskLabelsForTask(ctx context.Context, taskID pgtype.UUID) error
+	DeleteTaskRecurrence(ctx context.Context, arg DeleteTaskRecurrenceParams) error
+	EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int64, error)
+	ExportLabels(ctx context.Context, arg ExportLabelsParams) ([]Label, error)
+	// The Export queries page by id so a full export streams in bounded batches
+	// without holding a transaction open; the nil UUID starts from the beginning.
+	ExportProjects(ctx context.Context, arg ExportProjectsParams) ([]Project, error)
+	ExportRootTasks(ctx context.Context, arg ExportRootTasksParams) ([]Task, error)
+	ExportTaskLabels(ctx context.Context, arg ExportTaskLabelsParams) ([]TaskLabel, error)
+	ExportTasks(ctx context.Context, arg ExportTasksParams) ([]Task, error)
+	FirstProjectStatusInCategory(ctx context.Context, arg FirstProjectStatusInCategoryParams) (ProjectStatus, error)
+	GetCalendarFeedByID(ctx context.Context, arg GetCalendarFeedByIDParams) (CalendarFeed, error)
+	GetCalendarFeedByTokenHash(ctx context.Context, tokenHash []byte) (CalendarFeed, error)
+	GetCustomFieldByID(ctx context.Context, arg GetCustomFieldByIDParams) (CustomField, error)
 	GetLabelByID(ctx context.Context, arg GetLabelByIDParams) (Label, error)
 	GetLabelsByIDs(ctx context.Context, arg GetLabelsByIDsParams) ([]Label, error)
+	// names must be lower-cased; matching is case-insensitive like the unique
+	// index.
+	GetLabelsByNames(ctx context.Context, arg GetLabelsByNamesParams) ([]Label, error)
+	GetNodeType(ctx context.Context, arg GetNodeTypeParams) (string, error)
 	GetProjectByID(ctx context.Context, arg GetProjectByIDParams) (Project, error)
+	GetProjectSectionByID(ctx context.Context, arg GetProjectSectionByIDParams) (ProjectSection, error)
+	GetProjectStatusByID(ctx context.Context, arg GetProjectStatusByIDParams) (ProjectStatus, error)
+	// Locks the status row so concurrent moves into it see each other's WIP.
+	GetProjectStatusForUpdate(ctx context.Context, arg GetProjectStatusForUpdateParams) (ProjectStatus, error)
+	GetRunningTimeEntry(ctx context.Context, userID pgtype.UUID) (TimeEntry, error)
+	GetSavedFilterByID(ctx context.Context, arg GetSavedFilterByIDParams) (SavedFilter, error)
 	GetTaskByID(ctx context.Context, arg GetTaskByIDParams) (Task, error)
+	GetTemplateByID(ctx context.Context, arg GetTemplateByIDParams) (Template, error)
+	GetTimeEntryByID(ctx context.Context, arg GetTimeEntryByIDParams) (TimeEntry, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id pgtype.UUID) (User, error)
+	GetUserCapacity(ctx context.Context, userID pgtype.UUID) (UserCapacity, error)
+	GetWebhookDeliveryByID(ctx context.Context, arg GetWebhookDeliveryByIDParams) (WebhookDelivery, error)
+	GetWebhookSubscriptionByID(ctx context.Context, arg GetWebhookSubscriptionByIDParams) (WebhookSubscription, error)
+	InsertOutboxEvent(ctx context.Context, arg InsertOutboxEventParams) error
+	InsertStatusRequirements(ctx context.Context, arg InsertStatusRequirementsParams) error
+	InsertStatusTransitions(ctx context.Context, arg InsertStatusTransitionsParams) error
 	InsertTaskLabel(ctx context.Context, arg InsertTaskLabelParams) error
+	InsertTaskStatusChange(ctx context.Context, arg InsertTaskStatusChangeParams) error
+	// Lead time (created_at to completed_at) of tasks completed in the range;
+	// both figures are 0 when there are none.
+	LeadTimeStats(ctx context.Context, arg LeadTimeStatsParams) (LeadTimeStatsRow, error)
+	ListCalendarFeeds(ctx context.Context, userID pgtype.UUID) ([]CalendarFeed, error)
+	ListCustomFields(ctx context.Context, arg ListCustomFieldsParams) ([]CustomField, error)
+	// Open, estimated tasks of live, unarchived projects whose schedule
+	// (start_at to due_at, either alone) overlaps [range_from, range_to).
+	ListEstimatedTasksInRange(ctx context.Context, arg ListEstimatedTasksInRangeParams) ([]Task, error)
+	ListLabelNamesByTaskIDs(ctx context.Context, arg ListLabelNamesByTaskIDsParams) ([]ListLabelNamesByTaskIDsRow, error)
 	ListLabels(ctx context.Context, arg ListLabelsParams) ([]Label, error)
+	ListLabelsBefore(ctx context.Context, arg ListLabelsBeforeParams) ([]Label, error)
 	ListLabelsByTaskID(ctx context.Context, arg ListLabelsByTaskIDParams) ([]Label, error)
+	ListLabelsForTasks(ctx context.Context, arg ListLabelsForTasksParams) ([]ListLabelsForTasksRow, error)
+	ListProjectSections(ctx context.Context, arg ListProjectSectionsParams) ([]ProjectSection, error)
+	ListProjectStatuses(ctx context.Context, arg ListProjectStatusesParams) ([]ProjectStatus, error)
+	// last_modified moves whenever a rendered task could change: soft deletes
+	// bump tasks.updated_at, label renames show up in CATEGORIES and the user's
+	// timezone is used for every date.
+	ListProjectSyncStates(ctx context.Context, userID pgtype.UUID) ([]ListProjectSyncStatesRow, error)
+	ListProjectTasks(ctx context.Context, arg ListProjectTasksParams) ([]Task, error)
+	ListProjectTitles(ctx context.Context, userID pgtype.UUID) ([]ListProjectTitlesRow, error)
 	ListProjects(ctx context.Context, arg ListProjectsParams) ([]Project, error)
+	ListProjectsBefore(ctx context.Context, arg ListProjectsBeforeParams) ([]Project, error)
 	ListRootTasks(ctx context.Context, arg ListRootTasksParams) ([]Task, error)
+	ListRootTasksBefore(ctx context.Context, arg ListRootTasksBeforeParams) ([]Task, error)
+	ListSavedFilters(ctx context.Context, userID pgtype.UUID) ([]SavedFilter, error)
+	ListScheduledTasks(ctx context.Context, arg ListScheduledTasksParams) ([]Task, error)
+	// Open tasks of live, unarchived projects that start before range_to and,
+	// taking default_minutes for tasks without an estimate, end after range_from.
+	ListScheduledTasksInRange(ctx context.Context, arg ListScheduledTasksInRangeParams) ([]Task, error)
+	ListStatusRequirements(ctx context.Context, arg ListStatusRequirementsParams) ([]ListStatusRequirementsRow, error)
+	ListStatusTransitions(ctx context.Context, arg ListStatusTransitionsParams) ([]ListStatusTransitionsRow, error)
 	ListSubtasks(ctx context.Context, arg ListSubtasksParams) ([]Task, error)
+	ListSubtasksBefore(ctx context.Context, arg ListSubtasksBeforeParams) ([]Task, error)
 	ListSubtasksByParentID(ctx context.Context, arg ListSubtasksByParentIDParams) ([]Task, error)
-	SoftDeleteDirectSubtasks(ctx context.Context, arg SoftDeleteDirectSubtasksParams) (int64, error)
+	ListSubtasksByParentIDs(ctx context.Context, arg ListSubtasksByParentIDsParams) ([]Task, error)
+	// Values of live fields for several tasks, in field order.
+	ListTaskCustomFieldValues(ctx context.Context, arg ListTaskCustomFieldValuesParams) ([]ListTaskCustomFieldValuesRow, error)
+	ListTaskRecurrences(ctx context.Context, arg ListTaskRecurrencesParams) ([]TaskRecurrence, error)
+	ListTemplates(ctx context.Context, userID pgtype.UUID) ([]Template, error)
+	ListTimeEntriesByTask(ctx context.Context, arg ListTimeEntriesByTaskParams) ([]TimeEntry, error)
+	// Entries of live tasks overlapping [range_from, range_to).
+	ListTimeEntriesForReport(ctx context.Context, arg ListTimeEntriesForReportParams) ([]ListTimeEntriesForReportRow, error)
+	// Open tasks without a start of live, unarchived projects, optionally limited
+	// to project_ids.
+	ListUnscheduledTasks(ctx context.Context, arg ListUnscheduledTasksParams) ([]Task, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookDeliveriesBefore(ctx context.Context, arg ListWebhookDeliveriesBeforeParams) ([]WebhookDelivery, error)
+	ListWebhookSubscriptions(ctx context.Context, userID pgtype.UUID) ([]WebhookSubscription, error)
+	// Serialises position changes within one project for the rest of the
+	// transaction.
+	LockCustomFields(ctx context.Context, projectID pgtype.UUID) error
+	// Serialises position changes within one project for the rest of the
+	// transaction.
+	LockProjectSections(ctx context.Context, projectID pgtype.UUID) error
+	// Serialises position changes within one project for the rest of the
+	// transaction.
+	LockProjectStatuses(ctx context.Context, projectID pgtype.UUID) error
+	// Serialises concurrent subtask roll-ups onto the same parent.
+	LockTask(ctx context.Context, arg LockTaskParams) error
+	MarkOutboxEventDispatched(ctx context.Context, id pgtype.UUID) error
+	MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error
+	MarkWebhookDeliverySucceeded(ctx context.Context, arg MarkWebhookDeliverySucceededParams) error
+	// Re-homes the tasks of a section being deleted; a NULL new_section_id
+	// leaves them unsectioned.
+	MoveTasksToSection(ctx context.Context, arg MoveTasksToSectionParams) ([]Task, error)
+	// Re-homes the live tasks of a status being deleted and brings their category
+	// and completion time in line with the new status, recording the status
+	// change. Returns the moved tasks.
+	MoveTasksToStatus(ctx context.Context, arg MoveTasksToStatusParams) ([]MoveTasksToStatusRow, error)
+	// OverdueByPriority per label; a task counts toward each of its labels.
+	OverdueByLabel(ctx context.Context, arg OverdueByLabelParams) ([]OverdueByLabelRow, error)
+	// Tasks due in the range, up to now, and how many of them were completed
+	// late or are still open.
+	OverdueByPriority(ctx context.Context, arg OverdueByPriorityParams) ([]OverdueByPriorityRow, error)
+	// Open tasks of each project at the end of each bucket (or at range_to for
+	// the last one). A task is open from its creation until its completion.
+	// Projects archived before the range are left out.
+	ProjectBurndown(ctx context.Context, arg ProjectBurndownParams) ([]ProjectBurndownRow, error)
+	RetryWebhookDelivery(ctx context.Context, arg RetryWebhookDeliveryParams) (WebhookDelivery, error)
+	RevokeCalendarFeed(ctx context.Context, arg RevokeCalendarFeedParams) (RevokeCalendarFeedRow, error)
+	// Sets the start of a task that is still unscheduled.
+	ScheduleTask(ctx context.Context, arg ScheduleTaskParams) (Task, error)
+	// Applies a status's new category to the tasks already in it, recording the
+	// status change. Returns the changed tasks.
+	SetTaskCategoryForStatus(ctx context.Context, arg SetTaskCategoryForStatusParams) ([]SetTaskCategoryForStatusRow, error)
+	// Moves the fields with position in [from_position, to_position] by delta
+	// (+1 or -1) to open or close a gap.
+	ShiftCustomFields(ctx context.Context, arg ShiftCustomFieldsParams) error
+	// Moves the sections with position in [from_position, to_position] by delta
+	// (+1 or -1) to open or close a gap.
+	ShiftProjectSections(ctx context.Context, arg ShiftProjectSectionsParams) error
+	// Moves the statuses with position in [from_position, to_position] by delta
+	// (+1 or -1) to open or close a gap.
+	ShiftProjectStatuses(ctx context.Context, arg ShiftProjectStatusesParams) error
+	SoftDeleteCustomField(ctx context.Context, arg SoftDeleteCustomFieldParams) (SoftDeleteCustomFieldRow, error)
+	SoftDeleteCustomFieldsByProject(ctx context.Context, arg SoftDeleteCustomFieldsByProjectParams) (int64, error)
+	SoftDeleteDirectSubtasks(ctx context.Context, arg SoftDeleteDirectSubtasksParams) ([]Task, error)
 	SoftDeleteLabel(ctx context.Context, arg SoftDeleteLabelParams) (SoftDeleteLabelRow, error)
 	SoftDeleteProject(ctx context.Context, arg SoftDeleteProjectParams) (SoftDeleteProjectRow, error)
-	SoftDeleteTask(ctx context.Context, arg SoftDeleteTaskParams) (SoftDeleteTaskRow, error)
-	SoftDeleteTasksByProject(ctx context.Context, arg SoftDeleteTasksByProjectParams) (int64, error)
+	SoftDeleteProjectSection(ctx context.Context, arg SoftDeleteProjectSectionParams) (SoftDeleteProjectSectionRow, error)
+	SoftDeleteProjectStatus(ctx context.Context, arg SoftDeleteProjectStatusParams) (SoftDeleteProjectStatusRow, error)
+	SoftDeleteSavedFilter(ctx context.Context, arg SoftDeleteSavedFilterParams) (SoftDeleteSavedFilterRow, error)
+	SoftDeleteSectionsByProject(ctx context.Context, arg SoftDeleteSectionsByProjectParams) (int64, error)
+	SoftDeleteStatusesByProject(ctx context.Context, arg SoftDeleteStatusesByProjectParams) (int64, error)
+	SoftDeleteTask(ctx context.Context, arg SoftDeleteTaskParams) (Task, error)
+	SoftDeleteTasksByProject(ctx context.Context, arg SoftDeleteTasksByProjectParams) ([]Task, error)
+	SoftDeleteTemplate(ctx context.Context, arg SoftDeleteTemplateParams) (SoftDeleteTemplateRow, error)
+	SoftDeleteTimeEntry(ctx context.Context, arg SoftDeleteTimeEntryParams) (SoftDeleteTimeEntryRow, error)
+	SoftDeleteWebhookSubscription(ctx context.Context, arg SoftDeleteWebhookSubscriptionParams) (SoftDeleteWebhookSubscriptionRow, error)
+	StartTimeEntry(ctx context.Context, arg StartTimeEntryParams) (TimeEntry, error)
+	StopRunningTimeEntry(ctx context.Context, userID pgtype.UUID) (TimeEntry, error)
+	// Live subtask counts for a batch of parent tasks; parents without subtasks
+	// are absent.
+	SubtaskProgress(ctx context.Context, arg SubtaskProgressParams) ([]SubtaskProgressRow, error)
+	// Seconds tracked on each task and its live subtasks; running timers count
+	// up to now.
+	TaskTimeSpent(ctx context.Context, arg TaskTimeSpentParams) ([]TaskTimeSpentRow, error)
+	// Time spent in each status category within the range. A task stays in a
+	// status until its next change, or until now.
+	TimeInStatus(ctx context.Context, arg TimeInStatusParams) ([]TimeInStatusRow, error)
+	UnarchiveProject(ctx context.Context, arg UnarchiveProjectParams) (Project, error)
+	UpdateCustomField(ctx context.Context, arg UpdateCustomFieldParams) (CustomField, error)
 	UpdateLabel(ctx context.Context, arg UpdateLabelParams) (Label, error)
+	// A NULL auto_complete_parents keeps the current setting.
 	UpdateProject(ctx context.Context, arg UpdateProjectParams) (Project, error)
+	UpdateProjectSection(ctx context.Context, arg UpdateProjectSectionParams) (ProjectSection, error)
+	UpdateProjectStatus(ctx context.Context, arg UpdateProjectStatusParams) (ProjectStatus, error)
+	UpdateSavedFilter(ctx context.Context, arg UpdateSavedFilterParams) (SavedFilter, error)
 	UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, error)
+	UpdateTimeEntry(ctx context.Context, arg UpdateTimeEntryParams) (TimeEntry, error)
+	UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (WebhookSubscription, error)
+	UpsertTaskCustomFieldValue(ctx context.Context, arg UpsertTaskCustomFieldValueParams) error
+	UpsertTaskRecurrence(ctx context.Context, arg UpsertTaskRecurrenceParams) (TaskRecurrence, error)
 	UpsertUserByEmail(ctx context.Context, arg UpsertUserByEmailParams) (User, error)
+	UpsertUserCapacity(ctx context.Context, arg UpsertUserCapacityParams) (UserCapacity, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,171 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: saved_filters.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createSavedFilter = `-- name: CreateSavedFilter :one
+INSERT INTO saved_filters (user_id, name, expression)
+VALUES ($1, $2, $3)
+RETURNING id, user_id, name, expression, created_at, updated_at, deleted_at
+`
+
+type CreateSavedFilterParams struct {
+	UserID     pgtype.UUID `json:"user_id"`
+	Name       string      `json:"name"`
+	Expression string      `json:"expression"`
+}
+
+func (q *Queries) CreateSavedFilter(ctx context.Context, arg CreateSavedFilterParams) (SavedFilter, error) {
+	row := q.db.QueryRow(ctx, createSavedFilter, arg.UserID, arg.Name, arg.Expression)
+	var i SavedFilter
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Expression,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getSavedFilterByID = `-- name: GetSavedFilterByID :one
+SELECT id, user_id, name, expression, created_at, updated_at, deleted_at
+FROM saved_filters
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+LIMIT 1
+`
+
+type GetSavedFilterByIDParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetSavedFilterByID(ctx context.Context, arg GetSavedFilterByIDParams) (SavedFilter, error) {
+	row := q.db.QueryRow(ctx, getSavedFilterByID, arg.ID, arg.UserID)
+	var i SavedFilter
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Expression,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const listSavedFilters = `-- name: ListSavedFilters :many
+SELECT id, user_id, name, expression, created_at, updated_at, deleted_at
+FROM saved_filters
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY LOWER(name), id
+`
+
+func (q *Queries) ListSavedFilters(ctx context.Context, userID pgtype.UUID) ([]SavedFilter, error) {
+	rows, err := q.db.Query(ctx, listSavedFilters, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SavedFilter{}
+	for rows.Next() {
+		var i SavedFilter
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Expression,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const softDeleteSavedFilter = `-- name: SoftDeleteSavedFilter :one
+UPDATE saved_filters
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, deleted_at
+`
+
+type SoftDeleteSavedFilterParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type SoftDeleteSavedFilterRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+func (q *Queries) SoftDeleteSavedFilter(ctx context.Context, arg SoftDeleteSavedFilterParams) (SoftDeleteSavedFilterRow, error) {
+	row := q.db.QueryRow(ctx, softDeleteSavedFilter, arg.ID, arg.UserID)
+	var i SoftDeleteSavedFilterRow
+	err := row.Scan(&i.ID, &i.DeletedAt)
+	return i, err
+}
+
+const updateSavedFilter = `-- name: UpdateSavedFilter :one
+UPDATE saved_filters
+SET
+  name = $3,
+  expression = $4,
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, name, expression, created_at, updated_at, deleted_at
+`
+
+type UpdateSavedFilterParams struct {
+	ID         pgtype.UUID `json:"id"`
+	UserID     pgtype.UUID `json:"user_id"`
+	Name       string      `json:"name"`
+	Expression string      `json:"expression"`
+}
+
+func (q *Queries) UpdateSavedFilter(ctx context.Context, arg UpdateSavedFilterParams) (SavedFilter, error) {
+	row := q.db.QueryRow(ctx, updateSavedFilter,
+		arg.ID,
+		arg.UserID,
+		arg.Name,
+		arg.Expression,
+	)
+	var i SavedFilter
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Expression,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
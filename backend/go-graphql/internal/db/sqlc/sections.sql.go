@@ -0,0 +1,269 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: sections.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countProjectSections = `-- name: CountProjectSections :one
+SELECT COUNT(*)
+FROM project_sections
+WHERE project_id = $1
+  AND deleted_at IS NULL
+`
+
+func (q *Queries) CountProjectSections(ctx context.Context, projectID pgtype.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, countProjectSections, projectID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createProjectSection = `-- name: CreateProjectSection :one
+INSERT INTO project_sections (user_id, project_id, name, position)
+SELECT $1, $2, $3, COALESCE(MAX(position) + 1, 0)
+FROM project_sections
+WHERE project_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, name, position, created_at, updated_at, deleted_at
+`
+
+type CreateProjectSectionParams struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	ProjectID pgtype.UUID `json:"project_id"`
+	Name      string      `json:"name"`
+}
+
+// New sections go last.
+func (q *Queries) CreateProjectSection(ctx context.Context, arg CreateProjectSectionParams) (ProjectSection, error) {
+	row := q.db.QueryRow(ctx, createProjectSection, arg.UserID, arg.ProjectID, arg.Name)
+	var i ProjectSection
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.Name,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getProjectSectionByID = `-- name: GetProjectSectionByID :one
+SELECT id, user_id, project_id, name, position, created_at, updated_at, deleted_at
+FROM project_sections
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+LIMIT 1
+`
+
+type GetProjectSectionByIDParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetProjectSectionByID(ctx context.Context, arg GetProjectSectionByIDParams) (ProjectSection, error) {
+	row := q.db.QueryRow(ctx, getProjectSectionByID, arg.ID, arg.UserID)
+	var i ProjectSection
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.Name,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const listProjectSections = `-- name: ListProjectSections :many
+SELECT id, user_id, project_id, name, position, created_at, updated_at, deleted_at
+FROM project_sections
+WHERE project_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY position, id
+`
+
+type ListProjectSectionsParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) ListProjectSections(ctx context.Context, arg ListProjectSectionsParams) ([]ProjectSection, error) {
+	rows, err := q.db.Query(ctx, listProjectSections, arg.ProjectID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProjectSection{}
+	for rows.Next() {
+		var i ProjectSection
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.Name,
+			&i.Position,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockProjectSections = `-- name: LockProjectSections :exec
+SELECT pg_advisory_xact_lock(hashtextextended('project_sections:' || $1::uuid::text, 0))
+`
+
+// Serialises position changes within one project for the rest of the
+// transaction.
+func (q *Queries) LockProjectSections(ctx context.Context, projectID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, lockProjectSections, projectID)
+	return err
+}
+
+const shiftProjectSections = `-- name: ShiftProjectSections :exec
+UPDATE project_sections
+SET
+  position = position + $1::int,
+  updated_at = NOW()
+WHERE project_id = $2
+  AND deleted_at IS NULL
+  AND position BETWEEN $3::int AND $4::int
+`
+
+type ShiftProjectSectionsParams struct {
+	Delta        int32       `json:"delta"`
+	ProjectID    pgtype.UUID `json:"project_id"`
+	FromPosition int32       `json:"from_position"`
+	ToPosition   int32       `json:"to_position"`
+}
+
+// Moves the sections with position in [from_position, to_position] by delta
+// (+1 or -1) to open or close a gap.
+func (q *Queries) ShiftProjectSections(ctx context.Context, arg ShiftProjectSectionsParams) error {
+	_, err := q.db.Exec(ctx, shiftProjectSections,
+		arg.Delta,
+		arg.ProjectID,
+		arg.FromPosition,
+		arg.ToPosition,
+	)
+	return err
+}
+
+const softDeleteProjectSection = `-- name: SoftDeleteProjectSection :one
+UPDATE project_sections
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, project_id, position, deleted_at
+`
+
+type SoftDeleteProjectSectionParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type SoftDeleteProjectSectionRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	ProjectID pgtype.UUID        `json:"project_id"`
+	Position  int32              `json:"position"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+func (q *Queries) SoftDeleteProjectSection(ctx context.Context, arg SoftDeleteProjectSectionParams) (SoftDeleteProjectSectionRow, error) {
+	row := q.db.QueryRow(ctx, softDeleteProjectSection, arg.ID, arg.UserID)
+	var i SoftDeleteProjectSectionRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Position,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const softDeleteSectionsByProject = `-- name: SoftDeleteSectionsByProject :execrows
+UPDATE project_sections
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE project_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+`
+
+type SoftDeleteSectionsByProjectParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) SoftDeleteSectionsByProject(ctx context.Context, arg SoftDeleteSectionsByProjectParams) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteSectionsByProject, arg.ProjectID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateProjectSection = `-- name: UpdateProjectSection :one
+UPDATE project_sections
+SET
+  name = $3,
+  position = $4,
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, name, position, created_at, updated_at, deleted_at
+`
+
+type UpdateProjectSectionParams struct {
+	ID       pgtype.UUID `json:"id"`
+	UserID   pgtype.UUID `json:"user_id"`
+	Name     string      `json:"name"`
+	Position int32       `json:"position"`
+}
+
+func (q *Queries) UpdateProjectSection(ctx context.Context, arg UpdateProjectSectionParams) (ProjectSection, error) {
+	row := q.db.QueryRow(ctx, updateProjectSection,
+		arg.ID,
+		arg.UserID,
+		arg.Name,
+		arg.Position,
+	)
+	var i ProjectSection
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.Name,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,430 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: statuses.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countProjectStatuses = `-- name: CountProjectStatuses :one
+SELECT COUNT(*)
+FROM project_statuses
+WHERE project_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+`
+
+type CountProjectStatusesParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) CountProjectStatuses(ctx context.Context, arg CountProjectStatusesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countProjectStatuses, arg.ProjectID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createDefaultProjectStatuses = `-- name: CreateDefaultProjectStatuses :many
+INSERT INTO project_statuses (user_id, project_id, name, category, position)
+SELECT $1, $2, d.name, d.category, d.position
+FROM (
+  VALUES
+    ('To Do', 'TODO', 0),
+    ('In Progress', 'IN_PROGRESS', 1),
+    ('Blocked', 'BLOCKED', 2),
+    ('Done', 'DONE', 3)
+) AS d (name, category, position)
+RETURNING id, user_id, project_id, name, category, color, wip_limit, position, created_at, updated_at, deleted_at
+`
+
+type CreateDefaultProjectStatusesParams struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	ProjectID pgtype.UUID `json:"project_id"`
+}
+
+func (q *Queries) CreateDefaultProjectStatuses(ctx context.Context, arg CreateDefaultProjectStatusesParams) ([]ProjectStatus, error) {
+	rows, err := q.db.Query(ctx, createDefaultProjectStatuses, arg.UserID, arg.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProjectStatus{}
+	for rows.Next() {
+		var i ProjectStatus
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.Name,
+			&i.Category,
+			&i.Color,
+			&i.WipLimit,
+			&i.Position,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createProjectStatus = `-- name: CreateProjectStatus :one
+INSERT INTO project_statuses (user_id, project_id, name, category, color, wip_limit, position)
+SELECT $1, $2, $3, $4, $5, $6, COALESCE(MAX(position) + 1, 0)
+FROM project_statuses
+WHERE project_id = $2
+  AND user_id = $1
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, name, category, color, wip_limit, position, created_at, updated_at, deleted_at
+`
+
+type CreateProjectStatusParams struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	ProjectID pgtype.UUID `json:"project_id"`
+	Name      string      `json:"name"`
+	Category  string      `json:"category"`
+	Color     *string     `json:"color"`
+	WipLimit  *int32      `json:"wip_limit"`
+}
+
+// New statuses go last.
+func (q *Queries) CreateProjectStatus(ctx context.Context, arg CreateProjectStatusParams) (ProjectStatus, error) {
+	row := q.db.QueryRow(ctx, createProjectStatus,
+		arg.UserID,
+		arg.ProjectID,
+		arg.Name,
+		arg.Category,
+		arg.Color,
+		arg.WipLimit,
+	)
+	var i ProjectStatus
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.Name,
+		&i.Category,
+		&i.Color,
+		&i.WipLimit,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const firstProjectStatusInCategory = `-- name: FirstProjectStatusInCategory :one
+SELECT id, user_id, project_id, name, category, color, wip_limit, position, created_at, updated_at, deleted_at
+FROM project_statuses
+WHERE project_id = $1
+  AND user_id = $2
+  AND category = $3
+  AND deleted_at IS NULL
+ORDER BY position, id
+LIMIT 1
+`
+
+type FirstProjectStatusInCategoryParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+	Category  string      `json:"category"`
+}
+
+func (q *Queries) FirstProjectStatusInCategory(ctx context.Context, arg FirstProjectStatusInCategoryParams) (ProjectStatus, error) {
+	row := q.db.QueryRow(ctx, firstProjectStatusInCategory, arg.ProjectID, arg.UserID, arg.Category)
+	var i ProjectStatus
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.Name,
+		&i.Category,
+		&i.Color,
+		&i.WipLimit,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getProjectStatusByID = `-- name: GetProjectStatusByID :one
+SELECT id, user_id, project_id, name, category, color, wip_limit, position, created_at, updated_at, deleted_at
+FROM project_statuses
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+LIMIT 1
+`
+
+type GetProjectStatusByIDParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetProjectStatusByID(ctx context.Context, arg GetProjectStatusByIDParams) (ProjectStatus, error) {
+	row := q.db.QueryRow(ctx, getProjectStatusByID, arg.ID, arg.UserID)
+	var i ProjectStatus
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.Name,
+		&i.Category,
+		&i.Color,
+		&i.WipLimit,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getProjectStatusForUpdate = `-- name: GetProjectStatusForUpdate :one
+SELECT id, user_id, project_id, name, category, color, wip_limit, position, created_at, updated_at, deleted_at
+FROM project_statuses
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+FOR UPDATE
+`
+
+type GetProjectStatusForUpdateParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+// Locks the status row so concurrent moves into it see each other's WIP.
+func (q *Queries) GetProjectStatusForUpdate(ctx context.Context, arg GetProjectStatusForUpdateParams) (ProjectStatus, error) {
+	row := q.db.QueryRow(ctx, getProjectStatusForUpdate, arg.ID, arg.UserID)
+	var i ProjectStatus
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.Name,
+		&i.Category,
+		&i.Color,
+		&i.WipLimit,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const listProjectStatuses = `-- name: ListProjectStatuses :many
+SELECT id, user_id, project_id, name, category, color, wip_limit, position, created_at, updated_at, deleted_at
+FROM project_statuses
+WHERE project_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY position, id
+`
+
+type ListProjectStatusesParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) ListProjectStatuses(ctx context.Context, arg ListProjectStatusesParams) ([]ProjectStatus, error) {
+	rows, err := q.db.Query(ctx, listProjectStatuses, arg.ProjectID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProjectStatus{}
+	for rows.Next() {
+		var i ProjectStatus
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.Name,
+			&i.Category,
+			&i.Color,
+			&i.WipLimit,
+			&i.Position,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockProjectStatuses = `-- name: LockProjectStatuses :exec
+SELECT pg_advisory_xact_lock(hashtextextended('project_statuses:' || $1::uuid::text, 0))
+`
+
+// Serialises position changes within one project for the rest of the
+// transaction.
+func (q *Queries) LockProjectStatuses(ctx context.Context, projectID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, lockProjectStatuses, projectID)
+	return err
+}
+
+const shiftProjectStatuses = `-- name: ShiftProjectStatuses :exec
+UPDATE project_statuses
+SET
+  position = position + $1::int,
+  updated_at = NOW()
+WHERE project_id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL
+  AND position BETWEEN $4::int AND $5::int
+`
+
+type ShiftProjectStatusesParams struct {
+	Delta        int32       `json:"delta"`
+	ProjectID    pgtype.UUID `json:"project_id"`
+	UserID       pgtype.UUID `json:"user_id"`
+	FromPosition int32       `json:"from_position"`
+	ToPosition   int32       `json:"to_position"`
+}
+
+// Moves the statuses with position in [from_position, to_position] by delta
+// (+1 or -1) to open or close a gap.
+func (q *Queries) ShiftProjectStatuses(ctx context.Context, arg ShiftProjectStatusesParams) error {
+	_, err := q.db.Exec(ctx, shiftProjectStatuses,
+		arg.Delta,
+		arg.ProjectID,
+		arg.UserID,
+		arg.FromPosition,
+		arg.ToPosition,
+	)
+	return err
+}
+
+const softDeleteProjectStatus = `-- name: SoftDeleteProjectStatus :one
+UPDATE project_statuses
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, project_id, position, deleted_at
+`
+
+type SoftDeleteProjectStatusParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type SoftDeleteProjectStatusRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	ProjectID pgtype.UUID        `json:"project_id"`
+	Position  int32              `json:"position"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+func (q *Queries) SoftDeleteProjectStatus(ctx context.Context, arg SoftDeleteProjectStatusParams) (SoftDeleteProjectStatusRow, error) {
+	row := q.db.QueryRow(ctx, softDeleteProjectStatus, arg.ID, arg.UserID)
+	var i SoftDeleteProjectStatusRow
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Position,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const softDeleteStatusesByProject = `-- name: SoftDeleteStatusesByProject :execrows
+UPDATE project_statuses
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE project_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+`
+
+type SoftDeleteStatusesByProjectParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) SoftDeleteStatusesByProject(ctx context.Context, arg SoftDeleteStatusesByProjectParams) (int64, error) {
+	result, err := q.db.Exec(ctx, softDeleteStatusesByProject, arg.ProjectID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateProjectStatus = `-- name: UpdateProjectStatus :one
+UPDATE project_statuses
+SET
+  name = $3,
+  category = $4,
+  color = $5,
+  wip_limit = $6,
+  position = $7,
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, name, category, color, wip_limit, position, created_at, updated_at, deleted_at
+`
+
+type UpdateProjectStatusParams struct {
+	ID       pgtype.UUID `json:"id"`
+	UserID   pgtype.UUID `json:"user_id"`
+	Name     string      `json:"name"`
+	Category string      `json:"category"`
+	Color    *string     `json:"color"`
+	WipLimit *int32      `json:"wip_limit"`
+	Position int32       `json:"position"`
+}
+
+func (q *Queries) UpdateProjectStatus(ctx context.Context, arg UpdateProjectStatusParams) (ProjectStatus, error) {
+	row := q.db.QueryRow(ctx, updateProjectStatus,
+		arg.ID,
+		arg.UserID,
+		arg.Name,
+		arg.Category,
+		arg.Color,
+		arg.WipLimit,
+		arg.Position,
+	)
+	var i ProjectStatus
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.Name,
+		&i.Category,
+		&i.Color,
+		&i.WipLimit,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,95 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: task_recurrences.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteTaskRecurrence = `-- name: DeleteTaskRecurrence :exec
+DELETE FROM task_recurrences r
+USING tasks t
+WHERE r.task_id = $1
+  AND t.id = r.task_id
+  AND t.user_id = $2
+`
+
+type DeleteTaskRecurrenceParams struct {
+	TaskID pgtype.UUID `json:"task_id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) DeleteTaskRecurrence(ctx context.Context, arg DeleteTaskRecurrenceParams) error {
+	_, err := q.db.Exec(ctx, deleteTaskRecurrence, arg.TaskID, arg.UserID)
+	return err
+}
+
+const listTaskRecurrences = `-- name: ListTaskRecurrences :many
+SELECT r.task_id, r.rule, r.created_at, r.updated_at
+FROM task_recurrences r
+JOIN tasks t ON t.id = r.task_id
+WHERE r.task_id = ANY($1::uuid[])
+  AND t.user_id = $2
+  AND t.deleted_at IS NULL
+`
+
+type ListTaskRecurrencesParams struct {
+	TaskIds []pgtype.UUID `json:"task_ids"`
+	UserID  pgtype.UUID   `json:"user_id"`
+}
+
+func (q *Queries) ListTaskRecurrences(ctx context.Context, arg ListTaskRecurrencesParams) ([]TaskRecurrence, error) {
+	rows, err := q.db.Query(ctx, listTaskRecurrences, arg.TaskIds, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TaskRecurrence{}
+	for rows.Next() {
+		var i TaskRecurrence
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.Rule,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertTaskRecurrence = `-- name: UpsertTaskRecurrence :one
+INSERT INTO task_recurrences (task_id, rule)
+VALUES ($1, $2)
+ON CONFLICT (task_id) DO UPDATE
+SET rule = EXCLUDED.rule,
+    updated_at = NOW()
+RETURNING task_id, rule, created_at, updated_at
+`
+
+type UpsertTaskRecurrenceParams struct {
+	TaskID pgtype.UUID `json:"task_id"`
+	Rule   string      `json:"rule"`
+}
+
+func (q *Queries) UpsertTaskRecurrence(ctx context.Context, arg UpsertTaskRecurrenceParams) (TaskRecurrence, error) {
+	row := q.db.QueryRow(ctx, upsertTaskRecurrence, arg.TaskID, arg.Rule)
+	var i TaskRecurrence
+	err := row.Scan(
+		&i.TaskID,
+		&i.Rule,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
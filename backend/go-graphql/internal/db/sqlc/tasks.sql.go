@@ -11,8 +11,246 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countOpenSubtasks = `-- name: CountOpenSubtasks :one
+SELECT COUNT(*)
+FROM tasks
+WHERE parent_task_id = $1
+  AND user_id = $2
+  AND status <> 'DONE'
+  AND deleted_at IS NULL
+`
+
+type CountOpenSubtasksParams struct {
+	ParentTaskID pgtype.UUID `json:"parent_task_id"`
+	UserID       pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) CountOpenSubtasks(ctx context.Context, arg CountOpenSubtasksParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countOpenSubtasks, arg.ParentTaskID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countRootTasks = `-- name: CountRootTasks :one
+SELECT COUNT(*)
+FROM tasks
+WHERE user_id = $1
+  AND project_id = $2
+  AND parent_task_id IS NULL
+  AND deleted_at IS NULL
+  AND (cardinality($3::text[]) = 0 OR status = ANY($3::text[]))
+  AND (cardinality($4::text[]) = 0 OR priority = ANY($4::text[]))
+  AND (
+    cardinality($5::uuid[]) = 0
+    OR (
+      SELECT COUNT(*)
+      FROM task_labels tl
+      WHERE tl.task_id = tasks.id
+        AND tl.label_id = ANY($5::uuid[])
+    ) >= CASE WHEN $6::boolean THEN cardinality($5::uuid[]) ELSE 1 END
+  )
+  AND ($7::timestamptz IS NULL OR due_at < $7::timestamptz)
+  AND ($8::timestamptz IS NULL OR due_at >= $8::timestamptz)
+  AND ($9::timestamptz IS NULL OR start_at < $9::timestamptz)
+  AND ($10::timestamptz IS NULL OR start_at >= $10::timestamptz)
+  AND ($11::timestamptz IS NULL OR completed_at >= $11::timestamptz)
+  AND ($12::timestamptz IS NULL OR completed_at < $12::timestamptz)
+  AND ($13::boolean IS NULL OR (due_at IS NOT NULL) = $13::boolean)
+  AND ($14::timestamptz IS NULL OR updated_at >= $14::timestamptz)
+  AND ($15::text IS NULL OR title ILIKE $15::text)
+  AND ($16::uuid IS NULL OR section_id = $16::uuid)
+  AND ($17::uuid IS NULL OR status_id = $17::uuid)
+`
+
+type CountRootTasksParams struct {
+	UserID         pgtype.UUID        `json:"user_id"`
+	ProjectID      pgtype.UUID        `json:"project_id"`
+	Statuses       []string           `json:"statuses"`
+	Priorities     []string           `json:"priorities"`
+	LabelIds       []pgtype.UUID      `json:"label_ids"`
+	MatchAllLabels bool               `json:"match_all_labels"`
+	DueBefore      pgtype.Timestamptz `json:"due_before"`
+	DueAfter       pgtype.Timestamptz `json:"due_after"`
+	StartBefore    pgtype.Timestamptz `json:"start_before"`
+	StartAfter     pgtype.Timestamptz `json:"start_after"`
+	CompletedFrom  pgtype.Timestamptz `json:"completed_from"`
+	CompletedTo    pgtype.Timestamptz `json:"completed_to"`
+	HasDueDate     *bool              `json:"has_due_date"`
+	UpdatedSince   pgtype.Timestamptz `json:"updated_since"`
+	TitlePattern   *string            `json:"title_pattern"`
+	SectionID      pgtype.UUID        `json:"section_id"`
+	StatusID       pgtype.UUID        `json:"status_id"`
+}
+
+func (q *Queries) CountRootTasks(ctx context.Context, arg CountRootTasksParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countRootTasks,
+		arg.UserID,
+		arg.ProjectID,
+		arg.Statuses,
+		arg.Priorities,
+		arg.LabelIds,
+		arg.MatchAllLabels,
+		arg.DueBefore,
+		arg.DueAfter,
+		arg.StartBefore,
+		arg.StartAfter,
+		arg.CompletedFrom,
+		arg.CompletedTo,
+		arg.HasDueDate,
+		arg.UpdatedSince,
+		arg.TitlePattern,
+		arg.SectionID,
+		arg.StatusID,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countRootTasksByStatus = `-- name: CountRootTasksByStatus :many
+SELECT status_id, COUNT(*) AS task_count
+FROM tasks
+WHERE project_id = $1
+  AND user_id = $2
+  AND parent_task_id IS NULL
+  AND deleted_at IS NULL
+GROUP BY status_id
+`
+
+type CountRootTasksByStatusParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+type CountRootTasksByStatusRow struct {
+	StatusID  pgtype.UUID `json:"status_id"`
+	TaskCount int64       `json:"task_count"`
+}
+
+// Board column counts: live root tasks of a project per workflow status.
+func (q *Queries) CountRootTasksByStatus(ctx context.Context, arg CountRootTasksByStatusParams) ([]CountRootTasksByStatusRow, error) {
+	rows, err := q.db.Query(ctx, countRootTasksByStatus, arg.ProjectID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountRootTasksByStatusRow{}
+	for rows.Next() {
+		var i CountRootTasksByStatusRow
+		if err := rows.Scan(&i.StatusID, &i.TaskCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countRootTasksInStatus = `-- name: CountRootTasksInStatus :one
+SELECT COUNT(*)
+FROM tasks
+WHERE status_id = $1
+  AND user_id = $2
+  AND parent_task_id IS NULL
+  AND deleted_at IS NULL
+`
+
+type CountRootTasksInStatusParams struct {
+	StatusID pgtype.UUID `json:"status_id"`
+	UserID   pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) CountRootTasksInStatus(ctx context.Context, arg CountRootTasksInStatusParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countRootTasksInStatus, arg.StatusID, arg.UserID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countSubtasks = `-- name: CountSubtasks :one
+SELECT COUNT(*)
+FROM tasks
+WHERE user_id = $1
+  AND project_id = $2
+  AND parent_task_id = $3
+  AND deleted_at IS NULL
+  AND (cardinality($4::text[]) = 0 OR status = ANY($4::text[]))
+  AND (cardinality($5::text[]) = 0 OR priority = ANY($5::text[]))
+  AND (
+    cardinality($6::uuid[]) = 0
+    OR (
+      SELECT COUNT(*)
+      FROM task_labels tl
+      WHERE tl.task_id = tasks.id
+        AND tl.label_id = ANY($6::uuid[])
+    ) >= CASE WHEN $7::boolean THEN cardinality($6::uuid[]) ELSE 1 END
+  )
+  AND ($8::timestamptz IS NULL OR due_at < $8::timestamptz)
+  AND ($9::timestamptz IS NULL OR due_at >= $9::timestamptz)
+  AND ($10::timestamptz IS NULL OR start_at < $10::timestamptz)
+  AND ($11::timestamptz IS NULL OR start_at >= $11::timestamptz)
+  AND ($12::timestamptz IS NULL OR completed_at >= $12::timestamptz)
+  AND ($13::timestamptz IS NULL OR completed_at < $13::timestamptz)
+  AND ($14::boolean IS NULL OR (due_at IS NOT NULL) = $14::boolean)
+  AND ($15::timestamptz IS NULL OR updated_at >= $15::timestamptz)
+  AND ($16::text IS NULL OR title ILIKE $16::text)
+  AND ($17::uuid IS NULL OR section_id = $17::uuid)
+  AND ($18::uuid IS NULL OR status_id = $18::uuid)
+`
+
+type CountSubtasksParams struct {
+	UserID         pgtype.UUID        `json:"user_id"`
+	ProjectID      pgtype.UUID        `json:"project_id"`
+	ParentTaskID   pgtype.UUID        `json:"parent_task_id"`
+	Statuses       []string           `json:"statuses"`
+	Priorities     []string           `json:"priorities"`
+	LabelIds       []pgtype.UUID      `json:"label_ids"`
+	MatchAllLabels bool               `json:"match_all_labels"`
+	DueBefore      pgtype.Timestamptz `json:"due_before"`
+	DueAfter       pgtype.Timestamptz `json:"due_after"`
+	StartBefore    pgtype.Timestamptz `json:"start_before"`
+	StartAfter     pgtype.Timestamptz `json:"start_after"`
+	CompletedFrom  pgtype.Timestamptz `json:"completed_from"`
+	CompletedTo    pgtype.Timestamptz `json:"completed_to"`
+	HasDueDate     *bool              `json:"has_due_date"`
+	UpdatedSince   pgtype.Timestamptz `json:"updated_since"`
+	TitlePattern   *string            `json:"title_pattern"`
+	SectionID      pgtype.UUID        `json:"section_id"`
+	StatusID       pgtype.UUID        `json:"status_id"`
+}
+
+func (q *Queries) CountSubtasks(ctx context.Context, arg CountSubtasksParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countSubtasks,
+		arg.UserID,
+		arg.ProjectID,
+		arg.ParentTaskID,
+		arg.Statuses,
+		arg.Priorities,
+		arg.LabelIds,
+		arg.MatchAllLabels,
+		arg.DueBefore,
+		arg.DueAfter,
+		arg.StartBefore,
+		arg.StartAfter,
+		arg.CompletedFrom,
+		arg.CompletedTo,
+		arg.HasDueDate,
+		arg.UpdatedSince,
+		arg.TitlePattern,
+		arg.SectionID,
+		arg.StatusID,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTask = `-- name: CreateTask :one
 INSERT INTO tasks (
+  id,
   user_id,
   project_id,
   parent_task_id,
@@ -22,25 +260,35 @@ INSERT INTO tasks (
   priority,
   start_at,
   due_at,
-  completed_at
+  completed_at,
+  section_id,
+  status_id,
+  blocked_reason,
+  estimate_minutes
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at
+VALUES (COALESCE($15::uuid, gen_random_uuid()), $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
 `
 
 type CreateTaskParams struct {
-	UserID       pgtype.UUID        `json:"user_id"`
-	ProjectID    pgtype.UUID        `json:"project_id"`
-	ParentTaskID pgtype.UUID        `json:"parent_task_id"`
-	Title        string             `json:"title"`
-	Description  *string            `json:"description"`
-	Status       string             `json:"status"`
-	Priority     string             `json:"priority"`
-	StartAt      pgtype.Timestamptz `json:"start_at"`
-	DueAt        pgtype.Timestamptz `json:"due_at"`
-	CompletedAt  pgtype.Timestamptz `json:"completed_at"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	ProjectID       pgtype.UUID        `json:"project_id"`
+	ParentTaskID    pgtype.UUID        `json:"parent_task_id"`
+	Title           string             `json:"title"`
+	Description     *string            `json:"description"`
+	Status          string             `json:"status"`
+	Priority        string             `json:"priority"`
+	StartAt         pgtype.Timestamptz `json:"start_at"`
+	DueAt           pgtype.Timestamptz `json:"due_at"`
+	CompletedAt     pgtype.Timestamptz `json:"completed_at"`
+	SectionID       pgtype.UUID        `json:"section_id"`
+	StatusID        pgtype.UUID        `json:"status_id"`
+	BlockedReason   *string            `json:"blocked_reason"`
+	EstimateMinutes *int32             `json:"estimate_minutes"`
+	ID              pgtype.UUID        `json:"id"`
 }
 
+// A null id gets a random one; CalDAV clients pick their own.
 func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, error) {
 	row := q.db.QueryRow(ctx, createTask,
 		arg.UserID,
@@ -53,6 +301,11 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 		arg.StartAt,
 		arg.DueAt,
 		arg.CompletedAt,
+		arg.SectionID,
+		arg.StatusID,
+		arg.BlockedReason,
+		arg.EstimateMinutes,
+		arg.ID,
 	)
 	var i Task
 	err := row.Scan(
@@ -70,12 +323,16 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) (Task, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SectionID,
+		&i.StatusID,
+		&i.BlockedReason,
+		&i.EstimateMinutes,
 	)
 	return i, err
 }
 
 const getTaskByID = `-- name: GetTaskByID :one
-SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
 FROM tasks
 WHERE id = $1
   AND user_id = $2
@@ -106,12 +363,32 @@ func (q *Queries) GetTaskByID(ctx context.Context, arg GetTaskByIDParams) (Task,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SectionID,
+		&i.StatusID,
+		&i.BlockedReason,
+		&i.EstimateMinutes,
 	)
 	return i, err
 }
 
+const insertTaskStatusChange = `-- name: InsertTaskStatusChange :exec
+INSERT INTO task_status_changes (task_id, user_id, status)
+VALUES ($1, $2, $3)
+`
+
+type InsertTaskStatusChangeParams struct {
+	TaskID pgtype.UUID `json:"task_id"`
+	UserID pgtype.UUID `json:"user_id"`
+	Status string      `json:"status"`
+}
+
+func (q *Queries) InsertTaskStatusChange(ctx context.Context, arg InsertTaskStatusChangeParams) error {
+	_, err := q.db.Exec(ctx, insertTaskStatusChange, arg.TaskID, arg.UserID, arg.Status)
+	return err
+}
+
 const listRootTasks = `-- name: ListRootTasks :many
-SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
 FROM tasks
 WHERE user_id = $1
   AND project_id = $2
@@ -120,34 +397,202 @@ WHERE user_id = $1
   AND (cardinality($3::text[]) = 0 OR status = ANY($3::text[]))
   AND (cardinality($4::text[]) = 0 OR priority = ANY($4::text[]))
   AND (
-    NOT $5::boolean
-    OR (created_at, id) < ($6::timestamptz, $7::uuid)
+    cardinality($5::uuid[]) = 0
+    OR (
+      SELECT COUNT(*)
+      FROM task_labels tl
+      WHERE tl.task_id = tasks.id
+        AND tl.label_id = ANY($5::uuid[])
+    ) >= CASE WHEN $6::boolean THEN cardinality($5::uuid[]) ELSE 1 END
+  )
+  AND ($7::timestamptz IS NULL OR due_at < $7::timestamptz)
+  AND ($8::timestamptz IS NULL OR due_at >= $8::timestamptz)
+  AND ($9::timestamptz IS NULL OR start_at < $9::timestamptz)
+  AND ($10::timestamptz IS NULL OR start_at >= $10::timestamptz)
+  AND ($11::timestamptz IS NULL OR completed_at >= $11::timestamptz)
+  AND ($12::timestamptz IS NULL OR completed_at < $12::timestamptz)
+  AND ($13::boolean IS NULL OR (due_at IS NOT NULL) = $13::boolean)
+  AND ($14::timestamptz IS NULL OR updated_at >= $14::timestamptz)
+  AND ($15::text IS NULL OR title ILIKE $15::text)
+  AND ($16::uuid IS NULL OR section_id = $16::uuid)
+  AND ($17::uuid IS NULL OR status_id = $17::uuid)
+  AND (
+    NOT $18::boolean
+    OR (created_at, id) < ($19::timestamptz, $20::uuid)
   )
 ORDER BY created_at DESC, id DESC
-LIMIT $8
+LIMIT $21
 `
 
 type ListRootTasksParams struct {
-	UserID    pgtype.UUID        `json:"user_id"`
-	ProjectID pgtype.UUID        `json:"project_id"`
-	Column3   []string           `json:"column_3"`
-	Column4   []string           `json:"column_4"`
-	Column5   bool               `json:"column_5"`
-	Column6   pgtype.Timestamptz `json:"column_6"`
-	Column7   pgtype.UUID        `json:"column_7"`
-	Limit     int32              `json:"limit"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	ProjectID       pgtype.UUID        `json:"project_id"`
+	Statuses        []string           `json:"statuses"`
+	Priorities      []string           `json:"priorities"`
+	LabelIds        []pgtype.UUID      `json:"label_ids"`
+	MatchAllLabels  bool               `json:"match_all_labels"`
+	DueBefore       pgtype.Timestamptz `json:"due_before"`
+	DueAfter        pgtype.Timestamptz `json:"due_after"`
+	StartBefore     pgtype.Timestamptz `json:"start_before"`
+	StartAfter      pgtype.Timestamptz `json:"start_after"`
+	CompletedFrom   pgtype.Timestamptz `json:"completed_from"`
+	CompletedTo     pgtype.Timestamptz `json:"completed_to"`
+	HasDueDate      *bool              `json:"has_due_date"`
+	UpdatedSince    pgtype.Timestamptz `json:"updated_since"`
+	TitlePattern    *string            `json:"title_pattern"`
+	SectionID       pgtype.UUID        `json:"section_id"`
+	StatusID        pgtype.UUID        `json:"status_id"`
+	UseCursor       bool               `json:"use_cursor"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.UUID        `json:"cursor_id"`
+	RowLimit        int32              `json:"row_limit"`
 }
 
 func (q *Queries) ListRootTasks(ctx context.Context, arg ListRootTasksParams) ([]Task, error) {
 	rows, err := q.db.Query(ctx, listRootTasks,
 		arg.UserID,
 		arg.ProjectID,
-		arg.Column3,
-		arg.Column4,
-		arg.Column5,
-		arg.Column6,
-		arg.Column7,
-		arg.Limit,
+		arg.Statuses,
+		arg.Priorities,
+		arg.LabelIds,
+		arg.MatchAllLabels,
+		arg.DueBefore,
+		arg.DueAfter,
+		arg.StartBefore,
+		arg.StartAfter,
+		arg.CompletedFrom,
+		arg.CompletedTo,
+		arg.HasDueDate,
+		arg.UpdatedSince,
+		arg.TitlePattern,
+		arg.SectionID,
+		arg.StatusID,
+		arg.UseCursor,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRootTasksBefore = `-- name: ListRootTasksBefore :many
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM tasks
+WHERE user_id = $1
+  AND project_id = $2
+  AND parent_task_id IS NULL
+  AND deleted_at IS NULL
+  AND (cardinality($3::text[]) = 0 OR status = ANY($3::text[]))
+  AND (cardinality($4::text[]) = 0 OR priority = ANY($4::text[]))
+  AND (
+    cardinality($5::uuid[]) = 0
+    OR (
+      SELECT COUNT(*)
+      FROM task_labels tl
+      WHERE tl.task_id = tasks.id
+        AND tl.label_id = ANY($5::uuid[])
+    ) >= CASE WHEN $6::boolean THEN cardinality($5::uuid[]) ELSE 1 END
+  )
+  AND ($7::timestamptz IS NULL OR due_at < $7::timestamptz)
+  AND ($8::timestamptz IS NULL OR due_at >= $8::timestamptz)
+  AND ($9::timestamptz IS NULL OR start_at < $9::timestamptz)
+  AND ($10::timestamptz IS NULL OR start_at >= $10::timestamptz)
+  AND ($11::timestamptz IS NULL OR completed_at >= $11::timestamptz)
+  AND ($12::timestamptz IS NULL OR completed_at < $12::timestamptz)
+  AND ($13::boolean IS NULL OR (due_at IS NOT NULL) = $13::boolean)
+  AND ($14::timestamptz IS NULL OR updated_at >= $14::timestamptz)
+  AND ($15::text IS NULL OR title ILIKE $15::text)
+  AND ($16::uuid IS NULL OR section_id = $16::uuid)
+  AND ($17::uuid IS NULL OR status_id = $17::uuid)
+  AND (
+    NOT $18::boolean
+    OR (created_at, id) > ($19::timestamptz, $20::uuid)
+  )
+ORDER BY created_at ASC, id ASC
+LIMIT $21
+`
+
+type ListRootTasksBeforeParams struct {
+	UserID          pgtype.UUID        `json:"user_id"`
+	ProjectID       pgtype.UUID        `json:"project_id"`
+	Statuses        []string           `json:"statuses"`
+	Priorities      []string           `json:"priorities"`
+	LabelIds        []pgtype.UUID      `json:"label_ids"`
+	MatchAllLabels  bool               `json:"match_all_labels"`
+	DueBefore       pgtype.Timestamptz `json:"due_before"`
+	DueAfter        pgtype.Timestamptz `json:"due_after"`
+	StartBefore     pgtype.Timestamptz `json:"start_before"`
+	StartAfter      pgtype.Timestamptz `json:"start_after"`
+	CompletedFrom   pgtype.Timestamptz `json:"completed_from"`
+	CompletedTo     pgtype.Timestamptz `json:"completed_to"`
+	HasDueDate      *bool              `json:"has_due_date"`
+	UpdatedSince    pgtype.Timestamptz `json:"updated_since"`
+	TitlePattern    *string            `json:"title_pattern"`
+	SectionID       pgtype.UUID        `json:"section_id"`
+	StatusID        pgtype.UUID        `json:"status_id"`
+	UseCursor       bool               `json:"use_cursor"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.UUID        `json:"cursor_id"`
+	RowLimit        int32              `json:"row_limit"`
+}
+
+func (q *Queries) ListRootTasksBefore(ctx context.Context, arg ListRootTasksBeforeParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listRootTasksBefore,
+		arg.UserID,
+		arg.ProjectID,
+		arg.Statuses,
+		arg.Priorities,
+		arg.LabelIds,
+		arg.MatchAllLabels,
+		arg.DueBefore,
+		arg.DueAfter,
+		arg.StartBefore,
+		arg.StartAfter,
+		arg.CompletedFrom,
+		arg.CompletedTo,
+		arg.HasDueDate,
+		arg.UpdatedSince,
+		arg.TitlePattern,
+		arg.SectionID,
+		arg.StatusID,
+		arg.UseCursor,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.RowLimit,
 	)
 	if err != nil {
 		return nil, err
@@ -171,6 +616,10 @@ func (q *Queries) ListRootTasks(ctx context.Context, arg ListRootTasksParams) ([
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
 		); err != nil {
 			return nil, err
 		}
@@ -183,7 +632,7 @@ func (q *Queries) ListRootTasks(ctx context.Context, arg ListRootTasksParams) ([
 }
 
 const listSubtasks = `-- name: ListSubtasks :many
-SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
 FROM tasks
 WHERE user_id = $1
   AND project_id = $2
@@ -192,23 +641,56 @@ WHERE user_id = $1
   AND (cardinality($4::text[]) = 0 OR status = ANY($4::text[]))
   AND (cardinality($5::text[]) = 0 OR priority = ANY($5::text[]))
   AND (
-    NOT $6::boolean
-    OR (created_at, id) < ($7::timestamptz, $8::uuid)
+    cardinality($6::uuid[]) = 0
+    OR (
+      SELECT COUNT(*)
+      FROM task_labels tl
+      WHERE tl.task_id = tasks.id
+        AND tl.label_id = ANY($6::uuid[])
+    ) >= CASE WHEN $7::boolean THEN cardinality($6::uuid[]) ELSE 1 END
+  )
+  AND ($8::timestamptz IS NULL OR due_at < $8::timestamptz)
+  AND ($9::timestamptz IS NULL OR due_at >= $9::timestamptz)
+  AND ($10::timestamptz IS NULL OR start_at < $10::timestamptz)
+  AND ($11::timestamptz IS NULL OR start_at >= $11::timestamptz)
+  AND ($12::timestamptz IS NULL OR completed_at >= $12::timestamptz)
+  AND ($13::timestamptz IS NULL OR completed_at < $13::timestamptz)
+  AND ($14::boolean IS NULL OR (due_at IS NOT NULL) = $14::boolean)
+  AND ($15::timestamptz IS NULL OR updated_at >= $15::timestamptz)
+  AND ($16::text IS NULL OR title ILIKE $16::text)
+  AND ($17::uuid IS NULL OR section_id = $17::uuid)
+  AND ($18::uuid IS NULL OR status_id = $18::uuid)
+  AND (
+    NOT $19::boolean
+    OR (created_at, id) < ($20::timestamptz, $21::uuid)
   )
 ORDER BY created_at DESC, id DESC
-LIMIT $9
+LIMIT $22
 `
 
 type ListSubtasksParams struct {
-	UserID       pgtype.UUID        `json:"user_id"`
-	ProjectID    pgtype.UUID        `json:"project_id"`
-	ParentTaskID pgtype.UUID        `json:"parent_task_id"`
-	Column4      []string           `json:"column_4"`
-	Column5      []string           `json:"column_5"`
-	Column6      bool               `json:"column_6"`
-	Column7      pgtype.Timestamptz `json:"column_7"`
-	Column8      pgtype.UUID        `json:"column_8"`
-	Limit        int32              `json:"limit"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	ProjectID       pgtype.UUID        `json:"project_id"`
+	ParentTaskID    pgtype.UUID        `json:"parent_task_id"`
+	Statuses        []string           `json:"statuses"`
+	Priorities      []string           `json:"priorities"`
+	LabelIds        []pgtype.UUID      `json:"label_ids"`
+	MatchAllLabels  bool               `json:"match_all_labels"`
+	DueBefore       pgtype.Timestamptz `json:"due_before"`
+	DueAfter        pgtype.Timestamptz `json:"due_after"`
+	StartBefore     pgtype.Timestamptz `json:"start_before"`
+	StartAfter      pgtype.Timestamptz `json:"start_after"`
+	CompletedFrom   pgtype.Timestamptz `json:"completed_from"`
+	CompletedTo     pgtype.Timestamptz `json:"completed_to"`
+	HasDueDate      *bool              `json:"has_due_date"`
+	UpdatedSince    pgtype.Timestamptz `json:"updated_since"`
+	TitlePattern    *string            `json:"title_pattern"`
+	SectionID       pgtype.UUID        `json:"section_id"`
+	StatusID        pgtype.UUID        `json:"status_id"`
+	UseCursor       bool               `json:"use_cursor"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.UUID        `json:"cursor_id"`
+	RowLimit        int32              `json:"row_limit"`
 }
 
 func (q *Queries) ListSubtasks(ctx context.Context, arg ListSubtasksParams) ([]Task, error) {
@@ -216,12 +698,25 @@ func (q *Queries) ListSubtasks(ctx context.Context, arg ListSubtasksParams) ([]T
 		arg.UserID,
 		arg.ProjectID,
 		arg.ParentTaskID,
-		arg.Column4,
-		arg.Column5,
-		arg.Column6,
-		arg.Column7,
-		arg.Column8,
-		arg.Limit,
+		arg.Statuses,
+		arg.Priorities,
+		arg.LabelIds,
+		arg.MatchAllLabels,
+		arg.DueBefore,
+		arg.DueAfter,
+		arg.StartBefore,
+		arg.StartAfter,
+		arg.CompletedFrom,
+		arg.CompletedTo,
+		arg.HasDueDate,
+		arg.UpdatedSince,
+		arg.TitlePattern,
+		arg.SectionID,
+		arg.StatusID,
+		arg.UseCursor,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.RowLimit,
 	)
 	if err != nil {
 		return nil, err
@@ -245,6 +740,134 @@ func (q *Queries) ListSubtasks(ctx context.Context, arg ListSubtasksParams) ([]T
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSubtasksBefore = `-- name: ListSubtasksBefore :many
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM tasks
+WHERE user_id = $1
+  AND project_id = $2
+  AND parent_task_id = $3
+  AND deleted_at IS NULL
+  AND (cardinality($4::text[]) = 0 OR status = ANY($4::text[]))
+  AND (cardinality($5::text[]) = 0 OR priority = ANY($5::text[]))
+  AND (
+    cardinality($6::uuid[]) = 0
+    OR (
+      SELECT COUNT(*)
+      FROM task_labels tl
+      WHERE tl.task_id = tasks.id
+        AND tl.label_id = ANY($6::uuid[])
+    ) >= CASE WHEN $7::boolean THEN cardinality($6::uuid[]) ELSE 1 END
+  )
+  AND ($8::timestamptz IS NULL OR due_at < $8::timestamptz)
+  AND ($9::timestamptz IS NULL OR due_at >= $9::timestamptz)
+  AND ($10::timestamptz IS NULL OR start_at < $10::timestamptz)
+  AND ($11::timestamptz IS NULL OR start_at >= $11::timestamptz)
+  AND ($12::timestamptz IS NULL OR completed_at >= $12::timestamptz)
+  AND ($13::timestamptz IS NULL OR completed_at < $13::timestamptz)
+  AND ($14::boolean IS NULL OR (due_at IS NOT NULL) = $14::boolean)
+  AND ($15::timestamptz IS NULL OR updated_at >= $15::timestamptz)
+  AND ($16::text IS NULL OR title ILIKE $16::text)
+  AND ($17::uuid IS NULL OR section_id = $17::uuid)
+  AND ($18::uuid IS NULL OR status_id = $18::uuid)
+  AND (
+    NOT $19::boolean
+    OR (created_at, id) > ($20::timestamptz, $21::uuid)
+  )
+ORDER BY created_at ASC, id ASC
+LIMIT $22
+`
+
+type ListSubtasksBeforeParams struct {
+	UserID          pgtype.UUID        `json:"user_id"`
+	ProjectID       pgtype.UUID        `json:"project_id"`
+	ParentTaskID    pgtype.UUID        `json:"parent_task_id"`
+	Statuses        []string           `json:"statuses"`
+	Priorities      []string           `json:"priorities"`
+	LabelIds        []pgtype.UUID      `json:"label_ids"`
+	MatchAllLabels  bool               `json:"match_all_labels"`
+	DueBefore       pgtype.Timestamptz `json:"due_before"`
+	DueAfter        pgtype.Timestamptz `json:"due_after"`
+	StartBefore     pgtype.Timestamptz `json:"start_before"`
+	StartAfter      pgtype.Timestamptz `json:"start_after"`
+	CompletedFrom   pgtype.Timestamptz `json:"completed_from"`
+	CompletedTo     pgtype.Timestamptz `json:"completed_to"`
+	HasDueDate      *bool              `json:"has_due_date"`
+	UpdatedSince    pgtype.Timestamptz `json:"updated_since"`
+	TitlePattern    *string            `json:"title_pattern"`
+	SectionID       pgtype.UUID        `json:"section_id"`
+	StatusID        pgtype.UUID        `json:"status_id"`
+	UseCursor       bool               `json:"use_cursor"`
+	CursorCreatedAt pgtype.Timestamptz `json:"cursor_created_at"`
+	CursorID        pgtype.UUID        `json:"cursor_id"`
+	RowLimit        int32              `json:"row_limit"`
+}
+
+func (q *Queries) ListSubtasksBefore(ctx context.Context, arg ListSubtasksBeforeParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, listSubtasksBefore,
+		arg.UserID,
+		arg.ProjectID,
+		arg.ParentTaskID,
+		arg.Statuses,
+		arg.Priorities,
+		arg.LabelIds,
+		arg.MatchAllLabels,
+		arg.DueBefore,
+		arg.DueAfter,
+		arg.StartBefore,
+		arg.StartAfter,
+		arg.CompletedFrom,
+		arg.CompletedTo,
+		arg.HasDueDate,
+		arg.UpdatedSince,
+		arg.TitlePattern,
+		arg.SectionID,
+		arg.StatusID,
+		arg.UseCursor,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
 		); err != nil {
 			return nil, err
 		}
@@ -257,7 +880,7 @@ func (q *Queries) ListSubtasks(ctx context.Context, arg ListSubtasksParams) ([]T
 }
 
 const listSubtasksByParentID = `-- name: ListSubtasksByParentID :many
-SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
 FROM tasks
 WHERE user_id = $1
   AND parent_task_id = $2
@@ -294,6 +917,278 @@ func (q *Queries) ListSubtasksByParentID(ctx context.Context, arg ListSubtasksBy
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockTask = `-- name: LockTask :exec
+SELECT id
+FROM tasks
+WHERE id = $1
+  AND user_id = $2
+FOR UPDATE
+`
+
+type LockTaskParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+// Serialises concurrent subtask roll-ups onto the same parent.
+func (q *Queries) LockTask(ctx context.Context, arg LockTaskParams) error {
+	_, err := q.db.Exec(ctx, lockTask, arg.ID, arg.UserID)
+	return err
+}
+
+const moveTasksToSection = `-- name: MoveTasksToSection :many
+UPDATE tasks
+SET
+  section_id = $1,
+  updated_at = NOW()
+WHERE section_id = $2
+  AND user_id = $3
+  AND deleted_at IS NULL
+RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+`
+
+type MoveTasksToSectionParams struct {
+	NewSectionID pgtype.UUID `json:"new_section_id"`
+	SectionID    pgtype.UUID `json:"section_id"`
+	UserID       pgtype.UUID `json:"user_id"`
+}
+
+// Re-homes the tasks of a section being deleted; a NULL new_section_id
+// leaves them unsectioned.
+func (q *Queries) MoveTasksToSection(ctx context.Context, arg MoveTasksToSectionParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, moveTasksToSection, arg.NewSectionID, arg.SectionID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moveTasksToStatus = `-- name: MoveTasksToStatus :many
+WITH moved AS (
+  UPDATE tasks t
+  SET
+    status_id = $1,
+    status = $2::text,
+    completed_at = CASE
+      WHEN $2::text = 'DONE' THEN COALESCE(t.completed_at, NOW())
+      ELSE NULL
+    END,
+    blocked_reason = CASE WHEN $2::text = 'BLOCKED' THEN t.blocked_reason ELSE NULL END,
+    updated_at = NOW()
+  WHERE t.status_id = $3
+    AND t.user_id = $4
+    AND t.deleted_at IS NULL
+  RETURNING t.id, t.user_id, t.project_id, t.parent_task_id, t.title, t.description, t.status, t.priority, t.start_at, t.due_at, t.completed_at, t.created_at, t.updated_at, t.deleted_at, t.section_id, t.status_id, t.blocked_reason, t.estimate_minutes
+), logged AS (
+  INSERT INTO task_status_changes (task_id, user_id, status)
+  SELECT id, user_id, status FROM moved
+)
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM moved
+`
+
+type MoveTasksToStatusParams struct {
+	NewStatusID pgtype.UUID `json:"new_status_id"`
+	Category    string      `json:"category"`
+	StatusID    pgtype.UUID `json:"status_id"`
+	UserID      pgtype.UUID `json:"user_id"`
+}
+
+type MoveTasksToStatusRow struct {
+	ID              pgtype.UUID        `json:"id"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	ProjectID       pgtype.UUID        `json:"project_id"`
+	ParentTaskID    pgtype.UUID        `json:"parent_task_id"`
+	Title           string             `json:"title"`
+	Description     *string            `json:"description"`
+	Status          string             `json:"status"`
+	Priority        string             `json:"priority"`
+	StartAt         pgtype.Timestamptz `json:"start_at"`
+	DueAt           pgtype.Timestamptz `json:"due_at"`
+	CompletedAt     pgtype.Timestamptz `json:"completed_at"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
+	SectionID       pgtype.UUID        `json:"section_id"`
+	StatusID        pgtype.UUID        `json:"status_id"`
+	BlockedReason   *string            `json:"blocked_reason"`
+	EstimateMinutes *int32             `json:"estimate_minutes"`
+}
+
+// Re-homes the live tasks of a status being deleted and brings their category
+// and completion time in line with the new status, recording the status
+// change. Returns the moved tasks.
+func (q *Queries) MoveTasksToStatus(ctx context.Context, arg MoveTasksToStatusParams) ([]MoveTasksToStatusRow, error) {
+	rows, err := q.db.Query(ctx, moveTasksToStatus,
+		arg.NewStatusID,
+		arg.Category,
+		arg.StatusID,
+		arg.UserID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []MoveTasksToStatusRow{}
+	for rows.Next() {
+		var i MoveTasksToStatusRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setTaskCategoryForStatus = `-- name: SetTaskCategoryForStatus :many
+WITH changed AS (
+  UPDATE tasks t
+  SET
+    status = $1::text,
+    completed_at = CASE
+      WHEN $1::text = 'DONE' THEN COALESCE(t.completed_at, NOW())
+      ELSE NULL
+    END,
+    blocked_reason = CASE WHEN $1::text = 'BLOCKED' THEN t.blocked_reason ELSE NULL END,
+    updated_at = NOW()
+  WHERE t.status_id = $2
+    AND t.user_id = $3
+    AND t.status <> $1::text
+    AND t.deleted_at IS NULL
+  RETURNING t.id, t.user_id, t.project_id, t.parent_task_id, t.title, t.description, t.status, t.priority, t.start_at, t.due_at, t.completed_at, t.created_at, t.updated_at, t.deleted_at, t.section_id, t.status_id, t.blocked_reason, t.estimate_minutes
+), logged AS (
+  INSERT INTO task_status_changes (task_id, user_id, status)
+  SELECT id, user_id, status FROM changed
+)
+SELECT id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
+FROM changed
+`
+
+type SetTaskCategoryForStatusParams struct {
+	Category string      `json:"category"`
+	StatusID pgtype.UUID `json:"status_id"`
+	UserID   pgtype.UUID `json:"user_id"`
+}
+
+type SetTaskCategoryForStatusRow struct {
+	ID              pgtype.UUID        `json:"id"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	ProjectID       pgtype.UUID        `json:"project_id"`
+	ParentTaskID    pgtype.UUID        `json:"parent_task_id"`
+	Title           string             `json:"title"`
+	Description     *string            `json:"description"`
+	Status          string             `json:"status"`
+	Priority        string             `json:"priority"`
+	StartAt         pgtype.Timestamptz `json:"start_at"`
+	DueAt           pgtype.Timestamptz `json:"due_at"`
+	CompletedAt     pgtype.Timestamptz `json:"completed_at"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
+	SectionID       pgtype.UUID        `json:"section_id"`
+	StatusID        pgtype.UUID        `json:"status_id"`
+	BlockedReason   *string            `json:"blocked_reason"`
+	EstimateMinutes *int32             `json:"estimate_minutes"`
+}
+
+// Applies a status's new category to the tasks already in it, recording the
+// status change. Returns the changed tasks.
+func (q *Queries) SetTaskCategoryForStatus(ctx context.Context, arg SetTaskCategoryForStatusParams) ([]SetTaskCategoryForStatusRow, error) {
+	rows, err := q.db.Query(ctx, setTaskCategoryForStatus, arg.Category, arg.StatusID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SetTaskCategoryForStatusRow{}
+	for rows.Next() {
+		var i SetTaskCategoryForStatusRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
 		); err != nil {
 			return nil, err
 		}
@@ -305,7 +1200,7 @@ func (q *Queries) ListSubtasksByParentID(ctx context.Context, arg ListSubtasksBy
 	return items, nil
 }
 
-const softDeleteDirectSubtasks = `-- name: SoftDeleteDirectSubtasks :execrows
+const softDeleteDirectSubtasks = `-- name: SoftDeleteDirectSubtasks :many
 UPDATE tasks
 SET
   deleted_at = NOW(),
@@ -313,6 +1208,7 @@ SET
 WHERE parent_task_id = $1
   AND user_id = $2
   AND deleted_at IS NULL
+RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
 `
 
 type SoftDeleteDirectSubtasksParams struct {
@@ -320,12 +1216,43 @@ type SoftDeleteDirectSubtasksParams struct {
 	UserID       pgtype.UUID `json:"user_id"`
 }
 
-func (q *Queries) SoftDeleteDirectSubtasks(ctx context.Context, arg SoftDeleteDirectSubtasksParams) (int64, error) {
-	result, err := q.db.Exec(ctx, softDeleteDirectSubtasks, arg.ParentTaskID, arg.UserID)
+func (q *Queries) SoftDeleteDirectSubtasks(ctx context.Context, arg SoftDeleteDirectSubtasksParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, softDeleteDirectSubtasks, arg.ParentTaskID, arg.UserID)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return result.RowsAffected(), nil
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const softDeleteTask = `-- name: SoftDeleteTask :one
@@ -336,7 +1263,7 @@ SET
 WHERE id = $1
   AND user_id = $2
   AND deleted_at IS NULL
-RETURNING id, deleted_at
+RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
 `
 
 type SoftDeleteTaskParams struct {
@@ -344,19 +1271,33 @@ type SoftDeleteTaskParams struct {
 	UserID pgtype.UUID `json:"user_id"`
 }
 
-type SoftDeleteTaskRow struct {
-	ID        pgtype.UUID        `json:"id"`
-	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
-}
-
-func (q *Queries) SoftDeleteTask(ctx context.Context, arg SoftDeleteTaskParams) (SoftDeleteTaskRow, error) {
+func (q *Queries) SoftDeleteTask(ctx context.Context, arg SoftDeleteTaskParams) (Task, error) {
 	row := q.db.QueryRow(ctx, softDeleteTask, arg.ID, arg.UserID)
-	var i SoftDeleteTaskRow
-	err := row.Scan(&i.ID, &i.DeletedAt)
+	var i Task
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ProjectID,
+		&i.ParentTaskID,
+		&i.Title,
+		&i.Description,
+		&i.Status,
+		&i.Priority,
+		&i.StartAt,
+		&i.DueAt,
+		&i.CompletedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.SectionID,
+		&i.StatusID,
+		&i.BlockedReason,
+		&i.EstimateMinutes,
+	)
 	return i, err
 }
 
-const softDeleteTasksByProject = `-- name: SoftDeleteTasksByProject :execrows
+const softDeleteTasksByProject = `-- name: SoftDeleteTasksByProject :many
 UPDATE tasks
 SET
   deleted_at = NOW(),
@@ -364,6 +1305,7 @@ SET
 WHERE project_id = $1
   AND user_id = $2
   AND deleted_at IS NULL
+RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
 `
 
 type SoftDeleteTasksByProjectParams struct {
@@ -371,12 +1313,88 @@ type SoftDeleteTasksByProjectParams struct {
 	UserID    pgtype.UUID `json:"user_id"`
 }
 
-func (q *Queries) SoftDeleteTasksByProject(ctx context.Context, arg SoftDeleteTasksByProjectParams) (int64, error) {
-	result, err := q.db.Exec(ctx, softDeleteTasksByProject, arg.ProjectID, arg.UserID)
+func (q *Queries) SoftDeleteTasksByProject(ctx context.Context, arg SoftDeleteTasksByProjectParams) ([]Task, error) {
+	rows, err := q.db.Query(ctx, softDeleteTasksByProject, arg.ProjectID, arg.UserID)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	return result.RowsAffected(), nil
+	defer rows.Close()
+	items := []Task{}
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.ProjectID,
+			&i.ParentTaskID,
+			&i.Title,
+			&i.Description,
+			&i.Status,
+			&i.Priority,
+			&i.StartAt,
+			&i.DueAt,
+			&i.CompletedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.SectionID,
+			&i.StatusID,
+			&i.BlockedReason,
+			&i.EstimateMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const subtaskProgress = `-- name: SubtaskProgress :many
+SELECT
+  parent_task_id,
+  COUNT(*) AS total,
+  COUNT(*) FILTER (WHERE status = 'DONE') AS done
+FROM tasks
+WHERE user_id = $1
+  AND parent_task_id = ANY($2::uuid[])
+  AND deleted_at IS NULL
+GROUP BY parent_task_id
+`
+
+type SubtaskProgressParams struct {
+	UserID    pgtype.UUID   `json:"user_id"`
+	ParentIds []pgtype.UUID `json:"parent_ids"`
+}
+
+type SubtaskProgressRow struct {
+	ParentTaskID pgtype.UUID `json:"parent_task_id"`
+	Total        int64       `json:"total"`
+	Done         int64       `json:"done"`
+}
+
+// Live subtask counts for a batch of parent tasks; parents without subtasks
+// are absent.
+func (q *Queries) SubtaskProgress(ctx context.Context, arg SubtaskProgressParams) ([]SubtaskProgressRow, error) {
+	rows, err := q.db.Query(ctx, subtaskProgress, arg.UserID, arg.ParentIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SubtaskProgressRow{}
+	for rows.Next() {
+		var i SubtaskProgressRow
+		if err := rows.Scan(&i.ParentTaskID, &i.Total, &i.Done); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const updateTask = `-- name: UpdateTask :one
@@ -389,23 +1407,31 @@ SET
   start_at = $7,
   due_at = $8,
   completed_at = $9,
+  section_id = $10,
+  status_id = $11,
+  blocked_reason = $12,
+  estimate_minutes = $13,
   updated_at = NOW()
 WHERE id = $1
   AND user_id = $2
   AND deleted_at IS NULL
-RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at
+RETURNING id, user_id, project_id, parent_task_id, title, description, status, priority, start_at, due_at, completed_at, created_at, updated_at, deleted_at, section_id, status_id, blocked_reason, estimate_minutes
 `
 
 type UpdateTaskParams struct {
-	ID          pgtype.UUID        `json:"id"`
-	UserID      pgtype.UUID        `json:"user_id"`
-	Title       string             `json:"title"`
-	Description *string            `json:"description"`
-	Status      string             `json:"status"`
-	Priority    string             `json:"priority"`
-	StartAt     pgtype.Timestamptz `json:"start_at"`
-	DueAt       pgtype.Timestamptz `json:"due_at"`
-	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+	ID              pgtype.UUID        `json:"id"`
+	UserID          pgtype.UUID        `json:"user_id"`
+	Title           string             `json:"title"`
+	Description     *string            `json:"description"`
+	Status          string             `json:"status"`
+	Priority        string             `json:"priority"`
+	StartAt         pgtype.Timestamptz `json:"start_at"`
+	DueAt           pgtype.Timestamptz `json:"due_at"`
+	CompletedAt     pgtype.Timestamptz `json:"completed_at"`
+	SectionID       pgtype.UUID        `json:"section_id"`
+	StatusID        pgtype.UUID        `json:"status_id"`
+	BlockedReason   *string            `json:"blocked_reason"`
+	EstimateMinutes *int32             `json:"estimate_minutes"`
 }
 
 func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, error) {
@@ -419,6 +1445,10 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, e
 		arg.StartAt,
 		arg.DueAt,
 		arg.CompletedAt,
+		arg.SectionID,
+		arg.StatusID,
+		arg.BlockedReason,
+		arg.EstimateMinutes,
 	)
 	var i Task
 	err := row.Scan(
@@ -436,6 +1466,10 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) (Task, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.SectionID,
+		&i.StatusID,
+		&i.BlockedReason,
+		&i.EstimateMinutes,
 	)
 	return i, err
 }
@@ -0,0 +1,141 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: templates.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createTemplate = `-- name: CreateTemplate :one
+INSERT INTO templates (user_id, name, kind, content)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, name, kind, content, created_at, updated_at, deleted_at
+`
+
+type CreateTemplateParams struct {
+	UserID  pgtype.UUID `json:"user_id"`
+	Name    string      `json:"name"`
+	Kind    string      `json:"kind"`
+	Content []byte      `json:"content"`
+}
+
+func (q *Queries) CreateTemplate(ctx context.Context, arg CreateTemplateParams) (Template, error) {
+	row := q.db.QueryRow(ctx, createTemplate,
+		arg.UserID,
+		arg.Name,
+		arg.Kind,
+		arg.Content,
+	)
+	var i Template
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Kind,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getTemplateByID = `-- name: GetTemplateByID :one
+SELECT id, user_id, name, kind, content, created_at, updated_at, deleted_at
+FROM templates
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+LIMIT 1
+`
+
+type GetTemplateByIDParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetTemplateByID(ctx context.Context, arg GetTemplateByIDParams) (Template, error) {
+	row := q.db.QueryRow(ctx, getTemplateByID, arg.ID, arg.UserID)
+	var i Template
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Kind,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const listTemplates = `-- name: ListTemplates :many
+SELECT id, user_id, name, kind, content, created_at, updated_at, deleted_at
+FROM templates
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY LOWER(name), id
+`
+
+func (q *Queries) ListTemplates(ctx context.Context, userID pgtype.UUID) ([]Template, error) {
+	rows, err := q.db.Query(ctx, listTemplates, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Template{}
+	for rows.Next() {
+		var i Template
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Kind,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const softDeleteTemplate = `-- name: SoftDeleteTemplate :one
+UPDATE templates
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, deleted_at
+`
+
+type SoftDeleteTemplateParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type SoftDeleteTemplateRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+func (q *Queries) SoftDeleteTemplate(ctx context.Context, arg SoftDeleteTemplateParams) (SoftDeleteTemplateRow, error) {
+	row := q.db.QueryRow(ctx, softDeleteTemplate, arg.ID, arg.UserID)
+	var i SoftDeleteTemplateRow
+	err := row.Scan(&i.ID, &i.DeletedAt)
+	return i, err
+}
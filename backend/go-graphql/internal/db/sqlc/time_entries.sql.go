@@ -0,0 +1,420 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: time_entries.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createTimeEntry = `-- name: CreateTimeEntry :one
+INSERT INTO time_entries (user_id, task_id, started_at, ended_at, note)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, user_id, task_id, started_at, ended_at, note, created_at, updated_at, deleted_at
+`
+
+type CreateTimeEntryParams struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	TaskID    pgtype.UUID        `json:"task_id"`
+	StartedAt pgtype.Timestamptz `json:"started_at"`
+	EndedAt   pgtype.Timestamptz `json:"ended_at"`
+	Note      *string            `json:"note"`
+}
+
+func (q *Queries) CreateTimeEntry(ctx context.Context, arg CreateTimeEntryParams) (TimeEntry, error) {
+	row := q.db.QueryRow(ctx, createTimeEntry,
+		arg.UserID,
+		arg.TaskID,
+		arg.StartedAt,
+		arg.EndedAt,
+		arg.Note,
+	)
+	var i TimeEntry
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TaskID,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Note,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getRunningTimeEntry = `-- name: GetRunningTimeEntry :one
+SELECT id, user_id, task_id, started_at, ended_at, note, created_at, updated_at, deleted_at
+FROM time_entries
+WHERE user_id = $1
+  AND ended_at IS NULL
+  AND deleted_at IS NULL
+LIMIT 1
+`
+
+func (q *Queries) GetRunningTimeEntry(ctx context.Context, userID pgtype.UUID) (TimeEntry, error) {
+	row := q.db.QueryRow(ctx, getRunningTimeEntry, userID)
+	var i TimeEntry
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TaskID,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Note,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getTimeEntryByID = `-- name: GetTimeEntryByID :one
+SELECT id, user_id, task_id, started_at, ended_at, note, created_at, updated_at, deleted_at
+FROM time_entries
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+LIMIT 1
+`
+
+type GetTimeEntryByIDParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetTimeEntryByID(ctx context.Context, arg GetTimeEntryByIDParams) (TimeEntry, error) {
+	row := q.db.QueryRow(ctx, getTimeEntryByID, arg.ID, arg.UserID)
+	var i TimeEntry
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TaskID,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Note,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const listLabelsForTasks = `-- name: ListLabelsForTasks :many
+SELECT tl.task_id, l.id, l.name
+FROM task_labels tl
+JOIN labels l ON l.id = tl.label_id
+WHERE tl.task_id = ANY($1::uuid[])
+  AND l.user_id = $2
+  AND l.deleted_at IS NULL
+ORDER BY l.name, l.id
+`
+
+type ListLabelsForTasksParams struct {
+	TaskIds []pgtype.UUID `json:"task_ids"`
+	UserID  pgtype.UUID   `json:"user_id"`
+}
+
+type ListLabelsForTasksRow struct {
+	TaskID pgtype.UUID `json:"task_id"`
+	ID     pgtype.UUID `json:"id"`
+	Name   string      `json:"name"`
+}
+
+func (q *Queries) ListLabelsForTasks(ctx context.Context, arg ListLabelsForTasksParams) ([]ListLabelsForTasksRow, error) {
+	rows, err := q.db.Query(ctx, listLabelsForTasks, arg.TaskIds, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListLabelsForTasksRow{}
+	for rows.Next() {
+		var i ListLabelsForTasksRow
+		if err := rows.Scan(&i.TaskID, &i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTimeEntriesByTask = `-- name: ListTimeEntriesByTask :many
+SELECT id, user_id, task_id, started_at, ended_at, note, created_at, updated_at, deleted_at
+FROM time_entries
+WHERE task_id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+ORDER BY started_at DESC, id DESC
+`
+
+type ListTimeEntriesByTaskParams struct {
+	TaskID pgtype.UUID `json:"task_id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) ListTimeEntriesByTask(ctx context.Context, arg ListTimeEntriesByTaskParams) ([]TimeEntry, error) {
+	rows, err := q.db.Query(ctx, listTimeEntriesByTask, arg.TaskID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TimeEntry{}
+	for rows.Next() {
+		var i TimeEntry
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.TaskID,
+			&i.StartedAt,
+			&i.EndedAt,
+			&i.Note,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTimeEntriesForReport = `-- name: ListTimeEntriesForReport :many
+SELECT e.task_id, t.project_id, p.title AS project_title, e.started_at, COALESCE(e.ended_at, NOW())::timestamptz AS ended_at
+FROM time_entries e
+JOIN tasks t ON t.id = e.task_id AND t.deleted_at IS NULL
+JOIN projects p ON p.id = t.project_id
+WHERE e.user_id = $1
+  AND e.deleted_at IS NULL
+  AND e.started_at < $2::timestamptz
+  AND COALESCE(e.ended_at, NOW()) > $3::timestamptz
+ORDER BY e.started_at
+`
+
+type ListTimeEntriesForReportParams struct {
+	UserID    pgtype.UUID        `json:"user_id"`
+	RangeTo   pgtype.Timestamptz `json:"range_to"`
+	RangeFrom pgtype.Timestamptz `json:"range_from"`
+}
+
+type ListTimeEntriesForReportRow struct {
+	TaskID       pgtype.UUID        `json:"task_id"`
+	ProjectID    pgtype.UUID        `json:"project_id"`
+	ProjectTitle string             `json:"project_title"`
+	StartedAt    pgtype.Timestamptz `json:"started_at"`
+	EndedAt      pgtype.Timestamptz `json:"ended_at"`
+}
+
+// Entries of live tasks overlapping [range_from, range_to).
+func (q *Queries) ListTimeEntriesForReport(ctx context.Context, arg ListTimeEntriesForReportParams) ([]ListTimeEntriesForReportRow, error) {
+	rows, err := q.db.Query(ctx, listTimeEntriesForReport, arg.UserID, arg.RangeTo, arg.RangeFrom)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTimeEntriesForReportRow{}
+	for rows.Next() {
+		var i ListTimeEntriesForReportRow
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.ProjectID,
+			&i.ProjectTitle,
+			&i.StartedAt,
+			&i.EndedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const softDeleteTimeEntry = `-- name: SoftDeleteTimeEntry :one
+UPDATE time_entries
+SET
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, deleted_at
+`
+
+type SoftDeleteTimeEntryParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type SoftDeleteTimeEntryRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+func (q *Queries) SoftDeleteTimeEntry(ctx context.Context, arg SoftDeleteTimeEntryParams) (SoftDeleteTimeEntryRow, error) {
+	row := q.db.QueryRow(ctx, softDeleteTimeEntry, arg.ID, arg.UserID)
+	var i SoftDeleteTimeEntryRow
+	err := row.Scan(&i.ID, &i.DeletedAt)
+	return i, err
+}
+
+const startTimeEntry = `-- name: StartTimeEntry :one
+INSERT INTO time_entries (user_id, task_id, started_at, note)
+VALUES ($1, $2, NOW(), $3)
+RETURNING id, user_id, task_id, started_at, ended_at, note, created_at, updated_at, deleted_at
+`
+
+type StartTimeEntryParams struct {
+	UserID pgtype.UUID `json:"user_id"`
+	TaskID pgtype.UUID `json:"task_id"`
+	Note   *string     `json:"note"`
+}
+
+func (q *Queries) StartTimeEntry(ctx context.Context, arg StartTimeEntryParams) (TimeEntry, error) {
+	row := q.db.QueryRow(ctx, startTimeEntry, arg.UserID, arg.TaskID, arg.Note)
+	var i TimeEntry
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TaskID,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Note,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const stopRunningTimeEntry = `-- name: StopRunningTimeEntry :one
+UPDATE time_entries
+SET
+  ended_at = GREATEST(NOW(), started_at),
+  updated_at = NOW()
+WHERE user_id = $1
+  AND ended_at IS NULL
+  AND deleted_at IS NULL
+RETURNING id, user_id, task_id, started_at, ended_at, note, created_at, updated_at, deleted_at
+`
+
+func (q *Queries) StopRunningTimeEntry(ctx context.Context, userID pgtype.UUID) (TimeEntry, error) {
+	row := q.db.QueryRow(ctx, stopRunningTimeEntry, userID)
+	var i TimeEntry
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TaskID,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Note,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const taskTimeSpent = `-- name: TaskTimeSpent :many
+SELECT
+  t.id AS task_id,
+  COALESCE(SUM(EXTRACT(EPOCH FROM COALESCE(e.ended_at, NOW()) - e.started_at)), 0)::bigint AS seconds
+FROM tasks t
+JOIN tasks c ON (c.id = t.id OR c.parent_task_id = t.id) AND c.deleted_at IS NULL
+JOIN time_entries e ON e.task_id = c.id AND e.deleted_at IS NULL
+WHERE t.id = ANY($1::uuid[])
+  AND t.user_id = $2
+GROUP BY t.id
+`
+
+type TaskTimeSpentParams struct {
+	TaskIds []pgtype.UUID `json:"task_ids"`
+	UserID  pgtype.UUID   `json:"user_id"`
+}
+
+type TaskTimeSpentRow struct {
+	TaskID  pgtype.UUID `json:"task_id"`
+	Seconds int64       `json:"seconds"`
+}
+
+// Seconds tracked on each task and its live subtasks; running timers count
+// up to now.
+func (q *Queries) TaskTimeSpent(ctx context.Context, arg TaskTimeSpentParams) ([]TaskTimeSpentRow, error) {
+	rows, err := q.db.Query(ctx, taskTimeSpent, arg.TaskIds, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TaskTimeSpentRow{}
+	for rows.Next() {
+		var i TaskTimeSpentRow
+		if err := rows.Scan(&i.TaskID, &i.Seconds); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTimeEntry = `-- name: UpdateTimeEntry :one
+UPDATE time_entries
+SET
+  task_id = $3,
+  started_at = $4,
+  ended_at = $5,
+  note = $6,
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, task_id, started_at, ended_at, note, created_at, updated_at, deleted_at
+`
+
+type UpdateTimeEntryParams struct {
+	ID        pgtype.UUID        `json:"id"`
+	UserID    pgtype.UUID        `json:"user_id"`
+	TaskID    pgtype.UUID        `json:"task_id"`
+	StartedAt pgtype.Timestamptz `json:"started_at"`
+	EndedAt   pgtype.Timestamptz `json:"ended_at"`
+	Note      *string            `json:"note"`
+}
+
+func (q *Queries) UpdateTimeEntry(ctx context.Context, arg UpdateTimeEntryParams) (TimeEntry, error) {
+	row := q.db.QueryRow(ctx, updateTimeEntry,
+		arg.ID,
+		arg.UserID,
+		arg.TaskID,
+		arg.StartedAt,
+		arg.EndedAt,
+		arg.Note,
+	)
+	var i TimeEntry
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.TaskID,
+		&i.StartedAt,
+		&i.EndedAt,
+		&i.Note,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,164 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: transitions.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteStatusRequirements = `-- name: DeleteStatusRequirements :exec
+DELETE FROM project_status_requirements
+WHERE project_id = $1
+  AND user_id = $2
+`
+
+type DeleteStatusRequirementsParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) DeleteStatusRequirements(ctx context.Context, arg DeleteStatusRequirementsParams) error {
+	_, err := q.db.Exec(ctx, deleteStatusRequirements, arg.ProjectID, arg.UserID)
+	return err
+}
+
+const deleteStatusTransitions = `-- name: DeleteStatusTransitions :exec
+DELETE FROM project_status_transitions
+WHERE project_id = $1
+  AND user_id = $2
+`
+
+type DeleteStatusTransitionsParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) DeleteStatusTransitions(ctx context.Context, arg DeleteStatusTransitionsParams) error {
+	_, err := q.db.Exec(ctx, deleteStatusTransitions, arg.ProjectID, arg.UserID)
+	return err
+}
+
+const insertStatusRequirements = `-- name: InsertStatusRequirements :exec
+INSERT INTO project_status_requirements (project_id, user_id, status, field)
+SELECT $1::uuid, $2::uuid, unnest($3::text[]), unnest($4::text[])
+`
+
+type InsertStatusRequirementsParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+	Statuses  []string    `json:"statuses"`
+	Fields    []string    `json:"fields"`
+}
+
+func (q *Queries) InsertStatusRequirements(ctx context.Context, arg InsertStatusRequirementsParams) error {
+	_, err := q.db.Exec(ctx, insertStatusRequirements,
+		arg.ProjectID,
+		arg.UserID,
+		arg.Statuses,
+		arg.Fields,
+	)
+	return err
+}
+
+const insertStatusTransitions = `-- name: InsertStatusTransitions :exec
+INSERT INTO project_status_transitions (project_id, user_id, from_status, to_status)
+SELECT $1::uuid, $2::uuid, unnest($3::text[]), unnest($4::text[])
+`
+
+type InsertStatusTransitionsParams struct {
+	ProjectID    pgtype.UUID `json:"project_id"`
+	UserID       pgtype.UUID `json:"user_id"`
+	FromStatuses []string    `json:"from_statuses"`
+	ToStatuses   []string    `json:"to_statuses"`
+}
+
+func (q *Queries) InsertStatusTransitions(ctx context.Context, arg InsertStatusTransitionsParams) error {
+	_, err := q.db.Exec(ctx, insertStatusTransitions,
+		arg.ProjectID,
+		arg.UserID,
+		arg.FromStatuses,
+		arg.ToStatuses,
+	)
+	return err
+}
+
+const listStatusRequirements = `-- name: ListStatusRequirements :many
+SELECT status, field
+FROM project_status_requirements
+WHERE project_id = $1
+  AND user_id = $2
+ORDER BY status, field
+`
+
+type ListStatusRequirementsParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+type ListStatusRequirementsRow struct {
+	Status string `json:"status"`
+	Field  string `json:"field"`
+}
+
+func (q *Queries) ListStatusRequirements(ctx context.Context, arg ListStatusRequirementsParams) ([]ListStatusRequirementsRow, error) {
+	rows, err := q.db.Query(ctx, listStatusRequirements, arg.ProjectID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStatusRequirementsRow{}
+	for rows.Next() {
+		var i ListStatusRequirementsRow
+		if err := rows.Scan(&i.Status, &i.Field); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatusTransitions = `-- name: ListStatusTransitions :many
+SELECT from_status, to_status
+FROM project_status_transitions
+WHERE project_id = $1
+  AND user_id = $2
+ORDER BY from_status, to_status
+`
+
+type ListStatusTransitionsParams struct {
+	ProjectID pgtype.UUID `json:"project_id"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+type ListStatusTransitionsRow struct {
+	FromStatus string `json:"from_status"`
+	ToStatus   string `json:"to_status"`
+}
+
+func (q *Queries) ListStatusTransitions(ctx context.Context, arg ListStatusTransitionsParams) ([]ListStatusTransitionsRow, error) {
+	rows, err := q.db.Query(ctx, listStatusTransitions, arg.ProjectID, arg.UserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListStatusTransitionsRow{}
+	for rows.Next() {
+		var i ListStatusTransitionsRow
+		if err := rows.Scan(&i.FromStatus, &i.ToStatus); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,680 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: webhooks.sql
+
+package sqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const cancelPendingWebhookDeliveries = `-- name: CancelPendingWebhookDeliveries :execrows
+UPDATE webhook_deliveries
+SET
+  status = 'DEAD',
+  last_error = $1::text,
+  updated_at = NOW()
+WHERE subscription_id = $2
+  AND user_id = $3
+  AND status = 'PENDING'
+`
+
+type CancelPendingWebhookDeliveriesParams struct {
+	Reason         string      `json:"reason"`
+	SubscriptionID pgtype.UUID `json:"subscription_id"`
+	UserID         pgtype.UUID `json:"user_id"`
+}
+
+// Dead-letters what a subscription still has queued when it is deleted or
+// deactivated.
+func (q *Queries) CancelPendingWebhookDeliveries(ctx context.Context, arg CancelPendingWebhookDeliveriesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, cancelPendingWebhookDeliveries, arg.Reason, arg.SubscriptionID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const claimDueWebhookDeliveries = `-- name: ClaimDueWebhookDeliveries :many
+UPDATE webhook_deliveries d
+SET
+  next_attempt_at = NOW() + $1::interval,
+  updated_at = NOW()
+FROM webhook_subscriptions s, outbox_events e
+WHERE d.id IN (
+    SELECT pd.id
+    FROM webhook_deliveries pd
+    JOIN webhook_subscriptions ps ON ps.id = pd.subscription_id
+    WHERE pd.status = 'PENDING'
+      AND pd.next_attempt_at <= NOW()
+      AND ps.active
+      AND ps.deleted_at IS NULL
+    ORDER BY pd.next_attempt_at
+    LIMIT $2
+    FOR UPDATE OF pd SKIP LOCKED
+  )
+  AND s.id = d.subscription_id
+  AND s.active
+  AND s.deleted_at IS NULL
+  AND e.id = d.event_id
+RETURNING d.id, d.attempts, d.event_type, d.event_id, e.payload, e.created_at AS event_created_at, s.url, s.secret
+`
+
+type ClaimDueWebhookDeliveriesParams struct {
+	Lease     pgtype.Interval `json:"lease"`
+	BatchSize int32           `json:"batch_size"`
+}
+
+type ClaimDueWebhookDeliveriesRow struct {
+	ID             pgtype.UUID        `json:"id"`
+	Attempts       int32              `json:"attempts"`
+	EventType      string             `json:"event_type"`
+	EventID        pgtype.UUID        `json:"event_id"`
+	Payload        []byte             `json:"payload"`
+	EventCreatedAt pgtype.Timestamptz `json:"event_created_at"`
+	Url            string             `json:"url"`
+	Secret         string             `json:"secret"`
+}
+
+// Pushes next_attempt_at forward by the lease so a crashed worker's claims
+// become due again instead of being lost. Deliveries of deleted or inactive
+// subscriptions are never claimed.
+func (q *Queries) ClaimDueWebhookDeliveries(ctx context.Context, arg ClaimDueWebhookDeliveriesParams) ([]ClaimDueWebhookDeliveriesRow, error) {
+	rows, err := q.db.Query(ctx, claimDueWebhookDeliveries, arg.Lease, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ClaimDueWebhookDeliveriesRow{}
+	for rows.Next() {
+		var i ClaimDueWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Attempts,
+			&i.EventType,
+			&i.EventID,
+			&i.Payload,
+			&i.EventCreatedAt,
+			&i.Url,
+			&i.Secret,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const claimOutboxEvents = `-- name: ClaimOutboxEvents :many
+SELECT id, user_id, event_type, payload, created_at, dispatched_at
+FROM outbox_events
+WHERE dispatched_at IS NULL
+ORDER BY created_at, id
+LIMIT $1
+FOR UPDATE SKIP LOCKED
+`
+
+func (q *Queries) ClaimOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error) {
+	rows, err := q.db.Query(ctx, claimOutboxEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OutboxEvent{}
+	for rows.Next() {
+		var i OutboxEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.EventType,
+			&i.Payload,
+			&i.CreatedAt,
+			&i.DispatchedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countWebhookDeliveries = `-- name: CountWebhookDeliveries :one
+SELECT COUNT(*)
+FROM webhook_deliveries
+WHERE user_id = $1
+  AND ($2::boolean = FALSE OR subscription_id = $3::uuid)
+  AND (cardinality($4::text[]) = 0 OR status = ANY($4::text[]))
+`
+
+type CountWebhookDeliveriesParams struct {
+	UserID  pgtype.UUID `json:"user_id"`
+	Column2 bool        `json:"column_2"`
+	Column3 pgtype.UUID `json:"column_3"`
+	Column4 []string    `json:"column_4"`
+}
+
+func (q *Queries) CountWebhookDeliveries(ctx context.Context, arg CountWebhookDeliveriesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countWebhookDeliveries,
+		arg.UserID,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createWebhookSubscription = `-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (user_id, url, secret, event_types)
+VALUES ($1, $2, $3, $4)
+RETURNING id, user_id, url, secret, event_types, active, created_at, updated_at, deleted_at
+`
+
+type CreateWebhookSubscriptionParams struct {
+	UserID     pgtype.UUID `json:"user_id"`
+	Url        string      `json:"url"`
+	Secret     string      `json:"secret"`
+	EventTypes []string    `json:"event_types"`
+}
+
+func (q *Queries) CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, createWebhookSubscription,
+		arg.UserID,
+		arg.Url,
+		arg.Secret,
+		arg.EventTypes,
+	)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Url,
+		&i.Secret,
+		&i.EventTypes,
+		&i.Active,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const enqueueWebhookDeliveries = `-- name: EnqueueWebhookDeliveries :execrows
+INSERT INTO webhook_deliveries (user_id, subscription_id, event_id, event_type)
+SELECT s.user_id, s.id, $1::uuid, $2::text
+FROM webhook_subscriptions s
+WHERE s.user_id = $3::uuid
+  AND s.active
+  AND s.deleted_at IS NULL
+  AND (
+    cardinality(s.event_types) = 0
+    OR $2::text = ANY(s.event_types)
+  )
+ON CONFLICT (subscription_id, event_id) DO NOTHING
+`
+
+type EnqueueWebhookDeliveriesParams struct {
+	EventID   pgtype.UUID `json:"event_id"`
+	EventType string      `json:"event_type"`
+	UserID    pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) EnqueueWebhookDeliveries(ctx context.Context, arg EnqueueWebhookDeliveriesParams) (int64, error) {
+	result, err := q.db.Exec(ctx, enqueueWebhookDeliveries, arg.EventID, arg.EventType, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getWebhookDeliveryByID = `-- name: GetWebhookDeliveryByID :one
+SELECT id, user_id, subscription_id, event_id, event_type, status, attempts, next_attempt_at, last_status_code, last_error, delivered_at, created_at, updated_at
+FROM webhook_deliveries
+WHERE id = $1
+  AND user_id = $2
+LIMIT 1
+`
+
+type GetWebhookDeliveryByIDParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetWebhookDeliveryByID(ctx context.Context, arg GetWebhookDeliveryByIDParams) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, getWebhookDeliveryByID, arg.ID, arg.UserID)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.SubscriptionID,
+		&i.EventID,
+		&i.EventType,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastStatusCode,
+		&i.LastError,
+		&i.DeliveredAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getWebhookSubscriptionByID = `-- name: GetWebhookSubscriptionByID :one
+SELECT id, user_id, url, secret, event_types, active, created_at, updated_at, deleted_at
+FROM webhook_subscriptions
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+LIMIT 1
+`
+
+type GetWebhookSubscriptionByIDParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) GetWebhookSubscriptionByID(ctx context.Context, arg GetWebhookSubscriptionByIDParams) (WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, getWebhookSubscriptionByID, arg.ID, arg.UserID)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Url,
+		&i.Secret,
+		&i.EventTypes,
+		&i.Active,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const insertOutboxEvent = `-- name: InsertOutboxEvent :exec
+INSERT INTO outbox_events (user_id, event_type, payload)
+VALUES ($1, $2, $3)
+`
+
+type InsertOutboxEventParams struct {
+	UserID    pgtype.UUID `json:"user_id"`
+	EventType string      `json:"event_type"`
+	Payload   []byte      `json:"payload"`
+}
+
+func (q *Queries) InsertOutboxEvent(ctx context.Context, arg InsertOutboxEventParams) error {
+	_, err := q.db.Exec(ctx, insertOutboxEvent, arg.UserID, arg.EventType, arg.Payload)
+	return err
+}
+
+const listWebhookDeliveries = `-- name: ListWebhookDeliveries :many
+SELECT id, user_id, subscription_id, event_id, event_type, status, attempts, next_attempt_at, last_status_code, last_error, delivered_at, created_at, updated_at
+FROM webhook_deliveries
+WHERE user_id = $1
+  AND ($2::boolean = FALSE OR subscription_id = $3::uuid)
+  AND (cardinality($4::text[]) = 0 OR status = ANY($4::text[]))
+  AND (
+    NOT $5::boolean
+    OR (created_at, id) < ($6::timestamptz, $7::uuid)
+  )
+ORDER BY created_at DESC, id DESC
+LIMIT $8
+`
+
+type ListWebhookDeliveriesParams struct {
+	UserID  pgtype.UUID        `json:"user_id"`
+	Column2 bool               `json:"column_2"`
+	Column3 pgtype.UUID        `json:"column_3"`
+	Column4 []string           `json:"column_4"`
+	Column5 bool               `json:"column_5"`
+	Column6 pgtype.Timestamptz `json:"column_6"`
+	Column7 pgtype.UUID        `json:"column_7"`
+	Limit   int32              `json:"limit"`
+}
+
+func (q *Queries) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, listWebhookDeliveries,
+		arg.UserID,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+		arg.Column6,
+		arg.Column7,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookDelivery{}
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.SubscriptionID,
+			&i.EventID,
+			&i.EventType,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastStatusCode,
+			&i.LastError,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookDeliveriesBefore = `-- name: ListWebhookDeliveriesBefore :many
+SELECT id, user_id, subscription_id, event_id, event_type, status, attempts, next_attempt_at, last_status_code, last_error, delivered_at, created_at, updated_at
+FROM webhook_deliveries
+WHERE user_id = $1
+  AND ($2::boolean = FALSE OR subscription_id = $3::uuid)
+  AND (cardinality($4::text[]) = 0 OR status = ANY($4::text[]))
+  AND (
+    NOT $5::boolean
+    OR (created_at, id) > ($6::timestamptz, $7::uuid)
+  )
+ORDER BY created_at ASC, id ASC
+LIMIT $8
+`
+
+type ListWebhookDeliveriesBeforeParams struct {
+	UserID  pgtype.UUID        `json:"user_id"`
+	Column2 bool               `json:"column_2"`
+	Column3 pgtype.UUID        `json:"column_3"`
+	Column4 []string           `json:"column_4"`
+	Column5 bool               `json:"column_5"`
+	Column6 pgtype.Timestamptz `json:"column_6"`
+	Column7 pgtype.UUID        `json:"column_7"`
+	Limit   int32              `json:"limit"`
+}
+
+func (q *Queries) ListWebhookDeliveriesBefore(ctx context.Context, arg ListWebhookDeliveriesBeforeParams) ([]WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, listWebhookDeliveriesBefore,
+		arg.UserID,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+		arg.Column6,
+		arg.Column7,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookDelivery{}
+	for rows.Next() {
+		var i WebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.SubscriptionID,
+			&i.EventID,
+			&i.EventType,
+			&i.Status,
+			&i.Attempts,
+			&i.NextAttemptAt,
+			&i.LastStatusCode,
+			&i.LastError,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookSubscriptions = `-- name: ListWebhookSubscriptions :many
+SELECT id, user_id, url, secret, event_types, active, created_at, updated_at, deleted_at
+FROM webhook_subscriptions
+WHERE user_id = $1
+  AND deleted_at IS NULL
+ORDER BY created_at DESC, id DESC
+`
+
+func (q *Queries) ListWebhookSubscriptions(ctx context.Context, userID pgtype.UUID) ([]WebhookSubscription, error) {
+	rows, err := q.db.Query(ctx, listWebhookSubscriptions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookSubscription{}
+	for rows.Next() {
+		var i WebhookSubscription
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Url,
+			&i.Secret,
+			&i.EventTypes,
+			&i.Active,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markOutboxEventDispatched = `-- name: MarkOutboxEventDispatched :exec
+UPDATE outbox_events
+SET dispatched_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) MarkOutboxEventDispatched(ctx context.Context, id pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, markOutboxEventDispatched, id)
+	return err
+}
+
+const markWebhookDeliveryFailed = `-- name: MarkWebhookDeliveryFailed :exec
+UPDATE webhook_deliveries
+SET
+  status = $2,
+  attempts = attempts + 1,
+  next_attempt_at = $3,
+  last_status_code = $4,
+  last_error = $5,
+  updated_at = NOW()
+WHERE id = $1
+`
+
+type MarkWebhookDeliveryFailedParams struct {
+	ID             pgtype.UUID        `json:"id"`
+	Status         string             `json:"status"`
+	NextAttemptAt  pgtype.Timestamptz `json:"next_attempt_at"`
+	LastStatusCode *int32             `json:"last_status_code"`
+	LastError      *string            `json:"last_error"`
+}
+
+func (q *Queries) MarkWebhookDeliveryFailed(ctx context.Context, arg MarkWebhookDeliveryFailedParams) error {
+	_, err := q.db.Exec(ctx, markWebhookDeliveryFailed,
+		arg.ID,
+		arg.Status,
+		arg.NextAttemptAt,
+		arg.LastStatusCode,
+		arg.LastError,
+	)
+	return err
+}
+
+const markWebhookDeliverySucceeded = `-- name: MarkWebhookDeliverySucceeded :exec
+UPDATE webhook_deliveries
+SET
+  status = 'DELIVERED',
+  attempts = attempts + 1,
+  last_status_code = $2,
+  last_error = NULL,
+  delivered_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+`
+
+type MarkWebhookDeliverySucceededParams struct {
+	ID             pgtype.UUID `json:"id"`
+	LastStatusCode *int32      `json:"last_status_code"`
+}
+
+func (q *Queries) MarkWebhookDeliverySucceeded(ctx context.Context, arg MarkWebhookDeliverySucceededParams) error {
+	_, err := q.db.Exec(ctx, markWebhookDeliverySucceeded, arg.ID, arg.LastStatusCode)
+	return err
+}
+
+const retryWebhookDelivery = `-- name: RetryWebhookDelivery :one
+UPDATE webhook_deliveries
+SET
+  status = 'PENDING',
+  attempts = 0,
+  next_attempt_at = NOW(),
+  updated_at = NOW()
+WHERE webhook_deliveries.id = $1
+  AND webhook_deliveries.user_id = $2
+  AND webhook_deliveries.status = 'DEAD'
+  AND EXISTS (
+    SELECT 1
+    FROM webhook_subscriptions s
+    WHERE s.id = webhook_deliveries.subscription_id
+      AND s.user_id = $2
+      AND s.active
+      AND s.deleted_at IS NULL
+  )
+RETURNING id, user_id, subscription_id, event_id, event_type, status, attempts, next_attempt_at, last_status_code, last_error, delivered_at, created_at, updated_at
+`
+
+type RetryWebhookDeliveryParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+func (q *Queries) RetryWebhookDelivery(ctx context.Context, arg RetryWebhookDeliveryParams) (WebhookDelivery, error) {
+	row := q.db.QueryRow(ctx, retryWebhookDelivery, arg.ID, arg.UserID)
+	var i WebhookDelivery
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.SubscriptionID,
+		&i.EventID,
+		&i.EventType,
+		&i.Status,
+		&i.Attempts,
+		&i.NextAttemptAt,
+		&i.LastStatusCode,
+		&i.LastError,
+		&i.DeliveredAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const softDeleteWebhookSubscription = `-- name: SoftDeleteWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET
+  active = FALSE,
+  deleted_at = NOW(),
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, deleted_at
+`
+
+type SoftDeleteWebhookSubscriptionParams struct {
+	ID     pgtype.UUID `json:"id"`
+	UserID pgtype.UUID `json:"user_id"`
+}
+
+type SoftDeleteWebhookSubscriptionRow struct {
+	ID        pgtype.UUID        `json:"id"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+func (q *Queries) SoftDeleteWebhookSubscription(ctx context.Context, arg SoftDeleteWebhookSubscriptionParams) (SoftDeleteWebhookSubscriptionRow, error) {
+	row := q.db.QueryRow(ctx, softDeleteWebhookSubscription, arg.ID, arg.UserID)
+	var i SoftDeleteWebhookSubscriptionRow
+	err := row.Scan(&i.ID, &i.DeletedAt)
+	return i, err
+}
+
+const updateWebhookSubscription = `-- name: UpdateWebhookSubscription :one
+UPDATE webhook_subscriptions
+SET
+  url = $3,
+  secret = $4,
+  event_types = $5,
+  active = $6,
+  updated_at = NOW()
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at IS NULL
+RETURNING id, user_id, url, secret, event_types, active, created_at, updated_at, deleted_at
+`
+
+type UpdateWebhookSubscriptionParams struct {
+	ID         pgtype.UUID `json:"id"`
+	UserID     pgtype.UUID `json:"user_id"`
+	Url        string      `json:"url"`
+	Secret     string      `json:"secret"`
+	EventTypes []string    `json:"event_types"`
+	Active     bool        `json:"active"`
+}
+
+func (q *Queries) UpdateWebhookSubscription(ctx context.Context, arg UpdateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	row := q.db.QueryRow(ctx, updateWebhookSubscription,
+		arg.ID,
+		arg.UserID,
+		arg.Url,
+		arg.Secret,
+		arg.EventTypes,
+		arg.Active,
+	)
+	var i WebhookSubscription
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Url,
+		&i.Secret,
+		&i.EventTypes,
+		&i.Active,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,122 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+)
+
+// writeCSV writes a zip holding manifest.json (version, export time, omitted
+// data and user) and projects.csv, labels.csv, tasks.csv and task_labels.csv. The
+// columns follow the JSON field names; times are RFC 3339 in UTC and empty
+// cells are null.
+func writeCSV(ctx context.Context, src Source, w io.Writer, now time.Time) error {
+	user, err := src.Me(ctx)
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+	manifest, err := zw.CreateHeader(&zip.FileHeader{Name: "manifest.json", Method: zip.Deflate, Modified: now})
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(manifest).Encode(struct {
+		Version    int        `json:"version"`
+		ExportedAt time.Time  `json:"exportedAt"`
+		Omitted    []string   `json:"omitted"`
+		User       userRecord `json:"user"`
+	}{Version, now, Omitted, toUserRecord(user)}); err != nil {
+		return err
+	}
+
+	err = csvFile(zw, "projects.csv", now,
+		[]string{"id", "title", "description", "color", "createdAt", "updatedAt"},
+		func(write func([]string) error) error {
+			return src.EachProject(ctx, func(p sqlc.Project) error {
+				r := toProjectRecord(p)
+				return write([]string{r.ID, r.Title, cell(r.Description), cell(r.Color), timeCell(&r.CreatedAt), timeCell(&r.UpdatedAt)})
+			})
+		})
+	if err != nil {
+		return err
+	}
+
+	err = csvFile(zw, "labels.csv", now,
+		[]string{"id", "name", "createdAt", "updatedAt"},
+		func(write func([]string) error) error {
+			return src.EachLabel(ctx, func(l sqlc.Label) error {
+				r := toLabelRecord(l)
+				return write([]string{r.ID, r.Name, timeCell(&r.CreatedAt), timeCell(&r.UpdatedAt)})
+			})
+		})
+	if err != nil {
+		return err
+	}
+
+	err = csvFile(zw, "tasks.csv", now,
+		[]string{"id", "projectId", "parentTaskId", "title", "description", "status", "priority", "startAt", "dueAt", "completedAt", "createdAt", "updatedAt"},
+		func(write func([]string) error) error {
+			return src.EachTask(ctx, func(t sqlc.Task) error {
+				r := toTaskRecord(t)
+				return write([]string{
+					r.ID, r.ProjectID, cell(r.ParentTaskID), r.Title, cell(r.Description), r.Status, r.Priority,
+					timeCell(r.StartAt), timeCell(r.DueAt), timeCell(r.CompletedAt), timeCell(&r.CreatedAt), timeCell(&r.UpdatedAt),
+				})
+			})
+		})
+	if err != nil {
+		return err
+	}
+
+	err = csvFile(zw, "task_labels.csv", now,
+		[]string{"taskId", "labelId"},
+		func(write func([]string) error) error {
+			return src.EachTaskLabel(ctx, func(tl sqlc.TaskLabel) error {
+				r := toTaskLabelRecord(tl)
+				return write([]string{r.TaskID, r.LabelID})
+			})
+		})
+	if err != nil {
+		return err
+	}
+
+	return zw.Close()
+}
+
+// csvFile adds one CSV file to the zip. Each file must be complete before
+// the next is created.
+func csvFile(zw *zip.Writer, name string, modified time.Time, header []string, rows func(write func([]string) error) error) error {
+	f, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+	if err != nil {
+		return err
+	}
+	cw := csv.NewWriter(f)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	if err := rows(cw.Write); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func cell(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func timeCell(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,76 @@
+// Package export writes everything a user owns as a versioned JSON document,
+// a zip of per-entity CSV files, or a Markdown checklist per project. Rows
+// are read from a Source in batches and written as they arrive, so memory
+// use does not grow with the size of the account.
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Version is written into every JSON export. Bump it when a field is removed
+// or changes meaning; adding fields does not need a bump.
+const Version = 1
+
+// Omitted names the data a version 1 export leaves out. It is written next to
+// the version so a reader can tell the export is partial; remove a name once
+// its rows are exported.
+var Omitted = []string{
+	"sections",
+	"statuses",
+	"transitionPolicies",
+	"customFields",
+	"customFieldValues",
+	"timeEntries",
+	"templates",
+	"webhooks",
+}
+
+// Source is the part of *service.Service an export reads from.
+type Source interface {
+	Me(ctx context.Context) (sqlc.User, error)
+	EachProject(ctx context.Context, fn func(sqlc.Project) error) error
+	EachLabel(ctx context.Context, fn func(sqlc.Label) error) error
+	EachTask(ctx context.Context, fn func(sqlc.Task) error) error
+	EachTaskLabel(ctx context.Context, fn func(sqlc.TaskLabel) error) error
+	EachTaskTree(ctx context.Context, projectID pgtype.UUID, fn func(service.TaskTree) error) error
+}
+
+// Write streams an export in format to w.
+func Write(ctx context.Context, src Source, format service.ExportFormat, w io.Writer) error {
+	now := time.Now().UTC()
+	switch format {
+	case service.ExportJSON:
+		return writeJSON(ctx, src, w, now)
+	case service.ExportCSV:
+		return writeCSV(ctx, src, w, now)
+	case service.ExportMarkdown:
+		return writeMarkdown(ctx, src, w, now)
+	default:
+		return service.NewBadInput(fmt.Sprintf("unsupported export format %q", format))
+	}
+}
+
+// ContentType is the media type of a download in format.
+func ContentType(format service.ExportFormat) string {
+	switch format {
+	case service.ExportCSV:
+		return "application/zip"
+	case service.ExportMarkdown:
+		return "text/markdown; charset=utf-8"
+	default:
+		return "application/json"
+	}
+}
+
+// Filename names a download, e.g. "zenlist-export-20240701.zip".
+func Filename(format service.ExportFormat, at time.Time) string {
+	return "zenlist-export-" + at.UTC().Format("20060102") + format.Extension()
+}
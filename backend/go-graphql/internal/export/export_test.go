@@ -0,0 +1,217 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type fakeSource struct {
+	user       sqlc.User
+	projects   []sqlc.Project
+	labels     []sqlc.Label
+	tasks      []sqlc.Task
+	taskLabels []sqlc.TaskLabel
+	labelNames map[uuid.UUID][]string
+}
+
+func (f fakeSource) Me(context.Context) (sqlc.User, error) { return f.user, nil }
+
+func (f fakeSource) EachProject(_ context.Context, fn func(sqlc.Project) error) error {
+	return each(f.projects, fn)
+}
+
+func (f fakeSource) EachLabel(_ context.Context, fn func(sqlc.Label) error) error {
+	return each(f.labels, fn)
+}
+
+func (f fakeSource) EachTask(_ context.Context, fn func(sqlc.Task) error) error {
+	return each(f.tasks, fn)
+}
+
+func (f fakeSource) EachTaskLabel(_ context.Context, fn func(sqlc.TaskLabel) error) error {
+	return each(f.taskLabels, fn)
+}
+
+func (f fakeSource) EachTaskTree(_ context.Context, projectID pgtype.UUID, fn func(service.TaskTree) error) error {
+	for _, t := range f.tasks {
+		if t.ProjectID != projectID || t.ParentTaskID.Valid {
+			continue
+		}
+		tree := service.TaskTree{Task: t, Labels: f.labelNames}
+		for _, sub := range f.tasks {
+			if sub.ParentTaskID == t.ID {
+				tree.Subtasks = append(tree.Subtasks, sub)
+			}
+		}
+		if err := fn(tree); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func each[T any](rows []T, fn func(T) error) error {
+	for _, row := range rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pgID(id uuid.UUID) pgtype.UUID { return pgtype.UUID{Bytes: id, Valid: true} }
+
+func pgTime(t time.Time) pgtype.Timestamptz { return pgtype.Timestamptz{Time: t, Valid: true} }
+
+func newFakeSource() fakeSource {
+	created := time.Date(2024, 7, 1, 8, 0, 0, 0, time.UTC)
+	projectID, labelID, taskID, subID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	desc := "Weekend *chores*"
+	return fakeSource{
+		user: sqlc.User{ID: pgID(uuid.New()), Name: "Ada", Email: "ada@example.com", Timezone: "UTC"},
+		projects: []sqlc.Project{
+			{ID: pgID(projectID), Title: "Home", Description: &desc, CreatedAt: pgTime(created), UpdatedAt: pgTime(created)},
+		},
+		labels: []sqlc.Label{{ID: pgID(labelID), Name: "errand", CreatedAt: pgTime(created), UpdatedAt: pgTime(created)}},
+		tasks: []sqlc.Task{
+			{
+				ID: pgID(taskID), ProjectID: pgID(projectID), Title: "Buy paint, white", Status: "IN_PROGRESS", Priority: "P1",
+				DueAt: pgTime(time.Date(2024, 7, 2, 0, 0, 0, 0, time.UTC)), CreatedAt: pgTime(created), UpdatedAt: pgTime(created),
+			},
+			{
+				ID: pgID(subID), ProjectID: pgID(projectID), ParentTaskID: pgID(taskID), Title: "Pick [colour]", Status: "DONE", Priority: "P3",
+				CreatedAt: pgTime(created), UpdatedAt: pgTime(created),
+			},
+		},
+		taskLabels: []sqlc.TaskLabel{{TaskID: pgID(taskID), LabelID: pgID(labelID)}},
+		labelNames: map[uuid.UUID][]string{taskID: {"errand"}},
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	src := newFakeSource()
+	var buf bytes.Buffer
+	if err := Write(context.Background(), src, service.ExportJSON, &buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	var doc struct {
+		Version    int               `json:"version"`
+		Omitted    []string          `json:"omitted"`
+		User       userRecord        `json:"user"`
+		Projects   []projectRecord   `json:"projects"`
+		Labels     []labelRecord     `json:"labels"`
+		Tasks      []taskRecord      `json:"tasks"`
+		TaskLabels []taskLabelRecord `json:"taskLabels"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if doc.Version != Version || doc.User.Email != "ada@example.com" {
+		t.Fatalf("unexpected header: %+v", doc)
+	}
+	if strings.Join(doc.Omitted, ",") != strings.Join(Omitted, ",") {
+		t.Fatalf("omitted: got %v, want %v", doc.Omitted, Omitted)
+	}
+	if len(doc.Projects) != 1 || len(doc.Labels) != 1 || len(doc.Tasks) != 2 || len(doc.TaskLabels) != 1 {
+		t.Fatalf("unexpected counts: %+v", doc)
+	}
+	if doc.Tasks[1].ParentTaskID == nil || *doc.Tasks[1].ParentTaskID != doc.Tasks[0].ID {
+		t.Fatalf("subtask should reference its parent: %+v", doc.Tasks[1])
+	}
+
+	// Empty collections must still be arrays.
+	buf.Reset()
+	if err := Write(context.Background(), fakeSource{}, service.ExportJSON, &buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if !strings.Contains(buf.String(), `"projects":[],"labels":[],"tasks":[],"taskLabels":[]`) {
+		t.Fatalf("unexpected empty export: %s", buf.String())
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(context.Background(), newFakeSource(), service.ExportCSV, &buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("output is not a zip: %v", err)
+	}
+	rows := map[string][][]string{}
+	for _, f := range zr.File {
+		if !strings.HasSuffix(f.Name, ".csv") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		rows[f.Name], err = csv.NewReader(rc).ReadAll()
+		rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", f.Name, err)
+		}
+	}
+
+	want := map[string]int{"projects.csv": 2, "labels.csv": 2, "tasks.csv": 3, "task_labels.csv": 2}
+	for name, n := range want {
+		if len(rows[name]) != n {
+			t.Errorf("%s: got %d rows, want %d", name, len(rows[name]), n)
+		}
+	}
+	if got := rows["tasks.csv"][1][3]; got != "Buy paint, white" {
+		t.Errorf("title should survive CSV quoting, got %q", got)
+	}
+	if got := rows["tasks.csv"][1][8]; got != "2024-07-02T00:00:00Z" {
+		t.Errorf("dueAt: got %q", got)
+	}
+	if zr.File[0].Name != "manifest.json" {
+		t.Errorf("manifest.json should come first, got %s", zr.File[0].Name)
+	}
+	rc, err := zr.File[0].Open()
+	if err != nil {
+		t.Fatalf("open manifest.json: %v", err)
+	}
+	defer rc.Close()
+	var manifest struct {
+		Version int      `json:"version"`
+		Omitted []string `json:"omitted"`
+	}
+	if err := json.NewDecoder(rc).Decode(&manifest); err != nil {
+		t.Fatalf("decode manifest.json: %v", err)
+	}
+	if manifest.Version != Version || len(manifest.Omitted) != len(Omitted) {
+		t.Errorf("unexpected manifest: %+v", manifest)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Write(context.Background(), newFakeSource(), service.ExportMarkdown, &buf); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"## Home\n\nWeekend \\*chores\\*\n",
+		"- [ ] Buy paint, white (in progress, P1, due 2024-07-02, `errand`)\n",
+		"  - [x] Pick \\[colour\\]\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+// Handler serves GET /export/{token}.{ext}, the links returned by
+// exportData. Exports can take longer than an API request, so the handler
+// sets its own deadline instead of running behind the request timeout.
+type Handler struct {
+	svc     *service.Service
+	timeout time.Duration
+	mux     *http.ServeMux
+}
+
+func NewHandler(svc *service.Service, timeout time.Duration) *Handler {
+	h := &Handler{svc: svc, timeout: timeout, mux: http.NewServeMux()}
+	h.mux.HandleFunc("GET /export/{file}", h.download)
+	h.mux.HandleFunc("/export/", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) download(w http.ResponseWriter, r *http.Request) {
+	file := r.PathValue("file")
+	dot := strings.LastIndexByte(file, '.')
+	if dot < 0 {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	format, err := h.svc.ExportLinkFormat(ctx, file[:dot])
+	if err != nil || format.Extension() != file[dot:] {
+		if err == nil || service.IsAppErrorCode(err, service.CodeNotFound) {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Now().Add(h.timeout))
+	w.Header().Set("Content-Type", ContentType(format))
+	w.Header().Set("Content-Disposition", `attachment; filename="`+Filename(format, time.Now())+`"`)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	if err := Write(ctx, h.svc, format, w); err != nil {
+		// The status is already sent; dropping the connection is the only way
+		// to stop the client keeping a truncated file as if it were complete.
+		panic(http.ErrAbortHandler)
+	}
+}
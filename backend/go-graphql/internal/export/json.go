@@ -0,0 +1,95 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+)
+
+// writeJSON writes
+//
+//	{"version":1,"exportedAt":...,"omitted":[...],"user":{...},"projects":[...],"labels":[...],"tasks":[...],"taskLabels":[...]}
+//
+// one element at a time. Tasks and subtasks share one array; subtasks have
+// parentTaskId set.
+func writeJSON(ctx context.Context, src Source, w io.Writer, now time.Time) error {
+	user, err := src.Me(ctx)
+	if err != nil {
+		return err
+	}
+
+	jw := &jsonWriter{w: bufio.NewWriter(w)}
+	jw.raw(`{"version":`)
+	jw.value(Version)
+	jw.raw(`,"exportedAt":`)
+	jw.value(now)
+	jw.raw(`,"omitted":`)
+	jw.value(Omitted)
+	jw.raw(`,"user":`)
+	jw.value(toUserRecord(user))
+
+	jw.array("projects", func(add func(any)) error {
+		return src.EachProject(ctx, func(p sqlc.Project) error { add(toProjectRecord(p)); return jw.err })
+	})
+	jw.array("labels", func(add func(any)) error {
+		return src.EachLabel(ctx, func(l sqlc.Label) error { add(toLabelRecord(l)); return jw.err })
+	})
+	jw.array("tasks", func(add func(any)) error {
+		return src.EachTask(ctx, func(t sqlc.Task) error { add(toTaskRecord(t)); return jw.err })
+	})
+	jw.array("taskLabels", func(add func(any)) error {
+		return src.EachTaskLabel(ctx, func(tl sqlc.TaskLabel) error { add(toTaskLabelRecord(tl)); return jw.err })
+	})
+	jw.raw("}\n")
+
+	if jw.err != nil {
+		return jw.err
+	}
+	return jw.w.Flush()
+}
+
+// jsonWriter keeps the first error so the document can be written as a
+// straight sequence of calls.
+type jsonWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (jw *jsonWriter) raw(s string) {
+	if jw.err == nil {
+		_, jw.err = jw.w.WriteString(s)
+	}
+}
+
+func (jw *jsonWriter) value(v any) {
+	if jw.err != nil {
+		return
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		jw.err = err
+		return
+	}
+	_, jw.err = jw.w.Write(b)
+}
+
+// array writes `,"name":[...]`, with each element passed to add by each.
+func (jw *jsonWriter) array(name string, each func(add func(any)) error) {
+	jw.raw(`,"` + name + `":[`)
+	first := true
+	err := each(func(v any) {
+		if !first {
+			jw.raw(",")
+		}
+		first = false
+		jw.value(v)
+	})
+	if jw.err == nil {
+		jw.err = err
+	}
+	jw.raw("]")
+}
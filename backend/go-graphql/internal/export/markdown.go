@@ -0,0 +1,111 @@
+package export
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/google/uuid"
+)
+
+// writeMarkdown writes one section per project with a task-list item per
+// task and subtasks indented beneath. Dates are shown in the user's
+// timezone. It is meant for reading and printing, not for re-import.
+func writeMarkdown(ctx context.Context, src Source, w io.Writer, now time.Time) error {
+	user, err := src.Me(ctx)
+	if err != nil {
+		return err
+	}
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# ZenList export\n\nExported %s for %s.\n", now.In(loc).Format("2006-01-02 15:04 MST"), markdownText(user.Name))
+
+	err = src.EachProject(ctx, func(p sqlc.Project) error {
+		fmt.Fprintf(bw, "\n## %s\n\n", markdownText(p.Title))
+		if p.Description != nil && strings.TrimSpace(*p.Description) != "" {
+			fmt.Fprintf(bw, "%s\n\n", markdownText(*p.Description))
+		}
+
+		empty := true
+		err := src.EachTaskTree(ctx, p.ID, func(tree service.TaskTree) error {
+			empty = false
+			writeChecklistItem(bw, "", tree.Task, tree.Labels, loc)
+			for _, sub := range tree.Subtasks {
+				writeChecklistItem(bw, "  ", sub, tree.Labels, loc)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if empty {
+			bw.WriteString("_No tasks._\n")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+func writeChecklistItem(w *bufio.Writer, indent string, t sqlc.Task, labels map[uuid.UUID][]string, loc *time.Location) {
+	box := "[ ]"
+	if t.Status == "DONE" {
+		box = "[x]"
+	}
+
+	var details []string
+	switch t.Status {
+	case "IN_PROGRESS":
+		details = append(details, "in progress")
+	case "BLOCKED":
+		details = append(details, "blocked")
+	}
+	if t.Priority != "P3" {
+		details = append(details, t.Priority)
+	}
+	if t.StartAt.Valid {
+		details = append(details, "starts "+markdownDate(t.StartAt.Time, loc))
+	}
+	if t.DueAt.Valid {
+		details = append(details, "due "+markdownDate(t.DueAt.Time, loc))
+	}
+	for _, name := range labels[uuid.UUID(t.ID.Bytes)] {
+		details = append(details, "`"+strings.ReplaceAll(name, "`", "'")+"`")
+	}
+
+	line := indent + "- " + box + " " + markdownText(t.Title)
+	if len(details) > 0 {
+		line += " (" + strings.Join(details, ", ") + ")"
+	}
+	w.WriteString(line + "\n")
+}
+
+// markdownDate omits midnight, which is how date-only values are stored.
+func markdownDate(t time.Time, loc *time.Location) string {
+	t = t.In(loc)
+	if t.Hour() == 0 && t.Minute() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, "`", "\\`", "#", `\#`,
+)
+
+// markdownText puts free text on one line and escapes the characters that
+// would otherwise start emphasis, links, HTML or headings.
+func markdownText(s string) string {
+	return markdownEscaper.Replace(strings.Join(strings.Fields(s), " "))
+}
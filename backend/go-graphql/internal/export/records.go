@@ -0,0 +1,120 @@
+package export
+
+import (
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// The record types fix the export's field names independently of the sqlc
+// models, so a schema change cannot silently change the file format.
+
+type userRecord struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Timezone string `json:"timezone"`
+}
+
+type projectRecord struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+	Color       *string   `json:"color"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+type labelRecord struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type taskRecord struct {
+	ID           string     `json:"id"`
+	ProjectID    string     `json:"projectId"`
+	ParentTaskID *string    `json:"parentTaskId"`
+	Title        string     `json:"title"`
+	Description  *string    `json:"description"`
+	Status       string     `json:"status"`
+	Priority     string     `json:"priority"`
+	StartAt      *time.Time `json:"startAt"`
+	DueAt        *time.Time `json:"dueAt"`
+	CompletedAt  *time.Time `json:"completedAt"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
+
+type taskLabelRecord struct {
+	TaskID  string `json:"taskId"`
+	LabelID string `json:"labelId"`
+}
+
+func toUserRecord(u sqlc.User) userRecord {
+	return userRecord{ID: uuidString(u.ID), Name: u.Name, Email: u.Email, Timezone: u.Timezone}
+}
+
+func toProjectRecord(p sqlc.Project) projectRecord {
+	return projectRecord{
+		ID:          uuidString(p.ID),
+		Title:       p.Title,
+		Description: p.Description,
+		Color:       p.Color,
+		CreatedAt:   p.CreatedAt.Time.UTC(),
+		UpdatedAt:   p.UpdatedAt.Time.UTC(),
+	}
+}
+
+func toLabelRecord(l sqlc.Label) labelRecord {
+	return labelRecord{
+		ID:        uuidString(l.ID),
+		Name:      l.Name,
+		CreatedAt: l.CreatedAt.Time.UTC(),
+		UpdatedAt: l.UpdatedAt.Time.UTC(),
+	}
+}
+
+func toTaskRecord(t sqlc.Task) taskRecord {
+	var parent *string
+	if t.ParentTaskID.Valid {
+		id := uuidString(t.ParentTaskID)
+		parent = &id
+	}
+	return taskRecord{
+		ID:           uuidString(t.ID),
+		ProjectID:    uuidString(t.ProjectID),
+		ParentTaskID: parent,
+		Title:        t.Title,
+		Description:  t.Description,
+		Status:       t.Status,
+		Priority:     t.Priority,
+		StartAt:      timePtr(t.StartAt),
+		DueAt:        timePtr(t.DueAt),
+		CompletedAt:  timePtr(t.CompletedAt),
+		CreatedAt:    t.CreatedAt.Time.UTC(),
+		UpdatedAt:    t.UpdatedAt.Time.UTC(),
+	}
+}
+
+func toTaskLabelRecord(tl sqlc.TaskLabel) taskLabelRecord {
+	return taskLabelRecord{TaskID: uuidString(tl.TaskID), LabelID: uuidString(tl.LabelID)}
+}
+
+func uuidString(v pgtype.UUID) string {
+	if !v.Valid {
+		return ""
+	}
+	return uuid.UUID(v.Bytes).String()
+}
+
+func timePtr(v pgtype.Timestamptz) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	t := v.Time.UTC()
+	return &t
+}
@@ -0,0 +1,282 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// none is the value that matches a missing date or a task without labels.
+const none = "none"
+
+type fieldKind int
+
+const (
+	kindText fieldKind = iota
+	kindStatus
+	kindPriority
+	kindLabel
+	kindProject
+	kindDate
+)
+
+type fieldDef struct {
+	kind   fieldKind
+	column string
+}
+
+var fields = map[string]fieldDef{
+	"title":     {kindText, "t.title"},
+	"status":    {kindStatus, "t.status"},
+	"priority":  {kindPriority, "t.priority"},
+	"label":     {kindLabel, ""},
+	"project":   {kindProject, "t.project_id"},
+	"due":       {kindDate, "t.due_at"},
+	"start":     {kindDate, "t.start_at"},
+	"completed": {kindDate, "t.completed_at"},
+	"created":   {kindDate, "t.created_at"},
+	"updated":   {kindDate, "t.updated_at"},
+}
+
+var statuses = []string{"TODO", "IN_PROGRESS", "BLOCKED", "DONE"}
+
+func (f fieldDef) allows(op string) bool {
+	switch op {
+	case "=", "!=":
+		return true
+	case "<", "<=", ">", ">=":
+		return f.kind == kindPriority || f.kind == kindDate
+	}
+	return false
+}
+
+func (f fieldDef) normalize(v string) (string, error) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return "", errors.New("value is empty")
+	}
+	switch f.kind {
+	case kindStatus:
+		s := strings.ToUpper(v)
+		for _, valid := range statuses {
+			if s == valid {
+				return s, nil
+			}
+		}
+		return "", fmt.Errorf("invalid status %q", v)
+	case kindPriority:
+		p := strings.ToUpper(v)
+		if len(p) == 1 {
+			p = "P" + p
+		}
+		if len(p) != 2 || p[0] != 'P' || p[1] < '1' || p[1] > '5' {
+			return "", fmt.Errorf("invalid priority %q; use P1 to P5", v)
+		}
+		return p, nil
+	case kindLabel:
+		if strings.EqualFold(v, none) {
+			return none, nil
+		}
+	case kindDate:
+		d := strings.ToLower(v)
+		if d == none {
+			return none, nil
+		}
+		if _, _, err := dateRange(d, time.Now(), time.UTC); err != nil {
+			return "", err
+		}
+		return d, nil
+	}
+	return v, nil
+}
+
+// Options controls how relative values are resolved.
+type Options struct {
+	Now time.Time
+	// Location is the user's timezone; "today" and "+3d" are whole days in
+	// it. It defaults to UTC.
+	Location *time.Location
+}
+
+// Query is a compiled expression.
+type Query struct {
+	// Where is a boolean SQL expression over the tasks table aliased as "t".
+	Where string
+	Args  []any
+}
+
+// Compile turns e into SQL. Every value is a bind parameter, numbered from
+// argOffset+1 so the caller can put its own parameters first.
+func Compile(e Expr, opts Options, argOffset int) Query {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	b := &builder{opts: opts, offset: argOffset}
+	where := e.compile(b)
+	return Query{Where: where, Args: b.args}
+}
+
+type builder struct {
+	opts   Options
+	offset int
+	args   []any
+}
+
+func (b *builder) arg(v any) string {
+	b.args = append(b.args, v)
+	return "$" + strconv.Itoa(b.offset+len(b.args))
+}
+
+func (e andExpr) compile(b *builder) string {
+	return "(" + e.left.compile(b) + " AND " + e.right.compile(b) + ")"
+}
+
+func (e orExpr) compile(b *builder) string {
+	return "(" + e.left.compile(b) + " OR " + e.right.compile(b) + ")"
+}
+
+// NOT treats unknown as false, so "NOT due < +3d" includes tasks without a
+// due date.
+func (e notExpr) compile(b *builder) string {
+	return "NOT COALESCE(" + e.x.compile(b) + ", FALSE)"
+}
+
+func (e textExpr) compile(b *builder) string {
+	return "t.title ILIKE " + b.arg(likePattern(e.value))
+}
+
+func (e cond) compile(b *builder) string {
+	def := fields[e.field]
+	switch def.kind {
+	case kindText:
+		op := "ILIKE"
+		if e.op == "!=" {
+			op = "NOT ILIKE"
+		}
+		return def.column + " " + op + " " + b.arg(likePattern(e.value))
+	case kindStatus, kindPriority:
+		return def.column + " " + sqlOp(e.op) + " " + b.arg(e.value) + "::text"
+	case kindLabel:
+		if e.value == none {
+			exists := "EXISTS (SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.label_id WHERE tl.task_id = t.id AND l.deleted_at IS NULL)"
+			if e.op == "=" {
+				return "NOT " + exists
+			}
+			return exists
+		}
+		exists := "EXISTS (SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.label_id WHERE tl.task_id = t.id AND l.deleted_at IS NULL AND LOWER(l.name) = LOWER(" + b.arg(e.value) + "::text))"
+		if e.op == "!=" {
+			return "NOT " + exists
+		}
+		return exists
+	case kindProject:
+		op := "IN"
+		if e.op == "!=" {
+			op = "NOT IN"
+		}
+		return def.column + " " + op + " (SELECT p.id FROM projects p WHERE p.user_id = t.user_id AND p.deleted_at IS NULL AND LOWER(p.title) = LOWER(" + b.arg(e.value) + "::text))"
+	default:
+		return e.compileDate(b, def.column)
+	}
+}
+
+// compileDate compares a timestamp column with the half-open range a date
+// value covers: "due <= today" means before tomorrow starts.
+func (e cond) compileDate(b *builder, column string) string {
+	if e.value == none {
+		if e.op == "=" {
+			return column + " IS NULL"
+		}
+		return column + " IS NOT NULL"
+	}
+	// The value was validated by Parse.
+	from, to, _ := dateRange(e.value, b.opts.Now, b.opts.Location)
+	switch e.op {
+	case "<":
+		return column + " < " + b.arg(from) + "::timestamptz"
+	case "<=":
+		return column + " < " + b.arg(to) + "::timestamptz"
+	case ">":
+		return column + " >= " + b.arg(to) + "::timestamptz"
+	case ">=":
+		return column + " >= " + b.arg(from) + "::timestamptz"
+	case "!=":
+		return "(" + column + " < " + b.arg(from) + "::timestamptz OR " + column + " >= " + b.arg(to) + "::timestamptz)"
+	default:
+		return "(" + column + " >= " + b.arg(from) + "::timestamptz AND " + column + " < " + b.arg(to) + "::timestamptz)"
+	}
+}
+
+func sqlOp(op string) string {
+	if op == "!=" {
+		return "<>"
+	}
+	return op
+}
+
+func likePattern(v string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(v) + "%"
+}
+
+// dateRange resolves a lower-cased date value to [from, to). Named days,
+// offsets and ISO dates cover a whole day in loc; "now" and timestamps are a
+// single instant.
+//
+//	today, tomorrow, yesterday, now
+//	+3d, -2w, +1m, +1y   (days, weeks, months, years from today)
+//	2024-07-01, 2024-07-01t09:00, 2024-07-01t09:00:00z
+func dateRange(v string, now time.Time, loc *time.Location) (time.Time, time.Time, error) {
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	day := func(d time.Time) (time.Time, time.Time, error) { return d, d.AddDate(0, 0, 1), nil }
+	instant := func(t time.Time) (time.Time, time.Time, error) { return t, t.Add(time.Microsecond), nil }
+
+	switch v {
+	case "now":
+		return instant(now)
+	case "today":
+		return day(today)
+	case "tomorrow":
+		return day(today.AddDate(0, 0, 1))
+	case "yesterday":
+		return day(today.AddDate(0, 0, -1))
+	}
+
+	if len(v) >= 3 && (v[0] == '+' || v[0] == '-') {
+		n, err := strconv.Atoi(v[1 : len(v)-1])
+		if err == nil && n <= 10000 {
+			if v[0] == '-' {
+				n = -n
+			}
+			switch v[len(v)-1] {
+			case 'd':
+				return day(today.AddDate(0, 0, n))
+			case 'w':
+				return day(today.AddDate(0, 0, 7*n))
+			case 'm':
+				return day(today.AddDate(0, n, 0))
+			case 'y':
+				return day(today.AddDate(n, 0, 0))
+			}
+		}
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", v, loc); err == nil {
+		return day(t)
+	}
+	upper := strings.ToUpper(v)
+	if t, err := time.Parse(time.RFC3339, upper); err == nil {
+		return instant(t)
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if t, err := time.ParseInLocation(layout, upper, loc); err == nil {
+			return instant(t)
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q; use today, +3d, 2024-07-01 or an RFC 3339 time", v)
+}
@@ -0,0 +1,146 @@
+package filter
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseCanonical(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{
+			in:   "priority <= P2 AND label:urgent AND due < +3d AND project:Work",
+			want: "(((priority <= P2 AND label:urgent) AND due < +3d) AND project:Work)",
+		},
+		{in: "status:done or status:blocked p<1", want: ""},
+		{in: "Status = in_progress", want: "status:IN_PROGRESS"},
+		{in: "priority>=2", want: "priority >= P2"},
+		{in: `label:"to read" rent`, want: `(label:"to read" AND rent)`},
+		{in: "a OR b c", want: "(a OR (b AND c))"},
+		{in: "NOT (due = none OR label:none)", want: "NOT (due = none OR label:none)"},
+		{in: `"and"`, want: `"and"`},
+		{in: "due >= 2024-07-01T09:00", want: "due >= 2024-07-01t09:00"},
+	}
+	for _, tc := range tests {
+		expr, err := Parse(tc.in)
+		if tc.want == "" {
+			if err == nil {
+				t.Fatalf("Parse(%q): expected error, got %s", tc.in, expr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tc.in, err)
+		}
+		if got := expr.String(); got != tc.want {
+			t.Fatalf("Parse(%q) = %s, want %s", tc.in, got, tc.want)
+		}
+		// The canonical form parses to itself.
+		again, err := Parse(expr.String())
+		if err != nil || again.String() != tc.want {
+			t.Fatalf("Parse(%q) does not round-trip: %v %v", tc.want, again, err)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		in  string
+		msg string
+	}{
+		{in: "", msg: "empty"},
+		{in: "   ", msg: "empty"},
+		{in: "color:red", msg: `unknown field "color"`},
+		{in: "label < a", msg: `label does not support "<"`},
+		{in: "status:later", msg: `invalid status "later"`},
+		{in: "priority:P9", msg: "invalid priority"},
+		{in: "due < soon", msg: `invalid date "soon"`},
+		{in: "due < none", msg: "not supported"},
+		{in: "(a OR b", msg: `expected ")"`},
+		{in: "a AND", msg: "unexpected end"},
+		{in: "a ! b", msg: `expected "!="`},
+		{in: `title:"open`, msg: "unterminated string"},
+		{in: "priority:", msg: "expected a value"},
+		{in: "OR a", msg: "unexpected OR"},
+		{in: "a )", msg: `unexpected ")"`},
+		{in: strings.Repeat("a ", 51), msg: "more than 50 conditions"},
+		{in: strings.Repeat("a", MaxLength+1), msg: "longer than"},
+	}
+	for _, tc := range tests {
+		_, err := Parse(tc.in)
+		if err == nil || !strings.Contains(err.Error(), tc.msg) {
+			t.Fatalf("Parse(%q): got %v, want error containing %q", tc.in, err, tc.msg)
+		}
+	}
+}
+
+func TestCompile(t *testing.T) {
+	loc, _ := time.LoadLocation("Asia/Kolkata")
+	now := time.Date(2024, 7, 1, 22, 0, 0, 0, time.UTC) // 2 July 03:30 in Kolkata
+	day := func(d int) time.Time { return time.Date(2024, 7, d, 0, 0, 0, 0, loc) }
+
+	expr, err := Parse("priority <= P2 AND label:urgent AND due < +3d AND project:Work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := Compile(expr, Options{Now: now, Location: loc}, 1)
+	wantWhere := "(((t.priority <= $2::text AND " +
+		"EXISTS (SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.label_id WHERE tl.task_id = t.id AND l.deleted_at IS NULL AND LOWER(l.name) = LOWER($3::text))) AND " +
+		"t.due_at < $4::timestamptz) AND " +
+		"t.project_id IN (SELECT p.id FROM projects p WHERE p.user_id = t.user_id AND p.deleted_at IS NULL AND LOWER(p.title) = LOWER($5::text)))"
+	if q.Where != wantWhere {
+		t.Fatalf("Where:\n got %s\nwant %s", q.Where, wantWhere)
+	}
+	wantArgs := []any{"P2", "urgent", day(5), "Work"}
+	if !reflect.DeepEqual(q.Args, wantArgs) {
+		t.Fatalf("Args: got %v want %v", q.Args, wantArgs)
+	}
+}
+
+func TestCompileDates(t *testing.T) {
+	now := time.Date(2024, 7, 10, 12, 0, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2024, 7, d, 0, 0, 0, 0, time.UTC) }
+	tests := []struct {
+		in    string
+		where string
+		args  []any
+	}{
+		{in: "due <= today", where: "t.due_at < $1::timestamptz", args: []any{day(11)}},
+		{in: "due > tomorrow", where: "t.due_at >= $1::timestamptz", args: []any{day(12)}},
+		{in: "start >= -1w", where: "t.start_at >= $1::timestamptz", args: []any{day(3)}},
+		{in: "completed:yesterday", where: "(t.completed_at >= $1::timestamptz AND t.completed_at < $2::timestamptz)", args: []any{day(9), day(10)}},
+		{in: "created != 2024-07-04", where: "(t.created_at < $1::timestamptz OR t.created_at >= $2::timestamptz)", args: []any{day(4), day(5)}},
+		{in: "due:none", where: "t.due_at IS NULL"},
+		{in: "due != none", where: "t.due_at IS NOT NULL"},
+		{in: "updated > \"2024-07-10T08:00:00Z\"", where: "t.updated_at >= $1::timestamptz", args: []any{time.Date(2024, 7, 10, 8, 0, 0, 1000, time.UTC)}},
+	}
+	for _, tc := range tests {
+		expr, err := Parse(tc.in)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tc.in, err)
+		}
+		q := Compile(expr, Options{Now: now}, 0)
+		if q.Where != tc.where || !reflect.DeepEqual(q.Args, tc.args) {
+			t.Fatalf("Compile(%q) = %s %v, want %s %v", tc.in, q.Where, q.Args, tc.where, tc.args)
+		}
+	}
+}
+
+func TestCompileTextAndNegation(t *testing.T) {
+	expr, err := Parse(`NOT title:"50%_off" OR label:none`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := Compile(expr, Options{}, 0)
+	want := "(NOT COALESCE(t.title ILIKE $1, FALSE) OR NOT EXISTS (SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.label_id WHERE tl.task_id = t.id AND l.deleted_at IS NULL))"
+	if q.Where != want {
+		t.Fatalf("Where:\n got %s\nwant %s", q.Where, want)
+	}
+	if !reflect.DeepEqual(q.Args, []any{`%50\%\_off%`}) {
+		t.Fatalf("Args: got %v", q.Args)
+	}
+}
@@ -0,0 +1,111 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// SyntaxError reports where an expression stopped making sense. Pos is a
+// byte offset into the expression.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos+1)
+}
+
+func lex(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokRParen, ")", i})
+			i++
+		case c == ':' || c == '=':
+			tokens = append(tokens, token{tokOp, string(c), i})
+			i++
+		case c == '!' || c == '<' || c == '>':
+			op := string(c)
+			if i+1 < len(src) && src[i+1] == '=' {
+				op += "="
+			}
+			if op == "!" {
+				return nil, &SyntaxError{i, `expected "!="`}
+			}
+			tokens = append(tokens, token{tokOp, op, i})
+			i += len(op)
+		case c == '"':
+			start := i
+			var b strings.Builder
+			i++
+			for {
+				if i >= len(src) {
+					return nil, &SyntaxError{start, "unterminated string"}
+				}
+				if src[i] == '\\' && i+1 < len(src) {
+					b.WriteByte(src[i+1])
+					i += 2
+					continue
+				}
+				if src[i] == '"' {
+					i++
+					break
+				}
+				b.WriteByte(src[i])
+				i++
+			}
+			tokens = append(tokens, token{tokString, b.String(), start})
+		default:
+			start := i
+			for i < len(src) && (isWordByte(src[i]) || isTimeColon(src, i)) {
+				i++
+			}
+			tokens = append(tokens, token{tokWord, src[start:i], start})
+		}
+	}
+	return append(tokens, token{tokEOF, "", len(src)}), nil
+}
+
+// isTimeColon lets unquoted times such as 2024-07-01T09:30 stay one word:
+// a colon between two digits does not separate a field from its value.
+func isTimeColon(src string, i int) bool {
+	return src[i] == ':' && i > 0 && i+1 < len(src) && isDigit(src[i-1]) && isDigit(src[i+1])
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isWordByte(c byte) bool {
+	if c >= 0x80 {
+		return true
+	}
+	if unicode.IsSpace(rune(c)) {
+		return false
+	}
+	return !strings.ContainsRune(`():=!<>"`, rune(c))
+}
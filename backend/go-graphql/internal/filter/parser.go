@@ -0,0 +1,233 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// MaxLength bounds the expression text.
+	MaxLength = 1000
+	// maxTerms bounds the number of conditions so a filter cannot build an
+	// arbitrarily large query.
+	maxTerms = 50
+)
+
+// Expr is a parsed filter expression.
+type Expr interface {
+	// String renders the expression in canonical form: keywords upper-cased,
+	// fields lower-cased, and values quoted where needed.
+	String() string
+	compile(b *builder) string
+}
+
+type andExpr struct{ left, right Expr }
+type orExpr struct{ left, right Expr }
+type notExpr struct{ x Expr }
+
+// cond compares a field with a value, e.g. "priority <= P2".
+type cond struct {
+	field string
+	op    string
+	value string
+}
+
+// textExpr is a bare word or string; it matches titles containing it.
+type textExpr struct{ value string }
+
+func (e andExpr) String() string  { return "(" + e.left.String() + " AND " + e.right.String() + ")" }
+func (e orExpr) String() string   { return "(" + e.left.String() + " OR " + e.right.String() + ")" }
+func (e notExpr) String() string  { return "NOT " + e.x.String() }
+func (e textExpr) String() string { return quote(e.value) }
+
+func (e cond) String() string {
+	if e.op == "=" && fields[e.field].kind != kindDate && fields[e.field].kind != kindPriority {
+		return e.field + ":" + quote(e.value)
+	}
+	return e.field + " " + e.op + " " + quote(e.value)
+}
+
+func quote(v string) string {
+	plain := v != "" && !isKeyword(v)
+	for i := 0; i < len(v) && plain; i++ {
+		plain = isWordByte(v[i]) || isTimeColon(v, i)
+	}
+	if plain {
+		return v
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(v) + `"`
+}
+
+func isKeyword(w string) bool {
+	switch strings.ToUpper(w) {
+	case "AND", "OR", "NOT":
+		return true
+	}
+	return false
+}
+
+// Parse parses a filter expression such as
+//
+//	priority <= P2 AND label:urgent AND due < +3d AND project:Work
+//
+// Conditions are field, operator, value; ":" is a synonym for "=". Adjacent
+// terms are joined with AND, and AND binds tighter than OR. A word or quoted
+// string on its own matches task titles containing it.
+func Parse(src string) (Expr, error) {
+	if len(src) > MaxLength {
+		return nil, &SyntaxError{MaxLength, fmt.Sprintf("expression is longer than %d characters", MaxLength)}
+	}
+	tokens, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokEOF {
+		return nil, &SyntaxError{0, "expression is empty"}
+	}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, &SyntaxError{t.pos, fmt.Sprintf("unexpected %q", t.text)}
+	}
+	return expr, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	terms  int
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) keyword(t token, kw string) bool {
+	return t.kind == tokWord && strings.EqualFold(t.text, kw)
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword(p.peek(), "OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orExpr{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		t := p.peek()
+		switch {
+		case p.keyword(t, "AND"):
+			p.next()
+		case t.kind == tokWord && !p.keyword(t, "OR"), t.kind == tokString, t.kind == tokLParen:
+			// Implicit AND.
+		default:
+			return left, nil
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andExpr{left, right}
+	}
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if p.keyword(p.peek(), "NOT") {
+		p.next()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	t := p.next()
+	switch t.kind {
+	case tokLParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, &SyntaxError{closing.pos, `expected ")"`}
+		}
+		return expr, nil
+	case tokString:
+		return p.term(t, textExpr{t.text})
+	case tokWord:
+		if isKeyword(t.text) {
+			return nil, &SyntaxError{t.pos, fmt.Sprintf("unexpected %s", strings.ToUpper(t.text))}
+		}
+		if p.peek().kind != tokOp {
+			return p.term(t, textExpr{t.text})
+		}
+		return p.parseCond(t)
+	case tokEOF:
+		return nil, &SyntaxError{t.pos, "unexpected end of expression"}
+	default:
+		return nil, &SyntaxError{t.pos, fmt.Sprintf("unexpected %q", t.text)}
+	}
+}
+
+func (p *parser) parseCond(name token) (Expr, error) {
+	field := strings.ToLower(name.text)
+	def, ok := fields[field]
+	if !ok {
+		return nil, &SyntaxError{name.pos, fmt.Sprintf("unknown field %q", name.text)}
+	}
+	opTok := p.next()
+	op := opTok.text
+	if op == ":" {
+		op = "="
+	}
+	if !def.allows(op) {
+		return nil, &SyntaxError{opTok.pos, fmt.Sprintf("%s does not support %q", field, opTok.text)}
+	}
+
+	v := p.next()
+	if v.kind != tokWord && v.kind != tokString {
+		return nil, &SyntaxError{v.pos, fmt.Sprintf("expected a value for %s", field)}
+	}
+	value, err := def.normalize(v.text)
+	if err != nil {
+		return nil, &SyntaxError{v.pos, err.Error()}
+	}
+	if value == none && op != "=" && op != "!=" {
+		return nil, &SyntaxError{v.pos, fmt.Sprintf("%s %s none is not supported", field, op)}
+	}
+	return p.term(name, cond{field: field, op: op, value: value})
+}
+
+func (p *parser) term(t token, e Expr) (Expr, error) {
+	p.terms++
+	if p.terms > maxTerms {
+		return nil, &SyntaxError{t.pos, fmt.Sprintf("expression has more than %d conditions", maxTerms)}
+	}
+	return e, nil
+}
@@ -0,0 +1,135 @@
+package extension
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/99designs/gqlgen/complexity"
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const (
+	costExtension   = "QueryCost"
+	costResponseKey = "cost"
+
+	errComplexityLimit = "COMPLEXITY_LIMIT_EXCEEDED"
+	errDepthLimit      = "DEPTH_LIMIT_EXCEEDED"
+)
+
+// QueryCost rejects operations whose computed complexity or selection depth
+// exceeds the configured limits, and reports the computed values under the
+// "cost" key of the response extensions so clients can tune their queries.
+type QueryCost struct {
+	MaxComplexity int
+	MaxDepth      int
+
+	es graphql.ExecutableSchema
+}
+
+var _ interface {
+	graphql.HandlerExtension
+	graphql.OperationContextMutator
+	graphql.ResponseInterceptor
+} = &QueryCost{}
+
+// CostStats is the per-operation cost reported back to clients.
+type CostStats struct {
+	Complexity      int `json:"complexity"`
+	ComplexityLimit int `json:"complexityLimit"`
+	Depth           int `json:"depth"`
+	DepthLimit      int `json:"depthLimit"`
+}
+
+func (c QueryCost) ExtensionName() string {
+	return costExtension
+}
+
+func (c *QueryCost) Validate(schema graphql.ExecutableSchema) error {
+	if c.MaxComplexity < 1 {
+		return errors.New("QueryCost MaxComplexity must be >= 1")
+	}
+	if c.MaxDepth < 1 {
+		return errors.New("QueryCost MaxDepth must be >= 1")
+	}
+	c.es = schema
+	return nil
+}
+
+func (c QueryCost) MutateOperationContext(ctx context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	op := rc.Doc.Operations.ForName(rc.OperationName)
+
+	stats := &CostStats{
+		Complexity:      complexity.Calculate(c.es, op, rc.Variables),
+		ComplexityLimit: c.MaxComplexity,
+		Depth:           selectionDepth(rc.Doc, op.SelectionSet, map[string]bool{}),
+		DepthLimit:      c.MaxDepth,
+	}
+	rc.Stats.SetExtension(costExtension, stats)
+
+	if stats.Depth > stats.DepthLimit {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", stats.Depth, stats.DepthLimit)
+		errcode.Set(err, errDepthLimit)
+		return err
+	}
+	if stats.Complexity > stats.ComplexityLimit {
+		err := gqlerror.Errorf("operation has complexity %d, which exceeds the limit of %d", stats.Complexity, stats.ComplexityLimit)
+		errcode.Set(err, errComplexityLimit)
+		return err
+	}
+	return nil
+}
+
+func (c QueryCost) InterceptResponse(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+	if stats := GetCostStats(ctx); stats != nil {
+		graphql.RegisterExtension(ctx, costResponseKey, stats)
+	}
+	return next(ctx)
+}
+
+// GetCostStats returns the cost computed for the current operation, if any.
+func GetCostStats(ctx context.Context) *CostStats {
+	if !graphql.HasOperationContext(ctx) {
+		return nil
+	}
+	rc := graphql.GetOperationContext(ctx)
+	s, _ := rc.Stats.GetExtension(costExtension).(*CostStats)
+	return s
+}
+
+// selectionDepth returns the deepest field nesting in a selection set. Fragments
+// do not add a level of their own, and introspection fields are not counted so
+// schema tooling keeps working under tight limits.
+func selectionDepth(doc *ast.QueryDocument, set ast.SelectionSet, visiting map[string]bool) int {
+	depth := 0
+	for _, sel := range set {
+		d := 0
+		switch sel := sel.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(sel.Name, "__") {
+				continue
+			}
+			d = 1 + selectionDepth(doc, sel.SelectionSet, visiting)
+		case *ast.InlineFragment:
+			d = selectionDepth(doc, sel.SelectionSet, visiting)
+		case *ast.FragmentSpread:
+			if visiting[sel.Name] {
+				continue
+			}
+			fragment := doc.Fragments.ForName(sel.Name)
+			if fragment == nil {
+				continue
+			}
+			visiting[sel.Name] = true
+			d = selectionDepth(doc, fragment.SelectionSet, visiting)
+			delete(visiting, sel.Name)
+		}
+		if d > depth {
+			depth = d
+		}
+	}
+	return depth
+}
@@ -0,0 +1,98 @@
+package extension
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	gqlextension "github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/faizp/zenlist/backend/go-graphql/graph"
+)
+
+type costResponse struct {
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+	Extensions struct {
+		Cost *CostStats `json:"cost"`
+	} `json:"extensions"`
+}
+
+func runCostQuery(t *testing.T, cost *QueryCost, query string) costResponse {
+	t.Helper()
+
+	srv := handler.New(graph.NewExecutableSchema(graph.Config{
+		Resolvers:  &graph.Resolver{},
+		Complexity: graph.NewComplexity(),
+	}))
+	srv.AddTransport(transport.POST{})
+	srv.Use(gqlextension.Introspection{})
+	srv.Use(cost)
+
+	body, _ := json.Marshal(map[string]string{"query": query})
+	req := httptest.NewRequest(http.MethodPost, "/query", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	var resp costResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v (%s)", err, rec.Body.String())
+	}
+	return resp
+}
+
+func TestQueryCostReportsStats(t *testing.T) {
+	resp := runCostQuery(t, &QueryCost{MaxComplexity: 100, MaxDepth: 5}, `{ __typename }`)
+	if len(resp.Errors) != 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+	if resp.Extensions.Cost == nil {
+		t.Fatal("expected cost extension in response")
+	}
+	if resp.Extensions.Cost.ComplexityLimit != 100 || resp.Extensions.Cost.DepthLimit != 5 {
+		t.Fatalf("unexpected limits: %+v", resp.Extensions.Cost)
+	}
+}
+
+func TestQueryCostMultipliesPageSize(t *testing.T) {
+	small := runCostQuery(t, &QueryCost{MaxComplexity: 1, MaxDepth: 10},
+		`{ tasks(projectId: "x", first: 1) { edges { node { id subtasks { labels { id } } } } } }`)
+	large := runCostQuery(t, &QueryCost{MaxComplexity: 1, MaxDepth: 10},
+		`{ tasks(projectId: "x", first: 100) { edges { node { id subtasks { labels { id } } } } } }`)
+
+	if small.Extensions.Cost == nil || large.Extensions.Cost == nil {
+		t.Fatal("expected cost extension in response")
+	}
+	if got, want := large.Extensions.Cost.Complexity, small.Extensions.Cost.Complexity*100; got < want-100 {
+		t.Fatalf("complexity for first: 100 = %d, expected about %d", got, want)
+	}
+	if len(large.Errors) != 1 || large.Errors[0].Extensions["code"] != errComplexityLimit {
+		t.Fatalf("expected %s error, got %+v", errComplexityLimit, large.Errors)
+	}
+}
+
+func TestQueryCostDepthLimit(t *testing.T) {
+	query := `
+		query { ...root }
+		fragment root on Query { tasks(projectId: "x") { edges { node { subtasks { labels { id } } } } } }
+	`
+	resp := runCostQuery(t, &QueryCost{MaxComplexity: 1_000_000, MaxDepth: 5}, query)
+	if resp.Extensions.Cost == nil || resp.Extensions.Cost.Depth != 6 {
+		t.Fatalf("expected depth 6, got %+v", resp.Extensions.Cost)
+	}
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != errDepthLimit {
+		t.Fatalf("expected %s error, got %+v", errDepthLimit, resp.Errors)
+	}
+
+	introspection := runCostQuery(t, &QueryCost{MaxComplexity: 1_000_000, MaxDepth: 2},
+		`{ __schema { types { fields { type { ofType { name } } } } } }`)
+	if len(introspection.Errors) != 0 {
+		t.Fatalf("introspection should not count towards depth: %+v", introspection.Errors)
+	}
+}
@@ -17,7 +17,7 @@ func RequestID(next http.Handler) http.Handler {
 		if reqID == "" {
 			reqID = uuid.NewString()
 		}
-		ctx := context.WithValue(r.Context(), requestIDKey, reqID)
+		ctx := WithRequestID(r.Context(), reqID)
 		w.Header().Set("X-Request-ID", reqID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
@@ -27,3 +27,9 @@ func RequestIDFromContext(ctx context.Context) string {
 	v, _ := ctx.Value(requestIDKey).(string)
 	return v
 }
+
+// WithRequestID stores reqID in ctx for transports that do not go through
+// the HTTP middleware chain.
+func WithRequestID(ctx context.Context, reqID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, reqID)
+}
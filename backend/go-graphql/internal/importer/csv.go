@@ -0,0 +1,136 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+// Generic CSV columns. Only project and title are required; the header row
+// is mandatory and column order does not matter.
+//
+//	project      project title; projects with the same title are merged
+//	title        task title
+//	description  free text
+//	status       TODO, IN_PROGRESS, BLOCKED or DONE (default TODO)
+//	priority     P1..P5 or 1..5 (default P3)
+//	start_at     RFC 3339, "2006-01-02 15:04" or "2006-01-02"
+//	due_at       same formats as start_at
+//	labels       label names separated by ";"
+//	parent       title of the parent task in the same project
+var genericColumns = []string{"project", "title", "description", "status", "priority", "start_at", "due_at", "labels", "parent"}
+
+func parseGenericCSV(r io.Reader, opts ParseOptions) (Dataset, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return Dataset{}, service.NewBadInput("CSV file is empty")
+		}
+		return Dataset{}, service.NewBadInput(fmt.Sprintf("invalid CSV: %v", err))
+	}
+	idx := headerIndex(header)
+	for _, required := range []string{"project", "title"} {
+		if _, ok := idx[required]; !ok {
+			return Dataset{}, service.NewBadInput(fmt.Sprintf("CSV header must include %q; supported columns: %s", required, strings.Join(genericColumns, ", ")))
+		}
+	}
+
+	type row struct {
+		line   int
+		task   Task
+		parent string
+	}
+
+	var (
+		ds       Dataset
+		order    []string
+		rows     = map[string][]row{}
+		titles   = map[string]string{}
+		warnings []string
+	)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Dataset{}, service.NewBadInput(fmt.Sprintf("invalid CSV: %v", err))
+		}
+
+		project := column(record, idx, "project")
+		if project == "" {
+			project = opts.ProjectTitle
+		}
+		if project == "" {
+			warnings = append(warnings, fmt.Sprintf("line %d: no project; row skipped", line))
+			continue
+		}
+
+		t := Task{
+			Title:       column(record, idx, "title"),
+			Description: optionalString(column(record, idx, "description")),
+			Status:      normalizeStatus(column(record, idx, "status")),
+			Priority:    normalizePriority(column(record, idx, "priority")),
+		}
+		if t.Status == "" {
+			warnings = append(warnings, fmt.Sprintf("line %d: unknown status %q; using TODO", line, column(record, idx, "status")))
+			t.Status = "TODO"
+		}
+		if t.Priority == "" {
+			warnings = append(warnings, fmt.Sprintf("line %d: unknown priority %q; using P3", line, column(record, idx, "priority")))
+			t.Priority = "P3"
+		}
+		if t.StartAt, err = parseTime(column(record, idx, "start_at"), opts.Location); err != nil {
+			warnings = append(warnings, fmt.Sprintf("line %d: start_at: %v", line, err))
+		}
+		if t.DueAt, err = parseTime(column(record, idx, "due_at"), opts.Location); err != nil {
+			warnings = append(warnings, fmt.Sprintf("line %d: due_at: %v", line, err))
+		}
+		for _, l := range strings.Split(column(record, idx, "labels"), ";") {
+			if l = strings.TrimSpace(l); l != "" {
+				t.Labels = append(t.Labels, l)
+			}
+		}
+
+		key := normalizeName(project)
+		if _, ok := rows[key]; !ok {
+			order = append(order, key)
+			titles[key] = project
+		}
+		rows[key] = append(rows[key], row{line: line, task: t, parent: column(record, idx, "parent")})
+	}
+
+	for _, key := range order {
+		p := Project{Title: titles[key]}
+		roots := map[string]int{}
+		for _, r := range rows[key] {
+			if r.parent == "" {
+				if _, ok := roots[normalizeName(r.task.Title)]; !ok {
+					roots[normalizeName(r.task.Title)] = len(p.Tasks)
+				}
+				p.Tasks = append(p.Tasks, r.task)
+			}
+		}
+		for _, r := range rows[key] {
+			if r.parent == "" {
+				continue
+			}
+			i, ok := roots[normalizeName(r.parent)]
+			if !ok {
+				warnings = append(warnings, fmt.Sprintf("line %d: parent %q not found in project %q; imported as a top-level task", r.line, r.parent, p.Title))
+				p.Tasks = append(p.Tasks, r.task)
+				continue
+			}
+			p.Tasks[i].Subtasks = append(p.Tasks[i].Subtasks, r.task)
+		}
+		ds.Projects = append(ds.Projects, p)
+	}
+	ds.Warnings = warnings
+	return ds, nil
+}
@@ -0,0 +1,91 @@
+package importer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// parseTime accepts RFC 3339 and the common ISO-like layouts; values without
+// an offset are read in loc. An empty value is nil.
+func parseTime(value string, loc *time.Location) (*time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, value, loc); err == nil {
+			utc := t.UTC()
+			return &utc, nil
+		}
+	}
+	return nil, fmt.Errorf("unrecognised date %q", value)
+}
+
+// normalizeStatus maps "in progress", "in-progress" and "IN_PROGRESS" to the
+// ZenList status; unknown values return "".
+func normalizeStatus(value string) string {
+	s := strings.ToUpper(strings.TrimSpace(value))
+	s = strings.NewReplacer(" ", "_", "-", "_").Replace(s)
+	switch s {
+	case "", "TODO", "TO_DO", "OPEN":
+		return "TODO"
+	case "IN_PROGRESS", "DOING":
+		return "IN_PROGRESS"
+	case "BLOCKED":
+		return "BLOCKED"
+	case "DONE", "COMPLETED", "COMPLETE", "CLOSED":
+		return "DONE"
+	}
+	return ""
+}
+
+// normalizePriority accepts "P2" or "2"; unknown values return "".
+func normalizePriority(value string) string {
+	p := strings.ToUpper(strings.TrimSpace(value))
+	if p == "" {
+		return "P3"
+	}
+	p = strings.TrimPrefix(p, "P")
+	if len(p) == 1 && p[0] >= '1' && p[0] <= '5' {
+		return "P" + p
+	}
+	return ""
+}
+
+func optionalString(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// headerIndex maps lower-cased CSV header names to column positions.
+func headerIndex(header []string) map[string]int {
+	idx := make(map[string]int, len(header))
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		if _, ok := idx[h]; !ok {
+			idx[h] = i
+		}
+	}
+	return idx
+}
+
+func column(record []string, idx map[string]int, name string) string {
+	i, ok := idx[name]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
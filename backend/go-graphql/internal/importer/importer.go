@@ -0,0 +1,408 @@
+// Package importer loads projects, labels and tasks exported from other tools
+// into ZenList. Parsers turn each source format into a Dataset; Run writes a
+// Dataset through the service layer, reusing projects and labels with the
+// same name and skipping tasks that already exist.
+package importer
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/google/uuid"
+)
+
+type Format string
+
+const (
+	FormatTodoistCSV  Format = "todoist-csv"
+	FormatTodoistJSON Format = "todoist-json"
+	FormatTrelloJSON  Format = "trello-json"
+	FormatCSV         Format = "csv"
+)
+
+// Formats lists every supported format.
+var Formats = []Format{FormatTodoistCSV, FormatTodoistJSON, FormatTrelloJSON, FormatCSV}
+
+// maxWarnings bounds the report so a malformed file cannot produce an
+// unbounded response.
+const maxWarnings = 100
+
+// Dataset is a source-neutral import.
+type Dataset struct {
+	Projects []Project
+	Warnings []string
+}
+
+type Project struct {
+	Title       string
+	Description *string
+	Color       *string
+	Tasks       []Task
+}
+
+// Task mirrors service.CreateTaskInput with label names instead of IDs.
+// ZenList supports one level of subtasks; parsers attach deeper items to
+// their top-level ancestor.
+type Task struct {
+	Title       string
+	Description *string
+	Status      string
+	Priority    string
+	StartAt     *time.Time
+	DueAt       *time.Time
+	Labels      []string
+	Subtasks    []Task
+}
+
+// ParseOptions configure parsing.
+type ParseOptions struct {
+	// ProjectTitle names the project for formats that hold a single project
+	// without naming it (Todoist CSV). It overrides the name for Trello.
+	ProjectTitle string
+	// Location resolves dates without a UTC offset.
+	Location *time.Location
+}
+
+// Parse reads r in the given format.
+func Parse(format Format, r io.Reader, opts ParseOptions) (Dataset, error) {
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	switch format {
+	case FormatTodoistCSV:
+		return parseTodoistCSV(r, opts)
+	case FormatTodoistJSON:
+		return parseTodoistJSON(r, opts)
+	case FormatTrelloJSON:
+		return parseTrelloJSON(r, opts)
+	case FormatCSV:
+		return parseGenericCSV(r, opts)
+	default:
+		return Dataset{}, service.NewBadInput(fmt.Sprintf("unsupported import format %q", format))
+	}
+}
+
+// Report summarises an import. In a dry run the counts are what would have
+// been created.
+type Report struct {
+	DryRun            bool     `json:"dryRun"`
+	ProjectsCreated   int      `json:"projectsCreated"`
+	ProjectsReused    int      `json:"projectsReused"`
+	LabelsCreated     int      `json:"labelsCreated"`
+	LabelsReused      int      `json:"labelsReused"`
+	TasksCreated      int      `json:"tasksCreated"`
+	SubtasksCreated   int      `json:"subtasksCreated"`
+	DuplicatesSkipped int      `json:"duplicatesSkipped"`
+	Warnings          []string `json:"warnings"`
+}
+
+func (r *Report) warn(format string, args ...any) {
+	if len(r.Warnings) < maxWarnings {
+		r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+	}
+}
+
+// Importer writes Datasets through the service so imports get the same
+// validation, outbox events and live updates as API writes. Each entity is
+// created in its own transaction; a failed import leaves what was already
+// created, and re-running it skips those rows as duplicates.
+type Importer struct {
+	svc *service.Service
+}
+
+func New(svc *service.Service) *Importer {
+	return &Importer{svc: svc}
+}
+
+// Import parses r and runs it. Dates without an offset are read in the
+// user's timezone.
+func (im *Importer) Import(ctx context.Context, format Format, r io.Reader, projectTitle string, dryRun bool) (Report, error) {
+	user, err := im.svc.Me(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	ds, err := Parse(format, r, ParseOptions{ProjectTitle: projectTitle, Location: loc})
+	if err != nil {
+		return Report{}, err
+	}
+	return im.Run(ctx, ds, dryRun)
+}
+
+// taskKey identifies a task for duplicate detection: same parent, same title
+// (case-insensitive) and same due time within a project.
+type taskKey struct {
+	parent string
+	title  string
+	due    int64
+}
+
+func newTaskKey(parentTitle string, title string, due *time.Time) taskKey {
+	k := taskKey{parent: normalizeName(parentTitle), title: normalizeName(title)}
+	if due != nil {
+		k.due = due.Unix()
+	}
+	return k
+}
+
+type projectState struct {
+	id    string
+	tasks map[taskKey]string
+}
+
+type run struct {
+	svc           *service.Service
+	dryRun        bool
+	report        Report
+	projects      map[string]*projectState
+	labels        map[string]string
+	labelsCounted map[string]bool
+}
+
+// Run imports ds. With dryRun nothing is written but the report is complete,
+// including duplicates found against existing data.
+func (im *Importer) Run(ctx context.Context, ds Dataset, dryRun bool) (Report, error) {
+	r := &run{
+		svc:           im.svc,
+		dryRun:        dryRun,
+		report:        Report{DryRun: dryRun},
+		projects:      map[string]*projectState{},
+		labels:        map[string]string{},
+		labelsCounted: map[string]bool{},
+	}
+	for _, w := range ds.Warnings {
+		r.report.warn("%s", w)
+	}
+
+	if err := r.loadExisting(ctx); err != nil {
+		return r.report, err
+	}
+	for _, p := range ds.Projects {
+		if err := r.importProject(ctx, p); err != nil {
+			return r.report, err
+		}
+	}
+	return r.report, nil
+}
+
+func (r *run) loadExisting(ctx context.Context) error {
+	// Archived projects accept no tasks, so a matching title gets a new
+	// project instead.
+	projects, err := collectPages(func(args service.PageArgs) (service.PageResult[sqlc.Project], error) {
+		return r.svc.ListProjects(ctx, false, args)
+	})
+	if err != nil {
+		return err
+	}
+	for _, p := range projects {
+		key := normalizeName(p.Title)
+		if _, ok := r.projects[key]; !ok {
+			// Tasks are loaded lazily, only for projects the import touches.
+			r.projects[key] = &projectState{id: uuid.UUID(p.ID.Bytes).String()}
+		}
+	}
+
+	labels, err := collectPages(func(args service.PageArgs) (service.PageResult[sqlc.Label], error) {
+		return r.svc.ListLabels(ctx, args)
+	})
+	if err != nil {
+		return err
+	}
+	for _, l := range labels {
+		r.labels[normalizeName(l.Name)] = uuid.UUID(l.ID.Bytes).String()
+	}
+	return nil
+}
+
+func (r *run) importProject(ctx context.Context, p Project) error {
+	title := strings.TrimSpace(p.Title)
+	if title == "" {
+		r.report.warn("skipped a project without a title")
+		return nil
+	}
+
+	state, ok := r.projects[normalizeName(title)]
+	switch {
+	case ok && state.tasks == nil:
+		r.report.ProjectsReused++
+		if err := r.loadTasks(ctx, state); err != nil {
+			return err
+		}
+	case ok:
+		// Already created or reused earlier in this import.
+	default:
+		state = &projectState{tasks: map[taskKey]string{}}
+		if !r.dryRun {
+			created, err := r.svc.CreateProject(ctx, service.CreateProjectInput{
+				Title:       title,
+				Description: p.Description,
+				Color:       p.Color,
+			})
+			if err != nil {
+				return fmt.Errorf("create project %q: %w", title, err)
+			}
+			state.id = uuid.UUID(created.ID.Bytes).String()
+		}
+		r.projects[normalizeName(title)] = state
+		r.report.ProjectsCreated++
+	}
+
+	for _, t := range p.Tasks {
+		if err := r.importTask(ctx, state, title, t, nil, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *run) loadTasks(ctx context.Context, state *projectState) error {
+	tasks, err := r.svc.ProjectTasks(ctx, state.id)
+	if err != nil {
+		return err
+	}
+	titles := make(map[[16]byte]string, len(tasks))
+	for _, t := range tasks {
+		titles[t.ID.Bytes] = t.Title
+	}
+	state.tasks = make(map[taskKey]string, len(tasks))
+	for _, t := range tasks {
+		parent := ""
+		if t.ParentTaskID.Valid {
+			parent = titles[t.ParentTaskID.Bytes]
+		}
+		var due *time.Time
+		if t.DueAt.Valid {
+			due = &t.DueAt.Time
+		}
+		state.tasks[newTaskKey(parent, t.Title, due)] = uuid.UUID(t.ID.Bytes).String()
+	}
+	return nil
+}
+
+func (r *run) importTask(ctx context.Context, state *projectState, projectTitle string, t Task, parentID *string, parentTitle string) error {
+	title := strings.TrimSpace(t.Title)
+	if title == "" {
+		r.report.warn("project %q: skipped a task without a title", projectTitle)
+		return nil
+	}
+	if t.StartAt != nil && t.DueAt != nil && t.DueAt.Before(*t.StartAt) {
+		r.report.warn("project %q: task %q is due before it starts; start date dropped", projectTitle, title)
+		t.StartAt = nil
+	}
+
+	key := newTaskKey(parentTitle, title, t.DueAt)
+	id, exists := state.tasks[key]
+	if exists {
+		r.report.DuplicatesSkipped++
+	} else {
+		labelIDs, err := r.ensureLabels(ctx, t.Labels)
+		if err != nil {
+			return err
+		}
+		if !r.dryRun {
+			created, err := r.svc.CreateTask(ctx, service.CreateTaskInput{
+				ProjectID:    state.id,
+				ParentTaskID: parentID,
+				Title:        title,
+				Description:  t.Description,
+				Status:       t.Status,
+				Priority:     t.Priority,
+				StartAt:      t.StartAt,
+				DueAt:        t.DueAt,
+				LabelIDs:     labelIDs,
+			})
+			if err != nil {
+				return fmt.Errorf("create task %q in project %q: %w", title, projectTitle, err)
+			}
+			id = uuid.UUID(created.ID.Bytes).String()
+		}
+		state.tasks[key] = id
+		if parentID == nil {
+			r.report.TasksCreated++
+		} else {
+			r.report.SubtasksCreated++
+		}
+	}
+
+	if parentID != nil && len(t.Subtasks) > 0 {
+		// Parsers flatten deeper levels; this only guards hand-built Datasets.
+		r.report.warn("project %q: subtasks of %q are nested too deeply and were skipped", projectTitle, title)
+		return nil
+	}
+	for _, sub := range t.Subtasks {
+		if err := r.importTask(ctx, state, projectTitle, sub, &id, title); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *run) ensureLabels(ctx context.Context, names []string) ([]string, error) {
+	ids := make([]string, 0, len(names))
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		key := normalizeName(name)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		id, ok := r.labels[key]
+		if !ok {
+			if !r.dryRun {
+				created, err := r.svc.CreateLabel(ctx, service.CreateLabelInput{Name: name})
+				if err != nil {
+					return nil, fmt.Errorf("create label %q: %w", name, err)
+				}
+				id = uuid.UUID(created.ID.Bytes).String()
+			}
+			r.labels[key] = id
+			r.labelsCounted[key] = true
+			r.report.LabelsCreated++
+		} else if !r.labelsCounted[key] {
+			r.labelsCounted[key] = true
+			r.report.LabelsReused++
+		}
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// collectPages walks a forward connection to the end.
+func collectPages[T any](list func(service.PageArgs) (service.PageResult[T], error)) ([]T, error) {
+	const pageSize = 100
+	var (
+		out   []T
+		after *string
+	)
+	for {
+		first := pageSize
+		page, err := list(service.PageArgs{First: &first, After: after})
+		if err != nil {
+			return nil, err
+		}
+		for _, edge := range page.Edges {
+			out = append(out, edge.Node)
+		}
+		if !page.HasNextPage || page.EndCursor == nil {
+			return out, nil
+		}
+		after = page.EndCursor
+	}
+}
+
+func normalizeName(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
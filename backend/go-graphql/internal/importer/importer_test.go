@@ -0,0 +1,168 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+func TestParseGenericCSV(t *testing.T) {
+	body := "\ufeffProject,Title,Status,Priority,Due_At,Labels,Parent\n" +
+		"Home,Paint fence,in progress,2,2024-07-01 09:30,outdoor; weekend,\n" +
+		"Home,Buy paint,done,,,,paint fence\n" +
+		"home,Mow lawn,someday,9,tomorrow,,\n" +
+		"Work,Orphan,,,,,Missing\n"
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	ds, err := Parse(FormatCSV, strings.NewReader(body), ParseOptions{Location: berlin})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(ds.Projects) != 2 || ds.Projects[0].Title != "Home" || ds.Projects[1].Title != "Work" {
+		t.Fatalf("unexpected projects: %+v", ds.Projects)
+	}
+
+	home := ds.Projects[0]
+	if len(home.Tasks) != 2 {
+		t.Fatalf("expected 2 top-level tasks, got %d", len(home.Tasks))
+	}
+	fence := home.Tasks[0]
+	if fence.Status != "IN_PROGRESS" || fence.Priority != "P2" || strings.Join(fence.Labels, ",") != "outdoor,weekend" {
+		t.Fatalf("unexpected task: %+v", fence)
+	}
+	if want := time.Date(2024, 7, 1, 7, 30, 0, 0, time.UTC); fence.DueAt == nil || !fence.DueAt.Equal(want) {
+		t.Fatalf("due: got %v want %s", fence.DueAt, want)
+	}
+	if len(fence.Subtasks) != 1 || fence.Subtasks[0].Title != "Buy paint" || fence.Subtasks[0].Status != "DONE" {
+		t.Fatalf("unexpected subtasks: %+v", fence.Subtasks)
+	}
+	if mow := home.Tasks[1]; mow.Status != "TODO" || mow.Priority != "P3" || mow.DueAt != nil {
+		t.Fatalf("invalid values should fall back to defaults: %+v", mow)
+	}
+	if len(ds.Projects[1].Tasks) != 1 {
+		t.Fatalf("a task with an unknown parent should be imported at the top level")
+	}
+	if len(ds.Warnings) != 4 {
+		t.Fatalf("expected 4 warnings, got %q", ds.Warnings)
+	}
+
+	_, err = Parse(FormatCSV, strings.NewReader("name\nx\n"), ParseOptions{})
+	if !service.IsAppErrorCode(err, service.CodeBadUserInput) {
+		t.Fatalf("expected BAD_USER_INPUT for a missing column, got %v", err)
+	}
+}
+
+func TestParseTodoistCSV(t *testing.T) {
+	body := "TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE\n" +
+		"section,Errands,,,,,,,,\n" +
+		"task,Call plumber @home @urgent,,1,1,,,2024-07-01,en,UTC\n" +
+		"note,Ask about the boiler,,,,,,,,\n" +
+		"task,Find invoice,,4,2,,,every monday,en,UTC\n" +
+		"task,Read book,,4,1,,,,,\n"
+
+	ds, err := Parse(FormatTodoistCSV, strings.NewReader(body), ParseOptions{ProjectTitle: "Chores"})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(ds.Projects) != 1 || ds.Projects[0].Title != "Chores" {
+		t.Fatalf("unexpected projects: %+v", ds.Projects)
+	}
+	tasks := ds.Projects[0].Tasks
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 top-level tasks, got %d", len(tasks))
+	}
+	call := tasks[0]
+	if call.Title != "Call plumber" || call.Priority != "P1" || strings.Join(call.Labels, ",") != "home,urgent" {
+		t.Fatalf("unexpected task: %+v", call)
+	}
+	if call.Description == nil || *call.Description != "Ask about the boiler" {
+		t.Fatalf("note should become the description, got %v", call.Description)
+	}
+	if len(call.Subtasks) != 1 || call.Subtasks[0].DueAt != nil {
+		t.Fatalf("recurring dates should be dropped: %+v", call.Subtasks)
+	}
+	if len(ds.Warnings) != 2 {
+		t.Fatalf("expected warnings for the recurring date and the section, got %q", ds.Warnings)
+	}
+}
+
+func TestParseTodoistJSON(t *testing.T) {
+	body := `{
+		"projects": [{"id": "1", "name": "Inbox"}, {"id": 2, "name": "Gone", "is_deleted": true}],
+		"items": [
+			{"id": "10", "project_id": "1", "content": "Plan trip", "priority": 4, "labels": ["travel"], "due": {"date": "2024-07-01T10:00:00Z"}},
+			{"id": "11", "project_id": "1", "parent_id": "10", "content": "Book flights", "checked": true},
+			{"id": "12", "project_id": "1", "parent_id": "11", "content": "Pick seats"},
+			{"id": "13", "project_id": 2, "content": "Lost"}
+		]
+	}`
+
+	ds, err := Parse(FormatTodoistJSON, strings.NewReader(body), ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(ds.Projects) != 1 || len(ds.Projects[0].Tasks) != 1 {
+		t.Fatalf("unexpected projects: %+v", ds.Projects)
+	}
+	plan := ds.Projects[0].Tasks[0]
+	if plan.Priority != "P1" || plan.DueAt == nil || len(plan.Labels) != 1 {
+		t.Fatalf("unexpected task: %+v", plan)
+	}
+	if len(plan.Subtasks) != 2 || plan.Subtasks[0].Status != "DONE" || plan.Subtasks[1].Title != "Pick seats" {
+		t.Fatalf("nested items should be flattened onto the root task: %+v", plan.Subtasks)
+	}
+	if len(ds.Warnings) != 1 {
+		t.Fatalf("expected a warning for the orphaned task, got %q", ds.Warnings)
+	}
+}
+
+func TestParseTrelloJSON(t *testing.T) {
+	body := `{
+		"name": "Launch",
+		"lists": [{"id": "l1", "name": "Doing"}, {"id": "l2", "name": "Old", "closed": true}],
+		"cards": [
+			{"id": "c1", "name": "Write copy", "idList": "l1", "due": "2024-07-01T10:00:00.000Z", "labels": [{"name": ""}, {"name": "", "color": "green"}]},
+			{"id": "c2", "name": "Archived", "idList": "l1", "closed": true},
+			{"id": "c3", "name": "Hidden", "idList": "l2"}
+		],
+		"checklists": [{"idCard": "c1", "checkItems": [{"name": "Draft", "state": "complete"}, {"name": "Review", "state": "incomplete"}]}]
+	}`
+
+	ds, err := Parse(FormatTrelloJSON, strings.NewReader(body), ParseOptions{})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(ds.Projects) != 1 || ds.Projects[0].Title != "Launch" || len(ds.Projects[0].Tasks) != 1 {
+		t.Fatalf("unexpected projects: %+v", ds.Projects)
+	}
+	card := ds.Projects[0].Tasks[0]
+	if card.Status != "IN_PROGRESS" || strings.Join(card.Labels, ",") != "green,Doing" || card.DueAt == nil {
+		t.Fatalf("unexpected task: %+v", card)
+	}
+	if len(card.Subtasks) != 2 || card.Subtasks[0].Status != "DONE" || card.Subtasks[1].Status != "TODO" {
+		t.Fatalf("unexpected subtasks: %+v", card.Subtasks)
+	}
+}
+
+func TestNormalizeFields(t *testing.T) {
+	statuses := map[string]string{"": "TODO", "in-progress": "IN_PROGRESS", "Doing": "IN_PROGRESS", "closed": "DONE", "later": ""}
+	for in, want := range statuses {
+		if got := normalizeStatus(in); got != want {
+			t.Errorf("normalizeStatus(%q) = %q, want %q", in, got, want)
+		}
+	}
+	priorities := map[string]string{"": "P3", "p1": "P1", "5": "P5", "P6": "", "high": ""}
+	for in, want := range priorities {
+		if got := normalizePriority(in); got != want {
+			t.Errorf("normalizePriority(%q) = %q, want %q", in, got, want)
+		}
+	}
+	if normalizeName("  Home   Office ") != "home office" {
+		t.Errorf("normalizeName should collapse spaces and lower-case")
+	}
+}
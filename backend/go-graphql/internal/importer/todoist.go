@@ -0,0 +1,265 @@
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+// parseTodoistCSV reads a Todoist project template / CSV export (columns
+// TYPE, CONTENT, DESCRIPTION, PRIORITY, INDENT, DATE, ...). PRIORITY 1 is the
+// most urgent, "@name" words in CONTENT become labels, INDENT 2 and deeper
+// become subtasks of the preceding top-level task, and note rows are appended
+// to the preceding task's description.
+func parseTodoistCSV(r io.Reader, opts ParseOptions) (Dataset, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return Dataset{}, service.NewBadInput(fmt.Sprintf("invalid Todoist CSV: %v", err))
+	}
+	idx := headerIndex(header)
+	if _, ok := idx["type"]; !ok {
+		return Dataset{}, service.NewBadInput("Todoist CSV must have TYPE and CONTENT columns")
+	}
+	if _, ok := idx["content"]; !ok {
+		return Dataset{}, service.NewBadInput("Todoist CSV must have TYPE and CONTENT columns")
+	}
+
+	title := strings.TrimSpace(opts.ProjectTitle)
+	if title == "" {
+		title = "Todoist import"
+	}
+
+	var (
+		ds       Dataset
+		project  = Project{Title: title}
+		last     *Task
+		sections int
+	)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Dataset{}, service.NewBadInput(fmt.Sprintf("invalid Todoist CSV: %v", err))
+		}
+
+		switch strings.ToLower(column(record, idx, "type")) {
+		case "task":
+		case "note":
+			if last != nil {
+				appendDescription(last, column(record, idx, "content"))
+			}
+			continue
+		case "section":
+			sections++
+			continue
+		default:
+			continue
+		}
+
+		content, labels := extractTodoistLabels(column(record, idx, "content"))
+		t := Task{
+			Title:       content,
+			Description: optionalString(column(record, idx, "description")),
+			Status:      "TODO",
+			Priority:    "P3",
+			Labels:      labels,
+		}
+		if p, err := strconv.Atoi(column(record, idx, "priority")); err == nil && p >= 1 && p <= 4 {
+			t.Priority = "P" + strconv.Itoa(p)
+		}
+		if date := column(record, idx, "date"); date != "" {
+			loc := opts.Location
+			if tz := column(record, idx, "timezone"); tz != "" {
+				if l, err := time.LoadLocation(tz); err == nil {
+					loc = l
+				}
+			}
+			if t.DueAt, err = parseTime(date, loc); err != nil {
+				ds.Warnings = append(ds.Warnings, fmt.Sprintf("line %d: due date %q is not a fixed date and was dropped", line, date))
+			}
+		}
+
+		indent, _ := strconv.Atoi(column(record, idx, "indent"))
+		if indent > 1 && len(project.Tasks) > 0 {
+			parent := &project.Tasks[len(project.Tasks)-1]
+			parent.Subtasks = append(parent.Subtasks, t)
+			last = &parent.Subtasks[len(parent.Subtasks)-1]
+			continue
+		}
+		project.Tasks = append(project.Tasks, t)
+		last = &project.Tasks[len(project.Tasks)-1]
+	}
+
+	if sections > 0 {
+		ds.Warnings = append(ds.Warnings, fmt.Sprintf("%d Todoist sections were ignored; their tasks were imported into the project", sections))
+	}
+	ds.Projects = []Project{project}
+	return ds, nil
+}
+
+// extractTodoistLabels removes "@label" words from a task title.
+func extractTodoistLabels(content string) (string, []string) {
+	var (
+		words  []string
+		labels []string
+	)
+	for _, w := range strings.Fields(content) {
+		if len(w) > 1 && w[0] == '@' {
+			labels = append(labels, w[1:])
+			continue
+		}
+		words = append(words, w)
+	}
+	return strings.Join(words, " "), labels
+}
+
+func appendDescription(t *Task, note string) {
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return
+	}
+	if t.Description == nil {
+		t.Description = &note
+		return
+	}
+	joined := *t.Description + "\n\n" + note
+	t.Description = &joined
+}
+
+type todoistBackup struct {
+	Projects []struct {
+		ID         json.RawMessage `json:"id"`
+		Name       string          `json:"name"`
+		IsArchived bool            `json:"is_archived"`
+		IsDeleted  bool            `json:"is_deleted"`
+	} `json:"projects"`
+	Items []todoistItem `json:"items"`
+}
+
+type todoistItem struct {
+	ID          json.RawMessage `json:"id"`
+	ProjectID   json.RawMessage `json:"project_id"`
+	ParentID    json.RawMessage `json:"parent_id"`
+	Content     string          `json:"content"`
+	Description string          `json:"description"`
+	Priority    int             `json:"priority"`
+	Labels      []string        `json:"labels"`
+	Checked     bool            `json:"checked"`
+	IsDeleted   bool            `json:"is_deleted"`
+	Due         *struct {
+		Date     string `json:"date"`
+		Timezone string `json:"timezone"`
+	} `json:"due"`
+}
+
+// parseTodoistJSON reads a Todoist Sync API dump ({"projects", "items"}).
+// API priorities run the other way round to the UI: 4 is p1.
+func parseTodoistJSON(r io.Reader, opts ParseOptions) (Dataset, error) {
+	var backup todoistBackup
+	if err := json.NewDecoder(r).Decode(&backup); err != nil {
+		return Dataset{}, service.NewBadInput(fmt.Sprintf("invalid Todoist JSON: %v", err))
+	}
+
+	var (
+		ds        Dataset
+		projectAt = map[string]int{}
+	)
+	for _, p := range backup.Projects {
+		if p.IsDeleted {
+			continue
+		}
+		projectAt[rawID(p.ID)] = len(ds.Projects)
+		ds.Projects = append(ds.Projects, Project{Title: p.Name})
+	}
+
+	parents := map[string]string{}
+	for _, it := range backup.Items {
+		parents[rawID(it.ID)] = rawID(it.ParentID)
+	}
+	// root follows parent links to the top-level ancestor.
+	root := func(id string) string {
+		for i := 0; i < len(parents) && parents[id] != ""; i++ {
+			id = parents[id]
+		}
+		return id
+	}
+
+	type ref struct{ project, index int }
+	roots := map[string]ref{}
+	var children []int
+	for i, it := range backup.Items {
+		if it.IsDeleted {
+			continue
+		}
+		if rawID(it.ParentID) != "" {
+			children = append(children, i)
+			continue
+		}
+		pi, ok := projectAt[rawID(it.ProjectID)]
+		if !ok {
+			ds.Warnings = append(ds.Warnings, fmt.Sprintf("task %q belongs to an unknown project and was skipped", it.Content))
+			continue
+		}
+		roots[rawID(it.ID)] = ref{pi, len(ds.Projects[pi].Tasks)}
+		ds.Projects[pi].Tasks = append(ds.Projects[pi].Tasks, todoistTask(it, opts, &ds))
+	}
+	for _, i := range children {
+		it := backup.Items[i]
+		parent, ok := roots[root(rawID(it.ID))]
+		if !ok {
+			ds.Warnings = append(ds.Warnings, fmt.Sprintf("subtask %q has no importable parent and was skipped", it.Content))
+			continue
+		}
+		t := &ds.Projects[parent.project].Tasks[parent.index]
+		t.Subtasks = append(t.Subtasks, todoistTask(it, opts, &ds))
+	}
+	return ds, nil
+}
+
+func todoistTask(it todoistItem, opts ParseOptions, ds *Dataset) Task {
+	t := Task{
+		Title:       it.Content,
+		Description: optionalString(it.Description),
+		Status:      "TODO",
+		Priority:    "P3",
+		Labels:      it.Labels,
+	}
+	if it.Priority >= 1 && it.Priority <= 4 {
+		t.Priority = "P" + strconv.Itoa(5-it.Priority)
+	}
+	if it.Checked {
+		t.Status = "DONE"
+	}
+	if it.Due != nil && it.Due.Date != "" {
+		loc := opts.Location
+		if l, err := time.LoadLocation(it.Due.Timezone); err == nil && it.Due.Timezone != "" {
+			loc = l
+		}
+		var err error
+		if t.DueAt, err = parseTime(it.Due.Date, loc); err != nil {
+			ds.Warnings = append(ds.Warnings, fmt.Sprintf("task %q: %v", it.Content, err))
+		}
+	}
+	return t
+}
+
+// rawID normalises Todoist IDs, which are numbers in older dumps and strings
+// in newer ones. null and absent IDs are "".
+func rawID(raw json.RawMessage) string {
+	s := strings.TrimSpace(string(raw))
+	if s == "" || s == "null" {
+		return ""
+	}
+	return strings.Trim(s, `"`)
+}
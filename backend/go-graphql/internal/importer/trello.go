@@ -0,0 +1,150 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+type trelloBoard struct {
+	Name  string `json:"name"`
+	Desc  string `json:"desc"`
+	Lists []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Closed bool   `json:"closed"`
+	} `json:"lists"`
+	Cards []struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Desc        string `json:"desc"`
+		IDList      string `json:"idList"`
+		Closed      bool   `json:"closed"`
+		Start       string `json:"start"`
+		Due         string `json:"due"`
+		DueComplete bool   `json:"dueComplete"`
+		Labels      []struct {
+			Name  string `json:"name"`
+			Color string `json:"color"`
+		} `json:"labels"`
+	} `json:"cards"`
+	Checklists []struct {
+		IDCard     string `json:"idCard"`
+		CheckItems []struct {
+			Name  string `json:"name"`
+			State string `json:"state"`
+			Due   string `json:"due"`
+		} `json:"checkItems"`
+	} `json:"checklists"`
+}
+
+// parseTrelloJSON reads a board exported with "Print and export > JSON". The
+// board becomes one project and each open card a task. The card's list sets
+// its status by name ("Done", "Doing", "Blocked"; anything else is TODO) and
+// is also added as a label so the column survives the import. Checklist
+// items become subtasks. Archived lists and cards are skipped.
+func parseTrelloJSON(r io.Reader, opts ParseOptions) (Dataset, error) {
+	var board trelloBoard
+	if err := json.NewDecoder(r).Decode(&board); err != nil {
+		return Dataset{}, service.NewBadInput(fmt.Sprintf("invalid Trello JSON: %v", err))
+	}
+
+	title := strings.TrimSpace(opts.ProjectTitle)
+	if title == "" {
+		title = board.Name
+	}
+	ds := Dataset{}
+	project := Project{Title: title, Description: optionalString(board.Desc)}
+
+	lists := map[string]string{}
+	closedLists := map[string]bool{}
+	for _, l := range board.Lists {
+		lists[l.ID] = l.Name
+		closedLists[l.ID] = l.Closed
+	}
+
+	cardAt := map[string]int{}
+	var archived int
+	for _, c := range board.Cards {
+		if c.Closed || closedLists[c.IDList] {
+			archived++
+			continue
+		}
+
+		listName := lists[c.IDList]
+		t := Task{
+			Title:       c.Name,
+			Description: optionalString(c.Desc),
+			Status:      trelloListStatus(listName),
+			Priority:    "P3",
+		}
+		if c.DueComplete {
+			t.Status = "DONE"
+		}
+		for _, l := range c.Labels {
+			name := strings.TrimSpace(l.Name)
+			if name == "" {
+				// Trello labels may be colour-only.
+				name = l.Color
+			}
+			if name != "" {
+				t.Labels = append(t.Labels, name)
+			}
+		}
+		if listName != "" {
+			t.Labels = append(t.Labels, listName)
+		}
+
+		var err error
+		if t.StartAt, err = parseTime(c.Start, opts.Location); err != nil {
+			ds.Warnings = append(ds.Warnings, fmt.Sprintf("card %q: start: %v", c.Name, err))
+		}
+		if t.DueAt, err = parseTime(c.Due, opts.Location); err != nil {
+			ds.Warnings = append(ds.Warnings, fmt.Sprintf("card %q: due: %v", c.Name, err))
+		}
+
+		cardAt[c.ID] = len(project.Tasks)
+		project.Tasks = append(project.Tasks, t)
+	}
+
+	for _, cl := range board.Checklists {
+		i, ok := cardAt[cl.IDCard]
+		if !ok {
+			continue
+		}
+		for _, item := range cl.CheckItems {
+			sub := Task{Title: item.Name, Status: "TODO", Priority: "P3"}
+			if item.State == "complete" {
+				sub.Status = "DONE"
+			}
+			var err error
+			if sub.DueAt, err = parseTime(item.Due, opts.Location); err != nil {
+				ds.Warnings = append(ds.Warnings, fmt.Sprintf("checklist item %q: due: %v", item.Name, err))
+			}
+			project.Tasks[i].Subtasks = append(project.Tasks[i].Subtasks, sub)
+		}
+	}
+
+	if archived > 0 {
+		ds.Warnings = append(ds.Warnings, fmt.Sprintf("%d archived cards were skipped", archived))
+	}
+	ds.Projects = []Project{project}
+	return ds, nil
+}
+
+func trelloListStatus(list string) string {
+	name := strings.ToLower(list)
+	switch {
+	case strings.Contains(name, "done"), strings.Contains(name, "complete"):
+		return "DONE"
+	case strings.Contains(name, "doing"), strings.Contains(name, "progress"):
+		return "IN_PROGRESS"
+	case strings.Contains(name, "blocked"):
+		return "BLOCKED"
+	default:
+		return "TODO"
+	}
+}
@@ -23,6 +23,10 @@ func (l *Logger) Info(msg string, kv ...interface{}) {
 	l.log("info", msg, kv...)
 }
 
+func (l *Logger) Warn(msg string, kv ...interface{}) {
+	l.log("warn", msg, kv...)
+}
+
 func (l *Logger) Error(msg string, kv ...interface{}) {
 	l.log("error", msg, kv...)
 }
@@ -0,0 +1,267 @@
+package quickadd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// date is a calendar day with no timezone.
+type date struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func civilDate(t time.Time) date {
+	y, m, d := t.Date()
+	return date{y, m, d}
+}
+
+func (d date) time() time.Time {
+	return time.Date(d.year, d.month, d.day, 0, 0, 0, 0, time.UTC)
+}
+
+func (d date) addDays(n int) date {
+	return civilDate(d.time().AddDate(0, 0, n))
+}
+
+func (d date) before(o date) bool {
+	return d.time().Before(o.time())
+}
+
+// nextWeekday is the first wd strictly after d.
+func (d date) nextWeekday(wd time.Weekday) date {
+	n := (int(wd) - int(d.time().Weekday()) + 7) % 7
+	if n == 0 {
+		n = 7
+	}
+	return d.addDays(n)
+}
+
+type clock struct {
+	hour, minute int
+}
+
+func (c clock) on(d date, loc *time.Location) time.Time {
+	return time.Date(d.year, d.month, d.day, c.hour, c.minute, 0, 0, loc)
+}
+
+// Ambiguous abbreviations ("sun", "sat", "mar") are left out so they stay
+// in titles.
+var weekdays = map[string]time.Weekday{
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tues": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"saturday": time.Saturday,
+	"sunday":   time.Sunday,
+}
+
+var months = map[string]time.Month{
+	"jan": time.January, "january": time.January,
+	"feb": time.February, "february": time.February,
+	"march": time.March,
+	"apr":   time.April, "april": time.April,
+	"may": time.May,
+	"jun": time.June, "june": time.June,
+	"jul": time.July, "july": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"oct": time.October, "october": time.October,
+	"nov": time.November, "november": time.November,
+	"dec": time.December, "december": time.December,
+}
+
+// matchDate recognises a date phrase at the start of tokens and returns the
+// number of tokens it used. "tonight" also implies a time.
+func matchDate(tokens []string, today date) (date, *clock, int) {
+	if len(tokens) == 0 {
+		return date{}, nil, 0
+	}
+	if tokens[0] == "on" || tokens[0] == "due" {
+		if d, c, n := matchDate(tokens[1:], today); n > 0 {
+			return d, c, n + 1
+		}
+		return date{}, nil, 0
+	}
+
+	switch tokens[0] {
+	case "today", "tod":
+		return today, nil, 1
+	case "tonight":
+		return today, &clock{hour: 20}, 1
+	case "tomorrow", "tmr", "tmrw":
+		return today.addDays(1), nil, 1
+	}
+	if wd, ok := weekdays[tokens[0]]; ok {
+		return today.nextWeekday(wd), nil, 1
+	}
+
+	if len(tokens) >= 2 && tokens[0] == "next" {
+		switch tokens[1] {
+		case "week":
+			return today.nextWeekday(time.Monday), nil, 2
+		case "month":
+			first := time.Date(today.year, today.month+1, 1, 0, 0, 0, 0, time.UTC)
+			return civilDate(first), nil, 2
+		}
+		if wd, ok := weekdays[tokens[1]]; ok {
+			return today.nextWeekday(wd), nil, 2
+		}
+	}
+
+	if len(tokens) >= 3 && tokens[0] == "in" {
+		if n, ok := count(tokens[1]); ok {
+			if d, ok := addUnit(today, n, tokens[2]); ok {
+				return d, nil, 3
+			}
+		}
+	}
+
+	if t, err := time.Parse("2006-01-02", tokens[0]); err == nil {
+		return civilDate(t), nil, 1
+	}
+
+	// "jul 1", "july 1st 2025", "1 jul", "1st of july".
+	var (
+		month time.Month
+		day   int
+		n     int
+	)
+	if m, ok := months[tokens[0]]; ok && len(tokens) >= 2 {
+		if d, ok := dayOfMonth(tokens[1]); ok {
+			month, day, n = m, d, 2
+		}
+	} else if d, ok := dayOfMonth(tokens[0]); ok && len(tokens) >= 2 {
+		rest := tokens[1:]
+		if rest[0] == "of" && len(rest) >= 2 {
+			rest = rest[1:]
+		}
+		if m, ok := months[rest[0]]; ok {
+			month, day, n = m, d, len(tokens)-len(rest)+1
+		}
+	}
+	if n == 0 {
+		return date{}, nil, 0
+	}
+
+	year := today.year
+	if n < len(tokens) {
+		if y, err := strconv.Atoi(tokens[n]); err == nil && y >= 1970 && y <= 9999 {
+			year, n = y, n+1
+		} else if (date{year, month, day}).before(today) {
+			year++
+		}
+	} else if (date{year, month, day}).before(today) {
+		year++
+	}
+	d := date{year, month, day}
+	if civilDate(d.time()) != d {
+		// "feb 30"
+		return date{}, nil, 0
+	}
+	return d, nil, n
+}
+
+// matchClock recognises "9am", "9:30 pm", "21:00", "noon" and "at 9".
+func matchClock(tokens []string) (clock, int) {
+	if len(tokens) == 0 {
+		return clock{}, 0
+	}
+	if tokens[0] == "at" {
+		if c, n := matchClock(tokens[1:]); n > 0 {
+			return c, n + 1
+		}
+		// "at 9" means 9:00; a bare number is only a time after "at".
+		if len(tokens) >= 2 {
+			if h, err := strconv.Atoi(tokens[1]); err == nil && h >= 0 && h <= 23 {
+				return clock{hour: h}, 2
+			}
+		}
+		return clock{}, 0
+	}
+	if tokens[0] == "noon" {
+		return clock{hour: 12}, 1
+	}
+
+	word, n := tokens[0], 1
+	if len(tokens) >= 2 && (tokens[1] == "am" || tokens[1] == "pm") {
+		word, n = word+tokens[1], 2
+	}
+
+	meridiem := ""
+	switch {
+	case strings.HasSuffix(word, "am"):
+		meridiem, word = "am", strings.TrimSuffix(word, "am")
+	case strings.HasSuffix(word, "pm"):
+		meridiem, word = "pm", strings.TrimSuffix(word, "pm")
+	}
+
+	hourText, minuteText, hasMinutes := strings.Cut(word, ":")
+	if meridiem == "" && !hasMinutes {
+		return clock{}, 0
+	}
+	hour, err := strconv.Atoi(hourText)
+	if err != nil {
+		return clock{}, 0
+	}
+	minute := 0
+	if hasMinutes {
+		if len(minuteText) != 2 {
+			return clock{}, 0
+		}
+		if minute, err = strconv.Atoi(minuteText); err != nil || minute > 59 {
+			return clock{}, 0
+		}
+	}
+
+	switch meridiem {
+	case "":
+		if hour > 23 {
+			return clock{}, 0
+		}
+	default:
+		if hour < 1 || hour > 12 {
+			return clock{}, 0
+		}
+		hour %= 12
+		if meridiem == "pm" {
+			hour += 12
+		}
+	}
+	return clock{hour: hour, minute: minute}, n
+}
+
+func count(word string) (int, bool) {
+	switch word {
+	case "a", "an", "one":
+		return 1, true
+	}
+	n, err := strconv.Atoi(word)
+	return n, err == nil && n > 0 && n <= 1000
+}
+
+func addUnit(d date, n int, unit string) (date, bool) {
+	switch strings.TrimSuffix(unit, "s") {
+	case "day":
+		return d.addDays(n), true
+	case "week":
+		return d.addDays(7 * n), true
+	case "month":
+		return civilDate(d.time().AddDate(0, n, 0)), true
+	case "year":
+		return civilDate(d.time().AddDate(n, 0, 0)), true
+	}
+	return date{}, false
+}
+
+// dayOfMonth accepts "1", "1st", "22nd", "3rd" and "4th".
+func dayOfMonth(word string) (int, bool) {
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		word = strings.TrimSuffix(word, suffix)
+	}
+	d, err := strconv.Atoi(word)
+	return d, err == nil && d >= 1 && d <= 31
+}
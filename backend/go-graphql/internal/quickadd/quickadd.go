@@ -0,0 +1,196 @@
+// Package quickadd parses one line of free text such as
+//
+//	Pay rent tomorrow 9am p1 #finance @home every month
+//
+// into the fields of a task. Recognised phrases are removed and whatever is
+// left becomes the title. Parsing never fails; text that is not understood
+// stays in the title.
+package quickadd
+
+import (
+	"strings"
+	"time"
+)
+
+type Options struct {
+	// Now anchors relative dates; it defaults to time.Now.
+	Now time.Time
+	// Location is the user's timezone; it defaults to UTC.
+	Location *time.Location
+	// Projects are the titles "#name" may refer to. A "#word" that matches no
+	// project is left in the title.
+	Projects []string
+}
+
+type Result struct {
+	Title string
+	// Priority is "P1".."P5", or "" when the text has none.
+	Priority string
+	// DueAt is midnight in Location when only a date was given.
+	DueAt *time.Time
+	// Project is the matched title from Options.Projects, or "".
+	Project string
+	// Labels are the "@name" references, without the "@".
+	Labels []string
+	// Recurrence is an RFC 5545 RRULE value such as "FREQ=WEEKLY;BYDAY=MO",
+	// or "".
+	Recurrence string
+}
+
+// Parse reads text. Only the first date, time, priority, project and
+// recurrence phrase is used; repeats stay in the title.
+func Parse(text string, opts Options) Result {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+
+	words := strings.Fields(text)
+	p := &parser{
+		words:    words,
+		consumed: make([]bool, len(words)),
+		opts:     opts,
+		today:    civilDate(opts.Now.In(opts.Location)),
+	}
+	return p.parse()
+}
+
+type parser struct {
+	words    []string
+	consumed []bool
+	opts     Options
+	today    date
+
+	date  *date
+	clock *clock
+	rule  *recurrence
+	res   Result
+}
+
+func (p *parser) parse() Result {
+	labels := map[string]bool{}
+	for i := 0; i < len(p.words); i++ {
+		word := p.words[i]
+		n := 0
+		switch {
+		case p.res.Priority == "" && isPriority(word):
+			p.res.Priority = strings.ToUpper(word)
+			n = 1
+		case strings.HasPrefix(word, "#") && p.res.Project == "":
+			n = p.matchProject(i)
+		case strings.HasPrefix(word, "@") && len(word) > 1:
+			name := word[1:]
+			if !labels[strings.ToLower(name)] {
+				labels[strings.ToLower(name)] = true
+				p.res.Labels = append(p.res.Labels, name)
+			}
+			n = 1
+		}
+		if n == 0 && p.rule == nil {
+			if r, k := matchRecurrence(p.tokens(i)); k > 0 {
+				p.rule, n = &r, k
+			}
+		}
+		if n == 0 && p.date == nil {
+			if d, c, k := matchDate(p.tokens(i), p.today); k > 0 {
+				p.date, n = &d, k
+				if c != nil && p.clock == nil {
+					p.clock = c
+				}
+			}
+		}
+		if n == 0 && p.clock == nil {
+			if c, k := matchClock(p.tokens(i)); k > 0 {
+				p.clock, n = &c, k
+			}
+		}
+		for j := i; j < i+n; j++ {
+			p.consumed[j] = true
+		}
+		if n > 1 {
+			i += n - 1
+		}
+	}
+
+	var title []string
+	for i, w := range p.words {
+		if !p.consumed[i] {
+			title = append(title, w)
+		}
+	}
+	p.res.Title = strings.Join(title, " ")
+
+	if p.rule != nil {
+		p.res.Recurrence = p.rule.String()
+	}
+	p.res.DueAt = p.due()
+	return p.res
+}
+
+// tokens returns the normalised words from i on, stopping at the first word
+// already consumed so phrases cannot span other references.
+func (p *parser) tokens(i int) []string {
+	var out []string
+	for j := i; j < len(p.words) && !p.consumed[j]; j++ {
+		out = append(out, normalize(p.words[j]))
+	}
+	return out
+}
+
+// matchProject matches "#Name" and, for titles with spaces, "#Home Office";
+// the longest known title wins.
+func (p *parser) matchProject(i int) int {
+	const maxWords = 5
+	best, bestN := "", 0
+	for n := 1; n <= maxWords && i+n <= len(p.words); n++ {
+		candidate := strings.Join(p.words[i:i+n], " ")[1:]
+		candidate = strings.TrimRight(candidate, ",.;")
+		for _, title := range p.opts.Projects {
+			if strings.EqualFold(strings.Join(strings.Fields(title), " "), candidate) {
+				best, bestN = title, n
+			}
+		}
+	}
+	p.res.Project = best
+	return bestN
+}
+
+// due combines the date, time and recurrence. A time alone means its next
+// occurrence; a recurrence alone starts at its first occurrence from today.
+func (p *parser) due() *time.Time {
+	d := p.date
+	if d == nil && p.rule != nil {
+		first := p.rule.first(p.today)
+		d = &first
+	}
+	if d == nil && p.clock != nil {
+		today := p.today
+		d = &today
+		if p.clock.on(today, p.opts.Location).Before(p.opts.Now) {
+			tomorrow := today.addDays(1)
+			d = &tomorrow
+		}
+	}
+	if d == nil {
+		return nil
+	}
+
+	var t time.Time
+	if p.clock != nil {
+		t = p.clock.on(*d, p.opts.Location)
+	} else {
+		t = time.Date(d.year, d.month, d.day, 0, 0, 0, 0, p.opts.Location)
+	}
+	t = t.UTC()
+	return &t
+}
+
+func isPriority(word string) bool {
+	return len(word) == 2 && (word[0] == 'p' || word[0] == 'P') && word[1] >= '1' && word[1] <= '5'
+}
+
+func normalize(word string) string {
+	return strings.ToLower(strings.TrimRight(word, ",.;"))
+}
@@ -0,0 +1,121 @@
+package quickadd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseExample(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("tzdata unavailable: %v", err)
+	}
+	// Wednesday.
+	now := time.Date(2024, 7, 3, 18, 0, 0, 0, berlin)
+
+	got := Parse("Pay rent tomorrow 9am p1 #finance @home every month", Options{
+		Now:      now,
+		Location: berlin,
+		Projects: []string{"Finance", "Home Office"},
+	})
+	if got.Title != "Pay rent" || got.Priority != "P1" || got.Project != "Finance" || got.Recurrence != "FREQ=MONTHLY" {
+		t.Fatalf("unexpected result: %+v", got)
+	}
+	if strings.Join(got.Labels, ",") != "home" {
+		t.Fatalf("labels: %v", got.Labels)
+	}
+	if want := time.Date(2024, 7, 4, 7, 0, 0, 0, time.UTC); got.DueAt == nil || !got.DueAt.Equal(want) {
+		t.Fatalf("due: got %v want %s", got.DueAt, want)
+	}
+}
+
+func TestParseDates(t *testing.T) {
+	// Wednesday 3 July 2024, 18:00 UTC.
+	now := time.Date(2024, 7, 3, 18, 0, 0, 0, time.UTC)
+	day := func(y int, m time.Month, d, h, min int) *time.Time {
+		t := time.Date(y, m, d, h, min, 0, 0, time.UTC)
+		return &t
+	}
+
+	tests := []struct {
+		text  string
+		title string
+		due   *time.Time
+	}{
+		{"Water plants", "Water plants", nil},
+		{"Water plants today", "Water plants", day(2024, 7, 3, 0, 0)},
+		{"Call mom tonight", "Call mom", day(2024, 7, 3, 20, 0)},
+		{"Gym friday at 7", "Gym", day(2024, 7, 5, 7, 0)},
+		{"Standup on wednesday 9:30", "Standup", day(2024, 7, 10, 9, 30)},
+		{"Review next week", "Review", day(2024, 7, 8, 0, 0)},
+		{"Budget next month", "Budget", day(2024, 8, 1, 0, 0)},
+		{"Renew passport in 3 weeks", "Renew passport", day(2024, 7, 24, 0, 0)},
+		{"Dentist jul 12 2:15pm", "Dentist", day(2024, 7, 12, 14, 15)},
+		{"Taxes 15th of april", "Taxes", day(2025, 4, 15, 0, 0)},
+		{"Conference 2024-09-01", "Conference", day(2024, 9, 1, 0, 0)},
+		{"Birthday may 5 2026", "Birthday", day(2026, 5, 5, 0, 0)},
+		// A time that has passed today means tomorrow.
+		{"Email Sam 9am", "Email Sam", day(2024, 7, 4, 9, 0)},
+		{"Email Sam 9 pm", "Email Sam", day(2024, 7, 3, 21, 0)},
+		// Not dates.
+		{"Put books in box", "Put books in box", nil},
+		{"Read chapter 12", "Read chapter 12", nil},
+		{"Enjoy the sun", "Enjoy the sun", nil},
+		{"Plan for feb 30", "Plan for feb 30", nil},
+	}
+	for _, tc := range tests {
+		got := Parse(tc.text, Options{Now: now})
+		if got.Title != tc.title {
+			t.Errorf("%q: title %q, want %q", tc.text, got.Title, tc.title)
+		}
+		switch {
+		case tc.due == nil && got.DueAt != nil:
+			t.Errorf("%q: unexpected due %s", tc.text, got.DueAt)
+		case tc.due != nil && (got.DueAt == nil || !got.DueAt.Equal(*tc.due)):
+			t.Errorf("%q: due %v, want %s", tc.text, got.DueAt, tc.due)
+		}
+	}
+}
+
+func TestParseRecurrence(t *testing.T) {
+	now := time.Date(2024, 7, 3, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		text, rule string
+		due        time.Time
+	}{
+		{"Stretch daily", "FREQ=DAILY", time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC)},
+		{"Report every other week", "FREQ=WEEKLY;INTERVAL=2", time.Date(2024, 7, 3, 0, 0, 0, 0, time.UTC)},
+		{"Backup every 3 days at 22:00", "FREQ=DAILY;INTERVAL=3", time.Date(2024, 7, 3, 22, 0, 0, 0, time.UTC)},
+		{"Standup every weekday 9am", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", time.Date(2024, 7, 3, 9, 0, 0, 0, time.UTC)},
+		{"Trash every mon and thu", "FREQ=WEEKLY;BYDAY=MO,TH", time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range tests {
+		got := Parse(tc.text, Options{Now: now})
+		if got.Recurrence != tc.rule {
+			t.Errorf("%q: rule %q, want %q", tc.text, got.Recurrence, tc.rule)
+		}
+		if got.DueAt == nil || !got.DueAt.Equal(tc.due) {
+			t.Errorf("%q: due %v, want %s", tc.text, got.DueAt, tc.due)
+		}
+		if strings.Contains(strings.ToLower(got.Title), "every") {
+			t.Errorf("%q: recurrence left in title %q", tc.text, got.Title)
+		}
+	}
+}
+
+func TestParseReferences(t *testing.T) {
+	got := Parse("Fix #Home Office lamp @diy @DIY @errand p2 p4 #nope", Options{Projects: []string{"Home", "Home Office"}})
+	if got.Project != "Home Office" {
+		t.Errorf("longest project title should win, got %q", got.Project)
+	}
+	if strings.Join(got.Labels, ",") != "diy,errand" {
+		t.Errorf("labels should be de-duplicated case-insensitively: %v", got.Labels)
+	}
+	if got.Priority != "P2" {
+		t.Errorf("first priority should win, got %q", got.Priority)
+	}
+	if got.Title != "Fix lamp p4 #nope" {
+		t.Errorf("unexpected title %q", got.Title)
+	}
+}
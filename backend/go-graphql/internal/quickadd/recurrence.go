@@ -0,0 +1,118 @@
+package quickadd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+type recurrence struct {
+	freq     string
+	interval int
+	byDay    []time.Weekday
+}
+
+var byDayCodes = [...]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// String renders the RRULE value, e.g. "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH".
+func (r recurrence) String() string {
+	parts := []string{"FREQ=" + r.freq}
+	if r.interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.interval))
+	}
+	if len(r.byDay) > 0 {
+		days := make([]string, 0, len(r.byDay))
+		for _, wd := range r.byDay {
+			days = append(days, byDayCodes[wd])
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	return strings.Join(parts, ";")
+}
+
+// first is the first occurrence on or after today.
+func (r recurrence) first(today date) date {
+	if len(r.byDay) == 0 {
+		return today
+	}
+	for i := 0; i < 7; i++ {
+		d := today.addDays(i)
+		for _, wd := range r.byDay {
+			if d.time().Weekday() == wd {
+				return d
+			}
+		}
+	}
+	return today
+}
+
+var frequencies = map[string]string{
+	"day": "DAILY", "week": "WEEKLY", "month": "MONTHLY", "year": "YEARLY",
+}
+
+// matchRecurrence recognises "daily", "every week", "every other month",
+// "every 3 days", "every weekday" and "every mon and thu".
+func matchRecurrence(tokens []string) (recurrence, int) {
+	if len(tokens) == 0 {
+		return recurrence{}, 0
+	}
+	switch tokens[0] {
+	case "daily":
+		return recurrence{freq: "DAILY"}, 1
+	case "weekly":
+		return recurrence{freq: "WEEKLY"}, 1
+	case "monthly":
+		return recurrence{freq: "MONTHLY"}, 1
+	case "yearly", "annually":
+		return recurrence{freq: "YEARLY"}, 1
+	case "every", "each":
+	default:
+		return recurrence{}, 0
+	}
+	if len(tokens) < 2 {
+		return recurrence{}, 0
+	}
+
+	switch tokens[1] {
+	case "weekday", "workday":
+		return recurrence{freq: "WEEKLY", byDay: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}}, 2
+	case "weekend":
+		return recurrence{freq: "WEEKLY", byDay: []time.Weekday{time.Saturday, time.Sunday}}, 2
+	}
+	if freq, ok := frequencies[tokens[1]]; ok {
+		return recurrence{freq: freq}, 2
+	}
+
+	if len(tokens) >= 3 {
+		interval := 0
+		if tokens[1] == "other" {
+			interval = 2
+		} else if n, err := strconv.Atoi(tokens[1]); err == nil && n >= 1 && n <= 365 {
+			interval = n
+		}
+		if freq, ok := frequencies[strings.TrimSuffix(tokens[2], "s")]; ok && interval > 0 {
+			return recurrence{freq: freq, interval: interval}, 3
+		}
+	}
+
+	// Weekday lists: "every mon", "every tue and fri", "every mon, wed".
+	var days []time.Weekday
+	n := 1
+	for n < len(tokens) {
+		wd, ok := weekdays[tokens[n]]
+		if !ok {
+			break
+		}
+		days = append(days, wd)
+		n++
+		if n+1 < len(tokens) && tokens[n] == "and" {
+			if _, ok := weekdays[tokens[n+1]]; ok {
+				n++
+			}
+		}
+	}
+	if len(days) == 0 {
+		return recurrence{}, 0
+	}
+	return recurrence{freq: "WEEKLY", byDay: days}, n
+}
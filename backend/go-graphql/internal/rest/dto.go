@@ -0,0 +1,255 @@
+package rest
+
+import (
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Resource and request bodies of the REST API. Type names double as OpenAPI
+// component names, and the `format`/`enum` tags feed the generated schemas.
+
+type User struct {
+	ID        string    `json:"id" format:"uuid"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email" format:"email"`
+	Timezone  string    `json:"timezone"`
+	AvatarURL *string   `json:"avatarUrl"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Project struct {
+	ID          string     `json:"id" format:"uuid"`
+	UserID      string     `json:"userId" format:"uuid"`
+	Title       string     `json:"title"`
+	Description *string    `json:"description"`
+	Color       *string    `json:"color"`
+	ArchivedAt  *time.Time `json:"archivedAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+type Label struct {
+	ID        string    `json:"id" format:"uuid"`
+	UserID    string    `json:"userId" format:"uuid"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Task struct {
+	ID           string     `json:"id" format:"uuid"`
+	UserID       string     `json:"userId" format:"uuid"`
+	ProjectID    string     `json:"projectId" format:"uuid"`
+	ParentTaskID *string    `json:"parentTaskId" format:"uuid"`
+	Title        string     `json:"title"`
+	Description  *string    `json:"description"`
+	Status       string     `json:"status" enum:"TODO,IN_PROGRESS,BLOCKED,DONE"`
+	Priority     string     `json:"priority" enum:"P1,P2,P3,P4,P5"`
+	StartAt      *time.Time `json:"startAt"`
+	DueAt        *time.Time `json:"dueAt"`
+	CompletedAt  *time.Time `json:"completedAt"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+}
+
+type PageInfo struct {
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	HasNextPage     bool    `json:"hasNextPage"`
+}
+
+type ProjectPage struct {
+	Data       []Project `json:"data"`
+	PageInfo   PageInfo  `json:"pageInfo"`
+	TotalCount *int      `json:"totalCount,omitempty"`
+}
+
+type LabelPage struct {
+	Data       []Label  `json:"data"`
+	PageInfo   PageInfo `json:"pageInfo"`
+	TotalCount *int     `json:"totalCount,omitempty"`
+}
+
+type TaskPage struct {
+	Data       []Task   `json:"data"`
+	PageInfo   PageInfo `json:"pageInfo"`
+	TotalCount *int     `json:"totalCount,omitempty"`
+}
+
+type LabelList struct {
+	Data []Label `json:"data"`
+}
+
+type TaskList struct {
+	Data []Task `json:"data"`
+}
+
+type DeleteResult struct {
+	ID        string    `json:"id" format:"uuid"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+type Error struct {
+	Error ErrorBody `json:"error"`
+}
+
+type ErrorBody struct {
+	Code      string `json:"code" enum:"BAD_USER_INPUT,NOT_FOUND,CONFLICT,METHOD_NOT_ALLOWED,INTERNAL"`
+	Message   string `json:"message"`
+	RequestID string `json:"requestId,omitempty"`
+}
+
+type UpsertMeRequest struct {
+	Name      string  `json:"name"`
+	Email     string  `json:"email" format:"email"`
+	Timezone  string  `json:"timezone"`
+	AvatarURL *string `json:"avatarUrl,omitempty"`
+}
+
+type ProjectRequest struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+	Color       *string `json:"color,omitempty"`
+}
+
+type LabelRequest struct {
+	Name string `json:"name"`
+}
+
+type CreateTaskRequest struct {
+	ProjectID    string     `json:"projectId" format:"uuid"`
+	ParentTaskID *string    `json:"parentTaskId,omitempty" format:"uuid"`
+	Title        string     `json:"title"`
+	Description  *string    `json:"description,omitempty"`
+	Status       *string    `json:"status,omitempty" enum:"TODO,IN_PROGRESS,BLOCKED,DONE"`
+	Priority     *string    `json:"priority,omitempty" enum:"P1,P2,P3,P4,P5"`
+	StartAt      *time.Time `json:"startAt,omitempty"`
+	DueAt        *time.Time `json:"dueAt,omitempty"`
+	LabelIDs     []string   `json:"labelIds,omitempty" format:"uuid"`
+}
+
+type UpdateTaskRequest struct {
+	Title       *string    `json:"title,omitempty"`
+	Description *string    `json:"description,omitempty"`
+	Status      *string    `json:"status,omitempty" enum:"TODO,IN_PROGRESS,BLOCKED,DONE"`
+	Priority    *string    `json:"priority,omitempty" enum:"P1,P2,P3,P4,P5"`
+	StartAt     *time.Time `json:"startAt,omitempty"`
+	DueAt       *time.Time `json:"dueAt,omitempty"`
+	LabelIDs    []string   `json:"labelIds,omitempty" format:"uuid"`
+}
+
+func uuidString(v pgtype.UUID) string {
+	if !v.Valid {
+		return ""
+	}
+	return uuid.UUID(v.Bytes).String()
+}
+
+func timeValue(v pgtype.Timestamptz) (out time.Time) {
+	if !v.Valid {
+		return out
+	}
+	return v.Time.UTC()
+}
+
+func timePtr(v pgtype.Timestamptz) *time.Time {
+	if !v.Valid {
+		return nil
+	}
+	t := v.Time.UTC()
+	return &t
+}
+
+func toUser(u sqlc.User) User {
+	return User{
+		ID:        uuidString(u.ID),
+		Name:      u.Name,
+		Email:     u.Email,
+		Timezone:  u.Timezone,
+		AvatarURL: u.AvatarUrl,
+		CreatedAt: timeValue(u.CreatedAt),
+		UpdatedAt: timeValue(u.UpdatedAt),
+	}
+}
+
+func toProject(p sqlc.Project) Project {
+	return Project{
+		ID:          uuidString(p.ID),
+		UserID:      uuidString(p.UserID),
+		Title:       p.Title,
+		Description: p.Description,
+		Color:       p.Color,
+		ArchivedAt:  timePtr(p.ArchivedAt),
+		CreatedAt:   timeValue(p.CreatedAt),
+		UpdatedAt:   timeValue(p.UpdatedAt),
+	}
+}
+
+func toLabel(l sqlc.Label) Label {
+	return Label{
+		ID:        uuidString(l.ID),
+		UserID:    uuidString(l.UserID),
+		Name:      l.Name,
+		CreatedAt: timeValue(l.CreatedAt),
+		UpdatedAt: timeValue(l.UpdatedAt),
+	}
+}
+
+func toTask(t sqlc.Task) Task {
+	var parentID *string
+	if t.ParentTaskID.Valid {
+		id := uuidString(t.ParentTaskID)
+		parentID = &id
+	}
+
+	return Task{
+		ID:           uuidString(t.ID),
+		UserID:       uuidString(t.UserID),
+		ProjectID:    uuidString(t.ProjectID),
+		ParentTaskID: parentID,
+		Title:        t.Title,
+		Description:  t.Description,
+		Status:       t.Status,
+		Priority:     t.Priority,
+		StartAt:      timePtr(t.StartAt),
+		DueAt:        timePtr(t.DueAt),
+		CompletedAt:  timePtr(t.CompletedAt),
+		CreatedAt:    timeValue(t.CreatedAt),
+		UpdatedAt:    timeValue(t.UpdatedAt),
+	}
+}
+
+func toDeleteResult(d service.DeleteResult) DeleteResult {
+	return DeleteResult{ID: d.ID.String(), DeletedAt: d.DeletedAt}
+}
+
+func toPageInfo[T any](page service.PageResult[T]) PageInfo {
+	return PageInfo{
+		StartCursor:     page.StartCursor,
+		EndCursor:       page.EndCursor,
+		HasPreviousPage: page.HasPreviousPage,
+		HasNextPage:     page.HasNextPage,
+	}
+}
+
+func mapEdges[T any, R any](edges []service.Edge[T], fn func(T) R) []R {
+	out := make([]R, 0, len(edges))
+	for _, edge := range edges {
+		out = append(out, fn(edge.Node))
+	}
+	return out
+}
+
+func mapSlice[T any, R any](items []T, fn func(T) R) []R {
+	out := make([]R, 0, len(items))
+	for _, item := range items {
+		out = append(out, fn(item))
+	}
+	return out
+}
@@ -0,0 +1,201 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/graphql/middleware"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+const (
+	basePath     = "/api/v1"
+	maxBodyBytes = 1 << 20
+)
+
+// Handler serves the REST/JSON API. It is a thin transport over
+// service.Service, mirroring the GraphQL resolvers.
+type Handler struct {
+	svc    *service.Service
+	mux    *http.ServeMux
+	routes []route
+	spec   []byte
+}
+
+func NewHandler(svc *service.Service) (*Handler, error) {
+	h := &Handler{svc: svc, mux: http.NewServeMux()}
+	h.routes = h.routeTable()
+	for _, rt := range h.routes {
+		h.mux.HandleFunc(rt.method+" "+basePath+rt.path, rt.handle)
+	}
+
+	spec, err := json.Marshal(buildSpec(h.routes))
+	if err != nil {
+		return nil, fmt.Errorf("build openapi spec: %w", err)
+	}
+	h.spec = spec
+	h.mux.HandleFunc("GET "+basePath+"/openapi.json", h.openAPI)
+	h.mux.HandleFunc(basePath+"/", h.notFound)
+	return h, nil
+}
+
+// codeMethodNotAllowed is the error code of a 405. It is REST-only, so it
+// sits beside the service codes rather than among them.
+const codeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+
+// notFound answers requests no route matched. A known path requested with
+// the wrong method gets 405 and the methods it supports in Allow.
+func (h *Handler) notFound(w http.ResponseWriter, r *http.Request) {
+	if allowed := h.allowedMethods(r); len(allowed) > 0 {
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		writeJSON(w, http.StatusMethodNotAllowed, Error{Error: ErrorBody{
+			Code:      codeMethodNotAllowed,
+			Message:   fmt.Sprintf("method %s not allowed", r.Method),
+			RequestID: middleware.RequestIDFromContext(r.Context()),
+		}})
+		return
+	}
+	writeError(w, r, service.NewNotFound("route not found"))
+}
+
+// allowedMethods lists the methods that have a route for r's path.
+func (h *Handler) allowedMethods(r *http.Request) []string {
+	var out []string
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete} {
+		probe := r.Clone(r.Context())
+		probe.Method = method
+		if _, pattern := h.mux.Handler(probe); pattern != "" && pattern != basePath+"/" {
+			out = append(out, method)
+		}
+	}
+	if slices.Contains(out, http.MethodGet) {
+		out = append(out, http.MethodHead)
+	}
+	return out
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) openAPI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(h.spec)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// writeError renders err with the same codes the GraphQL API reports in
+// extensions.code. Internal details never reach the client.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	body := ErrorBody{
+		Code:      string(service.CodeInternal),
+		Message:   "internal server error",
+		RequestID: middleware.RequestIDFromContext(r.Context()),
+	}
+	var appErr *service.AppError
+	if errors.As(err, &appErr) && appErr.Code != service.CodeInternal {
+		body.Code = string(appErr.Code)
+		body.Message = appErr.Message
+	}
+	writeJSON(w, statusForCode(service.ErrorCode(body.Code)), Error{Error: body})
+}
+
+func statusForCode(code service.ErrorCode) int {
+	switch code {
+	case service.CodeBadUserInput:
+		return http.StatusBadRequest
+	case service.CodeNotFound:
+		return http.StatusNotFound
+	case service.CodeConflict:
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func decodeBody(r *http.Request, dst interface{}) error {
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(dst); err != nil {
+		return service.NewBadInput(fmt.Sprintf("invalid JSON body: %v", err))
+	}
+	if dec.More() {
+		return service.NewBadInput("invalid JSON body: unexpected trailing data")
+	}
+	return nil
+}
+
+func pageArgs(r *http.Request) (service.PageArgs, error) {
+	q := r.URL.Query()
+	args := service.PageArgs{
+		After:  optionalString(q.Get("after")),
+		Before: optionalString(q.Get("before")),
+	}
+
+	var err error
+	if args.First, err = optionalInt(q.Get("first"), "first"); err != nil {
+		return service.PageArgs{}, err
+	}
+	if args.Last, err = optionalInt(q.Get("last"), "last"); err != nil {
+		return service.PageArgs{}, err
+	}
+	if args.WithTotal, err = optionalBool(q.Get("totalCount"), "totalCount"); err != nil {
+		return service.PageArgs{}, err
+	}
+	return args, nil
+}
+
+func optionalBool(raw string, name string) (bool, error) {
+	if raw == "" {
+		return false, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, service.NewBadInput(name + " must be a boolean")
+	}
+	return v, nil
+}
+
+func optionalString(v string) *string {
+	if strings.TrimSpace(v) == "" {
+		return nil
+	}
+	return &v
+}
+
+func optionalInt(v string, name string) (*int, error) {
+	if strings.TrimSpace(v) == "" {
+		return nil, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, service.NewBadInput(fmt.Sprintf("%s must be an integer", name))
+	}
+	return &i, nil
+}
+
+// multiValue accepts both repeated (?status=A&status=B) and comma-separated
+// (?status=A,B) query parameters.
+func multiValue(r *http.Request, name string) []string {
+	var out []string
+	for _, raw := range r.URL.Query()[name] {
+		for _, v := range strings.Split(raw, ",") {
+			if v = strings.TrimSpace(v); v != "" {
+				out = append(out, v)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,127 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+func TestOpenAPIDocumentCoversRoutes(t *testing.T) {
+	h, err := NewHandler(nil)
+	if err != nil {
+		t.Fatalf("new handler: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/openapi.json", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+
+	var doc struct {
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Required   []string                   `json:"required"`
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("decode spec: %v", err)
+	}
+
+	for _, rt := range h.routes {
+		methods, ok := doc.Paths[basePath+rt.path]
+		if !ok {
+			t.Fatalf("path %s missing from spec", rt.path)
+		}
+		if _, ok := methods[map[string]string{
+			http.MethodGet: "get", http.MethodPost: "post", http.MethodPut: "put",
+			http.MethodPatch: "patch", http.MethodDelete: "delete",
+		}[rt.method]]; !ok {
+			t.Fatalf("%s %s missing from spec", rt.method, rt.path)
+		}
+	}
+
+	task, ok := doc.Components.Schemas["Task"]
+	if !ok {
+		t.Fatal("Task schema missing")
+	}
+	if _, ok := task.Properties["parentTaskId"]; !ok {
+		t.Fatal("Task.parentTaskId missing")
+	}
+	for _, name := range task.Required {
+		if name == "parentTaskId" || name == "dueAt" {
+			t.Fatalf("nullable field %s should not be required", name)
+		}
+	}
+	if _, ok := doc.Components.Schemas["Error"]; !ok {
+		t.Fatal("Error schema missing")
+	}
+	var code struct {
+		Enum []string `json:"enum"`
+	}
+	if err := json.Unmarshal(doc.Components.Schemas["ErrorBody"].Properties["code"], &code); err != nil {
+		t.Fatalf("decode ErrorBody.code: %v", err)
+	}
+	if !slices.Contains(code.Enum, codeMethodNotAllowed) {
+		t.Fatalf("ErrorBody.code should list %s, got %v", codeMethodNotAllowed, code.Enum)
+	}
+}
+
+func TestErrorResponses(t *testing.T) {
+	h, err := NewHandler(nil)
+	if err != nil {
+		t.Fatalf("new handler: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		status int
+		code   string
+	}{
+		{name: "unknown route", method: http.MethodGet, target: "/api/v1/nope", status: http.StatusNotFound, code: "NOT_FOUND"},
+		{name: "invalid page size", method: http.MethodGet, target: "/api/v1/projects?first=ten", status: http.StatusBadRequest, code: "BAD_USER_INPUT"},
+		{name: "invalid totalCount", method: http.MethodGet, target: "/api/v1/labels?totalCount=maybe", status: http.StatusBadRequest, code: "BAD_USER_INPUT"},
+		{name: "wrong method", method: http.MethodDelete, target: "/api/v1/projects", status: http.StatusMethodNotAllowed, code: "METHOD_NOT_ALLOWED"},
+		{name: "invalid includeArchived", method: http.MethodGet, target: "/api/v1/projects?includeArchived=maybe", status: http.StatusBadRequest, code: "BAD_USER_INPUT"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tc.method, tc.target, nil))
+			if rec.Code != tc.status {
+				t.Fatalf("expected %d, got %d", tc.status, rec.Code)
+			}
+			var body Error
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+			if body.Error.Code != tc.code {
+				t.Fatalf("expected code %s, got %s", tc.code, body.Error.Code)
+			}
+		})
+	}
+}
+
+func TestMethodNotAllowedListsAllowedMethods(t *testing.T) {
+	h, err := NewHandler(nil)
+	if err != nil {
+		t.Fatalf("new handler: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/api/v1/projects/123", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rec.Code)
+	}
+	if got := rec.Header().Get("Allow"); got != "GET, PUT, DELETE, HEAD" {
+		t.Fatalf("unexpected Allow header %q", got)
+	}
+}
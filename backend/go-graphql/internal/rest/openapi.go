@@ -0,0 +1,232 @@
+package rest
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// buildSpec renders the OpenAPI 3.0 document for routes. Schemas are derived
+// from the DTO types by reflection, so the document follows the code.
+func buildSpec(routes []route) map[string]interface{} {
+	schemas := map[string]interface{}{}
+	schemaRef(reflect.TypeOf(Error{}), schemas)
+
+	paths := map[string]map[string]interface{}{}
+	for _, rt := range routes {
+		item, ok := paths[basePath+rt.path]
+		if !ok {
+			item = map[string]interface{}{}
+			paths[basePath+rt.path] = item
+		}
+		item[strings.ToLower(rt.method)] = operation(rt, schemas)
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Zenlist REST API",
+			"version":     "1.0.0",
+			"description": "REST/JSON view of the Zenlist GraphQL API. List endpoints use the same opaque cursors as the GraphQL connections.",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": schemas,
+		},
+	}
+}
+
+func operation(rt route, schemas map[string]interface{}) map[string]interface{} {
+	op := map[string]interface{}{
+		"operationId": rt.operationID,
+		"summary":     rt.summary,
+		"tags":        []string{rt.tag},
+	}
+
+	var params []interface{}
+	for _, name := range pathParams(rt.path) {
+		params = append(params, map[string]interface{}{
+			"name":     name,
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]interface{}{"type": "string", "format": "uuid"},
+		})
+	}
+	for _, qp := range rt.query {
+		schema := map[string]interface{}{"type": qp.kind}
+		if len(qp.enum) > 0 {
+			schema["enum"] = qp.enum
+		}
+		if qp.multi {
+			schema = map[string]interface{}{"type": "array", "items": schema}
+		}
+		param := map[string]interface{}{
+			"name":        qp.name,
+			"in":          "query",
+			"description": qp.description,
+			"required":    qp.required,
+			"schema":      schema,
+		}
+		if qp.multi {
+			param["style"] = "form"
+			param["explode"] = true
+		}
+		params = append(params, param)
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+
+	if rt.body != nil {
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": schemaRef(reflect.TypeOf(rt.body), schemas),
+				},
+			},
+		}
+	}
+
+	errorResponse := func(description string) map[string]interface{} {
+		return map[string]interface{}{
+			"description": description,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": map[string]interface{}{"$ref": "#/components/schemas/Error"},
+				},
+			},
+		}
+	}
+
+	responses := map[string]interface{}{
+		strconv.Itoa(rt.status): map[string]interface{}{
+			"description": http.StatusText(rt.status),
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": schemaRef(reflect.TypeOf(rt.response), schemas),
+				},
+			},
+		},
+		"400": errorResponse("Invalid input"),
+		"500": errorResponse("Internal error"),
+	}
+	if strings.Contains(rt.path, "{") {
+		responses["404"] = errorResponse("Not found")
+	}
+	if rt.method == http.MethodPost || rt.method == http.MethodPut || rt.method == http.MethodPatch {
+		responses["409"] = errorResponse("Conflict")
+	}
+	op["responses"] = responses
+	return op
+}
+
+func pathParams(path string) []string {
+	var out []string
+	for _, seg := range strings.Split(path, "/") {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			out = append(out, strings.Trim(seg, "{}"))
+		}
+	}
+	return out
+}
+
+// schemaRef registers named struct types under components.schemas and returns
+// a $ref to them; every other type is inlined.
+func schemaRef(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	if t.Kind() == reflect.Struct && t != timeType {
+		if _, ok := schemas[t.Name()]; !ok {
+			schemas[t.Name()] = nil // reserve the name before recursing
+			schemas[t.Name()] = structSchema(t, schemas)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + t.Name()}
+	}
+	return typeSchema(t, schemas)
+}
+
+func structSchema(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	properties := map[string]interface{}{}
+	var required []string
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		ft := field.Type
+		nullable := ft.Kind() == reflect.Pointer
+		if nullable {
+			ft = ft.Elem()
+		}
+
+		var prop map[string]interface{}
+		if ft.Kind() == reflect.Struct && ft != timeType {
+			prop = schemaRef(ft, schemas)
+		} else {
+			prop = typeSchema(ft, schemas)
+			if format := field.Tag.Get("format"); format != "" {
+				applyToScalar(prop, "format", format)
+			}
+			if enum := field.Tag.Get("enum"); enum != "" {
+				applyToScalar(prop, "enum", strings.Split(enum, ","))
+			}
+		}
+		if nullable && !strings.Contains(opts, "omitempty") {
+			prop["nullable"] = true
+		}
+		properties[name] = prop
+
+		if !nullable && !strings.Contains(opts, "omitempty") {
+			required = append(required, name)
+		}
+	}
+
+	schema := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func typeSchema(t reflect.Type, schemas map[string]interface{}) map[string]interface{} {
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeSchema(t.Elem(), schemas)
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": schemaRef(t.Elem(), schemas)}
+	case reflect.Struct:
+		return schemaRef(t, schemas)
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
+
+// applyToScalar sets key on the schema itself, or on its items for arrays, so
+// that tags such as format:"uuid" on []string describe the elements.
+func applyToScalar(schema map[string]interface{}, key string, value interface{}) {
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		schema = items
+	}
+	schema[key] = value
+}
@@ -0,0 +1,400 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+)
+
+// route describes one endpoint. The same table registers the handler and
+// generates the OpenAPI document, so the two cannot drift apart.
+type route struct {
+	method      string
+	path        string
+	operationID string
+	summary     string
+	tag         string
+	query       []queryParam
+	body        interface{}
+	status      int
+	response    interface{}
+	handle      http.HandlerFunc
+}
+
+type queryParam struct {
+	name        string
+	kind        string
+	description string
+	required    bool
+	multi       bool
+	enum        []string
+}
+
+var pageParams = []queryParam{
+	{name: "first", kind: "integer", description: "Page size when paging forward."},
+	{name: "after", kind: "string", description: "Cursor to page forward from (pageInfo.endCursor)."},
+	{name: "last", kind: "integer", description: "Page size when paging backward."},
+	{name: "before", kind: "string", description: "Cursor to page backward from (pageInfo.startCursor)."},
+	{name: "totalCount", kind: "boolean", description: "Include the total number of matching items."},
+}
+
+func (h *Handler) routeTable() []route {
+	projectParams := append([]queryParam{
+		{name: "includeArchived", kind: "boolean", description: "Include archived projects."},
+	}, pageParams...)
+	taskParams := append([]queryParam{
+		{name: "projectId", kind: "string", description: "Project to list tasks from.", required: true},
+		{name: "parentTaskId", kind: "string", description: "List subtasks of this task instead of root tasks."},
+		{name: "status", kind: "string", description: "Filter by status.", multi: true, enum: []string{"TODO", "IN_PROGRESS", "BLOCKED", "DONE"}},
+		{name: "priority", kind: "string", description: "Filter by priority.", multi: true, enum: []string{"P1", "P2", "P3", "P4", "P5"}},
+	}, pageParams...)
+
+	return []route{
+		{method: http.MethodGet, path: "/me", operationID: "getMe", summary: "Get the current user", tag: "users", status: http.StatusOK, response: User{}, handle: h.getMe},
+		{method: http.MethodPut, path: "/me", operationID: "upsertMe", summary: "Update the current user", tag: "users", body: UpsertMeRequest{}, status: http.StatusOK, response: User{}, handle: h.upsertMe},
+
+		{method: http.MethodGet, path: "/projects", operationID: "listProjects", summary: "List projects", tag: "projects", query: projectParams, status: http.StatusOK, response: ProjectPage{}, handle: h.listProjects},
+		{method: http.MethodPost, path: "/projects", operationID: "createProject", summary: "Create a project", tag: "projects", body: ProjectRequest{}, status: http.StatusCreated, response: Project{}, handle: h.createProject},
+		{method: http.MethodGet, path: "/projects/{id}", operationID: "getProject", summary: "Get a project", tag: "projects", status: http.StatusOK, response: Project{}, handle: h.getProject},
+		{method: http.MethodPut, path: "/projects/{id}", operationID: "updateProject", summary: "Update a project", tag: "projects", body: ProjectRequest{}, status: http.StatusOK, response: Project{}, handle: h.updateProject},
+		{method: http.MethodPost, path: "/projects/{id}/archive", operationID: "archiveProject", summary: "Archive a project; it accepts no new tasks", tag: "projects", status: http.StatusOK, response: Project{}, handle: h.archiveProject},
+		{method: http.MethodPost, path: "/projects/{id}/unarchive", operationID: "unarchiveProject", summary: "Unarchive a project", tag: "projects", status: http.StatusOK, response: Project{}, handle: h.unarchiveProject},
+		{method: http.MethodDelete, path: "/projects/{id}", operationID: "deleteProject", summary: "Delete a project and its tasks", tag: "projects", status: http.StatusOK, response: DeleteResult{}, handle: h.deleteProject},
+
+		{method: http.MethodGet, path: "/labels", operationID: "listLabels", summary: "List labels", tag: "labels", query: pageParams, status: http.StatusOK, response: LabelPage{}, handle: h.listLabels},
+		{method: http.MethodPost, path: "/labels", operationID: "createLabel", summary: "Create a label", tag: "labels", body: LabelRequest{}, status: http.StatusCreated, response: Label{}, handle: h.createLabel},
+		{method: http.MethodGet, path: "/labels/{id}", operationID: "getLabel", summary: "Get a label", tag: "labels", status: http.StatusOK, response: Label{}, handle: h.getLabel},
+		{method: http.MethodPut, path: "/labels/{id}", operationID: "updateLabel", summary: "Rename a label", tag: "labels", body: LabelRequest{}, status: http.StatusOK, response: Label{}, handle: h.updateLabel},
+		{method: http.MethodDelete, path: "/labels/{id}", operationID: "deleteLabel", summary: "Delete a label", tag: "labels", status: http.StatusOK, response: DeleteResult{}, handle: h.deleteLabel},
+
+		{method: http.MethodGet, path: "/tasks", operationID: "listTasks", summary: "List tasks of a project", tag: "tasks", query: taskParams, status: http.StatusOK, response: TaskPage{}, handle: h.listTasks},
+		{method: http.MethodPost, path: "/tasks", operationID: "createTask", summary: "Create a task or subtask", tag: "tasks", body: CreateTaskRequest{}, status: http.StatusCreated, response: Task{}, handle: h.createTask},
+		{method: http.MethodGet, path: "/tasks/{id}", operationID: "getTask", summary: "Get a task", tag: "tasks", status: http.StatusOK, response: Task{}, handle: h.getTask},
+		{method: http.MethodPatch, path: "/tasks/{id}", operationID: "updateTask", summary: "Update a task; omitted fields are unchanged", tag: "tasks", body: UpdateTaskRequest{}, status: http.StatusOK, response: Task{}, handle: h.updateTask},
+		{method: http.MethodDelete, path: "/tasks/{id}", operationID: "deleteTask", summary: "Delete a task and its subtasks", tag: "tasks", status: http.StatusOK, response: DeleteResult{}, handle: h.deleteTask},
+		{method: http.MethodGet, path: "/tasks/{id}/labels", operationID: "listTaskLabels", summary: "List labels attached to a task", tag: "tasks", status: http.StatusOK, response: LabelList{}, handle: h.listTaskLabels},
+		{method: http.MethodGet, path: "/tasks/{id}/subtasks", operationID: "listTaskSubtasks", summary: "List subtasks of a task", tag: "tasks", status: http.StatusOK, response: TaskList{}, handle: h.listTaskSubtasks},
+	}
+}
+
+func (h *Handler) getMe(w http.ResponseWriter, r *http.Request) {
+	user, err := h.svc.Me(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toUser(user))
+}
+
+func (h *Handler) upsertMe(w http.ResponseWriter, r *http.Request) {
+	var body UpsertMeRequest
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	user, err := h.svc.UpsertMe(r.Context(), service.UpsertMeInput{
+		Name:      body.Name,
+		Email:     body.Email,
+		Timezone:  body.Timezone,
+		AvatarURL: body.AvatarURL,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toUser(user))
+}
+
+func (h *Handler) listProjects(w http.ResponseWriter, r *http.Request) {
+	args, err := pageArgs(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	includeArchived, err := optionalBool(r.URL.Query().Get("includeArchived"), "includeArchived")
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	page, err := h.svc.ListProjects(r.Context(), includeArchived, args)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ProjectPage{
+		Data:       mapEdges(page.Edges, toProject),
+		PageInfo:   toPageInfo(page),
+		TotalCount: page.TotalCount,
+	})
+}
+
+func (h *Handler) createProject(w http.ResponseWriter, r *http.Request) {
+	var body ProjectRequest
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	project, err := h.svc.CreateProject(r.Context(), service.CreateProjectInput{
+		Title:       body.Title,
+		Description: body.Description,
+		Color:       body.Color,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toProject(project))
+}
+
+func (h *Handler) getProject(w http.ResponseWriter, r *http.Request) {
+	project, err := h.svc.Project(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if project == nil {
+		writeError(w, r, service.NewNotFound("project not found"))
+		return
+	}
+	writeJSON(w, http.StatusOK, toProject(*project))
+}
+
+func (h *Handler) archiveProject(w http.ResponseWriter, r *http.Request) {
+	project, err := h.svc.ArchiveProject(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toProject(project))
+}
+
+func (h *Handler) unarchiveProject(w http.ResponseWriter, r *http.Request) {
+	project, err := h.svc.UnarchiveProject(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toProject(project))
+}
+
+func (h *Handler) updateProject(w http.ResponseWriter, r *http.Request) {
+	var body ProjectRequest
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	project, err := h.svc.UpdateProject(r.Context(), service.UpdateProjectInput{
+		ID:          r.PathValue("id"),
+		Title:       body.Title,
+		Description: body.Description,
+		Color:       body.Color,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toProject(project))
+}
+
+func (h *Handler) deleteProject(w http.ResponseWriter, r *http.Request) {
+	deleted, err := h.svc.DeleteProject(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDeleteResult(deleted))
+}
+
+func (h *Handler) listLabels(w http.ResponseWriter, r *http.Request) {
+	args, err := pageArgs(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	page, err := h.svc.ListLabels(r.Context(), args)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, LabelPage{
+		Data:       mapEdges(page.Edges, toLabel),
+		PageInfo:   toPageInfo(page),
+		TotalCount: page.TotalCount,
+	})
+}
+
+func (h *Handler) createLabel(w http.ResponseWriter, r *http.Request) {
+	var body LabelRequest
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	label, err := h.svc.CreateLabel(r.Context(), service.CreateLabelInput{Name: body.Name})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toLabel(label))
+}
+
+func (h *Handler) getLabel(w http.ResponseWriter, r *http.Request) {
+	label, err := h.svc.Label(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if label == nil {
+		writeError(w, r, service.NewNotFound("label not found"))
+		return
+	}
+	writeJSON(w, http.StatusOK, toLabel(*label))
+}
+
+func (h *Handler) updateLabel(w http.ResponseWriter, r *http.Request) {
+	var body LabelRequest
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	label, err := h.svc.UpdateLabel(r.Context(), service.UpdateLabelInput{ID: r.PathValue("id"), Name: body.Name})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toLabel(label))
+}
+
+func (h *Handler) deleteLabel(w http.ResponseWriter, r *http.Request) {
+	deleted, err := h.svc.DeleteLabel(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDeleteResult(deleted))
+}
+
+func (h *Handler) listTasks(w http.ResponseWriter, r *http.Request) {
+	args, err := pageArgs(r)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	q := r.URL.Query()
+	page, err := h.svc.ListTasks(r.Context(), q.Get("projectId"), optionalString(q.Get("parentTaskId")), service.TaskFilter{
+		Statuses:   multiValue(r, "status"),
+		Priorities: multiValue(r, "priority"),
+	}, args)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, TaskPage{
+		Data:       mapEdges(page.Edges, toTask),
+		PageInfo:   toPageInfo(page),
+		TotalCount: page.TotalCount,
+	})
+}
+
+func (h *Handler) createTask(w http.ResponseWriter, r *http.Request) {
+	var body CreateTaskRequest
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	in := service.CreateTaskInput{
+		ProjectID:    body.ProjectID,
+		ParentTaskID: body.ParentTaskID,
+		Title:        body.Title,
+		Description:  body.Description,
+		StartAt:      body.StartAt,
+		DueAt:        body.DueAt,
+		LabelIDs:     body.LabelIDs,
+	}
+	if body.Status != nil {
+		in.Status = *body.Status
+	}
+	if body.Priority != nil {
+		in.Priority = *body.Priority
+	}
+
+	task, err := h.svc.CreateTask(r.Context(), in)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, toTask(task))
+}
+
+func (h *Handler) getTask(w http.ResponseWriter, r *http.Request) {
+	task, err := h.svc.Task(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	if task == nil {
+		writeError(w, r, service.NewNotFound("task not found"))
+		return
+	}
+	writeJSON(w, http.StatusOK, toTask(*task))
+}
+
+func (h *Handler) updateTask(w http.ResponseWriter, r *http.Request) {
+	var body UpdateTaskRequest
+	if err := decodeBody(r, &body); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	task, err := h.svc.UpdateTask(r.Context(), service.UpdateTaskInput{
+		ID:          r.PathValue("id"),
+		Title:       body.Title,
+		Description: body.Description,
+		Status:      body.Status,
+		Priority:    body.Priority,
+		StartAt:     body.StartAt,
+		DueAt:       body.DueAt,
+		LabelIDs:    body.LabelIDs,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toTask(task))
+}
+
+func (h *Handler) deleteTask(w http.ResponseWriter, r *http.Request) {
+	deleted, err := h.svc.DeleteTask(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, toDeleteResult(deleted))
+}
+
+func (h *Handler) listTaskLabels(w http.ResponseWriter, r *http.Request) {
+	labels, err := h.svc.LabelsForTask(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, LabelList{Data: mapSlice(labels, toLabel)})
+}
+
+func (h *Handler) listTaskSubtasks(w http.ResponseWriter, r *http.Request) {
+	subtasks, err := h.svc.SubtasksForTask(r.Context(), r.PathValue("id"))
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, TaskList{Data: mapSlice(subtasks, toTask)})
+}
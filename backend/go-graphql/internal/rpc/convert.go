@@ -0,0 +1,192 @@
+package rpc
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	zenlistv1 "github.com/faizp/zenlist/backend/go-graphql/internal/rpc/zenlist/v1"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// asStatusError maps service errors onto gRPC status codes. Internal details
+// never reach the client.
+func asStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var appErr *service.AppError
+	if errors.As(err, &appErr) {
+		switch appErr.Code {
+		case service.CodeBadUserInput:
+			return status.Error(codes.InvalidArgument, appErr.Message)
+		case service.CodeNotFound:
+			return status.Error(codes.NotFound, appErr.Message)
+		case service.CodeConflict:
+			return status.Error(codes.AlreadyExists, appErr.Message)
+		}
+	}
+	return status.Error(codes.Internal, "internal server error")
+}
+
+func uuidString(v pgtype.UUID) string {
+	if !v.Valid {
+		return ""
+	}
+	return uuid.UUID(v.Bytes).String()
+}
+
+func timestamp(v pgtype.Timestamptz) *timestamppb.Timestamp {
+	if !v.Valid {
+		return nil
+	}
+	return timestamppb.New(v.Time)
+}
+
+func toUser(u sqlc.User) *zenlistv1.User {
+	return &zenlistv1.User{
+		Id:         uuidString(u.ID),
+		Name:       u.Name,
+		Email:      u.Email,
+		Timezone:   u.Timezone,
+		AvatarUrl:  u.AvatarUrl,
+		CreateTime: timestamp(u.CreatedAt),
+		UpdateTime: timestamp(u.UpdatedAt),
+	}
+}
+
+func toProject(p sqlc.Project) *zenlistv1.Project {
+	return &zenlistv1.Project{
+		Id:          uuidString(p.ID),
+		UserId:      uuidString(p.UserID),
+		Title:       p.Title,
+		Description: p.Description,
+		Color:       p.Color,
+		CreateTime:  timestamp(p.CreatedAt),
+		UpdateTime:  timestamp(p.UpdatedAt),
+	}
+}
+
+func toLabel(l sqlc.Label) *zenlistv1.Label {
+	return &zenlistv1.Label{
+		Id:         uuidString(l.ID),
+		UserId:     uuidString(l.UserID),
+		Name:       l.Name,
+		CreateTime: timestamp(l.CreatedAt),
+		UpdateTime: timestamp(l.UpdatedAt),
+	}
+}
+
+func toTask(t sqlc.Task) *zenlistv1.Task {
+	var parentID *string
+	if t.ParentTaskID.Valid {
+		id := uuidString(t.ParentTaskID)
+		parentID = &id
+	}
+
+	return &zenlistv1.Task{
+		Id:           uuidString(t.ID),
+		UserId:       uuidString(t.UserID),
+		ProjectId:    uuidString(t.ProjectID),
+		ParentTaskId: parentID,
+		Title:        t.Title,
+		Description:  t.Description,
+		Status:       toTaskStatus(t.Status),
+		Priority:     toTaskPriority(t.Priority),
+		StartTime:    timestamp(t.StartAt),
+		DueTime:      timestamp(t.DueAt),
+		CompleteTime: timestamp(t.CompletedAt),
+		CreateTime:   timestamp(t.CreatedAt),
+		UpdateTime:   timestamp(t.UpdatedAt),
+	}
+}
+
+// Enum values are named TASK_STATUS_<service status>, so the mapping is a
+// prefix away in both directions.
+
+func toTaskStatus(v string) zenlistv1.TaskStatus {
+	return zenlistv1.TaskStatus(zenlistv1.TaskStatus_value["TASK_STATUS_"+v])
+}
+
+func fromTaskStatus(v zenlistv1.TaskStatus) string {
+	if v == zenlistv1.TaskStatus_TASK_STATUS_UNSPECIFIED {
+		return ""
+	}
+	return strings.TrimPrefix(v.String(), "TASK_STATUS_")
+}
+
+func toTaskPriority(v string) zenlistv1.TaskPriority {
+	return zenlistv1.TaskPriority(zenlistv1.TaskPriority_value["TASK_PRIORITY_"+v])
+}
+
+func fromTaskPriority(v zenlistv1.TaskPriority) string {
+	if v == zenlistv1.TaskPriority_TASK_PRIORITY_UNSPECIFIED {
+		return ""
+	}
+	return strings.TrimPrefix(v.String(), "TASK_PRIORITY_")
+}
+
+func toTaskChangeType(v service.TaskEventType) zenlistv1.TaskChangeType {
+	switch v {
+	case service.TaskCreated:
+		return zenlistv1.TaskChangeType_TASK_CHANGE_TYPE_CREATED
+	case service.TaskUpdated:
+		return zenlistv1.TaskChangeType_TASK_CHANGE_TYPE_UPDATED
+	case service.TaskDeleted:
+		return zenlistv1.TaskChangeType_TASK_CHANGE_TYPE_DELETED
+	default:
+		return zenlistv1.TaskChangeType_TASK_CHANGE_TYPE_UNSPECIFIED
+	}
+}
+
+func pageArgs(page *zenlistv1.PageRequest) service.PageArgs {
+	if page == nil {
+		return service.PageArgs{}
+	}
+
+	args := service.PageArgs{
+		After:     page.After,
+		Before:    page.Before,
+		WithTotal: page.IncludeTotalCount,
+	}
+	if page.First != nil {
+		first := int(page.GetFirst())
+		args.First = &first
+	}
+	if page.Last != nil {
+		last := int(page.GetLast())
+		args.Last = &last
+	}
+	return args
+}
+
+func toPageInfo[T any](page service.PageResult[T]) *zenlistv1.PageInfo {
+	info := &zenlistv1.PageInfo{
+		StartCursor:     page.StartCursor,
+		EndCursor:       page.EndCursor,
+		HasPreviousPage: page.HasPreviousPage,
+		HasNextPage:     page.HasNextPage,
+	}
+	if page.TotalCount != nil {
+		total := int32(*page.TotalCount)
+		info.TotalCount = &total
+	}
+	return info
+}
+
+// splitEdges returns the mapped nodes and their cursors as parallel slices.
+func splitEdges[T any, R any](edges []service.Edge[T], fn func(T) R) ([]R, []string) {
+	nodes := make([]R, 0, len(edges))
+	cursors := make([]string, 0, len(edges))
+	for _, edge := range edges {
+		nodes = append(nodes, fn(edge.Node))
+		cursors = append(cursors, edge.Cursor)
+	}
+	return nodes, cursors
+}
@@ -0,0 +1,48 @@
+package rpc
+
+import (
+	"errors"
+	"testing"
+
+	zenlistv1 "github.com/faizp/zenlist/backend/go-graphql/internal/rpc/zenlist/v1"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAsStatusError(t *testing.T) {
+	tests := []struct {
+		err     error
+		code    codes.Code
+		message string
+	}{
+		{err: service.NewBadInput("task title is required"), code: codes.InvalidArgument, message: "task title is required"},
+		{err: service.NewNotFound("task not found"), code: codes.NotFound, message: "task not found"},
+		{err: service.NewConflict("label already exists", nil), code: codes.AlreadyExists, message: "label already exists"},
+		{err: service.NewInternal("failed to list tasks", errors.New("db down")), code: codes.Internal, message: "internal server error"},
+		{err: errors.New("boom"), code: codes.Internal, message: "internal server error"},
+	}
+
+	for _, tc := range tests {
+		st := status.Convert(asStatusError(tc.err))
+		if st.Code() != tc.code || st.Message() != tc.message {
+			t.Fatalf("asStatusError(%v): got %s %q want %s %q", tc.err, st.Code(), st.Message(), tc.code, tc.message)
+		}
+	}
+}
+
+func TestTaskEnumRoundTrip(t *testing.T) {
+	for _, v := range []string{"TODO", "IN_PROGRESS", "BLOCKED", "DONE"} {
+		if got := fromTaskStatus(toTaskStatus(v)); got != v {
+			t.Fatalf("status %s round-tripped to %s", v, got)
+		}
+	}
+	for _, v := range []string{"P1", "P2", "P3", "P4", "P5"} {
+		if got := fromTaskPriority(toTaskPriority(v)); got != v {
+			t.Fatalf("priority %s round-tripped to %s", v, got)
+		}
+	}
+	if got := fromTaskStatus(zenlistv1.TaskStatus_TASK_STATUS_UNSPECIFIED); got != "" {
+		t.Fatalf("unspecified status should map to the service default, got %q", got)
+	}
+}
@@ -0,0 +1,71 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/graphql/middleware"
+	platformlogger "github.com/faizp/zenlist/backend/go-graphql/internal/platform/logger"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const requestIDHeader = "x-request-id"
+
+func unaryInterceptor(timeout time.Duration, logger *platformlogger.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		ctx = withRequestID(ctx)
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		resp, err := handler(ctx, req)
+		logCall(logger, ctx, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func streamInterceptor(logger *platformlogger.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		wrapped := &requestIDStream{ServerStream: ss, ctx: withRequestID(ss.Context())}
+
+		err := handler(srv, wrapped)
+		logCall(logger, wrapped.ctx, info.FullMethod, start, err)
+		return err
+	}
+}
+
+type requestIDStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDStream) Context() context.Context {
+	return s.ctx
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	var reqID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDHeader); len(values) > 0 {
+			reqID = values[0]
+		}
+	}
+	if reqID == "" {
+		reqID = uuid.NewString()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, reqID))
+	return middleware.WithRequestID(ctx, reqID)
+}
+
+func logCall(logger *platformlogger.Logger, ctx context.Context, method string, start time.Time, err error) {
+	logger.Info("grpc_request",
+		"method", method,
+		"code", status.Code(err).String(),
+		"request_id", middleware.RequestIDFromContext(ctx),
+		"duration_ms", time.Since(start).Milliseconds(),
+	)
+}
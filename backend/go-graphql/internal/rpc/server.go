@@ -0,0 +1,285 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	platformlogger "github.com/faizp/zenlist/backend/go-graphql/internal/platform/logger"
+	zenlistv1 "github.com/faizp/zenlist/backend/go-graphql/internal/rpc/zenlist/v1"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Server implements zenlistv1.ZenlistServiceServer as a thin transport over
+// service.Service, mirroring the GraphQL resolvers.
+type Server struct {
+	zenlistv1.UnimplementedZenlistServiceServer
+	svc *service.Service
+}
+
+func NewServer(svc *service.Service) *Server {
+	return &Server{svc: svc}
+}
+
+// NewGRPCServer builds a gRPC server with the same request ID, timeout and
+// logging behaviour as the HTTP middleware chain. Streams are not subject to
+// the request timeout.
+func NewGRPCServer(svc *service.Service, timeout time.Duration, logger *platformlogger.Logger) *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptor(timeout, logger)),
+		grpc.ChainStreamInterceptor(streamInterceptor(logger)),
+	)
+	zenlistv1.RegisterZenlistServiceServer(srv, NewServer(svc))
+	return srv
+}
+
+func (s *Server) GetMe(ctx context.Context, _ *zenlistv1.GetMeRequest) (*zenlistv1.GetMeResponse, error) {
+	user, err := s.svc.Me(ctx)
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	return &zenlistv1.GetMeResponse{User: toUser(user)}, nil
+}
+
+func (s *Server) ListProjects(ctx context.Context, req *zenlistv1.ListProjectsRequest) (*zenlistv1.ListProjectsResponse, error) {
+	page, err := s.svc.ListProjects(ctx, false, pageArgs(req.GetPage()))
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	projects, cursors := splitEdges(page.Edges, toProject)
+	return &zenlistv1.ListProjectsResponse{Projects: projects, Cursors: cursors, PageInfo: toPageInfo(page)}, nil
+}
+
+func (s *Server) GetProject(ctx context.Context, req *zenlistv1.GetProjectRequest) (*zenlistv1.GetProjectResponse, error) {
+	project, err := s.svc.Project(ctx, req.GetId())
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	if project == nil {
+		return nil, status.Error(codes.NotFound, "project not found")
+	}
+	return &zenlistv1.GetProjectResponse{Project: toProject(*project)}, nil
+}
+
+func (s *Server) CreateProject(ctx context.Context, req *zenlistv1.CreateProjectRequest) (*zenlistv1.CreateProjectResponse, error) {
+	project, err := s.svc.CreateProject(ctx, service.CreateProjectInput{
+		Title:       req.GetTitle(),
+		Description: req.Description,
+		Color:       req.Color,
+	})
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	return &zenlistv1.CreateProjectResponse{Project: toProject(project)}, nil
+}
+
+func (s *Server) UpdateProject(ctx context.Context, req *zenlistv1.UpdateProjectRequest) (*zenlistv1.UpdateProjectResponse, error) {
+	project, err := s.svc.UpdateProject(ctx, service.UpdateProjectInput{
+		ID:          req.GetId(),
+		Title:       req.GetTitle(),
+		Description: req.Description,
+		Color:       req.Color,
+	})
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	return &zenlistv1.UpdateProjectResponse{Project: toProject(project)}, nil
+}
+
+func (s *Server) DeleteProject(ctx context.Context, req *zenlistv1.DeleteProjectRequest) (*zenlistv1.DeleteProjectResponse, error) {
+	deleted, err := s.svc.DeleteProject(ctx, req.GetId())
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	return &zenlistv1.DeleteProjectResponse{Id: deleted.ID.String(), DeleteTime: timestamppb.New(deleted.DeletedAt)}, nil
+}
+
+func (s *Server) ListLabels(ctx context.Context, req *zenlistv1.ListLabelsRequest) (*zenlistv1.ListLabelsResponse, error) {
+	page, err := s.svc.ListLabels(ctx, pageArgs(req.GetPage()))
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	labels, cursors := splitEdges(page.Edges, toLabel)
+	return &zenlistv1.ListLabelsResponse{Labels: labels, Cursors: cursors, PageInfo: toPageInfo(page)}, nil
+}
+
+func (s *Server) GetLabel(ctx context.Context, req *zenlistv1.GetLabelRequest) (*zenlistv1.GetLabelResponse, error) {
+	label, err := s.svc.Label(ctx, req.GetId())
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	if label == nil {
+		return nil, status.Error(codes.NotFound, "label not found")
+	}
+	return &zenlistv1.GetLabelResponse{Label: toLabel(*label)}, nil
+}
+
+func (s *Server) CreateLabel(ctx context.Context, req *zenlistv1.CreateLabelRequest) (*zenlistv1.CreateLabelResponse, error) {
+	label, err := s.svc.CreateLabel(ctx, service.CreateLabelInput{Name: req.GetName()})
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	return &zenlistv1.CreateLabelResponse{Label: toLabel(label)}, nil
+}
+
+func (s *Server) UpdateLabel(ctx context.Context, req *zenlistv1.UpdateLabelRequest) (*zenlistv1.UpdateLabelResponse, error) {
+	label, err := s.svc.UpdateLabel(ctx, service.UpdateLabelInput{ID: req.GetId(), Name: req.GetName()})
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	return &zenlistv1.UpdateLabelResponse{Label: toLabel(label)}, nil
+}
+
+func (s *Server) DeleteLabel(ctx context.Context, req *zenlistv1.DeleteLabelRequest) (*zenlistv1.DeleteLabelResponse, error) {
+	deleted, err := s.svc.DeleteLabel(ctx, req.GetId())
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	return &zenlistv1.DeleteLabelResponse{Id: deleted.ID.String(), DeleteTime: timestamppb.New(deleted.DeletedAt)}, nil
+}
+
+func (s *Server) ListTasks(ctx context.Context, req *zenlistv1.ListTasksRequest) (*zenlistv1.ListTasksResponse, error) {
+	statuses := make([]string, 0, len(req.GetStatuses()))
+	for _, v := range req.GetStatuses() {
+		statuses = append(statuses, fromTaskStatus(v))
+	}
+	priorities := make([]string, 0, len(req.GetPriorities()))
+	for _, v := range req.GetPriorities() {
+		priorities = append(priorities, fromTaskPriority(v))
+	}
+
+	page, err := s.svc.ListTasks(ctx, req.GetProjectId(), req.ParentTaskId, service.TaskFilter{Statuses: statuses, Priorities: priorities}, pageArgs(req.GetPage()))
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	tasks, cursors := splitEdges(page.Edges, toTask)
+	return &zenlistv1.ListTasksResponse{Tasks: tasks, Cursors: cursors, PageInfo: toPageInfo(page)}, nil
+}
+
+func (s *Server) GetTask(ctx context.Context, req *zenlistv1.GetTaskRequest) (*zenlistv1.GetTaskResponse, error) {
+	task, err := s.svc.Task(ctx, req.GetId())
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	if task == nil {
+		return nil, status.Error(codes.NotFound, "task not found")
+	}
+	return &zenlistv1.GetTaskResponse{Task: toTask(*task)}, nil
+}
+
+func (s *Server) CreateTask(ctx context.Context, req *zenlistv1.CreateTaskRequest) (*zenlistv1.CreateTaskResponse, error) {
+	startAt, err := optionalTime(req.GetStartTime(), "start_time")
+	if err != nil {
+		return nil, err
+	}
+	dueAt, err := optionalTime(req.GetDueTime(), "due_time")
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.svc.CreateTask(ctx, service.CreateTaskInput{
+		ProjectID:    req.GetProjectId(),
+		ParentTaskID: req.ParentTaskId,
+		Title:        req.GetTitle(),
+		Description:  req.Description,
+		Status:       fromTaskStatus(req.GetStatus()),
+		Priority:     fromTaskPriority(req.GetPriority()),
+		StartAt:      startAt,
+		DueAt:        dueAt,
+		LabelIDs:     req.GetLabelIds(),
+	})
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	return &zenlistv1.CreateTaskResponse{Task: toTask(task)}, nil
+}
+
+func (s *Server) UpdateTask(ctx context.Context, req *zenlistv1.UpdateTaskRequest) (*zenlistv1.UpdateTaskResponse, error) {
+	startAt, err := optionalTime(req.GetStartTime(), "start_time")
+	if err != nil {
+		return nil, err
+	}
+	dueAt, err := optionalTime(req.GetDueTime(), "due_time")
+	if err != nil {
+		return nil, err
+	}
+
+	in := service.UpdateTaskInput{
+		ID:          req.GetId(),
+		Title:       req.Title,
+		Description: req.Description,
+		StartAt:     startAt,
+		DueAt:       dueAt,
+	}
+	if v := fromTaskStatus(req.GetStatus()); v != "" {
+		in.Status = &v
+	}
+	if v := fromTaskPriority(req.GetPriority()); v != "" {
+		in.Priority = &v
+	}
+	if req.LabelIds != nil {
+		in.LabelIDs = append([]string{}, req.LabelIds.GetIds()...)
+	}
+
+	task, err := s.svc.UpdateTask(ctx, in)
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	return &zenlistv1.UpdateTaskResponse{Task: toTask(task)}, nil
+}
+
+func (s *Server) DeleteTask(ctx context.Context, req *zenlistv1.DeleteTaskRequest) (*zenlistv1.DeleteTaskResponse, error) {
+	deleted, err := s.svc.DeleteTask(ctx, req.GetId())
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	return &zenlistv1.DeleteTaskResponse{Id: deleted.ID.String(), DeleteTime: timestamppb.New(deleted.DeletedAt)}, nil
+}
+
+func (s *Server) ListTaskLabels(ctx context.Context, req *zenlistv1.ListTaskLabelsRequest) (*zenlistv1.ListTaskLabelsResponse, error) {
+	labels, err := s.svc.LabelsForTask(ctx, req.GetTaskId())
+	if err != nil {
+		return nil, asStatusError(err)
+	}
+	out := make([]*zenlistv1.Label, 0, len(labels))
+	for _, label := range labels {
+		out = append(out, toLabel(label))
+	}
+	return &zenlistv1.ListTaskLabelsResponse{Labels: out}, nil
+}
+
+func (s *Server) WatchTasks(req *zenlistv1.WatchTasksRequest, stream zenlistv1.ZenlistService_WatchTasksServer) error {
+	ctx := stream.Context()
+	events, err := s.svc.WatchTasks(ctx, req.ProjectId)
+	if err != nil {
+		return asStatusError(err)
+	}
+
+	for ev := range events {
+		if err := stream.Send(&zenlistv1.WatchTasksResponse{
+			Type:      toTaskChangeType(ev.Type),
+			Task:      toTask(ev.Task),
+			OccurTime: timestamppb.New(ev.OccurredAt),
+		}); err != nil {
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Aborted, "watcher fell behind; reconnect and list tasks to resync")
+}
+
+func optionalTime(ts *timestamppb.Timestamp, field string) (*time.Time, error) {
+	if ts == nil {
+		return nil, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s is not a valid timestamp", field)
+	}
+	t := ts.AsTime()
+	return &t, nil
+}
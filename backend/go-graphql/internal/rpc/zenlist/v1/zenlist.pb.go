@@ -0,0 +1,3102 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: zenlist/v1/zenlist.proto
+
+package zenlistv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TaskStatus int32
+
+const (
+	TaskStatus_TASK_STATUS_UNSPECIFIED TaskStatus = 0
+	TaskStatus_TASK_STATUS_TODO        TaskStatus = 1
+	TaskStatus_TASK_STATUS_IN_PROGRESS TaskStatus = 2
+	TaskStatus_TASK_STATUS_BLOCKED     TaskStatus = 3
+	TaskStatus_TASK_STATUS_DONE        TaskStatus = 4
+)
+
+// Enum value maps for TaskStatus.
+var (
+	TaskStatus_name = map[int32]string{
+		0: "TASK_STATUS_UNSPECIFIED",
+		1: "TASK_STATUS_TODO",
+		2: "TASK_STATUS_IN_PROGRESS",
+		3: "TASK_STATUS_BLOCKED",
+		4: "TASK_STATUS_DONE",
+	}
+	TaskStatus_value = map[string]int32{
+		"TASK_STATUS_UNSPECIFIED": 0,
+		"TASK_STATUS_TODO":        1,
+		"TASK_STATUS_IN_PROGRESS": 2,
+		"TASK_STATUS_BLOCKED":     3,
+		"TASK_STATUS_DONE":        4,
+	}
+)
+
+func (x TaskStatus) Enum() *TaskStatus {
+	p := new(TaskStatus)
+	*p = x
+	return p
+}
+
+func (x TaskStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_zenlist_v1_zenlist_proto_enumTypes[0].Descriptor()
+}
+
+func (TaskStatus) Type() protoreflect.EnumType {
+	return &file_zenlist_v1_zenlist_proto_enumTypes[0]
+}
+
+func (x TaskStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskStatus.Descriptor instead.
+func (TaskStatus) EnumDescriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{0}
+}
+
+type TaskPriority int32
+
+const (
+	TaskPriority_TASK_PRIORITY_UNSPECIFIED TaskPriority = 0
+	TaskPriority_TASK_PRIORITY_P1          TaskPriority = 1
+	TaskPriority_TASK_PRIORITY_P2          TaskPriority = 2
+	TaskPriority_TASK_PRIORITY_P3          TaskPriority = 3
+	TaskPriority_TASK_PRIORITY_P4          TaskPriority = 4
+	TaskPriority_TASK_PRIORITY_P5          TaskPriority = 5
+)
+
+// Enum value maps for TaskPriority.
+var (
+	TaskPriority_name = map[int32]string{
+		0: "TASK_PRIORITY_UNSPECIFIED",
+		1: "TASK_PRIORITY_P1",
+		2: "TASK_PRIORITY_P2",
+		3: "TASK_PRIORITY_P3",
+		4: "TASK_PRIORITY_P4",
+		5: "TASK_PRIORITY_P5",
+	}
+	TaskPriority_value = map[string]int32{
+		"TASK_PRIORITY_UNSPECIFIED": 0,
+		"TASK_PRIORITY_P1":          1,
+		"TASK_PRIORITY_P2":          2,
+		"TASK_PRIORITY_P3":          3,
+		"TASK_PRIORITY_P4":          4,
+		"TASK_PRIORITY_P5":          5,
+	}
+)
+
+func (x TaskPriority) Enum() *TaskPriority {
+	p := new(TaskPriority)
+	*p = x
+	return p
+}
+
+func (x TaskPriority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskPriority) Descriptor() protoreflect.EnumDescriptor {
+	return file_zenlist_v1_zenlist_proto_enumTypes[1].Descriptor()
+}
+
+func (TaskPriority) Type() protoreflect.EnumType {
+	return &file_zenlist_v1_zenlist_proto_enumTypes[1]
+}
+
+func (x TaskPriority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskPriority.Descriptor instead.
+func (TaskPriority) EnumDescriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{1}
+}
+
+type TaskChangeType int32
+
+const (
+	TaskChangeType_TASK_CHANGE_TYPE_UNSPECIFIED TaskChangeType = 0
+	TaskChangeType_TASK_CHANGE_TYPE_CREATED     TaskChangeType = 1
+	TaskChangeType_TASK_CHANGE_TYPE_UPDATED     TaskChangeType = 2
+	TaskChangeType_TASK_CHANGE_TYPE_DELETED     TaskChangeType = 3
+)
+
+// Enum value maps for TaskChangeType.
+var (
+	TaskChangeType_name = map[int32]string{
+		0: "TASK_CHANGE_TYPE_UNSPECIFIED",
+		1: "TASK_CHANGE_TYPE_CREATED",
+		2: "TASK_CHANGE_TYPE_UPDATED",
+		3: "TASK_CHANGE_TYPE_DELETED",
+	}
+	TaskChangeType_value = map[string]int32{
+		"TASK_CHANGE_TYPE_UNSPECIFIED": 0,
+		"TASK_CHANGE_TYPE_CREATED":     1,
+		"TASK_CHANGE_TYPE_UPDATED":     2,
+		"TASK_CHANGE_TYPE_DELETED":     3,
+	}
+)
+
+func (x TaskChangeType) Enum() *TaskChangeType {
+	p := new(TaskChangeType)
+	*p = x
+	return p
+}
+
+func (x TaskChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_zenlist_v1_zenlist_proto_enumTypes[2].Descriptor()
+}
+
+func (TaskChangeType) Type() protoreflect.EnumType {
+	return &file_zenlist_v1_zenlist_proto_enumTypes[2]
+}
+
+func (x TaskChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskChangeType.Descriptor instead.
+func (TaskChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{2}
+}
+
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Timezone      string                 `protobuf:"bytes,4,opt,name=timezone,proto3" json:"timezone,omitempty"`
+	AvatarUrl     *string                `protobuf:"bytes,5,opt,name=avatar_url,json=avatarUrl,proto3,oneof" json:"avatar_url,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetTimezone() string {
+	if x != nil {
+		return x.Timezone
+	}
+	return ""
+}
+
+func (x *User) GetAvatarUrl() string {
+	if x != nil && x.AvatarUrl != nil {
+		return *x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *User) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *User) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type Project struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description   *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Color         *string                `protobuf:"bytes,5,opt,name=color,proto3,oneof" json:"color,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Project) Reset() {
+	*x = Project{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Project) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Project) ProtoMessage() {}
+
+func (x *Project) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Project.ProtoReflect.Descriptor instead.
+func (*Project) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Project) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Project) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Project) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Project) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Project) GetColor() string {
+	if x != nil && x.Color != nil {
+		return *x.Color
+	}
+	return ""
+}
+
+func (x *Project) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Project) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type Label struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Label) Reset() {
+	*x = Label{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Label) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Label) ProtoMessage() {}
+
+func (x *Label) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Label.ProtoReflect.Descriptor instead.
+func (*Label) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Label) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Label) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Label) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Label) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Label) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+type Task struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ProjectId     string                 `protobuf:"bytes,3,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	ParentTaskId  *string                `protobuf:"bytes,4,opt,name=parent_task_id,json=parentTaskId,proto3,oneof" json:"parent_task_id,omitempty"`
+	Title         string                 `protobuf:"bytes,5,opt,name=title,proto3" json:"title,omitempty"`
+	Description   *string                `protobuf:"bytes,6,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Status        TaskStatus             `protobuf:"varint,7,opt,name=status,proto3,enum=zenlist.v1.TaskStatus" json:"status,omitempty"`
+	Priority      TaskPriority           `protobuf:"varint,8,opt,name=priority,proto3,enum=zenlist.v1.TaskPriority" json:"priority,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	DueTime       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=due_time,json=dueTime,proto3" json:"due_time,omitempty"`
+	CompleteTime  *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=complete_time,json=completeTime,proto3" json:"complete_time,omitempty"`
+	CreateTime    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	UpdateTime    *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Task) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *Task) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *Task) GetParentTaskId() string {
+	if x != nil && x.ParentTaskId != nil {
+		return *x.ParentTaskId
+	}
+	return ""
+}
+
+func (x *Task) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Task) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *Task) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *Task) GetPriority() TaskPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_UNSPECIFIED
+}
+
+func (x *Task) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *Task) GetDueTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueTime
+	}
+	return nil
+}
+
+func (x *Task) GetCompleteTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompleteTime
+	}
+	return nil
+}
+
+func (x *Task) GetCreateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreateTime
+	}
+	return nil
+}
+
+func (x *Task) GetUpdateTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdateTime
+	}
+	return nil
+}
+
+// PageRequest mirrors the GraphQL connection arguments. Cursors are the same
+// opaque values the GraphQL and REST APIs return.
+type PageRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	First             *int32                 `protobuf:"varint,1,opt,name=first,proto3,oneof" json:"first,omitempty"`
+	After             *string                `protobuf:"bytes,2,opt,name=after,proto3,oneof" json:"after,omitempty"`
+	Last              *int32                 `protobuf:"varint,3,opt,name=last,proto3,oneof" json:"last,omitempty"`
+	Before            *string                `protobuf:"bytes,4,opt,name=before,proto3,oneof" json:"before,omitempty"`
+	IncludeTotalCount bool                   `protobuf:"varint,5,opt,name=include_total_count,json=includeTotalCount,proto3" json:"include_total_count,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PageRequest) Reset() {
+	*x = PageRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageRequest) ProtoMessage() {}
+
+func (x *PageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageRequest.ProtoReflect.Descriptor instead.
+func (*PageRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PageRequest) GetFirst() int32 {
+	if x != nil && x.First != nil {
+		return *x.First
+	}
+	return 0
+}
+
+func (x *PageRequest) GetAfter() string {
+	if x != nil && x.After != nil {
+		return *x.After
+	}
+	return ""
+}
+
+func (x *PageRequest) GetLast() int32 {
+	if x != nil && x.Last != nil {
+		return *x.Last
+	}
+	return 0
+}
+
+func (x *PageRequest) GetBefore() string {
+	if x != nil && x.Before != nil {
+		return *x.Before
+	}
+	return ""
+}
+
+func (x *PageRequest) GetIncludeTotalCount() bool {
+	if x != nil {
+		return x.IncludeTotalCount
+	}
+	return false
+}
+
+type PageInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	StartCursor     *string                `protobuf:"bytes,1,opt,name=start_cursor,json=startCursor,proto3,oneof" json:"start_cursor,omitempty"`
+	EndCursor       *string                `protobuf:"bytes,2,opt,name=end_cursor,json=endCursor,proto3,oneof" json:"end_cursor,omitempty"`
+	HasPreviousPage bool                   `protobuf:"varint,3,opt,name=has_previous_page,json=hasPreviousPage,proto3" json:"has_previous_page,omitempty"`
+	HasNextPage     bool                   `protobuf:"varint,4,opt,name=has_next_page,json=hasNextPage,proto3" json:"has_next_page,omitempty"`
+	TotalCount      *int32                 `protobuf:"varint,5,opt,name=total_count,json=totalCount,proto3,oneof" json:"total_count,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PageInfo) Reset() {
+	*x = PageInfo{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageInfo) ProtoMessage() {}
+
+func (x *PageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
+func (*PageInfo) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PageInfo) GetStartCursor() string {
+	if x != nil && x.StartCursor != nil {
+		return *x.StartCursor
+	}
+	return ""
+}
+
+func (x *PageInfo) GetEndCursor() string {
+	if x != nil && x.EndCursor != nil {
+		return *x.EndCursor
+	}
+	return ""
+}
+
+func (x *PageInfo) GetHasPreviousPage() bool {
+	if x != nil {
+		return x.HasPreviousPage
+	}
+	return false
+}
+
+func (x *PageInfo) GetHasNextPage() bool {
+	if x != nil {
+		return x.HasNextPage
+	}
+	return false
+}
+
+func (x *PageInfo) GetTotalCount() int32 {
+	if x != nil && x.TotalCount != nil {
+		return *x.TotalCount
+	}
+	return 0
+}
+
+type GetMeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMeRequest) Reset() {
+	*x = GetMeRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMeRequest) ProtoMessage() {}
+
+func (x *GetMeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMeRequest.ProtoReflect.Descriptor instead.
+func (*GetMeRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{6}
+}
+
+type GetMeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	User          *User                  `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMeResponse) Reset() {
+	*x = GetMeResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMeResponse) ProtoMessage() {}
+
+func (x *GetMeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMeResponse.ProtoReflect.Descriptor instead.
+func (*GetMeResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetMeResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+type ListProjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          *PageRequest           `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsRequest) Reset() {
+	*x = ListProjectsRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsRequest) ProtoMessage() {}
+
+func (x *ListProjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListProjectsRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListProjectsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListProjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Projects      []*Project             `protobuf:"bytes,1,rep,name=projects,proto3" json:"projects,omitempty"`
+	Cursors       []string               `protobuf:"bytes,2,rep,name=cursors,proto3" json:"cursors,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,3,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProjectsResponse) Reset() {
+	*x = ListProjectsResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProjectsResponse) ProtoMessage() {}
+
+func (x *ListProjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListProjectsResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListProjectsResponse) GetProjects() []*Project {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+func (x *ListProjectsResponse) GetCursors() []string {
+	if x != nil {
+		return x.Cursors
+	}
+	return nil
+}
+
+func (x *ListProjectsResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+type GetProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectRequest) Reset() {
+	*x = GetProjectRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectRequest) ProtoMessage() {}
+
+func (x *GetProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectRequest.ProtoReflect.Descriptor instead.
+func (*GetProjectRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProjectResponse) Reset() {
+	*x = GetProjectResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProjectResponse) ProtoMessage() {}
+
+func (x *GetProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProjectResponse.ProtoReflect.Descriptor instead.
+func (*GetProjectResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+type CreateProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description   *string                `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Color         *string                `protobuf:"bytes,3,opt,name=color,proto3,oneof" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectRequest) Reset() {
+	*x = CreateProjectRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectRequest) ProtoMessage() {}
+
+func (x *CreateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectRequest.ProtoReflect.Descriptor instead.
+func (*CreateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateProjectRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreateProjectRequest) GetColor() string {
+	if x != nil && x.Color != nil {
+		return *x.Color
+	}
+	return ""
+}
+
+type CreateProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateProjectResponse) Reset() {
+	*x = CreateProjectResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateProjectResponse) ProtoMessage() {}
+
+func (x *CreateProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateProjectResponse.ProtoReflect.Descriptor instead.
+func (*CreateProjectResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+type UpdateProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Color         *string                `protobuf:"bytes,4,opt,name=color,proto3,oneof" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectRequest) Reset() {
+	*x = UpdateProjectRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectRequest) ProtoMessage() {}
+
+func (x *UpdateProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectRequest.ProtoReflect.Descriptor instead.
+func (*UpdateProjectRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateProjectRequest) GetColor() string {
+	if x != nil && x.Color != nil {
+		return *x.Color
+	}
+	return ""
+}
+
+type UpdateProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       *Project               `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateProjectResponse) Reset() {
+	*x = UpdateProjectResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateProjectResponse) ProtoMessage() {}
+
+func (x *UpdateProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateProjectResponse.ProtoReflect.Descriptor instead.
+func (*UpdateProjectResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateProjectResponse) GetProject() *Project {
+	if x != nil {
+		return x.Project
+	}
+	return nil
+}
+
+type DeleteProjectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectRequest) Reset() {
+	*x = DeleteProjectRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectRequest) ProtoMessage() {}
+
+func (x *DeleteProjectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProjectRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteProjectRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteProjectResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeleteTime    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProjectResponse) Reset() {
+	*x = DeleteProjectResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProjectResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProjectResponse) ProtoMessage() {}
+
+func (x *DeleteProjectResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProjectResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProjectResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *DeleteProjectResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteProjectResponse) GetDeleteTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeleteTime
+	}
+	return nil
+}
+
+type ListLabelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Page          *PageRequest           `protobuf:"bytes,1,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLabelsRequest) Reset() {
+	*x = ListLabelsRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLabelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLabelsRequest) ProtoMessage() {}
+
+func (x *ListLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLabelsRequest.ProtoReflect.Descriptor instead.
+func (*ListLabelsRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListLabelsRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListLabelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Labels        []*Label               `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty"`
+	Cursors       []string               `protobuf:"bytes,2,rep,name=cursors,proto3" json:"cursors,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,3,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLabelsResponse) Reset() {
+	*x = ListLabelsResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLabelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLabelsResponse) ProtoMessage() {}
+
+func (x *ListLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLabelsResponse.ProtoReflect.Descriptor instead.
+func (*ListLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListLabelsResponse) GetLabels() []*Label {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *ListLabelsResponse) GetCursors() []string {
+	if x != nil {
+		return x.Cursors
+	}
+	return nil
+}
+
+func (x *ListLabelsResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+type GetLabelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLabelRequest) Reset() {
+	*x = GetLabelRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLabelRequest) ProtoMessage() {}
+
+func (x *GetLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLabelRequest.ProtoReflect.Descriptor instead.
+func (*GetLabelRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetLabelRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetLabelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         *Label                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLabelResponse) Reset() {
+	*x = GetLabelResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLabelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLabelResponse) ProtoMessage() {}
+
+func (x *GetLabelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLabelResponse.ProtoReflect.Descriptor instead.
+func (*GetLabelResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetLabelResponse) GetLabel() *Label {
+	if x != nil {
+		return x.Label
+	}
+	return nil
+}
+
+type CreateLabelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLabelRequest) Reset() {
+	*x = CreateLabelRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLabelRequest) ProtoMessage() {}
+
+func (x *CreateLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLabelRequest.ProtoReflect.Descriptor instead.
+func (*CreateLabelRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateLabelRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateLabelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         *Label                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateLabelResponse) Reset() {
+	*x = CreateLabelResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateLabelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateLabelResponse) ProtoMessage() {}
+
+func (x *CreateLabelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateLabelResponse.ProtoReflect.Descriptor instead.
+func (*CreateLabelResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *CreateLabelResponse) GetLabel() *Label {
+	if x != nil {
+		return x.Label
+	}
+	return nil
+}
+
+type UpdateLabelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLabelRequest) Reset() {
+	*x = UpdateLabelRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLabelRequest) ProtoMessage() {}
+
+func (x *UpdateLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLabelRequest.ProtoReflect.Descriptor instead.
+func (*UpdateLabelRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *UpdateLabelRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateLabelRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type UpdateLabelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         *Label                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateLabelResponse) Reset() {
+	*x = UpdateLabelResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateLabelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateLabelResponse) ProtoMessage() {}
+
+func (x *UpdateLabelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateLabelResponse.ProtoReflect.Descriptor instead.
+func (*UpdateLabelResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpdateLabelResponse) GetLabel() *Label {
+	if x != nil {
+		return x.Label
+	}
+	return nil
+}
+
+type DeleteLabelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLabelRequest) Reset() {
+	*x = DeleteLabelRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLabelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLabelRequest) ProtoMessage() {}
+
+func (x *DeleteLabelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLabelRequest.ProtoReflect.Descriptor instead.
+func (*DeleteLabelRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteLabelRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteLabelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeleteTime    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteLabelResponse) Reset() {
+	*x = DeleteLabelResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteLabelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteLabelResponse) ProtoMessage() {}
+
+func (x *DeleteLabelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteLabelResponse.ProtoReflect.Descriptor instead.
+func (*DeleteLabelResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *DeleteLabelResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteLabelResponse) GetDeleteTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeleteTime
+	}
+	return nil
+}
+
+type ListTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	ParentTaskId  *string                `protobuf:"bytes,2,opt,name=parent_task_id,json=parentTaskId,proto3,oneof" json:"parent_task_id,omitempty"`
+	Statuses      []TaskStatus           `protobuf:"varint,3,rep,packed,name=statuses,proto3,enum=zenlist.v1.TaskStatus" json:"statuses,omitempty"`
+	Priorities    []TaskPriority         `protobuf:"varint,4,rep,packed,name=priorities,proto3,enum=zenlist.v1.TaskPriority" json:"priorities,omitempty"`
+	Page          *PageRequest           `protobuf:"bytes,5,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListTasksRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetParentTaskId() string {
+	if x != nil && x.ParentTaskId != nil {
+		return *x.ParentTaskId
+	}
+	return ""
+}
+
+func (x *ListTasksRequest) GetStatuses() []TaskStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetPriorities() []TaskPriority {
+	if x != nil {
+		return x.Priorities
+	}
+	return nil
+}
+
+func (x *ListTasksRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	Cursors       []string               `protobuf:"bytes,2,rep,name=cursors,proto3" json:"cursors,omitempty"`
+	PageInfo      *PageInfo              `protobuf:"bytes,3,opt,name=page_info,json=pageInfo,proto3" json:"page_info,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+func (x *ListTasksResponse) GetCursors() []string {
+	if x != nil {
+		return x.Cursors
+	}
+	return nil
+}
+
+func (x *ListTasksResponse) GetPageInfo() *PageInfo {
+	if x != nil {
+		return x.PageInfo
+	}
+	return nil
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskResponse) Reset() {
+	*x = GetTaskResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskResponse) ProtoMessage() {}
+
+func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type CreateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProjectId     string                 `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3" json:"project_id,omitempty"`
+	ParentTaskId  *string                `protobuf:"bytes,2,opt,name=parent_task_id,json=parentTaskId,proto3,oneof" json:"parent_task_id,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Description   *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Status        TaskStatus             `protobuf:"varint,5,opt,name=status,proto3,enum=zenlist.v1.TaskStatus" json:"status,omitempty"`
+	Priority      TaskPriority           `protobuf:"varint,6,opt,name=priority,proto3,enum=zenlist.v1.TaskPriority" json:"priority,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	DueTime       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=due_time,json=dueTime,proto3" json:"due_time,omitempty"`
+	LabelIds      []string               `protobuf:"bytes,9,rep,name=label_ids,json=labelIds,proto3" json:"label_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateTaskRequest) GetProjectId() string {
+	if x != nil {
+		return x.ProjectId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetParentTaskId() string {
+	if x != nil && x.ParentTaskId != nil {
+		return *x.ParentTaskId
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *CreateTaskRequest) GetPriority() TaskPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_UNSPECIFIED
+}
+
+func (x *CreateTaskRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetDueTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueTime
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetLabelIds() []string {
+	if x != nil {
+		return x.LabelIds
+	}
+	return nil
+}
+
+type CreateTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskResponse) Reset() {
+	*x = CreateTaskResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskResponse) ProtoMessage() {}
+
+func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskResponse.ProtoReflect.Descriptor instead.
+func (*CreateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *CreateTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+// LabelIds wraps a list so UpdateTaskRequest can tell "leave labels alone"
+// (unset) from "remove all labels" (set with no ids).
+type LabelIds struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LabelIds) Reset() {
+	*x = LabelIds{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LabelIds) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LabelIds) ProtoMessage() {}
+
+func (x *LabelIds) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LabelIds.ProtoReflect.Descriptor instead.
+func (*LabelIds) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *LabelIds) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// UpdateTaskRequest only changes the fields that are set.
+type UpdateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Description   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	Status        TaskStatus             `protobuf:"varint,4,opt,name=status,proto3,enum=zenlist.v1.TaskStatus" json:"status,omitempty"`
+	Priority      TaskPriority           `protobuf:"varint,5,opt,name=priority,proto3,enum=zenlist.v1.TaskPriority" json:"priority,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	DueTime       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=due_time,json=dueTime,proto3" json:"due_time,omitempty"`
+	LabelIds      *LabelIds              `protobuf:"bytes,8,opt,name=label_ids,json=labelIds,proto3" json:"label_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpdateTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *UpdateTaskRequest) GetPriority() TaskPriority {
+	if x != nil {
+		return x.Priority
+	}
+	return TaskPriority_TASK_PRIORITY_UNSPECIFIED
+}
+
+func (x *UpdateTaskRequest) GetStartTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartTime
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetDueTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DueTime
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetLabelIds() *LabelIds {
+	if x != nil {
+		return x.LabelIds
+	}
+	return nil
+}
+
+type UpdateTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Task          *Task                  `protobuf:"bytes,1,opt,name=task,proto3" json:"task,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskResponse) Reset() {
+	*x = UpdateTaskResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskResponse) ProtoMessage() {}
+
+func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UpdateTaskResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+type DeleteTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeleteTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	DeleteTime    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=delete_time,json=deleteTime,proto3" json:"delete_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskResponse) Reset() {
+	*x = DeleteTaskResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskResponse) ProtoMessage() {}
+
+func (x *DeleteTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTaskResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *DeleteTaskResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteTaskResponse) GetDeleteTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeleteTime
+	}
+	return nil
+}
+
+type ListTaskLabelsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskLabelsRequest) Reset() {
+	*x = ListTaskLabelsRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskLabelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskLabelsRequest) ProtoMessage() {}
+
+func (x *ListTaskLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskLabelsRequest.ProtoReflect.Descriptor instead.
+func (*ListTaskLabelsRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListTaskLabelsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type ListTaskLabelsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Labels        []*Label               `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTaskLabelsResponse) Reset() {
+	*x = ListTaskLabelsResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTaskLabelsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTaskLabelsResponse) ProtoMessage() {}
+
+func (x *ListTaskLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTaskLabelsResponse.ProtoReflect.Descriptor instead.
+func (*ListTaskLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListTaskLabelsResponse) GetLabels() []*Label {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type WatchTasksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Limits the stream to one project. All of the user's tasks when unset.
+	ProjectId     *string `protobuf:"bytes,1,opt,name=project_id,json=projectId,proto3,oneof" json:"project_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTasksRequest) Reset() {
+	*x = WatchTasksRequest{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTasksRequest) ProtoMessage() {}
+
+func (x *WatchTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTasksRequest.ProtoReflect.Descriptor instead.
+func (*WatchTasksRequest) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *WatchTasksRequest) GetProjectId() string {
+	if x != nil && x.ProjectId != nil {
+		return *x.ProjectId
+	}
+	return ""
+}
+
+type WatchTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          TaskChangeType         `protobuf:"varint,1,opt,name=type,proto3,enum=zenlist.v1.TaskChangeType" json:"type,omitempty"`
+	Task          *Task                  `protobuf:"bytes,2,opt,name=task,proto3" json:"task,omitempty"`
+	OccurTime     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occur_time,json=occurTime,proto3" json:"occur_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchTasksResponse) Reset() {
+	*x = WatchTasksResponse{}
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchTasksResponse) ProtoMessage() {}
+
+func (x *WatchTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_zenlist_v1_zenlist_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchTasksResponse.ProtoReflect.Descriptor instead.
+func (*WatchTasksResponse) Descriptor() ([]byte, []int) {
+	return file_zenlist_v1_zenlist_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *WatchTasksResponse) GetType() TaskChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return TaskChangeType_TASK_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *WatchTasksResponse) GetTask() *Task {
+	if x != nil {
+		return x.Task
+	}
+	return nil
+}
+
+func (x *WatchTasksResponse) GetOccurTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurTime
+	}
+	return nil
+}
+
+var File_zenlist_v1_zenlist_proto protoreflect.FileDescriptor
+
+const file_zenlist_v1_zenlist_proto_rawDesc = "" +
+	"\n" +
+	"\x18zenlist/v1/zenlist.proto\x12\n" +
+	"zenlist.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x89\x02\n" +
+	"\x04User\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\x12\x1a\n" +
+	"\btimezone\x18\x04 \x01(\tR\btimezone\x12\"\n" +
+	"\n" +
+	"avatar_url\x18\x05 \x01(\tH\x00R\tavatarUrl\x88\x01\x01\x12;\n" +
+	"\vcreate_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTimeB\r\n" +
+	"\v_avatar_url\"\x9e\x02\n" +
+	"\aProject\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x19\n" +
+	"\x05color\x18\x05 \x01(\tH\x01R\x05color\x88\x01\x01\x12;\n" +
+	"\vcreate_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTimeB\x0e\n" +
+	"\f_descriptionB\b\n" +
+	"\x06_color\"\xbe\x01\n" +
+	"\x05Label\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12;\n" +
+	"\vcreate_time\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTime\"\xec\x04\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x03 \x01(\tR\tprojectId\x12)\n" +
+	"\x0eparent_task_id\x18\x04 \x01(\tH\x00R\fparentTaskId\x88\x01\x01\x12\x14\n" +
+	"\x05title\x18\x05 \x01(\tR\x05title\x12%\n" +
+	"\vdescription\x18\x06 \x01(\tH\x01R\vdescription\x88\x01\x01\x12.\n" +
+	"\x06status\x18\a \x01(\x0e2\x16.zenlist.v1.TaskStatusR\x06status\x124\n" +
+	"\bpriority\x18\b \x01(\x0e2\x18.zenlist.v1.TaskPriorityR\bpriority\x129\n" +
+	"\n" +
+	"start_time\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bdue_time\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\adueTime\x12?\n" +
+	"\rcomplete_time\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\fcompleteTime\x12;\n" +
+	"\vcreate_time\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"createTime\x12;\n" +
+	"\vupdate_time\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"updateTimeB\x11\n" +
+	"\x0f_parent_task_idB\x0e\n" +
+	"\f_description\"\xd1\x01\n" +
+	"\vPageRequest\x12\x19\n" +
+	"\x05first\x18\x01 \x01(\x05H\x00R\x05first\x88\x01\x01\x12\x19\n" +
+	"\x05after\x18\x02 \x01(\tH\x01R\x05after\x88\x01\x01\x12\x17\n" +
+	"\x04last\x18\x03 \x01(\x05H\x02R\x04last\x88\x01\x01\x12\x1b\n" +
+	"\x06before\x18\x04 \x01(\tH\x03R\x06before\x88\x01\x01\x12.\n" +
+	"\x13include_total_count\x18\x05 \x01(\bR\x11includeTotalCountB\b\n" +
+	"\x06_firstB\b\n" +
+	"\x06_afterB\a\n" +
+	"\x05_lastB\t\n" +
+	"\a_before\"\xfc\x01\n" +
+	"\bPageInfo\x12&\n" +
+	"\fstart_cursor\x18\x01 \x01(\tH\x00R\vstartCursor\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"end_cursor\x18\x02 \x01(\tH\x01R\tendCursor\x88\x01\x01\x12*\n" +
+	"\x11has_previous_page\x18\x03 \x01(\bR\x0fhasPreviousPage\x12\"\n" +
+	"\rhas_next_page\x18\x04 \x01(\bR\vhasNextPage\x12$\n" +
+	"\vtotal_count\x18\x05 \x01(\x05H\x02R\n" +
+	"totalCount\x88\x01\x01B\x0f\n" +
+	"\r_start_cursorB\r\n" +
+	"\v_end_cursorB\x0e\n" +
+	"\f_total_count\"\x0e\n" +
+	"\fGetMeRequest\"5\n" +
+	"\rGetMeResponse\x12$\n" +
+	"\x04user\x18\x01 \x01(\v2\x10.zenlist.v1.UserR\x04user\"B\n" +
+	"\x13ListProjectsRequest\x12+\n" +
+	"\x04page\x18\x01 \x01(\v2\x17.zenlist.v1.PageRequestR\x04page\"\x94\x01\n" +
+	"\x14ListProjectsResponse\x12/\n" +
+	"\bprojects\x18\x01 \x03(\v2\x13.zenlist.v1.ProjectR\bprojects\x12\x18\n" +
+	"\acursors\x18\x02 \x03(\tR\acursors\x121\n" +
+	"\tpage_info\x18\x03 \x01(\v2\x14.zenlist.v1.PageInfoR\bpageInfo\"#\n" +
+	"\x11GetProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"C\n" +
+	"\x12GetProjectResponse\x12-\n" +
+	"\aproject\x18\x01 \x01(\v2\x13.zenlist.v1.ProjectR\aproject\"\x88\x01\n" +
+	"\x14CreateProjectRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12%\n" +
+	"\vdescription\x18\x02 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x19\n" +
+	"\x05color\x18\x03 \x01(\tH\x01R\x05color\x88\x01\x01B\x0e\n" +
+	"\f_descriptionB\b\n" +
+	"\x06_color\"F\n" +
+	"\x15CreateProjectResponse\x12-\n" +
+	"\aproject\x18\x01 \x01(\v2\x13.zenlist.v1.ProjectR\aproject\"\x98\x01\n" +
+	"\x14UpdateProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x00R\vdescription\x88\x01\x01\x12\x19\n" +
+	"\x05color\x18\x04 \x01(\tH\x01R\x05color\x88\x01\x01B\x0e\n" +
+	"\f_descriptionB\b\n" +
+	"\x06_color\"F\n" +
+	"\x15UpdateProjectResponse\x12-\n" +
+	"\aproject\x18\x01 \x01(\v2\x13.zenlist.v1.ProjectR\aproject\"&\n" +
+	"\x14DeleteProjectRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"d\n" +
+	"\x15DeleteProjectResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12;\n" +
+	"\vdelete_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"deleteTime\"@\n" +
+	"\x11ListLabelsRequest\x12+\n" +
+	"\x04page\x18\x01 \x01(\v2\x17.zenlist.v1.PageRequestR\x04page\"\x8c\x01\n" +
+	"\x12ListLabelsResponse\x12)\n" +
+	"\x06labels\x18\x01 \x03(\v2\x11.zenlist.v1.LabelR\x06labels\x12\x18\n" +
+	"\acursors\x18\x02 \x03(\tR\acursors\x121\n" +
+	"\tpage_info\x18\x03 \x01(\v2\x14.zenlist.v1.PageInfoR\bpageInfo\"!\n" +
+	"\x0fGetLabelRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\";\n" +
+	"\x10GetLabelResponse\x12'\n" +
+	"\x05label\x18\x01 \x01(\v2\x11.zenlist.v1.LabelR\x05label\"(\n" +
+	"\x12CreateLabelRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\">\n" +
+	"\x13CreateLabelResponse\x12'\n" +
+	"\x05label\x18\x01 \x01(\v2\x11.zenlist.v1.LabelR\x05label\"8\n" +
+	"\x12UpdateLabelRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\">\n" +
+	"\x13UpdateLabelResponse\x12'\n" +
+	"\x05label\x18\x01 \x01(\v2\x11.zenlist.v1.LabelR\x05label\"$\n" +
+	"\x12DeleteLabelRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"b\n" +
+	"\x13DeleteLabelResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12;\n" +
+	"\vdelete_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"deleteTime\"\x8a\x02\n" +
+	"\x10ListTasksRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12)\n" +
+	"\x0eparent_task_id\x18\x02 \x01(\tH\x00R\fparentTaskId\x88\x01\x01\x122\n" +
+	"\bstatuses\x18\x03 \x03(\x0e2\x16.zenlist.v1.TaskStatusR\bstatuses\x128\n" +
+	"\n" +
+	"priorities\x18\x04 \x03(\x0e2\x18.zenlist.v1.TaskPriorityR\n" +
+	"priorities\x12+\n" +
+	"\x04page\x18\x05 \x01(\v2\x17.zenlist.v1.PageRequestR\x04pageB\x11\n" +
+	"\x0f_parent_task_id\"\x88\x01\n" +
+	"\x11ListTasksResponse\x12&\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x10.zenlist.v1.TaskR\x05tasks\x12\x18\n" +
+	"\acursors\x18\x02 \x03(\tR\acursors\x121\n" +
+	"\tpage_info\x18\x03 \x01(\v2\x14.zenlist.v1.PageInfoR\bpageInfo\" \n" +
+	"\x0eGetTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"7\n" +
+	"\x0fGetTaskResponse\x12$\n" +
+	"\x04task\x18\x01 \x01(\v2\x10.zenlist.v1.TaskR\x04task\"\xb2\x03\n" +
+	"\x11CreateTaskRequest\x12\x1d\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tR\tprojectId\x12)\n" +
+	"\x0eparent_task_id\x18\x02 \x01(\tH\x00R\fparentTaskId\x88\x01\x01\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x01R\vdescription\x88\x01\x01\x12.\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x16.zenlist.v1.TaskStatusR\x06status\x124\n" +
+	"\bpriority\x18\x06 \x01(\x0e2\x18.zenlist.v1.TaskPriorityR\bpriority\x129\n" +
+	"\n" +
+	"start_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bdue_time\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\adueTime\x12\x1b\n" +
+	"\tlabel_ids\x18\t \x03(\tR\blabelIdsB\x11\n" +
+	"\x0f_parent_task_idB\x0e\n" +
+	"\f_description\":\n" +
+	"\x12CreateTaskResponse\x12$\n" +
+	"\x04task\x18\x01 \x01(\v2\x10.zenlist.v1.TaskR\x04task\"\x1c\n" +
+	"\bLabelIds\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\tR\x03ids\"\x8a\x03\n" +
+	"\x11UpdateTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12%\n" +
+	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01\x12.\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x16.zenlist.v1.TaskStatusR\x06status\x124\n" +
+	"\bpriority\x18\x05 \x01(\x0e2\x18.zenlist.v1.TaskPriorityR\bpriority\x129\n" +
+	"\n" +
+	"start_time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tstartTime\x125\n" +
+	"\bdue_time\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\adueTime\x121\n" +
+	"\tlabel_ids\x18\b \x01(\v2\x14.zenlist.v1.LabelIdsR\blabelIdsB\b\n" +
+	"\x06_titleB\x0e\n" +
+	"\f_description\":\n" +
+	"\x12UpdateTaskResponse\x12$\n" +
+	"\x04task\x18\x01 \x01(\v2\x10.zenlist.v1.TaskR\x04task\"#\n" +
+	"\x11DeleteTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"a\n" +
+	"\x12DeleteTaskResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12;\n" +
+	"\vdelete_time\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"deleteTime\"0\n" +
+	"\x15ListTaskLabelsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"C\n" +
+	"\x16ListTaskLabelsResponse\x12)\n" +
+	"\x06labels\x18\x01 \x03(\v2\x11.zenlist.v1.LabelR\x06labels\"F\n" +
+	"\x11WatchTasksRequest\x12\"\n" +
+	"\n" +
+	"project_id\x18\x01 \x01(\tH\x00R\tprojectId\x88\x01\x01B\r\n" +
+	"\v_project_id\"\xa5\x01\n" +
+	"\x12WatchTasksResponse\x12.\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1a.zenlist.v1.TaskChangeTypeR\x04type\x12$\n" +
+	"\x04task\x18\x02 \x01(\v2\x10.zenlist.v1.TaskR\x04task\x129\n" +
+	"\n" +
+	"occur_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\toccurTime*\x8b\x01\n" +
+	"\n" +
+	"TaskStatus\x12\x1b\n" +
+	"\x17TASK_STATUS_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10TASK_STATUS_TODO\x10\x01\x12\x1b\n" +
+	"\x17TASK_STATUS_IN_PROGRESS\x10\x02\x12\x17\n" +
+	"\x13TASK_STATUS_BLOCKED\x10\x03\x12\x14\n" +
+	"\x10TASK_STATUS_DONE\x10\x04*\x9b\x01\n" +
+	"\fTaskPriority\x12\x1d\n" +
+	"\x19TASK_PRIORITY_UNSPECIFIED\x10\x00\x12\x14\n" +
+	"\x10TASK_PRIORITY_P1\x10\x01\x12\x14\n" +
+	"\x10TASK_PRIORITY_P2\x10\x02\x12\x14\n" +
+	"\x10TASK_PRIORITY_P3\x10\x03\x12\x14\n" +
+	"\x10TASK_PRIORITY_P4\x10\x04\x12\x14\n" +
+	"\x10TASK_PRIORITY_P5\x10\x05*\x8c\x01\n" +
+	"\x0eTaskChangeType\x12 \n" +
+	"\x1cTASK_CHANGE_TYPE_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18TASK_CHANGE_TYPE_CREATED\x10\x01\x12\x1c\n" +
+	"\x18TASK_CHANGE_TYPE_UPDATED\x10\x02\x12\x1c\n" +
+	"\x18TASK_CHANGE_TYPE_DELETED\x10\x032\x91\v\n" +
+	"\x0eZenlistService\x12<\n" +
+	"\x05GetMe\x12\x18.zenlist.v1.GetMeRequest\x1a\x19.zenlist.v1.GetMeResponse\x12Q\n" +
+	"\fListProjects\x12\x1f.zenlist.v1.ListProjectsRequest\x1a .zenlist.v1.ListProjectsResponse\x12K\n" +
+	"\n" +
+	"GetProject\x12\x1d.zenlist.v1.GetProjectRequest\x1a\x1e.zenlist.v1.GetProjectResponse\x12T\n" +
+	"\rCreateProject\x12 .zenlist.v1.CreateProjectRequest\x1a!.zenlist.v1.CreateProjectResponse\x12T\n" +
+	"\rUpdateProject\x12 .zenlist.v1.UpdateProjectRequest\x1a!.zenlist.v1.UpdateProjectResponse\x12T\n" +
+	"\rDeleteProject\x12 .zenlist.v1.DeleteProjectRequest\x1a!.zenlist.v1.DeleteProjectResponse\x12K\n" +
+	"\n" +
+	"ListLabels\x12\x1d.zenlist.v1.ListLabelsRequest\x1a\x1e.zenlist.v1.ListLabelsResponse\x12E\n" +
+	"\bGetLabel\x12\x1b.zenlist.v1.GetLabelRequest\x1a\x1c.zenlist.v1.GetLabelResponse\x12N\n" +
+	"\vCreateLabel\x12\x1e.zenlist.v1.CreateLabelRequest\x1a\x1f.zenlist.v1.CreateLabelResponse\x12N\n" +
+	"\vUpdateLabel\x12\x1e.zenlist.v1.UpdateLabelRequest\x1a\x1f.zenlist.v1.UpdateLabelResponse\x12N\n" +
+	"\vDeleteLabel\x12\x1e.zenlist.v1.DeleteLabelRequest\x1a\x1f.zenlist.v1.DeleteLabelResponse\x12H\n" +
+	"\tListTasks\x12\x1c.zenlist.v1.ListTasksRequest\x1a\x1d.zenlist.v1.ListTasksResponse\x12B\n" +
+	"\aGetTask\x12\x1a.zenlist.v1.GetTaskRequest\x1a\x1b.zenlist.v1.GetTaskResponse\x12K\n" +
+	"\n" +
+	"CreateTask\x12\x1d.zenlist.v1.CreateTaskRequest\x1a\x1e.zenlist.v1.CreateTaskResponse\x12K\n" +
+	"\n" +
+	"UpdateTask\x12\x1d.zenlist.v1.UpdateTaskRequest\x1a\x1e.zenlist.v1.UpdateTaskResponse\x12K\n" +
+	"\n" +
+	"DeleteTask\x12\x1d.zenlist.v1.DeleteTaskRequest\x1a\x1e.zenlist.v1.DeleteTaskResponse\x12W\n" +
+	"\x0eListTaskLabels\x12!.zenlist.v1.ListTaskLabelsRequest\x1a\".zenlist.v1.ListTaskLabelsResponse\x12M\n" +
+	"\n" +
+	"WatchTasks\x12\x1d.zenlist.v1.WatchTasksRequest\x1a\x1e.zenlist.v1.WatchTasksResponse0\x01BOZMgithub.com/faizp/zenlist/backend/go-graphql/internal/rpc/zenlist/v1;zenlistv1b\x06proto3"
+
+var (
+	file_zenlist_v1_zenlist_proto_rawDescOnce sync.Once
+	file_zenlist_v1_zenlist_proto_rawDescData []byte
+)
+
+func file_zenlist_v1_zenlist_proto_rawDescGZIP() []byte {
+	file_zenlist_v1_zenlist_proto_rawDescOnce.Do(func() {
+		file_zenlist_v1_zenlist_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_zenlist_v1_zenlist_proto_rawDesc), len(file_zenlist_v1_zenlist_proto_rawDesc)))
+	})
+	return file_zenlist_v1_zenlist_proto_rawDescData
+}
+
+var file_zenlist_v1_zenlist_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_zenlist_v1_zenlist_proto_msgTypes = make([]protoimpl.MessageInfo, 43)
+var file_zenlist_v1_zenlist_proto_goTypes = []any{
+	(TaskStatus)(0),                // 0: zenlist.v1.TaskStatus
+	(TaskPriority)(0),              // 1: zenlist.v1.TaskPriority
+	(TaskChangeType)(0),            // 2: zenlist.v1.TaskChangeType
+	(*User)(nil),                   // 3: zenlist.v1.User
+	(*Project)(nil),                // 4: zenlist.v1.Project
+	(*Label)(nil),                  // 5: zenlist.v1.Label
+	(*Task)(nil),                   // 6: zenlist.v1.Task
+	(*PageRequest)(nil),            // 7: zenlist.v1.PageRequest
+	(*PageInfo)(nil),               // 8: zenlist.v1.PageInfo
+	(*GetMeRequest)(nil),           // 9: zenlist.v1.GetMeRequest
+	(*GetMeResponse)(nil),          // 10: zenlist.v1.GetMeResponse
+	(*ListProjectsRequest)(nil),    // 11: zenlist.v1.ListProjectsRequest
+	(*ListProjectsResponse)(nil),   // 12: zenlist.v1.ListProjectsResponse
+	(*GetProjectRequest)(nil),      // 13: zenlist.v1.GetProjectRequest
+	(*GetProjectResponse)(nil),     // 14: zenlist.v1.GetProjectResponse
+	(*CreateProjectRequest)(nil),   // 15: zenlist.v1.CreateProjectRequest
+	(*CreateProjectResponse)(nil),  // 16: zenlist.v1.CreateProjectResponse
+	(*UpdateProjectRequest)(nil),   // 17: zenlist.v1.UpdateProjectRequest
+	(*UpdateProjectResponse)(nil),  // 18: zenlist.v1.UpdateProjectResponse
+	(*DeleteProjectRequest)(nil),   // 19: zenlist.v1.DeleteProjectRequest
+	(*DeleteProjectResponse)(nil),  // 20: zenlist.v1.DeleteProjectResponse
+	(*ListLabelsRequest)(nil),      // 21: zenlist.v1.ListLabelsRequest
+	(*ListLabelsResponse)(nil),     // 22: zenlist.v1.ListLabelsResponse
+	(*GetLabelRequest)(nil),        // 23: zenlist.v1.GetLabelRequest
+	(*GetLabelResponse)(nil),       // 24: zenlist.v1.GetLabelResponse
+	(*CreateLabelRequest)(nil),     // 25: zenlist.v1.CreateLabelRequest
+	(*CreateLabelResponse)(nil),    // 26: zenlist.v1.CreateLabelResponse
+	(*UpdateLabelRequest)(nil),     // 27: zenlist.v1.UpdateLabelRequest
+	(*UpdateLabelResponse)(nil),    // 28: zenlist.v1.UpdateLabelResponse
+	(*DeleteLabelRequest)(nil),     // 29: zenlist.v1.DeleteLabelRequest
+	(*DeleteLabelResponse)(nil),    // 30: zenlist.v1.DeleteLabelResponse
+	(*ListTasksRequest)(nil),       // 31: zenlist.v1.ListTasksRequest
+	(*ListTasksResponse)(nil),      // 32: zenlist.v1.ListTasksResponse
+	(*GetTaskRequest)(nil),         // 33: zenlist.v1.GetTaskRequest
+	(*GetTaskResponse)(nil),        // 34: zenlist.v1.GetTaskResponse
+	(*CreateTaskRequest)(nil),      // 35: zenlist.v1.CreateTaskRequest
+	(*CreateTaskResponse)(nil),     // 36: zenlist.v1.CreateTaskResponse
+	(*LabelIds)(nil),               // 37: zenlist.v1.LabelIds
+	(*UpdateTaskRequest)(nil),      // 38: zenlist.v1.UpdateTaskRequest
+	(*UpdateTaskResponse)(nil),     // 39: zenlist.v1.UpdateTaskResponse
+	(*DeleteTaskRequest)(nil),      // 40: zenlist.v1.DeleteTaskRequest
+	(*DeleteTaskResponse)(nil),     // 41: zenlist.v1.DeleteTaskResponse
+	(*ListTaskLabelsRequest)(nil),  // 42: zenlist.v1.ListTaskLabelsRequest
+	(*ListTaskLabelsResponse)(nil), // 43: zenlist.v1.ListTaskLabelsResponse
+	(*WatchTasksRequest)(nil),      // 44: zenlist.v1.WatchTasksRequest
+	(*WatchTasksResponse)(nil),     // 45: zenlist.v1.WatchTasksResponse
+	(*timestamppb.Timestamp)(nil),  // 46: google.protobuf.Timestamp
+}
+var file_zenlist_v1_zenlist_proto_depIdxs = []int32{
+	46, // 0: zenlist.v1.User.create_time:type_name -> google.protobuf.Timestamp
+	46, // 1: zenlist.v1.User.update_time:type_name -> google.protobuf.Timestamp
+	46, // 2: zenlist.v1.Project.create_time:type_name -> google.protobuf.Timestamp
+	46, // 3: zenlist.v1.Project.update_time:type_name -> google.protobuf.Timestamp
+	46, // 4: zenlist.v1.Label.create_time:type_name -> google.protobuf.Timestamp
+	46, // 5: zenlist.v1.Label.update_time:type_name -> google.protobuf.Timestamp
+	0,  // 6: zenlist.v1.Task.status:type_name -> zenlist.v1.TaskStatus
+	1,  // 7: zenlist.v1.Task.priority:type_name -> zenlist.v1.TaskPriority
+	46, // 8: zenlist.v1.Task.start_time:type_name -> google.protobuf.Timestamp
+	46, // 9: zenlist.v1.Task.due_time:type_name -> google.protobuf.Timestamp
+	46, // 10: zenlist.v1.Task.complete_time:type_name -> google.protobuf.Timestamp
+	46, // 11: zenlist.v1.Task.create_time:type_name -> google.protobuf.Timestamp
+	46, // 12: zenlist.v1.Task.update_time:type_name -> google.protobuf.Timestamp
+	3,  // 13: zenlist.v1.GetMeResponse.user:type_name -> zenlist.v1.User
+	7,  // 14: zenlist.v1.ListProjectsRequest.page:type_name -> zenlist.v1.PageRequest
+	4,  // 15: zenlist.v1.ListProjectsResponse.projects:type_name -> zenlist.v1.Project
+	8,  // 16: zenlist.v1.ListProjectsResponse.page_info:type_name -> zenlist.v1.PageInfo
+	4,  // 17: zenlist.v1.GetProjectResponse.project:type_name -> zenlist.v1.Project
+	4,  // 18: zenlist.v1.CreateProjectResponse.project:type_name -> zenlist.v1.Project
+	4,  // 19: zenlist.v1.UpdateProjectResponse.project:type_name -> zenlist.v1.Project
+	46, // 20: zenlist.v1.DeleteProjectResponse.delete_time:type_name -> google.protobuf.Timestamp
+	7,  // 21: zenlist.v1.ListLabelsRequest.page:type_name -> zenlist.v1.PageRequest
+	5,  // 22: zenlist.v1.ListLabelsResponse.labels:type_name -> zenlist.v1.Label
+	8,  // 23: zenlist.v1.ListLabelsResponse.page_info:type_name -> zenlist.v1.PageInfo
+	5,  // 24: zenlist.v1.GetLabelResponse.label:type_name -> zenlist.v1.Label
+	5,  // 25: zenlist.v1.CreateLabelResponse.label:type_name -> zenlist.v1.Label
+	5,  // 26: zenlist.v1.UpdateLabelResponse.label:type_name -> zenlist.v1.Label
+	46, // 27: zenlist.v1.DeleteLabelResponse.delete_time:type_name -> google.protobuf.Timestamp
+	0,  // 28: zenlist.v1.ListTasksRequest.statuses:type_name -> zenlist.v1.TaskStatus
+	1,  // 29: zenlist.v1.ListTasksRequest.priorities:type_name -> zenlist.v1.TaskPriority
+	7,  // 30: zenlist.v1.ListTasksRequest.page:type_name -> zenlist.v1.PageRequest
+	6,  // 31: zenlist.v1.ListTasksResponse.tasks:type_name -> zenlist.v1.Task
+	8,  // 32: zenlist.v1.ListTasksResponse.page_info:type_name -> zenlist.v1.PageInfo
+	6,  // 33: zenlist.v1.GetTaskResponse.task:type_name -> zenlist.v1.Task
+	0,  // 34: zenlist.v1.CreateTaskRequest.status:type_name -> zenlist.v1.TaskStatus
+	1,  // 35: zenlist.v1.CreateTaskRequest.priority:type_name -> zenlist.v1.TaskPriority
+	46, // 36: zenlist.v1.CreateTaskRequest.start_time:type_name -> google.protobuf.Timestamp
+	46, // 37: zenlist.v1.CreateTaskRequest.due_time:type_name -> google.protobuf.Timestamp
+	6,  // 38: zenlist.v1.CreateTaskResponse.task:type_name -> zenlist.v1.Task
+	0,  // 39: zenlist.v1.UpdateTaskRequest.status:type_name -> zenlist.v1.TaskStatus
+	1,  // 40: zenlist.v1.UpdateTaskRequest.priority:type_name -> zenlist.v1.TaskPriority
+	46, // 41: zenlist.v1.UpdateTaskRequest.start_time:type_name -> google.protobuf.Timestamp
+	46, // 42: zenlist.v1.UpdateTaskRequest.due_time:type_name -> google.protobuf.Timestamp
+	37, // 43: zenlist.v1.UpdateTaskRequest.label_ids:type_name -> zenlist.v1.LabelIds
+	6,  // 44: zenlist.v1.UpdateTaskResponse.task:type_name -> zenlist.v1.Task
+	46, // 45: zenlist.v1.DeleteTaskResponse.delete_time:type_name -> google.protobuf.Timestamp
+	5,  // 46: zenlist.v1.ListTaskLabelsResponse.labels:type_name -> zenlist.v1.Label
+	2,  // 47: zenlist.v1.WatchTasksResponse.type:type_name -> zenlist.v1.TaskChangeType
+	6,  // 48: zenlist.v1.WatchTasksResponse.task:type_name -> zenlist.v1.Task
+	46, // 49: zenlist.v1.WatchTasksResponse.occur_time:type_name -> google.protobuf.Timestamp
+	9,  // 50: zenlist.v1.ZenlistService.GetMe:input_type -> zenlist.v1.GetMeRequest
+	11, // 51: zenlist.v1.ZenlistService.ListProjects:input_type -> zenlist.v1.ListProjectsRequest
+	13, // 52: zenlist.v1.ZenlistService.GetProject:input_type -> zenlist.v1.GetProjectRequest
+	15, // 53: zenlist.v1.ZenlistService.CreateProject:input_type -> zenlist.v1.CreateProjectRequest
+	17, // 54: zenlist.v1.ZenlistService.UpdateProject:input_type -> zenlist.v1.UpdateProjectRequest
+	19, // 55: zenlist.v1.ZenlistService.DeleteProject:input_type -> zenlist.v1.DeleteProjectRequest
+	21, // 56: zenlist.v1.ZenlistService.ListLabels:input_type -> zenlist.v1.ListLabelsRequest
+	23, // 57: zenlist.v1.ZenlistService.GetLabel:input_type -> zenlist.v1.GetLabelRequest
+	25, // 58: zenlist.v1.ZenlistService.CreateLabel:input_type -> zenlist.v1.CreateLabelRequest
+	27, // 59: zenlist.v1.ZenlistService.UpdateLabel:input_type -> zenlist.v1.UpdateLabelRequest
+	29, // 60: zenlist.v1.ZenlistService.DeleteLabel:input_type -> zenlist.v1.DeleteLabelRequest
+	31, // 61: zenlist.v1.ZenlistService.ListTasks:input_type -> zenlist.v1.ListTasksRequest
+	33, // 62: zenlist.v1.ZenlistService.GetTask:input_type -> zenlist.v1.GetTaskRequest
+	35, // 63: zenlist.v1.ZenlistService.CreateTask:input_type -> zenlist.v1.CreateTaskRequest
+	38, // 64: zenlist.v1.ZenlistService.UpdateTask:input_type -> zenlist.v1.UpdateTaskRequest
+	40, // 65: zenlist.v1.ZenlistService.DeleteTask:input_type -> zenlist.v1.DeleteTaskRequest
+	42, // 66: zenlist.v1.ZenlistService.ListTaskLabels:input_type -> zenlist.v1.ListTaskLabelsRequest
+	44, // 67: zenlist.v1.ZenlistService.WatchTasks:input_type -> zenlist.v1.WatchTasksRequest
+	10, // 68: zenlist.v1.ZenlistService.GetMe:output_type -> zenlist.v1.GetMeResponse
+	12, // 69: zenlist.v1.ZenlistService.ListProjects:output_type -> zenlist.v1.ListProjectsResponse
+	14, // 70: zenlist.v1.ZenlistService.GetProject:output_type -> zenlist.v1.GetProjectResponse
+	16, // 71: zenlist.v1.ZenlistService.CreateProject:output_type -> zenlist.v1.CreateProjectResponse
+	18, // 72: zenlist.v1.ZenlistService.UpdateProject:output_type -> zenlist.v1.UpdateProjectResponse
+	20, // 73: zenlist.v1.ZenlistService.DeleteProject:output_type -> zenlist.v1.DeleteProjectResponse
+	22, // 74: zenlist.v1.ZenlistService.ListLabels:output_type -> zenlist.v1.ListLabelsResponse
+	24, // 75: zenlist.v1.ZenlistService.GetLabel:output_type -> zenlist.v1.GetLabelResponse
+	26, // 76: zenlist.v1.ZenlistService.CreateLabel:output_type -> zenlist.v1.CreateLabelResponse
+	28, // 77: zenlist.v1.ZenlistService.UpdateLabel:output_type -> zenlist.v1.UpdateLabelResponse
+	30, // 78: zenlist.v1.ZenlistService.DeleteLabel:output_type -> zenlist.v1.DeleteLabelResponse
+	32, // 79: zenlist.v1.ZenlistService.ListTasks:output_type -> zenlist.v1.ListTasksResponse
+	34, // 80: zenlist.v1.ZenlistService.GetTask:output_type -> zenlist.v1.GetTaskResponse
+	36, // 81: zenlist.v1.ZenlistService.CreateTask:output_type -> zenlist.v1.CreateTaskResponse
+	39, // 82: zenlist.v1.ZenlistService.UpdateTask:output_type -> zenlist.v1.UpdateTaskResponse
+	41, // 83: zenlist.v1.ZenlistService.DeleteTask:output_type -> zenlist.v1.DeleteTaskResponse
+	43, // 84: zenlist.v1.ZenlistService.ListTaskLabels:output_type -> zenlist.v1.ListTaskLabelsResponse
+	45, // 85: zenlist.v1.ZenlistService.WatchTasks:output_type -> zenlist.v1.WatchTasksResponse
+	68, // [68:86] is the sub-list for method output_type
+	50, // [50:68] is the sub-list for method input_type
+	50, // [50:50] is the sub-list for extension type_name
+	50, // [50:50] is the sub-list for extension extendee
+	0,  // [0:50] is the sub-list for field type_name
+}
+
+func init() { file_zenlist_v1_zenlist_proto_init() }
+func file_zenlist_v1_zenlist_proto_init() {
+	if File_zenlist_v1_zenlist_proto != nil {
+		return
+	}
+	file_zenlist_v1_zenlist_proto_msgTypes[0].OneofWrappers = []any{}
+	file_zenlist_v1_zenlist_proto_msgTypes[1].OneofWrappers = []any{}
+	file_zenlist_v1_zenlist_proto_msgTypes[3].OneofWrappers = []any{}
+	file_zenlist_v1_zenlist_proto_msgTypes[4].OneofWrappers = []any{}
+	file_zenlist_v1_zenlist_proto_msgTypes[5].OneofWrappers = []any{}
+	file_zenlist_v1_zenlist_proto_msgTypes[12].OneofWrappers = []any{}
+	file_zenlist_v1_zenlist_proto_msgTypes[14].OneofWrappers = []any{}
+	file_zenlist_v1_zenlist_proto_msgTypes[28].OneofWrappers = []any{}
+	file_zenlist_v1_zenlist_proto_msgTypes[32].OneofWrappers = []any{}
+	file_zenlist_v1_zenlist_proto_msgTypes[35].OneofWrappers = []any{}
+	file_zenlist_v1_zenlist_proto_msgTypes[41].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_zenlist_v1_zenlist_proto_rawDesc), len(file_zenlist_v1_zenlist_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   43,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_zenlist_v1_zenlist_proto_goTypes,
+		DependencyIndexes: file_zenlist_v1_zenlist_proto_depIdxs,
+		EnumInfos:         file_zenlist_v1_zenlist_proto_enumTypes,
+		MessageInfos:      file_zenlist_v1_zenlist_proto_msgTypes,
+	}.Build()
+	File_zenlist_v1_zenlist_proto = out.File
+	file_zenlist_v1_zenlist_proto_goTypes = nil
+	file_zenlist_v1_zenlist_proto_depIdxs = nil
+}
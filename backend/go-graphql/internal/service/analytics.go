@@ -0,0 +1,348 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	AnalyticsDay  = "DAY"
+	AnalyticsWeek = "WEEK"
+	// maxAnalyticsSpan bounds an analytics range; series list every bucket.
+	maxAnalyticsSpan = 366 * 24 * time.Hour
+)
+
+// AnalyticsScope selects what analytics are computed over: live tasks of
+// live projects, limited to ProjectIDs when set, bucketed in Timezone.
+type AnalyticsScope struct {
+	From       time.Time
+	To         time.Time
+	ProjectIDs []string
+	// Timezone is an IANA name; Analytics fills in the user's timezone.
+	Timezone string
+}
+
+// AnalyticsBucket is a count for one local day, or for the week starting on
+// Date (a Monday).
+type AnalyticsBucket struct {
+	Date  string
+	Count int
+}
+
+// ProjectBurndown is the number of open tasks of a project at the end of
+// each bucket.
+type ProjectBurndown struct {
+	Project sqlc.Project
+	Points  []AnalyticsBucket
+}
+
+// LeadTime summarises how long tasks completed in the range took from
+// creation to completion. The figures are 0 when CompletedCount is.
+type LeadTime struct {
+	CompletedCount int
+	AverageSeconds float64
+	MedianSeconds  float64
+}
+
+// StatusTime is the time tasks spent in a status category within the range.
+type StatusTime struct {
+	Status       string
+	TaskCount    int
+	TotalSeconds float64
+}
+
+func (t StatusTime) AverageSeconds() float64 {
+	if t.TaskCount == 0 {
+		return 0
+	}
+	return t.TotalSeconds / float64(t.TaskCount)
+}
+
+// OverdueRate counts tasks due in the range, up to now, for a priority or a
+// label, and how many of them were completed late or are still open.
+type OverdueRate struct {
+	Priority     string
+	Label        *sqlc.Label
+	DueCount     int
+	OverdueCount int
+}
+
+func (r OverdueRate) Rate() float64 {
+	if r.DueCount == 0 {
+		return 0
+	}
+	return float64(r.OverdueCount) / float64(r.DueCount)
+}
+
+// analyticsArgs are the query arguments shared by the analytics queries.
+type analyticsArgs struct {
+	userID     pgtype.UUID
+	from, to   pgtype.Timestamptz
+	projectIDs []pgtype.UUID
+	tz         string
+}
+
+// Analytics validates scope and fills in the user's timezone. The returned
+// scope is passed to the individual analytics.
+func (s *Service) Analytics(ctx context.Context, scope AnalyticsScope) (AnalyticsScope, error) {
+	if scope.Timezone == "" {
+		user, err := s.Me(ctx)
+		if err != nil {
+			return AnalyticsScope{}, err
+		}
+		scope.Timezone = user.Timezone
+		if _, err := time.LoadLocation(scope.Timezone); err != nil {
+			scope.Timezone = "UTC"
+		}
+	}
+	if _, err := s.analyticsArgs(ctx, scope); err != nil {
+		return AnalyticsScope{}, err
+	}
+	return scope, nil
+}
+
+func (s *Service) analyticsArgs(ctx context.Context, scope AnalyticsScope) (analyticsArgs, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return analyticsArgs{}, err
+	}
+
+	if !scope.From.Before(scope.To) {
+		return analyticsArgs{}, NewBadInput("analytics range is empty: from must be before to")
+	}
+	if scope.To.Sub(scope.From) > maxAnalyticsSpan {
+		return analyticsArgs{}, NewBadInput("analytics range cannot exceed 366 days")
+	}
+	if _, err := time.LoadLocation(scope.Timezone); err != nil || scope.Timezone == "" {
+		return analyticsArgs{}, NewBadInput("unknown timezone")
+	}
+	projectIDs, err := parseUUIDList(scope.ProjectIDs, "projectIds")
+	if err != nil {
+		return analyticsArgs{}, err
+	}
+	args := analyticsArgs{
+		userID:     toPgUUID(uid),
+		from:       toPgTime(&scope.From),
+		to:         toPgTime(&scope.To),
+		projectIDs: make([]pgtype.UUID, 0, len(projectIDs)),
+		tz:         scope.Timezone,
+	}
+	for _, id := range projectIDs {
+		args.projectIDs = append(args.projectIDs, toPgUUID(id))
+	}
+	return args, nil
+}
+
+// CompletedSeries counts tasks completed per day or week.
+func (s *Service) CompletedSeries(ctx context.Context, scope AnalyticsScope, interval string) ([]AnalyticsBucket, error) {
+	args, err := s.analyticsArgs(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	bucket, err := normalizeAnalyticsInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.store.Queries().CompletedTaskSeries(tctx, sqlc.CompletedTaskSeriesParams{
+		Bucket:     bucket,
+		Tz:         args.tz,
+		RangeFrom:  args.from,
+		RangeTo:    args.to,
+		UserID:     args.userID,
+		ProjectIds: args.projectIDs,
+	})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to load completed tasks")
+	}
+	out := make([]AnalyticsBucket, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, AnalyticsBucket{Date: formatPgDate(row.Bucket), Count: int(row.TaskCount)})
+	}
+	return out, nil
+}
+
+// Burndown counts each project's open tasks at the end of every day or week.
+func (s *Service) Burndown(ctx context.Context, scope AnalyticsScope, interval string) ([]ProjectBurndown, error) {
+	args, err := s.analyticsArgs(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	bucket, err := normalizeAnalyticsInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.store.Queries().ProjectBurndown(tctx, sqlc.ProjectBurndownParams{
+		UserID:     args.userID,
+		RangeFrom:  args.from,
+		ProjectIds: args.projectIDs,
+		Tz:         args.tz,
+		Bucket:     bucket,
+		RangeTo:    args.to,
+	})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to load burndown")
+	}
+	// Rows come grouped by project.
+	out := []ProjectBurndown{}
+	for _, row := range rows {
+		if n := len(out); n == 0 || out[n-1].Project.ID != row.Project.ID {
+			out = append(out, ProjectBurndown{Project: row.Project})
+		}
+		last := &out[len(out)-1]
+		last.Points = append(last.Points, AnalyticsBucket{Date: formatPgDate(row.Bucket), Count: int(row.TaskCount)})
+	}
+	return out, nil
+}
+
+func (s *Service) LeadTime(ctx context.Context, scope AnalyticsScope) (LeadTime, error) {
+	args, err := s.analyticsArgs(ctx, scope)
+	if err != nil {
+		return LeadTime{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	row, err := s.store.Queries().LeadTimeStats(tctx, sqlc.LeadTimeStatsParams{
+		UserID:     args.userID,
+		RangeFrom:  args.from,
+		RangeTo:    args.to,
+		ProjectIds: args.projectIDs,
+	})
+	if err != nil {
+		return LeadTime{}, s.wrapDBError(err, "failed to load lead time")
+	}
+	return LeadTime{
+		CompletedCount: int(row.CompletedCount),
+		AverageSeconds: row.AverageSeconds,
+		MedianSeconds:  row.MedianSeconds,
+	}, nil
+}
+
+// TimeInStatus totals the time tasks spent in each status category within
+// the range, from their recorded status changes. Time in the current status
+// runs until now.
+func (s *Service) TimeInStatus(ctx context.Context, scope AnalyticsScope) ([]StatusTime, error) {
+	args, err := s.analyticsArgs(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.store.Queries().TimeInStatus(tctx, sqlc.TimeInStatusParams{
+		RangeTo:    args.to,
+		RangeFrom:  args.from,
+		UserID:     args.userID,
+		ProjectIds: args.projectIDs,
+	})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to load time in status")
+	}
+	out := make([]StatusTime, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, StatusTime{Status: row.Status, TaskCount: int(row.TaskCount), TotalSeconds: row.TotalSeconds})
+	}
+	return out, nil
+}
+
+func (s *Service) OverdueByPriority(ctx context.Context, scope AnalyticsScope) ([]OverdueRate, error) {
+	args, err := s.analyticsArgs(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.store.Queries().OverdueByPriority(tctx, sqlc.OverdueByPriorityParams{
+		UserID:     args.userID,
+		RangeFrom:  args.from,
+		RangeTo:    args.to,
+		ProjectIds: args.projectIDs,
+	})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to load overdue rates")
+	}
+	out := make([]OverdueRate, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, OverdueRate{Priority: row.Priority, DueCount: int(row.DueCount), OverdueCount: int(row.OverdueCount)})
+	}
+	return out, nil
+}
+
+// OverdueByLabel is OverdueByPriority per label. Tasks count toward each of
+// their labels and unlabelled tasks are left out.
+func (s *Service) OverdueByLabel(ctx context.Context, scope AnalyticsScope) ([]OverdueRate, error) {
+	args, err := s.analyticsArgs(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.store.Queries().OverdueByLabel(tctx, sqlc.OverdueByLabelParams{
+		UserID:     args.userID,
+		RangeFrom:  args.from,
+		RangeTo:    args.to,
+		ProjectIds: args.projectIDs,
+	})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to load overdue rates")
+	}
+	out := make([]OverdueRate, 0, len(rows))
+	for _, row := range rows {
+		label := row.Label
+		out = append(out, OverdueRate{Label: &label, DueCount: int(row.DueCount), OverdueCount: int(row.OverdueCount)})
+	}
+	return out, nil
+}
+
+// recordStatusChange logs task entering its status when it differs from
+// previous, for time-in-status analytics.
+func (s *Service) recordStatusChange(ctx context.Context, q *sqlc.Queries, task sqlc.Task, previous string) error {
+	if task.Status == previous {
+		return nil
+	}
+	if err := q.InsertTaskStatusChange(ctx, sqlc.InsertTaskStatusChangeParams{
+		TaskID: task.ID,
+		UserID: task.UserID,
+		Status: task.Status,
+	}); err != nil {
+		return s.wrapDBError(err, "failed to record status change")
+	}
+	return nil
+}
+
+// normalizeAnalyticsInterval maps DAY or WEEK to the date_trunc field the
+// analytics queries bucket by.
+func normalizeAnalyticsInterval(v string) (string, error) {
+	switch strings.ToUpper(strings.TrimSpace(v)) {
+	case "", AnalyticsDay:
+		return "day", nil
+	case AnalyticsWeek:
+		return "week", nil
+	}
+	return "", NewBadInput("interval must be DAY or WEEK")
+}
+
+func formatPgDate(d pgtype.Date) string {
+	if !d.Valid {
+		return ""
+	}
+	return d.Time.Format(time.DateOnly)
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CalDAVCollections lists every live project with the task count and latest
+// change used to derive its CalDAV ctag.
+func (s *Service) CalDAVCollections(ctx context.Context) ([]sqlc.ListProjectSyncStatesRow, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.store.Queries().ListProjectSyncStates(tctx, toPgUUID(uid))
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to list projects")
+	}
+	return rows, nil
+}
+
+// CalDAVProject loads every live task in a project, subtasks included.
+func (s *Service) CalDAVProject(ctx context.Context, projectID string) (CalendarData, error) {
+	return s.caldavData(ctx, projectID, nil)
+}
+
+// CalDAVTask loads a single task, which must belong to projectID.
+func (s *Service) CalDAVTask(ctx context.Context, projectID string, taskID string) (CalendarData, error) {
+	tID, err := parseUUID(taskID, "task id")
+	if err != nil {
+		return CalendarData{}, err
+	}
+	pgID := toPgUUID(tID)
+	return s.caldavData(ctx, projectID, &pgID)
+}
+
+func (s *Service) caldavData(ctx context.Context, projectID string, taskID *pgtype.UUID) (CalendarData, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return CalendarData{}, err
+	}
+
+	pID, err := parseUUID(projectID, "project id")
+	if err != nil {
+		return CalendarData{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	q := s.store.Queries()
+	user, err := q.GetUserByID(tctx, toPgUUID(uid))
+	if err != nil {
+		return CalendarData{}, s.wrapDBError(err, "user not found")
+	}
+	project, err := q.GetProjectByID(tctx, sqlc.GetProjectByIDParams{ID: toPgUUID(pID), UserID: toPgUUID(uid)})
+	if err != nil {
+		return CalendarData{}, s.wrapDBError(err, "project not found")
+	}
+
+	data := CalendarData{User: user, Project: &project}
+	if taskID != nil {
+		task, err := q.GetTaskByID(tctx, sqlc.GetTaskByIDParams{ID: *taskID, UserID: toPgUUID(uid)})
+		if err != nil {
+			return CalendarData{}, s.wrapDBError(err, "task not found")
+		}
+		if task.ProjectID != project.ID {
+			return CalendarData{}, NewNotFound("task not found")
+		}
+		data.Tasks = []sqlc.Task{task}
+	} else {
+		data.Tasks, err = q.ListProjectTasks(tctx, sqlc.ListProjectTasksParams{
+			UserID:    toPgUUID(uid),
+			ProjectID: project.ID,
+			Limit:     maxProjectTasks,
+		})
+		if err != nil {
+			return CalendarData{}, s.wrapDBError(err, "failed to list tasks")
+		}
+	}
+
+	data.Labels, err = s.taskLabelNames(tctx, q, toPgUUID(uid), data.Tasks)
+	if err != nil {
+		return CalendarData{}, err
+	}
+	return data, nil
+}
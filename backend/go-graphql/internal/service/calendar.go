@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxCalendarTasks caps a feed; the most recently due tasks win.
+const maxCalendarTasks = 2000
+
+// CalendarFeedToken is returned once, when a feed is created. Only a hash of
+// Token is stored.
+type CalendarFeedToken struct {
+	Feed  sqlc.CalendarFeed
+	Token string
+	URL   string
+}
+
+// CalendarData is everything needed to render one feed.
+type CalendarData struct {
+	User    sqlc.User
+	Project *sqlc.Project
+	Tasks   []sqlc.Task
+	Labels  map[uuid.UUID][]string
+}
+
+func (s *Service) CreateCalendarFeed(ctx context.Context, projectID *string) (CalendarFeedToken, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return CalendarFeedToken{}, err
+	}
+
+	projectPg := pgtype.UUID{Valid: false}
+	if projectID != nil && strings.TrimSpace(*projectID) != "" {
+		pID, err := parseUUID(*projectID, "project id")
+		if err != nil {
+			return CalendarFeedToken{}, err
+		}
+		projectPg = toPgUUID(pID)
+	}
+
+	token, err := newCalendarToken()
+	if err != nil {
+		return CalendarFeedToken{}, NewInternal("failed to generate feed token", err)
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var feed sqlc.CalendarFeed
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		if projectPg.Valid {
+			if _, err := q.GetProjectByID(tctx, sqlc.GetProjectByIDParams{ID: projectPg, UserID: toPgUUID(uid)}); err != nil {
+				return s.wrapDBError(err, "project not found")
+			}
+		}
+
+		feed, err = q.CreateCalendarFeed(tctx, sqlc.CreateCalendarFeedParams{
+			UserID:    toPgUUID(uid),
+			ProjectID: projectPg,
+			TokenHash: hashCalendarToken(token),
+		})
+		if err != nil {
+			return s.wrapDBError(err, "failed to create calendar feed")
+		}
+		return nil
+	})
+	if err != nil {
+		return CalendarFeedToken{}, err
+	}
+
+	return CalendarFeedToken{
+		Feed:  feed,
+		Token: token,
+		URL:   s.publicBaseURL + "/calendar/" + token + ".ics",
+	}, nil
+}
+
+func (s *Service) ListCalendarFeeds(ctx context.Context) ([]sqlc.CalendarFeed, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	feeds, err := s.store.Queries().ListCalendarFeeds(tctx, toPgUUID(uid))
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to list calendar feeds")
+	}
+	return feeds, nil
+}
+
+// CalendarFeedByID returns a live calendar feed, or nil when it does not exist.
+func (s *Service) CalendarFeedByID(ctx context.Context, id string) (*sqlc.CalendarFeed, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	feedID, err := parseUUID(id, "calendar feed id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	feed, err := s.store.Queries().GetCalendarFeedByID(tctx, sqlc.GetCalendarFeedByIDParams{ID: toPgUUID(feedID), UserID: toPgUUID(uid)})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, s.wrapDBError(err, "failed to fetch calendar feed")
+	}
+	return &feed, nil
+}
+
+func (s *Service) RevokeCalendarFeed(ctx context.Context, id string) (DeleteResult, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	feedID, err := parseUUID(id, "calendar feed id")
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	revoked, err := s.store.Queries().RevokeCalendarFeed(tctx, sqlc.RevokeCalendarFeedParams{
+		ID:     toPgUUID(feedID),
+		UserID: toPgUUID(uid),
+	})
+	if err != nil {
+		return DeleteResult{}, s.wrapDBError(err, "calendar feed not found")
+	}
+	return DeleteResult{ID: fromPgUUID(revoked.ID), DeletedAt: revoked.RevokedAt.Time.UTC()}, nil
+}
+
+// CalendarFeed loads the feed identified by token. The token is the only
+// credential, so unknown, revoked and orphaned feeds all report NOT_FOUND.
+func (s *Service) CalendarFeed(ctx context.Context, token string) (CalendarData, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return CalendarData{}, NewNotFound("calendar feed not found")
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	q := s.store.Queries()
+	feed, err := q.GetCalendarFeedByTokenHash(tctx, hashCalendarToken(token))
+	if err != nil {
+		return CalendarData{}, s.wrapDBError(err, "calendar feed not found")
+	}
+
+	user, err := q.GetUserByID(tctx, feed.UserID)
+	if err != nil {
+		return CalendarData{}, s.wrapDBError(err, "calendar feed not found")
+	}
+
+	data := CalendarData{User: user}
+	if feed.ProjectID.Valid {
+		project, err := q.GetProjectByID(tctx, sqlc.GetProjectByIDParams{ID: feed.ProjectID, UserID: feed.UserID})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return CalendarData{}, NewNotFound("calendar feed not found")
+			}
+			return CalendarData{}, s.wrapDBError(err, "failed to load calendar project")
+		}
+		data.Project = &project
+	}
+
+	data.Tasks, err = q.ListScheduledTasks(tctx, sqlc.ListScheduledTasksParams{
+		UserID:  feed.UserID,
+		Column2: feed.ProjectID.Valid,
+		Column3: feed.ProjectID,
+		Limit:   maxCalendarTasks,
+	})
+	if err != nil {
+		return CalendarData{}, s.wrapDBError(err, "failed to load calendar tasks")
+	}
+	data.Labels, err = s.taskLabelNames(tctx, q, feed.UserID, data.Tasks)
+	if err != nil {
+		return CalendarData{}, err
+	}
+	return data, nil
+}
+
+// taskLabelNames maps each task to the names of its live labels.
+func (s *Service) taskLabelNames(ctx context.Context, q *sqlc.Queries, userID pgtype.UUID, tasks []sqlc.Task) (map[uuid.UUID][]string, error) {
+	labels := map[uuid.UUID][]string{}
+	if len(tasks) == 0 {
+		return labels, nil
+	}
+
+	taskIDs := make([]pgtype.UUID, 0, len(tasks))
+	for _, t := range tasks {
+		taskIDs = append(taskIDs, t.ID)
+	}
+	rows, err := q.ListLabelNamesByTaskIDs(ctx, sqlc.ListLabelNamesByTaskIDsParams{
+		Column1: taskIDs,
+		UserID:  userID,
+	})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to load task labels")
+	}
+	for _, row := range rows {
+		id := fromPgUUID(row.TaskID)
+		labels[id] = append(labels[id], row.Name)
+	}
+	return labels, nil
+}
+
+func newCalendarToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func hashCalendarToken(token string) []byte {
+	sum := sha256.Sum256([]byte(token))
+	return sum[:]
+}
@@ -0,0 +1,344 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultWeeklyMinutes = 40 * 60
+	maxWeeklyMinutes     = 7 * 24 * 60
+	maxEstimateMinutes   = 365 * 24 * 60
+	defaultWorkdayStart  = 9 * 60
+	defaultWorkdayEnd    = 17 * 60
+	// maxCapacitySpan bounds a capacity query; it lists every day.
+	maxCapacitySpan = 92 * 24 * time.Hour
+)
+
+// defaultWorkDays is Monday to Friday.
+var defaultWorkDays = []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+
+// Capacity is how much a user can work: WeeklyMinutes split evenly over
+// WorkDays. WorkdayStart and WorkdayEnd are the working hours of a work day
+// in minutes after local midnight.
+type Capacity struct {
+	WeeklyMinutes int
+	WorkDays      []time.Weekday
+	WorkdayStart  int
+	WorkdayEnd    int
+}
+
+// SetCapacityInput replaces the user's capacity. WorkDays must not be empty
+// unless WeeklyMinutes is 0. WorkdayStart and WorkdayEnd are "HH:MM"; the
+// current working hours are kept when they are nil.
+type SetCapacityInput struct {
+	WeeklyMinutes int
+	WorkDays      []time.Weekday
+	WorkdayStart  *string
+	WorkdayEnd    *string
+}
+
+// CapacityPlan compares planned effort with capacity for each day of a range.
+type CapacityPlan struct {
+	Capacity Capacity
+	Days     []CapacityDay
+}
+
+// CapacityDay is one calendar day in the user's timezone.
+type CapacityDay struct {
+	Date            string
+	CapacityMinutes int
+	PlannedMinutes  int
+	Tasks           []CapacityTask
+}
+
+// Overloaded reports whether more is planned than the day can hold.
+func (d CapacityDay) Overloaded() bool {
+	return d.PlannedMinutes > d.CapacityMinutes
+}
+
+// CapacityTask is the share of a task's estimate planned on one day.
+type CapacityTask struct {
+	Task    sqlc.Task
+	Minutes int
+}
+
+// dailyMinutes is the capacity of day: an even share of the week on work
+// days, with the minutes that do not divide evenly going to the earliest
+// work days of the week.
+func (c Capacity) dailyMinutes(day time.Weekday) int {
+	i := slices.Index(c.WorkDays, day)
+	if i < 0 {
+		return 0
+	}
+	n := len(c.WorkDays)
+	share := c.WeeklyMinutes / n
+	if i < c.WeeklyMinutes%n {
+		share++
+	}
+	return share
+}
+
+func (s *Service) Capacity(ctx context.Context) (Capacity, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return Capacity{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	return s.loadCapacity(tctx, s.store.Queries(), toPgUUID(uid))
+}
+
+func (s *Service) SetCapacity(ctx context.Context, in SetCapacityInput) (Capacity, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return Capacity{}, err
+	}
+
+	if in.WeeklyMinutes < 0 || in.WeeklyMinutes > maxWeeklyMinutes {
+		return Capacity{}, NewBadInput(fmt.Sprintf("weeklyMinutes must be between 0 and %d", maxWeeklyMinutes))
+	}
+	days := normalizeWorkDays(in.WorkDays)
+	if len(days) == 0 && in.WeeklyMinutes > 0 {
+		return Capacity{}, NewBadInput("at least one work day is required")
+	}
+	stored := make([]int16, 0, len(days))
+	for _, d := range days {
+		stored = append(stored, isoWeekday(d))
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	current, err := s.loadCapacity(tctx, s.store.Queries(), toPgUUID(uid))
+	if err != nil {
+		return Capacity{}, err
+	}
+	start, end := current.WorkdayStart, current.WorkdayEnd
+	if in.WorkdayStart != nil {
+		if start, err = parseClock(*in.WorkdayStart, "workdayStart"); err != nil {
+			return Capacity{}, err
+		}
+	}
+	if in.WorkdayEnd != nil {
+		if end, err = parseClock(*in.WorkdayEnd, "workdayEnd"); err != nil {
+			return Capacity{}, err
+		}
+	}
+	if start >= end {
+		return Capacity{}, NewBadInput("workdayStart must be before workdayEnd")
+	}
+
+	row, err := s.store.Queries().UpsertUserCapacity(tctx, sqlc.UpsertUserCapacityParams{
+		UserID:        toPgUUID(uid),
+		WeeklyMinutes: int32(in.WeeklyMinutes),
+		WorkDays:      stored,
+		WorkdayStart:  int16(start),
+		WorkdayEnd:    int16(end),
+	})
+	if err != nil {
+		return Capacity{}, s.wrapDBError(err, "failed to save capacity")
+	}
+	return capacityFromRow(row), nil
+}
+
+// CapacityPlan spreads the estimates of open tasks over their schedule and
+// totals them per day of [from, to) in the user's timezone. A task with both
+// dates is spread evenly over the work days from its start date to its due
+// date (or over every day when none of them is a work day); a task with one
+// date is planned entirely on that day.
+func (s *Service) CapacityPlan(ctx context.Context, from, to time.Time) (CapacityPlan, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return CapacityPlan{}, err
+	}
+
+	if !from.Before(to) {
+		return CapacityPlan{}, NewBadInput("capacity range is empty: from must be before to")
+	}
+	if to.Sub(from) > maxCapacitySpan {
+		return CapacityPlan{}, NewBadInput("capacity range cannot exceed 92 days")
+	}
+
+	loc, err := s.userLocation(ctx)
+	if err != nil {
+		return CapacityPlan{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	capacity, err := s.loadCapacity(tctx, s.store.Queries(), toPgUUID(uid))
+	if err != nil {
+		return CapacityPlan{}, err
+	}
+
+	// Widen the query to whole local days so a task due late on the last
+	// day is still found.
+	first, last := localDay(from, loc), localDay(to.Add(-time.Nanosecond), loc)
+	rangeFrom, rangeTo := first, last.AddDate(0, 0, 1)
+	tasks, err := s.store.Queries().ListEstimatedTasksInRange(tctx, sqlc.ListEstimatedTasksInRangeParams{
+		UserID:    toPgUUID(uid),
+		RangeFrom: toPgTime(&rangeFrom),
+		RangeTo:   toPgTime(&rangeTo),
+	})
+	if err != nil {
+		return CapacityPlan{}, s.wrapDBError(err, "failed to load estimated tasks")
+	}
+	return buildCapacityPlan(capacity, tasks, first, last, loc), nil
+}
+
+func buildCapacityPlan(capacity Capacity, tasks []sqlc.Task, first, last time.Time, loc *time.Location) CapacityPlan {
+	plan := CapacityPlan{Capacity: capacity}
+	index := map[string]int{}
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		key := day.Format(time.DateOnly)
+		index[key] = len(plan.Days)
+		plan.Days = append(plan.Days, CapacityDay{
+			Date:            key,
+			CapacityMinutes: capacity.dailyMinutes(day.Weekday()),
+			Tasks:           []CapacityTask{},
+		})
+	}
+
+	for _, t := range tasks {
+		if t.EstimateMinutes == nil {
+			continue
+		}
+		start, due := fromPgTime(t.StartAt), fromPgTime(t.DueAt)
+		if start == nil {
+			start = due
+		}
+		if due == nil {
+			due = start
+		}
+		if start == nil {
+			continue
+		}
+		startDay, dueDay := localDay(*start, loc), localDay(*due, loc)
+		if dueDay.Before(startDay) {
+			startDay, dueDay = dueDay, startDay
+		}
+
+		var days []time.Time
+		for day := startDay; !day.After(dueDay); day = day.AddDate(0, 0, 1) {
+			if slices.Contains(capacity.WorkDays, day.Weekday()) {
+				days = append(days, day)
+			}
+		}
+		if len(days) == 0 {
+			for day := startDay; !day.After(dueDay); day = day.AddDate(0, 0, 1) {
+				days = append(days, day)
+			}
+		}
+
+		estimate := int(*t.EstimateMinutes)
+		for i, day := range days {
+			share := estimate / len(days)
+			if i < estimate%len(days) {
+				share++
+			}
+			j, ok := index[day.Format(time.DateOnly)]
+			if !ok || share == 0 {
+				continue
+			}
+			plan.Days[j].PlannedMinutes += share
+			plan.Days[j].Tasks = append(plan.Days[j].Tasks, CapacityTask{Task: t, Minutes: share})
+		}
+	}
+	return plan
+}
+
+func (s *Service) loadCapacity(ctx context.Context, q *sqlc.Queries, uid pgtype.UUID) (Capacity, error) {
+	row, err := q.GetUserCapacity(ctx, uid)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return Capacity{
+				WeeklyMinutes: defaultWeeklyMinutes,
+				WorkDays:      slices.Clone(defaultWorkDays),
+				WorkdayStart:  defaultWorkdayStart,
+				WorkdayEnd:    defaultWorkdayEnd,
+			}, nil
+		}
+		return Capacity{}, s.wrapDBError(err, "failed to load capacity")
+	}
+	return capacityFromRow(row), nil
+}
+
+func capacityFromRow(row sqlc.UserCapacity) Capacity {
+	days := make([]time.Weekday, 0, len(row.WorkDays))
+	for _, d := range row.WorkDays {
+		days = append(days, time.Weekday(d%7))
+	}
+	return Capacity{
+		WeeklyMinutes: int(row.WeeklyMinutes),
+		WorkDays:      normalizeWorkDays(days),
+		WorkdayStart:  int(row.WorkdayStart),
+		WorkdayEnd:    int(row.WorkdayEnd),
+	}
+}
+
+// parseClock reads a "HH:MM" time of day as minutes after midnight. "24:00"
+// is accepted as the end of the day.
+func parseClock(v, field string) (int, error) {
+	v = strings.TrimSpace(v)
+	if v == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, NewBadInput(field + " must be a time of day as HH:MM")
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// FormatClock writes minutes after midnight as "HH:MM".
+func FormatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// normalizeWorkDays sorts days Monday first and drops duplicates.
+func normalizeWorkDays(days []time.Weekday) []time.Weekday {
+	out := make([]time.Weekday, 0, len(days))
+	for _, d := range days {
+		if !slices.Contains(out, d) {
+			out = append(out, d)
+		}
+	}
+	slices.SortFunc(out, func(a, b time.Weekday) int { return int(isoWeekday(a)) - int(isoWeekday(b)) })
+	return out
+}
+
+// isoWeekday numbers days from Monday (1) to Sunday (7).
+func isoWeekday(d time.Weekday) int16 {
+	if d == time.Sunday {
+		return 7
+	}
+	return int16(d)
+}
+
+func localDay(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+}
+
+func normalizeEstimate(minutes *int) (*int32, error) {
+	if minutes == nil {
+		return nil, nil
+	}
+	if *minutes < 1 || *minutes > maxEstimateMinutes {
+		return nil, NewBadInput(fmt.Sprintf("estimateMinutes must be between 1 and %d", maxEstimateMinutes))
+	}
+	v := int32(*minutes)
+	return &v, nil
+}
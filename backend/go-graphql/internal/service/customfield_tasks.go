@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// customFieldMatch is a CustomFieldFilter with a parsed field ID. value is
+// the trimmed input; it is read for the field's type once the field is
+// loaded.
+type customFieldMatch struct {
+	fieldID uuid.UUID
+	value   *string
+}
+
+type customFieldSort struct {
+	fieldID    uuid.UUID
+	descending bool
+}
+
+// key is the sort key cursors are fingerprinted with.
+func (s customFieldSort) key() string {
+	dir := "asc"
+	if s.descending {
+		dir = "desc"
+	}
+	return fmt.Sprintf("customField.%s:%s,created_at:%s,id:%s", s.fieldID, dir, dir, dir)
+}
+
+// listTasksByCustomFields serves ListTasks when it filters or sorts by custom
+// fields, which the generated queries do not cover. The other filters are
+// applied exactly as those queries apply them.
+func (s *Service) listTasksByCustomFields(ctx context.Context, uid, projectID uuid.UUID, parentID *uuid.UUID, f taskFilterParams, page pageQuery, fingerprint string, withTotal bool) (PageResult[sqlc.Task], error) {
+	fields, err := s.store.Queries().ListCustomFields(ctx, sqlc.ListCustomFieldsParams{ProjectID: toPgUUID(projectID), UserID: toPgUUID(uid)})
+	if err != nil {
+		return PageResult[sqlc.Task]{}, s.wrapDBError(err, "failed to load custom fields")
+	}
+	lookup := func(id uuid.UUID) (sqlc.CustomField, error) {
+		i := slices.IndexFunc(fields, func(f sqlc.CustomField) bool { return fromPgUUID(f.ID) == id })
+		if i < 0 {
+			return sqlc.CustomField{}, NewBadInput("custom field " + id.String() + " is not a field of this project")
+		}
+		return fields[i], nil
+	}
+
+	var b taskQuery
+	b.filter(uid, projectID, parentID, f)
+	for _, m := range f.customFields {
+		field, err := lookup(m.fieldID)
+		if err != nil {
+			return PageResult[sqlc.Task]{}, err
+		}
+		if err := b.matchCustomField(field, m.value); err != nil {
+			return PageResult[sqlc.Task]{}, err
+		}
+	}
+	countSQL, countArgs := b.count(), slices.Clone(b.args)
+
+	var sortField *sqlc.CustomField
+	descending := true
+	if f.sort != nil {
+		field, err := lookup(f.sort.fieldID)
+		if err != nil {
+			return PageResult[sqlc.Task]{}, err
+		}
+		sortField, descending = &field, f.sort.descending
+		if page.useCursor && (page.cursorSort == nil || page.cursorSort.FieldType != field.FieldType) {
+			return PageResult[sqlc.Task]{}, NewBadInput("cursor does not belong to this query; restart pagination without a cursor")
+		}
+	}
+	query := b.selectPage(sortField, descending, page)
+
+	pool := s.store.Pool()
+	rows, err := pool.Query(ctx, query, b.args...)
+	if err != nil {
+		return PageResult[sqlc.Task]{}, s.wrapDBError(err, "failed to list tasks")
+	}
+	var result PageResult[sqlc.Task]
+	if sortField == nil {
+		tasks, err := pgx.CollectRows(rows, pgx.RowToStructByPos[sqlc.Task])
+		if err != nil {
+			return PageResult[sqlc.Task]{}, s.wrapDBError(err, "failed to list tasks")
+		}
+		result = paginateRows(tasks, page, func(t sqlc.Task) string {
+			return s.cursors.encode(fingerprint, t.CreatedAt.Time, fromPgUUID(t.ID))
+		})
+	} else {
+		sorted, err := pgx.CollectRows(rows, pgx.RowToStructByPos[sortedTask])
+		if err != nil {
+			return PageResult[sqlc.Task]{}, s.wrapDBError(err, "failed to list tasks")
+		}
+		fieldType := sortField.FieldType
+		result = unwrapSortedPage(paginateRows(sorted, page, func(t sortedTask) string {
+			return s.cursors.encodeSorted(fingerprint, t.CreatedAt.Time, fromPgUUID(t.ID), &cursorSortValue{
+				FieldType: fieldType,
+				HasValue:  t.HasSortValue,
+				Value:     t.SortValue,
+			})
+		}))
+	}
+	if withTotal {
+		var total int64
+		if err := pool.QueryRow(ctx, countSQL, countArgs...).Scan(&total); err != nil {
+			return PageResult[sqlc.Task]{}, s.wrapDBError(err, "failed to count tasks")
+		}
+		result.TotalCount = intPtr(total)
+	}
+	return result, nil
+}
+
+// sortedTask is a task row followed by the custom field sort value it was
+// ordered by, which its cursor records.
+type sortedTask struct {
+	sqlc.Task
+	HasSortValue bool
+	SortValue    string
+}
+
+func unwrapSortedPage(page PageResult[sortedTask]) PageResult[sqlc.Task] {
+	edges := make([]Edge[sqlc.Task], 0, len(page.Edges))
+	for _, e := range page.Edges {
+		edges = append(edges, Edge[sqlc.Task]{Cursor: e.Cursor, Node: e.Node.Task})
+	}
+	return PageResult[sqlc.Task]{
+		Edges:           edges,
+		HasNextPage:     page.HasNextPage,
+		HasPreviousPage: page.HasPreviousPage,
+		StartCursor:     page.StartCursor,
+		EndCursor:       page.EndCursor,
+		TotalCount:      page.TotalCount,
+	}
+}
+
+// taskQuery accumulates the conditions and positional arguments of a task
+// listing built at run time. Tasks are aliased t.
+type taskQuery struct {
+	conds []string
+	args  []any
+}
+
+func (b *taskQuery) arg(v any) string {
+	b.args = append(b.args, v)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+func (b *taskQuery) where(format string, args ...any) {
+	b.conds = append(b.conds, fmt.Sprintf(format, args...))
+}
+
+// filter adds the conditions of ListRootTasks, or of ListSubtasks when
+// parentID is set.
+func (b *taskQuery) filter(uid, projectID uuid.UUID, parentID *uuid.UUID, f taskFilterParams) {
+	b.where("t.user_id = %s", b.arg(toPgUUID(uid)))
+	b.where("t.project_id = %s", b.arg(toPgUUID(projectID)))
+	if parentID != nil {
+		b.where("t.parent_task_id = %s", b.arg(toPgUUID(*parentID)))
+	} else {
+		b.where("t.parent_task_id IS NULL")
+	}
+	b.where("t.deleted_at IS NULL")
+
+	if len(f.statuses) > 0 {
+		b.where("t.status = ANY(%s::text[])", b.arg(f.statuses))
+	}
+	if len(f.priorities) > 0 {
+		b.where("t.priority = ANY(%s::text[])", b.arg(f.priorities))
+	}
+	if len(f.labelIDs) > 0 {
+		need := 1
+		if f.matchAllLabels {
+			need = len(f.labelIDs)
+		}
+		b.where("(SELECT COUNT(*) FROM task_labels tl WHERE tl.task_id = t.id AND tl.label_id = ANY(%s::uuid[])) >= %d", b.arg(f.labelIDs), need)
+	}
+
+	bounds := []struct {
+		cond string
+		v    pgtype.Timestamptz
+	}{
+		{"t.due_at < %s", f.dueBefore},
+		{"t.due_at >= %s", f.dueAfter},
+		{"t.start_at < %s", f.startBefore},
+		{"t.start_at >= %s", f.startAfter},
+		{"t.completed_at >= %s", f.completedFrom},
+		{"t.completed_at < %s", f.completedTo},
+		{"t.updated_at >= %s", f.updatedSince},
+	}
+	for _, bound := range bounds {
+		if bound.v.Valid {
+			b.where(bound.cond, b.arg(bound.v)+"::timestamptz")
+		}
+	}
+
+	if f.hasDueDate != nil {
+		b.where("(t.due_at IS NOT NULL) = %s::boolean", b.arg(*f.hasDueDate))
+	}
+	if f.titlePattern != nil {
+		b.where("t.title ILIKE %s::text", b.arg(*f.titlePattern))
+	}
+	if f.sectionID.Valid {
+		b.where("t.section_id = %s::uuid", b.arg(f.sectionID))
+	}
+	if f.statusID.Valid {
+		b.where("t.status_id = %s::uuid", b.arg(f.statusID))
+	}
+}
+
+// matchCustomField keeps tasks whose value for field equals value, or that
+// have none when value is nil.
+func (b *taskQuery) matchCustomField(field sqlc.CustomField, value *string) error {
+	if value == nil {
+		b.where("NOT EXISTS (SELECT 1 FROM task_custom_field_values v WHERE v.task_id = t.id AND v.field_id = %s::uuid)", b.arg(field.ID))
+		return nil
+	}
+	parsed, err := parseCustomFieldValue(field, *value)
+	if err != nil {
+		return err
+	}
+	b.where("EXISTS (SELECT 1 FROM task_custom_field_values v WHERE v.task_id = t.id AND v.field_id = %s::uuid AND v.value = %s::text)", b.arg(field.ID), b.arg(parsed.Text))
+	return nil
+}
+
+func (b *taskQuery) count() string {
+	return "SELECT COUNT(*) FROM tasks t WHERE " + strings.Join(b.conds, " AND ")
+}
+
+// selectPage returns the query for one page, newest first or ordered by
+// sort's values. The sort key leads with whether the task has a value, so
+// tasks without one come last in both directions. Sorted pages also select
+// the sort value for the cursor, and a cursor is compared with the value it
+// recorded rather than the current state of the task it points at, which may
+// since have changed or gone. page.cursorSort must be set for sorted pages
+// that use a cursor.
+func (b *taskQuery) selectPage(sort *sqlc.CustomField, descending bool, page pageQuery) string {
+	columns := filterTaskColumns
+	from := "tasks t"
+	key := []string{"t.created_at", "t.id"}
+	var cursorKey string
+	if sort != nil {
+		field := b.arg(sort.ID)
+		from += fmt.Sprintf(" LEFT JOIN task_custom_field_values sv ON sv.task_id = t.id AND sv.field_id = %s::uuid", field)
+		key = append([]string{hasNoValue("sv", descending), customFieldSortExpr("sv", sort.FieldType)}, key...)
+		columns += fmt.Sprintf(", (sv.task_id IS NOT NULL), (%s)::text", customFieldSortExpr("sv", sort.FieldType))
+		if page.useCursor {
+			// hasNoValue is true first when descending, so the flag is the
+			// recorded has-value bit or its inverse.
+			noValueKey := page.cursorSort.HasValue == descending
+			cursorKey = fmt.Sprintf("(%s::boolean, %s::%s, %s::timestamptz, %s::uuid)",
+				b.arg(noValueKey), b.arg(page.cursorSort.Value), customFieldSortType(sort.FieldType), b.arg(page.cursorTime), b.arg(page.cursorID))
+		}
+	} else if page.useCursor {
+		cursorKey = fmt.Sprintf("(%s::timestamptz, %s::uuid)", b.arg(page.cursorTime), b.arg(page.cursorID))
+	}
+
+	desc := descending != page.backward
+	cmp, order := ">", "ASC"
+	if desc {
+		cmp, order = "<", "DESC"
+	}
+	conds := b.conds
+	if page.useCursor {
+		conds = append(slices.Clone(conds), fmt.Sprintf("(%s) %s %s", strings.Join(key, ", "), cmp, cursorKey))
+	}
+	orderBy := make([]string, len(key))
+	for i, k := range key {
+		orderBy[i] = k + " " + order
+	}
+	return fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY %s LIMIT %s",
+		columns, from, strings.Join(conds, " AND "), strings.Join(orderBy, ", "), b.arg(int32(page.limit+1)))
+}
+
+// hasNoValue sorts tasks without a value after those with one: false comes
+// first ascending and true first descending.
+func hasNoValue(alias string, descending bool) string {
+	if descending {
+		return fmt.Sprintf("(%s.task_id IS NOT NULL)", alias)
+	}
+	return fmt.Sprintf("(%s.task_id IS NULL)", alias)
+}
+
+// customFieldSortExpr compares values by type. Missing values get a
+// placeholder so row comparisons never see NULL; hasNoValue already orders
+// them.
+func customFieldSortExpr(alias, fieldType string) string {
+	switch fieldType {
+	case FieldNumber:
+		return fmt.Sprintf("COALESCE(%s.number_value, 0)", alias)
+	case FieldDate:
+		return fmt.Sprintf("COALESCE(%s.date_value, DATE '0001-01-01')", alias)
+	case FieldCheckbox:
+		return fmt.Sprintf("COALESCE(%s.bool_value, false)", alias)
+	}
+	return fmt.Sprintf("COALESCE(%s.value, '')", alias)
+}
+
+// customFieldSortType is the SQL type of customFieldSortExpr, which a
+// cursor's text value is cast back to.
+func customFieldSortType(fieldType string) string {
+	switch fieldType {
+	case FieldNumber:
+		return "double precision"
+	case FieldDate:
+		return "date"
+	case FieldCheckbox:
+		return "boolean"
+	}
+	return "text"
+}
@@ -0,0 +1,490 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Custom field types.
+const (
+	FieldText     = "TEXT"
+	FieldNumber   = "NUMBER"
+	FieldDate     = "DATE"
+	FieldSelect   = "SELECT"
+	FieldURL      = "URL"
+	FieldCheckbox = "CHECKBOX"
+)
+
+const (
+	maxCustomFieldOptions = 100
+	maxCustomFieldText    = 2000
+)
+
+// CustomFieldValue is a task's value for one field, in the canonical form
+// parseCustomFieldValue produces.
+type CustomFieldValue struct {
+	Field sqlc.CustomField
+	Value string
+}
+
+// customFieldValue is a parsed value: Text is what is stored and matched,
+// and the typed members are set for the field types that sort by them.
+type customFieldValue struct {
+	Text    string
+	Number  *float64
+	Date    pgtype.Date
+	Checked *bool
+}
+
+func (s *Service) CreateCustomField(ctx context.Context, in CreateCustomFieldInput) (sqlc.CustomField, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.CustomField{}, err
+	}
+
+	projectID, err := parseUUID(in.ProjectID, "project id")
+	if err != nil {
+		return sqlc.CustomField{}, err
+	}
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return sqlc.CustomField{}, NewBadInput("custom field name is required")
+	}
+	fieldType, err := normalizeFieldType(in.Type)
+	if err != nil {
+		return sqlc.CustomField{}, err
+	}
+	options, err := normalizeFieldOptions(fieldType, in.Options)
+	if err != nil {
+		return sqlc.CustomField{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var field sqlc.CustomField
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		if _, err := q.GetProjectByID(tctx, sqlc.GetProjectByIDParams{ID: toPgUUID(projectID), UserID: toPgUUID(uid)}); err != nil {
+			return s.wrapDBError(err, "project not found")
+		}
+		if err := q.LockCustomFields(tctx, toPgUUID(projectID)); err != nil {
+			return s.wrapDBError(err, "failed to lock custom fields")
+		}
+		field, err = q.CreateCustomField(tctx, sqlc.CreateCustomFieldParams{
+			UserID:    toPgUUID(uid),
+			ProjectID: toPgUUID(projectID),
+			Name:      name,
+			FieldType: fieldType,
+			Options:   options,
+			Required:  in.Required,
+		})
+		if err != nil {
+			return s.wrapDBError(err, "failed to create custom field")
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.CustomField{}, err
+	}
+	return field, nil
+}
+
+func (s *Service) UpdateCustomField(ctx context.Context, in UpdateCustomFieldInput) (sqlc.CustomField, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.CustomField{}, err
+	}
+
+	fieldID, err := parseUUID(in.ID, "custom field id")
+	if err != nil {
+		return sqlc.CustomField{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var field sqlc.CustomField
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		existing, err := q.GetCustomFieldByID(tctx, sqlc.GetCustomFieldByIDParams{ID: toPgUUID(fieldID), UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "custom field not found")
+		}
+		if err := q.LockCustomFields(tctx, existing.ProjectID); err != nil {
+			return s.wrapDBError(err, "failed to lock custom fields")
+		}
+		// Re-read under the lock; a concurrent move may have shifted it.
+		existing, err = q.GetCustomFieldByID(tctx, sqlc.GetCustomFieldByIDParams{ID: toPgUUID(fieldID), UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "custom field not found")
+		}
+
+		name := existing.Name
+		if in.Name != nil {
+			name = strings.TrimSpace(*in.Name)
+			if name == "" {
+				return NewBadInput("custom field name cannot be empty")
+			}
+		}
+
+		options := existing.Options
+		if in.Options != nil {
+			options, err = normalizeFieldOptions(existing.FieldType, in.Options)
+			if err != nil {
+				return err
+			}
+			inUse, err := q.CountLiveTasksWithValueOutside(tctx, sqlc.CountLiveTasksWithValueOutsideParams{FieldID: existing.ID, Options: options})
+			if err != nil {
+				return s.wrapDBError(err, "failed to check option usage")
+			}
+			if inUse > 0 {
+				return NewConflict(fmt.Sprintf("%d tasks use an option that would be removed", inUse), nil)
+			}
+			// Deleted tasks may still hold removed options; drop those values.
+			if err := q.DeleteCustomFieldValuesOutside(tctx, sqlc.DeleteCustomFieldValuesOutsideParams{FieldID: existing.ID, Options: options}); err != nil {
+				return s.wrapDBError(err, "failed to update custom field values")
+			}
+		}
+
+		required := existing.Required
+		if in.Required != nil {
+			required = *in.Required
+		}
+
+		position := existing.Position
+		if in.Position != nil {
+			count, err := q.CountCustomFields(tctx, existing.ProjectID)
+			if err != nil {
+				return s.wrapDBError(err, "failed to count custom fields")
+			}
+			position = int32(min(max(*in.Position, 0), int(count)-1))
+		}
+		if position != existing.Position {
+			shift := sqlc.ShiftCustomFieldsParams{ProjectID: existing.ProjectID}
+			if position > existing.Position {
+				shift.Delta, shift.FromPosition, shift.ToPosition = -1, existing.Position+1, position
+			} else {
+				shift.Delta, shift.FromPosition, shift.ToPosition = 1, position, existing.Position-1
+			}
+			if err := q.ShiftCustomFields(tctx, shift); err != nil {
+				return s.wrapDBError(err, "failed to reorder custom fields")
+			}
+		}
+
+		field, err = q.UpdateCustomField(tctx, sqlc.UpdateCustomFieldParams{
+			ID:       existing.ID,
+			UserID:   toPgUUID(uid),
+			Name:     name,
+			Options:  options,
+			Required: required,
+			Position: position,
+		})
+		if err != nil {
+			return s.wrapDBError(err, "failed to update custom field")
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.CustomField{}, err
+	}
+	return field, nil
+}
+
+// DeleteCustomField removes a field and every task's value for it.
+func (s *Service) DeleteCustomField(ctx context.Context, id string) (DeleteResult, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	fieldID, err := parseUUID(id, "custom field id")
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var result DeleteResult
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		existing, err := q.GetCustomFieldByID(tctx, sqlc.GetCustomFieldByIDParams{ID: toPgUUID(fieldID), UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "custom field not found")
+		}
+		if err := q.LockCustomFields(tctx, existing.ProjectID); err != nil {
+			return s.wrapDBError(err, "failed to lock custom fields")
+		}
+
+		deleted, err := q.SoftDeleteCustomField(tctx, sqlc.SoftDeleteCustomFieldParams{ID: existing.ID, UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "custom field not found")
+		}
+		if err := q.DeleteCustomFieldValues(tctx, deleted.ID); err != nil {
+			return s.wrapDBError(err, "failed to delete custom field values")
+		}
+		if err := q.ShiftCustomFields(tctx, sqlc.ShiftCustomFieldsParams{
+			Delta:        -1,
+			ProjectID:    deleted.ProjectID,
+			FromPosition: deleted.Position + 1,
+			ToPosition:   math.MaxInt32,
+		}); err != nil {
+			return s.wrapDBError(err, "failed to reorder custom fields")
+		}
+
+		result = DeleteResult{ID: fromPgUUID(deleted.ID), DeletedAt: deleted.DeletedAt.Time.UTC()}
+		return nil
+	})
+	if err != nil {
+		return DeleteResult{}, err
+	}
+	return result, nil
+}
+
+// ProjectCustomFields lists a project's custom fields in order.
+func (s *Service) ProjectCustomFields(ctx context.Context, projectID string) ([]sqlc.CustomField, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pid, err := parseUUID(projectID, "project id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	fields, err := s.store.Queries().ListCustomFields(tctx, sqlc.ListCustomFieldsParams{ProjectID: toPgUUID(pid), UserID: toPgUUID(uid)})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to list custom fields")
+	}
+	return fields, nil
+}
+
+// CustomField returns a custom field, or nil when it does not exist.
+func (s *Service) CustomField(ctx context.Context, id string) (*sqlc.CustomField, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldID, err := parseUUID(id, "custom field id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	field, err := s.store.Queries().GetCustomFieldByID(tctx, sqlc.GetCustomFieldByIDParams{ID: toPgUUID(fieldID), UserID: toPgUUID(uid)})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, s.wrapDBError(err, "failed to fetch custom field")
+	}
+	return &field, nil
+}
+
+// TaskCustomFieldValuesBatch returns the set custom field values of each task
+// in taskIDs, keyed by the canonical task ID and in field order, in one query.
+func (s *Service) TaskCustomFieldValuesBatch(ctx context.Context, taskIDs []string) (map[string][]CustomFieldValue, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]pgtype.UUID, 0, len(taskIDs))
+	out := make(map[string][]CustomFieldValue, len(taskIDs))
+	for _, raw := range taskIDs {
+		id, err := parseUUID(raw, "task id")
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, toPgUUID(id))
+		out[id.String()] = []CustomFieldValue{}
+	}
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.store.Queries().ListTaskCustomFieldValues(tctx, sqlc.ListTaskCustomFieldValuesParams{
+		TaskIds: ids,
+		UserID:  toPgUUID(uid),
+	})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to load custom field values")
+	}
+	for _, row := range rows {
+		key := fromPgUUID(row.TaskID).String()
+		out[key] = append(out[key], CustomFieldValue{Field: row.CustomField, Value: row.Value})
+	}
+	return out, nil
+}
+
+// applyCustomFieldValues writes in to task. New tasks must also get a value
+// for every required field of the project.
+func (s *Service) applyCustomFieldValues(ctx context.Context, q *sqlc.Queries, uid uuid.UUID, task sqlc.Task, in []CustomFieldValueInput, creating bool) error {
+	if len(in) == 0 && !creating {
+		return nil
+	}
+	fields, err := q.ListCustomFields(ctx, sqlc.ListCustomFieldsParams{ProjectID: task.ProjectID, UserID: toPgUUID(uid)})
+	if err != nil {
+		return s.wrapDBError(err, "failed to load custom fields")
+	}
+
+	set := map[uuid.UUID]bool{}
+	for _, v := range in {
+		id, err := parseUUID(v.FieldID, "custom field id")
+		if err != nil {
+			return err
+		}
+		if _, ok := set[id]; ok {
+			return NewBadInput("custom field " + id.String() + " is listed twice")
+		}
+		i := slices.IndexFunc(fields, func(f sqlc.CustomField) bool { return fromPgUUID(f.ID) == id })
+		if i < 0 {
+			return NewBadInput("custom field " + id.String() + " is not a field of this project")
+		}
+		field := fields[i]
+
+		if v.Value == nil || strings.TrimSpace(*v.Value) == "" {
+			if field.Required {
+				return NewBadInput(fmt.Sprintf("custom field %q is required", field.Name))
+			}
+			set[id] = false
+			if err := q.DeleteTaskCustomFieldValue(ctx, sqlc.DeleteTaskCustomFieldValueParams{TaskID: task.ID, FieldID: field.ID}); err != nil {
+				return s.wrapDBError(err, "failed to clear custom field")
+			}
+			continue
+		}
+
+		parsed, err := parseCustomFieldValue(field, *v.Value)
+		if err != nil {
+			return err
+		}
+		set[id] = true
+		if err := q.UpsertTaskCustomFieldValue(ctx, sqlc.UpsertTaskCustomFieldValueParams{
+			TaskID:      task.ID,
+			FieldID:     field.ID,
+			Value:       parsed.Text,
+			NumberValue: parsed.Number,
+			DateValue:   parsed.Date,
+			BoolValue:   parsed.Checked,
+		}); err != nil {
+			return s.wrapDBError(err, "failed to save custom field")
+		}
+	}
+
+	if creating {
+		for _, f := range fields {
+			if f.Required && !set[fromPgUUID(f.ID)] {
+				return NewBadInput(fmt.Sprintf("custom field %q is required", f.Name))
+			}
+		}
+	}
+	return nil
+}
+
+// parseCustomFieldValue validates raw for field and returns its canonical
+// form: trimmed text, numbers without trailing zeros, dates as YYYY-MM-DD,
+// checkboxes as true/false and select values spelled as the option is.
+func parseCustomFieldValue(field sqlc.CustomField, raw string) (customFieldValue, error) {
+	raw = strings.TrimSpace(raw)
+	invalid := func(want string) error {
+		return NewBadInput(fmt.Sprintf("custom field %q needs %s", field.Name, want))
+	}
+
+	switch field.FieldType {
+	case FieldText:
+		if len(raw) > maxCustomFieldText {
+			return customFieldValue{}, invalid(fmt.Sprintf("at most %d characters", maxCustomFieldText))
+		}
+		return customFieldValue{Text: raw}, nil
+	case FieldNumber:
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return customFieldValue{}, invalid("a number")
+		}
+		return customFieldValue{Text: strconv.FormatFloat(n, 'f', -1, 64), Number: &n}, nil
+	case FieldDate:
+		d, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			return customFieldValue{}, invalid("a date as YYYY-MM-DD")
+		}
+		return customFieldValue{Text: d.Format(time.DateOnly), Date: pgtype.Date{Time: d, Valid: true}}, nil
+	case FieldSelect:
+		for _, opt := range field.Options {
+			if strings.EqualFold(opt, raw) {
+				return customFieldValue{Text: opt}, nil
+			}
+		}
+		return customFieldValue{}, invalid("one of its options")
+	case FieldURL:
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(raw) > maxCustomFieldText {
+			return customFieldValue{}, invalid("an http or https URL")
+		}
+		return customFieldValue{Text: raw}, nil
+	case FieldCheckbox:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return customFieldValue{}, invalid("true or false")
+		}
+		return customFieldValue{Text: strconv.FormatBool(b), Checked: &b}, nil
+	}
+	return customFieldValue{}, NewBadInput(fmt.Sprintf("custom field %q has unknown type %s", field.Name, field.FieldType))
+}
+
+func normalizeFieldType(v string) (string, error) {
+	t := strings.ToUpper(strings.TrimSpace(v))
+	switch t {
+	case FieldText, FieldNumber, FieldDate, FieldSelect, FieldURL, FieldCheckbox:
+		return t, nil
+	}
+	return "", NewBadInput("invalid custom field type")
+}
+
+// normalizeFieldOptions trims a SELECT field's options, which must be
+// non-empty and unique ignoring case. Other types take no options.
+func normalizeFieldOptions(fieldType string, in []string) ([]string, error) {
+	if fieldType != FieldSelect {
+		if len(in) > 0 {
+			return nil, NewBadInput("only SELECT fields have options")
+		}
+		return []string{}, nil
+	}
+	if len(in) > maxCustomFieldOptions {
+		return nil, NewBadInput(fmt.Sprintf("a field can have at most %d options", maxCustomFieldOptions))
+	}
+	out := make([]string, 0, len(in))
+	for _, opt := range in {
+		opt = strings.TrimSpace(opt)
+		if opt == "" {
+			return nil, NewBadInput("options cannot be empty")
+		}
+		if slices.ContainsFunc(out, func(o string) bool { return strings.EqualFold(o, opt) }) {
+			return nil, NewBadInput(fmt.Sprintf("option %q is listed twice", opt))
+		}
+		out = append(out, opt)
+	}
+	if len(out) == 0 {
+		return nil, NewBadInput("SELECT fields need at least one option")
+	}
+	return out, nil
+}
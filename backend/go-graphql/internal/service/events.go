@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/google/uuid"
+)
+
+type TaskEventType string
+
+const (
+	TaskCreated TaskEventType = "CREATED"
+	TaskUpdated TaskEventType = "UPDATED"
+	TaskDeleted TaskEventType = "DELETED"
+)
+
+// watchBuffer bounds how far a watcher may fall behind before it is dropped.
+const watchBuffer = 64
+
+type TaskEvent struct {
+	Type       TaskEventType
+	Task       sqlc.Task
+	OccurredAt time.Time
+}
+
+// taskHub fans task changes out to watchers in this process. Changes made by
+// other API instances are not observed.
+type taskHub struct {
+	mu       sync.Mutex
+	watchers map[*taskWatcher]struct{}
+}
+
+type taskWatcher struct {
+	userID    uuid.UUID
+	projectID *uuid.UUID
+	ch        chan TaskEvent
+}
+
+func newTaskHub() *taskHub {
+	return &taskHub{watchers: make(map[*taskWatcher]struct{})}
+}
+
+func (h *taskHub) subscribe(w *taskWatcher) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.watchers[w] = struct{}{}
+}
+
+func (h *taskHub) unsubscribe(w *taskWatcher) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.watchers[w]; ok {
+		delete(h.watchers, w)
+		close(w.ch)
+	}
+}
+
+// publish never blocks: a watcher whose buffer is full is unsubscribed, which
+// closes its channel.
+func (h *taskHub) publish(ev TaskEvent) {
+	userID := fromPgUUID(ev.Task.UserID)
+	projectID := fromPgUUID(ev.Task.ProjectID)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for w := range h.watchers {
+		if w.userID != userID || (w.projectID != nil && *w.projectID != projectID) {
+			continue
+		}
+		select {
+		case w.ch <- ev:
+		default:
+			delete(h.watchers, w)
+			close(w.ch)
+		}
+	}
+}
+
+// WatchTasks streams changes to the current user's tasks, optionally limited to
+// one project, until ctx is done. The channel is closed when ctx ends or when
+// the caller falls too far behind; callers tell the two apart via ctx.Err().
+func (s *Service) WatchTasks(ctx context.Context, projectID *string) (<-chan TaskEvent, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &taskWatcher{userID: uid, ch: make(chan TaskEvent, watchBuffer)}
+	if projectID != nil {
+		pID, err := parseUUID(*projectID, "project id")
+		if err != nil {
+			return nil, err
+		}
+		project, err := s.Project(ctx, pID.String())
+		if err != nil {
+			return nil, err
+		}
+		if project == nil {
+			return nil, NewNotFound("project not found")
+		}
+		w.projectID = &pID
+	}
+
+	s.tasks.subscribe(w)
+	go func() {
+		<-ctx.Done()
+		s.tasks.unsubscribe(w)
+	}()
+	return w.ch, nil
+}
+
+func (s *Service) publishTask(eventType TaskEventType, task sqlc.Task) {
+	s.tasks.publish(TaskEvent{Type: eventType, Task: task, OccurredAt: time.Now().UTC()})
+}
@@ -0,0 +1,349 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type ExportFormat string
+
+const (
+	ExportJSON     ExportFormat = "json"
+	ExportCSV      ExportFormat = "csv"
+	ExportMarkdown ExportFormat = "markdown"
+)
+
+// ExportFormats lists every supported format.
+var ExportFormats = []ExportFormat{ExportJSON, ExportCSV, ExportMarkdown}
+
+// Extension is the file extension of a download, including the dot. CSV
+// exports are a zip with one file per entity.
+func (f ExportFormat) Extension() string {
+	switch f {
+	case ExportCSV:
+		return ".zip"
+	case ExportMarkdown:
+		return ".md"
+	default:
+		return ".json"
+	}
+}
+
+func (f ExportFormat) valid() bool {
+	for _, v := range ExportFormats {
+		if f == v {
+			return true
+		}
+	}
+	return false
+}
+
+// exportBatchSize bounds how many rows an export holds at a time.
+const exportBatchSize = 500
+
+// Export link layout (before base64url encoding):
+//
+//	version(1) | expires unix seconds(8) | user id(16) | format | hmac(16)
+//
+// Links are stateless: nothing is stored, so they cannot be revoked and
+// should be short-lived.
+const (
+	exportLinkVersion byte = 1
+	exportLinkHeaderN      = 1 + 8 + 16
+	exportLinkMACN         = 16
+)
+
+// ExportLink is a signed download URL for an export.
+type ExportLink struct {
+	URL       string
+	Format    ExportFormat
+	ExpiresAt time.Time
+}
+
+func (s *Service) CreateExportLink(ctx context.Context, format ExportFormat) (ExportLink, error) {
+	if !format.valid() {
+		return ExportLink{}, NewBadInput("unsupported export format")
+	}
+
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return ExportLink{}, err
+	}
+
+	expiresAt := time.Now().Add(s.exportLinkTTL).UTC().Truncate(time.Second)
+	token := s.signExportLink(uid, format, expiresAt)
+	return ExportLink{
+		URL:       s.publicBaseURL + "/export/" + token + format.Extension(),
+		Format:    format,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// ExportLinkFormat checks a token minted by CreateExportLink for the current
+// user. Forged, expired and foreign links all report NOT_FOUND.
+func (s *Service) ExportLinkFormat(ctx context.Context, token string) (ExportFormat, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(token))
+	if err != nil || len(buf) <= exportLinkHeaderN+exportLinkMACN || buf[0] != exportLinkVersion {
+		return "", NewNotFound("export not found")
+	}
+	payload, mac := buf[:len(buf)-exportLinkMACN], buf[len(buf)-exportLinkMACN:]
+	if !hmac.Equal(mac, s.exportMAC(payload)) {
+		return "", NewNotFound("export not found")
+	}
+
+	expiresAt := time.Unix(int64(binary.BigEndian.Uint64(payload[1:9])), 0)
+	if time.Now().After(expiresAt) {
+		return "", NewNotFound("export link has expired")
+	}
+
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return "", err
+	}
+	if uuid.UUID(payload[9:exportLinkHeaderN]) != uid {
+		return "", NewNotFound("export not found")
+	}
+
+	format := ExportFormat(payload[exportLinkHeaderN:])
+	if !format.valid() {
+		return "", NewNotFound("export not found")
+	}
+	return format, nil
+}
+
+func (s *Service) signExportLink(uid uuid.UUID, format ExportFormat, expiresAt time.Time) string {
+	buf := make([]byte, exportLinkHeaderN, exportLinkHeaderN+len(format)+exportLinkMACN)
+	buf[0] = exportLinkVersion
+	binary.BigEndian.PutUint64(buf[1:9], uint64(expiresAt.Unix()))
+	copy(buf[9:], uid[:])
+	buf = append(buf, format...)
+	buf = append(buf, s.exportMAC(buf)...)
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// exportMAC keys the HMAC with the cursor secret under a distinct label, so
+// an export link can never verify as a cursor or the other way round.
+func (s *Service) exportMAC(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.cursors.secret)
+	mac.Write([]byte("export-link\x00"))
+	mac.Write(payload)
+	return mac.Sum(nil)[:exportLinkMACN]
+}
+
+// EachProject calls fn for every live project, in id order.
+func (s *Service) EachProject(ctx context.Context, fn func(sqlc.Project) error) error {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return err
+	}
+
+	after := toPgUUID(uuid.Nil)
+	for {
+		tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+		rows, err := s.store.Queries().ExportProjects(tctx, sqlc.ExportProjectsParams{
+			UserID: toPgUUID(uid),
+			ID:     after,
+			Limit:  exportBatchSize,
+		})
+		cancel()
+		if err != nil {
+			return s.wrapDBError(err, "failed to export projects")
+		}
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		if len(rows) < exportBatchSize {
+			return nil
+		}
+		after = rows[len(rows)-1].ID
+	}
+}
+
+// EachLabel calls fn for every live label, in id order.
+func (s *Service) EachLabel(ctx context.Context, fn func(sqlc.Label) error) error {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return err
+	}
+
+	after := toPgUUID(uuid.Nil)
+	for {
+		tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+		rows, err := s.store.Queries().ExportLabels(tctx, sqlc.ExportLabelsParams{
+			UserID: toPgUUID(uid),
+			ID:     after,
+			Limit:  exportBatchSize,
+		})
+		cancel()
+		if err != nil {
+			return s.wrapDBError(err, "failed to export labels")
+		}
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		if len(rows) < exportBatchSize {
+			return nil
+		}
+		after = rows[len(rows)-1].ID
+	}
+}
+
+// EachTask calls fn for every live task and subtask in a live project, in id
+// order; subtasks can come before their parent.
+func (s *Service) EachTask(ctx context.Context, fn func(sqlc.Task) error) error {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return err
+	}
+
+	after := toPgUUID(uuid.Nil)
+	for {
+		tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+		rows, err := s.store.Queries().ExportTasks(tctx, sqlc.ExportTasksParams{
+			UserID: toPgUUID(uid),
+			ID:     after,
+			Limit:  exportBatchSize,
+		})
+		cancel()
+		if err != nil {
+			return s.wrapDBError(err, "failed to export tasks")
+		}
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		if len(rows) < exportBatchSize {
+			return nil
+		}
+		after = rows[len(rows)-1].ID
+	}
+}
+
+// EachTaskLabel calls fn for every link between a live task and a live label.
+func (s *Service) EachTaskLabel(ctx context.Context, fn func(sqlc.TaskLabel) error) error {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return err
+	}
+
+	afterTask, afterLabel := toPgUUID(uuid.Nil), toPgUUID(uuid.Nil)
+	for {
+		tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+		rows, err := s.store.Queries().ExportTaskLabels(tctx, sqlc.ExportTaskLabelsParams{
+			UserID:       toPgUUID(uid),
+			AfterTaskID:  afterTask,
+			AfterLabelID: afterLabel,
+			RowLimit:     exportBatchSize,
+		})
+		cancel()
+		if err != nil {
+			return s.wrapDBError(err, "failed to export task labels")
+		}
+		for _, row := range rows {
+			if err := fn(row); err != nil {
+				return err
+			}
+		}
+		if len(rows) < exportBatchSize {
+			return nil
+		}
+		last := rows[len(rows)-1]
+		afterTask, afterLabel = last.TaskID, last.LabelID
+	}
+}
+
+// TaskTree is a top-level task with its subtasks and the label names of
+// both.
+type TaskTree struct {
+	Task     sqlc.Task
+	Subtasks []sqlc.Task
+	Labels   map[uuid.UUID][]string
+}
+
+// EachTaskTree calls fn for every live top-level task in a project, oldest
+// first.
+func (s *Service) EachTaskTree(ctx context.Context, projectID pgtype.UUID, fn func(TaskTree) error) error {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return err
+	}
+
+	afterCreatedAt := pgtype.Timestamptz{InfinityModifier: pgtype.NegativeInfinity, Valid: true}
+	afterID := toPgUUID(uuid.Nil)
+	for {
+		trees, err := s.taskTreeBatch(ctx, toPgUUID(uid), projectID, afterCreatedAt, afterID)
+		if err != nil {
+			return err
+		}
+		for _, tree := range trees {
+			if err := fn(tree); err != nil {
+				return err
+			}
+		}
+		if len(trees) < exportBatchSize {
+			return nil
+		}
+		last := trees[len(trees)-1].Task
+		afterCreatedAt, afterID = last.CreatedAt, last.ID
+	}
+}
+
+func (s *Service) taskTreeBatch(ctx context.Context, userID pgtype.UUID, projectID pgtype.UUID, afterCreatedAt pgtype.Timestamptz, afterID pgtype.UUID) ([]TaskTree, error) {
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	q := s.store.Queries()
+	roots, err := q.ExportRootTasks(tctx, sqlc.ExportRootTasksParams{
+		UserID:         userID,
+		ProjectID:      projectID,
+		AfterCreatedAt: afterCreatedAt,
+		AfterID:        afterID,
+		RowLimit:       exportBatchSize,
+	})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to export tasks")
+	}
+	if len(roots) == 0 {
+		return nil, nil
+	}
+
+	rootIDs := make([]pgtype.UUID, 0, len(roots))
+	for _, t := range roots {
+		rootIDs = append(rootIDs, t.ID)
+	}
+	subtasks, err := q.ListSubtasksByParentIDs(tctx, sqlc.ListSubtasksByParentIDsParams{
+		UserID:  userID,
+		Column2: rootIDs,
+	})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to export subtasks")
+	}
+	labels, err := s.taskLabelNames(tctx, q, userID, append(append([]sqlc.Task{}, roots...), subtasks...))
+	if err != nil {
+		return nil, err
+	}
+
+	children := map[[16]byte][]sqlc.Task{}
+	for _, sub := range subtasks {
+		children[sub.ParentTaskID.Bytes] = append(children[sub.ParentTaskID.Bytes], sub)
+	}
+	trees := make([]TaskTree, 0, len(roots))
+	for _, t := range roots {
+		trees = append(trees, TaskTree{Task: t, Subtasks: children[t.ID.Bytes], Labels: labels})
+	}
+	return trees, nil
+}
@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/filter"
+	"github.com/jackc/pgx/v5"
+)
+
+const filterTaskColumns = "t.id, t.user_id, t.project_id, t.parent_task_id, t.title, t.description, t.status, t.priority, t.start_at, t.due_at, t.completed_at, t.created_at, t.updated_at, t.deleted_at, t.section_id, t.status_id, t.blocked_reason, t.estimate_minutes"
+
+func (s *Service) CreateSavedFilter(ctx context.Context, in CreateSavedFilterInput) (sqlc.SavedFilter, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.SavedFilter{}, err
+	}
+
+	name, expression, err := normalizeSavedFilter(in.Name, in.Expression)
+	if err != nil {
+		return sqlc.SavedFilter{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	saved, err := s.store.Queries().CreateSavedFilter(tctx, sqlc.CreateSavedFilterParams{
+		UserID:     toPgUUID(uid),
+		Name:       name,
+		Expression: expression,
+	})
+	if err != nil {
+		return sqlc.SavedFilter{}, s.wrapDBError(err, "failed to create saved filter")
+	}
+	return saved, nil
+}
+
+func (s *Service) UpdateSavedFilter(ctx context.Context, in UpdateSavedFilterInput) (sqlc.SavedFilter, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.SavedFilter{}, err
+	}
+
+	filterID, err := parseUUID(in.ID, "saved filter id")
+	if err != nil {
+		return sqlc.SavedFilter{}, err
+	}
+	name, expression, err := normalizeSavedFilter(in.Name, in.Expression)
+	if err != nil {
+		return sqlc.SavedFilter{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	saved, err := s.store.Queries().UpdateSavedFilter(tctx, sqlc.UpdateSavedFilterParams{
+		ID:         toPgUUID(filterID),
+		UserID:     toPgUUID(uid),
+		Name:       name,
+		Expression: expression,
+	})
+	if err != nil {
+		return sqlc.SavedFilter{}, s.wrapDBError(err, "saved filter not found")
+	}
+	return saved, nil
+}
+
+func (s *Service) DeleteSavedFilter(ctx context.Context, id string) (DeleteResult, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	filterID, err := parseUUID(id, "saved filter id")
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	deleted, err := s.store.Queries().SoftDeleteSavedFilter(tctx, sqlc.SoftDeleteSavedFilterParams{
+		ID:     toPgUUID(filterID),
+		UserID: toPgUUID(uid),
+	})
+	if err != nil {
+		return DeleteResult{}, s.wrapDBError(err, "saved filter not found")
+	}
+	return DeleteResult{ID: fromPgUUID(deleted.ID), DeletedAt: deleted.DeletedAt.Time.UTC()}, nil
+}
+
+func (s *Service) ListSavedFilters(ctx context.Context) ([]sqlc.SavedFilter, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	filters, err := s.store.Queries().ListSavedFilters(tctx, toPgUUID(uid))
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to list saved filters")
+	}
+	return filters, nil
+}
+
+func (s *Service) SavedFilter(ctx context.Context, id string) (*sqlc.SavedFilter, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filterID, err := parseUUID(id, "saved filter id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	saved, err := s.store.Queries().GetSavedFilterByID(tctx, sqlc.GetSavedFilterByIDParams{
+		ID:     toPgUUID(filterID),
+		UserID: toPgUUID(uid),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, s.wrapDBError(err, "failed to fetch saved filter")
+	}
+	return &saved, nil
+}
+
+// TasksByFilter lists tasks, subtasks included, matching a saved filter or an
+// ad-hoc expression, newest first. Relative dates are resolved in the user's
+// timezone on every call, so "due < +3d" moves with the calendar.
+func (s *Service) TasksByFilter(ctx context.Context, filterID *string, expression *string, args PageArgs) (PageResult[sqlc.Task], error) {
+	hasID := filterID != nil && strings.TrimSpace(*filterID) != ""
+	hasExpr := expression != nil && strings.TrimSpace(*expression) != ""
+	if hasID == hasExpr {
+		return PageResult[sqlc.Task]{}, NewBadInput("exactly one of filterId or expression is required")
+	}
+
+	var text string
+	if hasID {
+		saved, err := s.SavedFilter(ctx, *filterID)
+		if err != nil {
+			return PageResult[sqlc.Task]{}, err
+		}
+		if saved == nil {
+			return PageResult[sqlc.Task]{}, NewNotFound("saved filter not found")
+		}
+		text = saved.Expression
+	} else {
+		text = *expression
+	}
+	expr, err := parseFilter(text)
+	if err != nil {
+		return PageResult[sqlc.Task]{}, err
+	}
+
+	user, err := s.Me(ctx)
+	if err != nil {
+		return PageResult[sqlc.Task]{}, err
+	}
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	fingerprint := queryFingerprint("tasksByFilter", sortCreatedDesc, map[string][]string{
+		"expression": {expr.String()},
+	})
+	page, err := s.resolvePage(args, fingerprint, 20, 100)
+	if err != nil {
+		return PageResult[sqlc.Task]{}, err
+	}
+
+	// $1 is the user; the expression's parameters follow, then the cursor.
+	compiled := filter.Compile(expr, filter.Options{Now: time.Now(), Location: loc}, 1)
+	where := "t.user_id = $1 AND t.deleted_at IS NULL AND " + compiled.Where
+	params := append([]any{user.ID}, compiled.Args...)
+
+	cmp, order := "<", "DESC"
+	if page.backward {
+		cmp, order = ">", "ASC"
+	}
+	n := len(params)
+	query := fmt.Sprintf(
+		"SELECT %s FROM tasks t WHERE %s AND (NOT $%d::boolean OR (t.created_at, t.id) %s ($%d::timestamptz, $%d::uuid)) ORDER BY t.created_at %s, t.id %s LIMIT $%d",
+		filterTaskColumns, where, n+1, cmp, n+2, n+3, order, order, n+4,
+	)
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	pool := s.store.Pool()
+	rows, err := pool.Query(tctx, query, append(params, page.useCursor, page.cursorTime, page.cursorID, int32(page.limit+1))...)
+	if err != nil {
+		return PageResult[sqlc.Task]{}, s.wrapDBError(err, "failed to list tasks")
+	}
+	tasks, err := pgx.CollectRows(rows, pgx.RowToStructByPos[sqlc.Task])
+	if err != nil {
+		return PageResult[sqlc.Task]{}, s.wrapDBError(err, "failed to list tasks")
+	}
+
+	result := paginateRows(tasks, page, func(t sqlc.Task) string {
+		return s.cursors.encode(fingerprint, t.CreatedAt.Time, fromPgUUID(t.ID))
+	})
+	if args.WithTotal {
+		var total int64
+		if err := pool.QueryRow(tctx, "SELECT COUNT(*) FROM tasks t WHERE "+where, params...).Scan(&total); err != nil {
+			return PageResult[sqlc.Task]{}, s.wrapDBError(err, "failed to count tasks")
+		}
+		result.TotalCount = intPtr(total)
+	}
+	return result, nil
+}
+
+func normalizeSavedFilter(name, expression string) (string, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", "", NewBadInput("saved filter name is required")
+	}
+	expression = strings.TrimSpace(expression)
+	if _, err := parseFilter(expression); err != nil {
+		return "", "", err
+	}
+	return name, expression, nil
+}
+
+func parseFilter(expression string) (filter.Expr, error) {
+	expr, err := filter.Parse(expression)
+	if err != nil {
+		return nil, NewBadInput("invalid filter expression: " + err.Error())
+	}
+	return expr, nil
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/quickadd"
+	"github.com/google/uuid"
+)
+
+type QuickAddTaskInput struct {
+	Text string
+	// ProjectID is used when the text has no "#project" reference.
+	ProjectID *string
+	// CreateLabels creates "@label" references that do not exist yet instead
+	// of rejecting them.
+	CreateLabels bool
+}
+
+type QuickAddTaskResult struct {
+	Task          sqlc.Task
+	CreatedLabels []sqlc.Label
+}
+
+// QuickAddTask parses one line of text in the user's timezone and creates
+// the task with CreateTask's validation. Missing labels are created in the
+// same transaction, so a task that fails validation leaves none behind.
+func (s *Service) QuickAddTask(ctx context.Context, in QuickAddTaskInput) (QuickAddTaskResult, error) {
+	user, err := s.Me(ctx)
+	if err != nil {
+		return QuickAddTaskResult{}, err
+	}
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	q := s.store.Queries()
+	projects, err := q.ListProjectTitles(tctx, user.ID)
+	if err != nil {
+		return QuickAddTaskResult{}, s.wrapDBError(err, "failed to list projects")
+	}
+	titles := make([]string, 0, len(projects))
+	for _, p := range projects {
+		titles = append(titles, p.Title)
+	}
+
+	parsed := quickadd.Parse(in.Text, quickadd.Options{Now: time.Now(), Location: loc, Projects: titles})
+	if parsed.Title == "" {
+		return QuickAddTaskResult{}, NewBadInput("task title is required")
+	}
+
+	var projectID string
+	switch {
+	case parsed.Project != "":
+		for _, p := range projects {
+			if p.Title == parsed.Project {
+				projectID = uuid.UUID(p.ID.Bytes).String()
+				break
+			}
+		}
+	case in.ProjectID != nil && strings.TrimSpace(*in.ProjectID) != "":
+		projectID = *in.ProjectID
+	default:
+		return QuickAddTaskResult{}, NewBadInput("no project: add #project to the text or pass projectId")
+	}
+
+	var (
+		labelIDs []string
+		missing  []string
+	)
+	if len(parsed.Labels) > 0 {
+		names := make([]string, 0, len(parsed.Labels))
+		for _, name := range parsed.Labels {
+			names = append(names, strings.ToLower(name))
+		}
+		existing, err := q.GetLabelsByNames(tctx, sqlc.GetLabelsByNamesParams{UserID: user.ID, Names: names})
+		if err != nil {
+			return QuickAddTaskResult{}, s.wrapDBError(err, "failed to load labels")
+		}
+		labelIDs, missing = matchLabels(parsed.Labels, existing)
+		if len(missing) > 0 && !in.CreateLabels {
+			return QuickAddTaskResult{}, NewBadInput(fmt.Sprintf("unknown labels: %s; set createLabels to create them", strings.Join(missing, ", ")))
+		}
+	}
+
+	var recurrence *string
+	if parsed.Recurrence != "" {
+		recurrence = &parsed.Recurrence
+	}
+
+	var res QuickAddTaskResult
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		for _, name := range missing {
+			label, err := s.createLabel(tctx, q, fromPgUUID(user.ID), CreateLabelInput{Name: name})
+			if err != nil {
+				return err
+			}
+			res.CreatedLabels = append(res.CreatedLabels, label)
+			labelIDs = append(labelIDs, uuid.UUID(label.ID.Bytes).String())
+		}
+		res.Task, err = s.createTask(tctx, q, fromPgUUID(user.ID), CreateTaskInput{
+			ProjectID:  projectID,
+			Title:      parsed.Title,
+			Priority:   parsed.Priority,
+			DueAt:      parsed.DueAt,
+			LabelIDs:   labelIDs,
+			Recurrence: recurrence,
+		})
+		return err
+	})
+	if err != nil {
+		return QuickAddTaskResult{}, err
+	}
+	s.publishTask(TaskCreated, res.Task)
+	return res, nil
+}
+
+// matchLabels splits label names into the ids of existing labels and the
+// names still to be created. Label names are unique regardless of case, so
+// both lists hold each name once however it was capitalised.
+func matchLabels(names []string, existing []sqlc.Label) (ids []string, missing []string) {
+	byName := make(map[string]sqlc.Label, len(existing))
+	for _, l := range existing {
+		byName[strings.ToLower(l.Name)] = l
+	}
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		key := strings.ToLower(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if l, ok := byName[key]; ok {
+			ids = append(ids, uuid.UUID(l.ID.Bytes).String())
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	return ids, missing
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TaskProgress summarises a task's live subtasks.
+type TaskProgress struct {
+	Total int
+	Done  int
+}
+
+// Percent is Done as a whole percentage of Total, rounded down; 0 without
+// subtasks.
+func (p TaskProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+	return p.Done * 100 / p.Total
+}
+
+// TaskProgressBatch returns the subtask progress of each task in taskIDs,
+// keyed by the canonical task ID, in one query. Tasks without subtasks (or
+// not visible to the caller) get a zero TaskProgress.
+func (s *Service) TaskProgressBatch(ctx context.Context, taskIDs []string) (map[string]TaskProgress, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]pgtype.UUID, 0, len(taskIDs))
+	out := make(map[string]TaskProgress, len(taskIDs))
+	for _, raw := range taskIDs {
+		id, err := parseUUID(raw, "task id")
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, toPgUUID(id))
+		out[id.String()] = TaskProgress{}
+	}
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.store.Queries().SubtaskProgress(tctx, sqlc.SubtaskProgressParams{
+		UserID:    toPgUUID(uid),
+		ParentIds: ids,
+	})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to load subtask progress")
+	}
+	for _, row := range rows {
+		out[fromPgUUID(row.ParentTaskID).String()] = TaskProgress{Total: int(row.Total), Done: int(row.Done)}
+	}
+	return out, nil
+}
+
+// rollUpParent applies the project's auto-complete rule after sub moved from
+// category oldStatus to its current one: finishing the last open subtask
+// completes the parent, and reopening a subtask moves a DONE parent back to
+// TODO. It returns the updated parent, or nil when the parent is unchanged.
+// A parent move the transition policy forbids is skipped rather than failing
+// the subtask update; WIP limits are not checked.
+func (s *Service) rollUpParent(ctx context.Context, q *sqlc.Queries, uid uuid.UUID, sub sqlc.Task, oldStatus string) (*sqlc.Task, error) {
+	if !sub.ParentTaskID.Valid || (sub.Status == "DONE") == (oldStatus == "DONE") {
+		return nil, nil
+	}
+
+	project, err := q.GetProjectByID(ctx, sqlc.GetProjectByIDParams{ID: sub.ProjectID, UserID: toPgUUID(uid)})
+	if err != nil {
+		return nil, s.wrapDBError(err, "project not found")
+	}
+	if !project.AutoCompleteParents {
+		return nil, nil
+	}
+
+	// Lock the parent first so that two subtasks finishing at once see each
+	// other when counting what is still open.
+	if err := q.LockTask(ctx, sqlc.LockTaskParams{ID: sub.ParentTaskID, UserID: toPgUUID(uid)}); err != nil {
+		return nil, s.wrapDBError(err, "failed to lock parent task")
+	}
+	parent, err := q.GetTaskByID(ctx, sqlc.GetTaskByIDParams{ID: sub.ParentTaskID, UserID: toPgUUID(uid)})
+	if err != nil {
+		return nil, s.wrapDBError(err, "parent task not found")
+	}
+
+	target := "TODO"
+	if sub.Status == "DONE" {
+		if parent.Status == "DONE" {
+			return nil, nil
+		}
+		open, err := q.CountOpenSubtasks(ctx, sqlc.CountOpenSubtasksParams{ParentTaskID: parent.ID, UserID: toPgUUID(uid)})
+		if err != nil {
+			return nil, s.wrapDBError(err, "failed to count open subtasks")
+		}
+		if open > 0 {
+			return nil, nil
+		}
+		target = "DONE"
+	} else if parent.Status != "DONE" {
+		return nil, nil
+	}
+
+	policy, err := s.loadTransitionPolicy(ctx, q, uid, parent.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if policy.check(parent.Status, target, statusFields{
+		Description: parent.Description,
+		StartAt:     fromPgTime(parent.StartAt),
+		DueAt:       fromPgTime(parent.DueAt),
+	}) != nil {
+		return nil, nil
+	}
+	workflow, err := q.FirstProjectStatusInCategory(ctx, sqlc.FirstProjectStatusInCategoryParams{ProjectID: parent.ProjectID, UserID: toPgUUID(uid), Category: target})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, s.wrapDBError(err, "failed to resolve status")
+	}
+
+	completedAt := pgtype.Timestamptz{Valid: false}
+	if target == "DONE" {
+		now := time.Now().UTC()
+		completedAt = toPgTime(&now)
+	}
+	updated, err := q.UpdateTask(ctx, sqlc.UpdateTaskParams{
+		ID:              parent.ID,
+		UserID:          toPgUUID(uid),
+		Title:           parent.Title,
+		Description:     parent.Description,
+		Status:          target,
+		Priority:        parent.Priority,
+		StartAt:         parent.StartAt,
+		DueAt:           parent.DueAt,
+		CompletedAt:     completedAt,
+		SectionID:       parent.SectionID,
+		StatusID:        workflow.ID,
+		BlockedReason:   nil,
+		EstimateMinutes: parent.EstimateMinutes,
+	})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to update parent task")
+	}
+	if err := s.recordStatusChange(ctx, q, updated, parent.Status); err != nil {
+		return nil, err
+	}
+	if err := s.recordEvent(ctx, q, uid, EventTaskUpdated, taskPayload(updated)); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
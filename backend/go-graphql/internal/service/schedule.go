@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// maxScheduleSpan bounds an auto-schedule run.
+	maxScheduleSpan = 92 * 24 * time.Hour
+	// unestimatedBusyMinutes is how long a scheduled task without an
+	// estimate keeps the user busy.
+	unestimatedBusyMinutes = 30
+	// scheduleStep aligns proposed starts.
+	scheduleStep = 15 * time.Minute
+)
+
+// Reasons a task is left unscheduled.
+const (
+	UnscheduledNoEstimate   = "NO_ESTIMATE"
+	UnscheduledNoFreeSlot   = "NO_FREE_SLOT"
+	UnscheduledWouldMissDue = "WOULD_MISS_DUE"
+)
+
+type AutoScheduleInput struct {
+	From       time.Time
+	To         time.Time
+	ProjectIDs []string
+	// Preview returns the plan without saving it.
+	Preview bool
+}
+
+// SchedulePlan is the outcome of an auto-schedule run. When it is not a
+// preview, Scheduled holds the tasks as saved.
+type SchedulePlan struct {
+	Preview     bool
+	Scheduled   []ScheduledTask
+	Unscheduled []UnscheduledTask
+}
+
+type ScheduledTask struct {
+	Task    sqlc.Task
+	StartAt time.Time
+	EndAt   time.Time
+}
+
+type UnscheduledTask struct {
+	Task   sqlc.Task
+	Reason string
+}
+
+// slot is a half-open interval of free time.
+type slot struct {
+	start, end time.Time
+}
+
+// AutoSchedule sets startAt on open, unscheduled tasks, fitting each task's
+// estimate into the user's working hours between from and to. Time already
+// taken by scheduled tasks is left free of new work, and no task is placed
+// in the past.
+func (s *Service) AutoSchedule(ctx context.Context, in AutoScheduleInput) (SchedulePlan, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return SchedulePlan{}, err
+	}
+
+	if !in.From.Before(in.To) {
+		return SchedulePlan{}, NewBadInput("schedule range is empty: from must be before to")
+	}
+	if in.To.Sub(in.From) > maxScheduleSpan {
+		return SchedulePlan{}, NewBadInput("schedule range cannot exceed 92 days")
+	}
+	projectIDs, err := parseUUIDList(in.ProjectIDs, "projectIds")
+	if err != nil {
+		return SchedulePlan{}, err
+	}
+	pgProjectIDs := make([]pgtype.UUID, 0, len(projectIDs))
+	for _, id := range projectIDs {
+		pgProjectIDs = append(pgProjectIDs, toPgUUID(id))
+	}
+
+	loc, err := s.userLocation(ctx)
+	if err != nil {
+		return SchedulePlan{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	q := s.store.Queries()
+	capacity, err := s.loadCapacity(tctx, q, toPgUUID(uid))
+	if err != nil {
+		return SchedulePlan{}, err
+	}
+	tasks, err := q.ListUnscheduledTasks(tctx, sqlc.ListUnscheduledTasksParams{
+		UserID:     toPgUUID(uid),
+		ProjectIds: pgProjectIDs,
+	})
+	if err != nil {
+		return SchedulePlan{}, s.wrapDBError(err, "failed to load unscheduled tasks")
+	}
+	busy, err := q.ListScheduledTasksInRange(tctx, sqlc.ListScheduledTasksInRangeParams{
+		UserID:         toPgUUID(uid),
+		RangeFrom:      toPgTime(&in.From),
+		RangeTo:        toPgTime(&in.To),
+		DefaultMinutes: unestimatedBusyMinutes,
+	})
+	if err != nil {
+		return SchedulePlan{}, s.wrapDBError(err, "failed to load scheduled tasks")
+	}
+
+	from := in.From
+	if now := time.Now().UTC(); now.After(from) {
+		from = now
+	}
+	plan := planSchedule(capacity, tasks, busy, from, in.To, loc)
+	plan.Preview = in.Preview
+	if in.Preview || len(plan.Scheduled) == 0 {
+		return plan, nil
+	}
+
+	var saved []ScheduledTask
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		saved = make([]ScheduledTask, 0, len(plan.Scheduled))
+		for _, st := range plan.Scheduled {
+			updated, err := q.ScheduleTask(tctx, sqlc.ScheduleTaskParams{
+				ID:      st.Task.ID,
+				UserID:  toPgUUID(uid),
+				StartAt: toPgTime(&st.StartAt),
+			})
+			if err != nil {
+				// Scheduled or deleted since the plan was made.
+				if errors.Is(err, pgx.ErrNoRows) {
+					continue
+				}
+				return s.wrapDBError(err, "failed to schedule task")
+			}
+			if err := s.recordEvent(tctx, q, uid, EventTaskUpdated, taskPayload(updated)); err != nil {
+				return err
+			}
+			st.Task = updated
+			saved = append(saved, st)
+		}
+		return nil
+	})
+	if err != nil {
+		return SchedulePlan{}, err
+	}
+
+	for _, st := range saved {
+		s.publishTask(TaskUpdated, st.Task)
+	}
+	plan.Scheduled = saved
+	return plan, nil
+}
+
+// planSchedule places tasks one after another in the earliest free slot of
+// the working hours in [from, to) that holds the whole estimate and ends by
+// the task's due date. Tasks due soonest go first, then by priority; tasks
+// without a due date follow by priority. busy tasks take their estimate, or
+// unestimatedBusyMinutes, from their start. Scheduled tasks are returned in
+// order of their start.
+func planSchedule(capacity Capacity, tasks, busy []sqlc.Task, from, to time.Time, loc *time.Location) SchedulePlan {
+	free := workingSlots(capacity, from, to, loc)
+	for _, t := range busy {
+		start := fromPgTime(t.StartAt)
+		if start == nil {
+			continue
+		}
+		minutes := unestimatedBusyMinutes
+		if t.EstimateMinutes != nil {
+			minutes = int(*t.EstimateMinutes)
+		}
+		free = takeSlot(free, slot{*start, start.Add(time.Duration(minutes) * time.Minute)})
+	}
+
+	ordered := slices.Clone(tasks)
+	slices.SortStableFunc(ordered, compareScheduleOrder)
+
+	plan := SchedulePlan{Scheduled: []ScheduledTask{}, Unscheduled: []UnscheduledTask{}}
+	for _, t := range ordered {
+		if t.EstimateMinutes == nil {
+			plan.Unscheduled = append(plan.Unscheduled, UnscheduledTask{Task: t, Reason: UnscheduledNoEstimate})
+			continue
+		}
+		length := time.Duration(*t.EstimateMinutes) * time.Minute
+		due := fromPgTime(t.DueAt)
+		start, ok := findSlot(free, length, due)
+		if !ok {
+			reason := UnscheduledNoFreeSlot
+			if _, fits := findSlot(free, length, nil); fits && due != nil {
+				reason = UnscheduledWouldMissDue
+			}
+			plan.Unscheduled = append(plan.Unscheduled, UnscheduledTask{Task: t, Reason: reason})
+			continue
+		}
+		end := start.Add(length)
+		free = takeSlot(free, slot{start, end})
+		plan.Scheduled = append(plan.Scheduled, ScheduledTask{Task: t, StartAt: start, EndAt: end})
+	}
+	slices.SortStableFunc(plan.Scheduled, func(a, b ScheduledTask) int { return a.StartAt.Compare(b.StartAt) })
+	return plan
+}
+
+func compareScheduleOrder(a, b sqlc.Task) int {
+	switch {
+	case a.DueAt.Valid && !b.DueAt.Valid:
+		return -1
+	case !a.DueAt.Valid && b.DueAt.Valid:
+		return 1
+	case a.DueAt.Valid && !a.DueAt.Time.Equal(b.DueAt.Time):
+		return a.DueAt.Time.Compare(b.DueAt.Time)
+	case a.Priority != b.Priority:
+		if a.Priority < b.Priority {
+			return -1
+		}
+		return 1
+	}
+	return a.CreatedAt.Time.Compare(b.CreatedAt.Time)
+}
+
+// workingSlots lists the working hours of each work day, in loc, that fall
+// within [from, to).
+func workingSlots(capacity Capacity, from, to time.Time, loc *time.Location) []slot {
+	var out []slot
+	for day := localDay(from, loc); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !slices.Contains(capacity.WorkDays, day.Weekday()) {
+			continue
+		}
+		y, m, d := day.Date()
+		start := time.Date(y, m, d, capacity.WorkdayStart/60, capacity.WorkdayStart%60, 0, 0, loc)
+		end := time.Date(y, m, d, capacity.WorkdayEnd/60, capacity.WorkdayEnd%60, 0, 0, loc)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if start.Before(end) {
+			out = append(out, slot{start, end})
+		}
+	}
+	return out
+}
+
+// findSlot returns the earliest start, aligned to scheduleStep, at which
+// length fits into a free slot and ends by deadline when one is given.
+func findSlot(free []slot, length time.Duration, deadline *time.Time) (time.Time, bool) {
+	for _, f := range free {
+		start := f.start.Truncate(scheduleStep)
+		if start.Before(f.start) {
+			start = start.Add(scheduleStep)
+		}
+		end := start.Add(length)
+		if end.After(f.end) {
+			continue
+		}
+		if deadline != nil && end.After(*deadline) {
+			return time.Time{}, false
+		}
+		return start, true
+	}
+	return time.Time{}, false
+}
+
+// takeSlot removes taken from the free slots.
+func takeSlot(free []slot, taken slot) []slot {
+	out := make([]slot, 0, len(free)+1)
+	for _, f := range free {
+		if !taken.start.Before(f.end) || !f.start.Before(taken.end) {
+			out = append(out, f)
+			continue
+		}
+		if f.start.Before(taken.start) {
+			out = append(out, slot{f.start, taken.start})
+		}
+		if taken.end.Before(f.end) {
+			out = append(out, slot{taken.end, f.end})
+		}
+	}
+	return out
+}
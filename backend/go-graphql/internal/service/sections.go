@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (s *Service) CreateProjectSection(ctx context.Context, in CreateProjectSectionInput) (sqlc.ProjectSection, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.ProjectSection{}, err
+	}
+
+	projectID, err := parseUUID(in.ProjectID, "project id")
+	if err != nil {
+		return sqlc.ProjectSection{}, err
+	}
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return sqlc.ProjectSection{}, NewBadInput("section name is required")
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var section sqlc.ProjectSection
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		if _, err := q.GetProjectByID(tctx, sqlc.GetProjectByIDParams{ID: toPgUUID(projectID), UserID: toPgUUID(uid)}); err != nil {
+			return s.wrapDBError(err, "project not found")
+		}
+		if err := q.LockProjectSections(tctx, toPgUUID(projectID)); err != nil {
+			return s.wrapDBError(err, "failed to lock sections")
+		}
+		section, err = q.CreateProjectSection(tctx, sqlc.CreateProjectSectionParams{
+			UserID:    toPgUUID(uid),
+			ProjectID: toPgUUID(projectID),
+			Name:      name,
+		})
+		if err != nil {
+			return s.wrapDBError(err, "failed to create section")
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.ProjectSection{}, err
+	}
+	return section, nil
+}
+
+func (s *Service) UpdateProjectSection(ctx context.Context, in UpdateProjectSectionInput) (sqlc.ProjectSection, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.ProjectSection{}, err
+	}
+
+	sectionID, err := parseUUID(in.ID, "section id")
+	if err != nil {
+		return sqlc.ProjectSection{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var section sqlc.ProjectSection
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		existing, err := q.GetProjectSectionByID(tctx, sqlc.GetProjectSectionByIDParams{ID: toPgUUID(sectionID), UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "section not found")
+		}
+		if err := q.LockProjectSections(tctx, existing.ProjectID); err != nil {
+			return s.wrapDBError(err, "failed to lock sections")
+		}
+		// Re-read under the lock; a concurrent move may have shifted it.
+		existing, err = q.GetProjectSectionByID(tctx, sqlc.GetProjectSectionByIDParams{ID: toPgUUID(sectionID), UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "section not found")
+		}
+
+		name := existing.Name
+		if in.Name != nil {
+			name = strings.TrimSpace(*in.Name)
+			if name == "" {
+				return NewBadInput("section name cannot be empty")
+			}
+		}
+
+		position := existing.Position
+		if in.Position != nil {
+			count, err := q.CountProjectSections(tctx, existing.ProjectID)
+			if err != nil {
+				return s.wrapDBError(err, "failed to count sections")
+			}
+			var delta, from, to int32
+			position, delta, from, to = reorderShift(existing.Position, *in.Position, count)
+			if delta != 0 {
+				shift := sqlc.ShiftProjectSectionsParams{ProjectID: existing.ProjectID, Delta: delta, FromPosition: from, ToPosition: to}
+				if err := q.ShiftProjectSections(tctx, shift); err != nil {
+					return s.wrapDBError(err, "failed to reorder sections")
+				}
+			}
+		}
+
+		section, err = q.UpdateProjectSection(tctx, sqlc.UpdateProjectSectionParams{
+			ID:       existing.ID,
+			UserID:   toPgUUID(uid),
+			Name:     name,
+			Position: position,
+		})
+		if err != nil {
+			return s.wrapDBError(err, "failed to update section")
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.ProjectSection{}, err
+	}
+	return section, nil
+}
+
+// DeleteProjectSection removes a section. Its tasks move to moveTasksTo, a
+// section of the same project, or become unsectioned when it is nil.
+func (s *Service) DeleteProjectSection(ctx context.Context, id string, moveTasksTo *string) (DeleteResult, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	sectionID, err := parseUUID(id, "section id")
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var (
+		result DeleteResult
+		moved  []sqlc.Task
+	)
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		existing, err := q.GetProjectSectionByID(tctx, sqlc.GetProjectSectionByIDParams{ID: toPgUUID(sectionID), UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "section not found")
+		}
+		if err := q.LockProjectSections(tctx, existing.ProjectID); err != nil {
+			return s.wrapDBError(err, "failed to lock sections")
+		}
+
+		target := pgtype.UUID{Valid: false}
+		if moveTasksTo != nil && strings.TrimSpace(*moveTasksTo) != "" {
+			target, err = s.resolveSection(tctx, q, uid, *moveTasksTo, fromPgUUID(existing.ProjectID))
+			if err != nil {
+				return err
+			}
+			if target == existing.ID {
+				return NewBadInput("cannot move tasks to the section being deleted")
+			}
+		}
+		moved, err = q.MoveTasksToSection(tctx, sqlc.MoveTasksToSectionParams{
+			NewSectionID: target,
+			SectionID:    existing.ID,
+			UserID:       toPgUUID(uid),
+		})
+		if err != nil {
+			return s.wrapDBError(err, "failed to move section tasks")
+		}
+		for _, t := range moved {
+			if err := s.recordEvent(tctx, q, uid, EventTaskUpdated, taskPayload(t)); err != nil {
+				return err
+			}
+		}
+
+		deleted, err := q.SoftDeleteProjectSection(tctx, sqlc.SoftDeleteProjectSectionParams{ID: existing.ID, UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "section not found")
+		}
+		if err := q.ShiftProjectSections(tctx, sqlc.ShiftProjectSectionsParams{
+			Delta:        -1,
+			ProjectID:    deleted.ProjectID,
+			FromPosition: deleted.Position + 1,
+			ToPosition:   math.MaxInt32,
+		}); err != nil {
+			return s.wrapDBError(err, "failed to reorder sections")
+		}
+
+		result = DeleteResult{ID: fromPgUUID(deleted.ID), DeletedAt: deleted.DeletedAt.Time.UTC()}
+		return nil
+	})
+	if err != nil {
+		return DeleteResult{}, err
+	}
+	for _, t := range moved {
+		s.publishTask(TaskUpdated, t)
+	}
+	return result, nil
+}
+
+// ProjectSections lists a project's sections in order.
+func (s *Service) ProjectSections(ctx context.Context, projectID string) ([]sqlc.ProjectSection, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pid, err := parseUUID(projectID, "project id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	sections, err := s.store.Queries().ListProjectSections(tctx, sqlc.ListProjectSectionsParams{ProjectID: toPgUUID(pid), UserID: toPgUUID(uid)})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to list sections")
+	}
+	return sections, nil
+}
+
+// ProjectSection returns a project section, or nil when it does not exist.
+func (s *Service) ProjectSection(ctx context.Context, id string) (*sqlc.ProjectSection, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sectionID, err := parseUUID(id, "section id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	section, err := s.store.Queries().GetProjectSectionByID(tctx, sqlc.GetProjectSectionByIDParams{ID: toPgUUID(sectionID), UserID: toPgUUID(uid)})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, s.wrapDBError(err, "failed to fetch section")
+	}
+	return &section, nil
+}
+
+// resolveSection checks that raw names a live section of projectID.
+func (s *Service) resolveSection(ctx context.Context, q *sqlc.Queries, uid uuid.UUID, raw string, projectID uuid.UUID) (pgtype.UUID, error) {
+	sectionID, err := parseUUID(raw, "section id")
+	if err != nil {
+		return pgtype.UUID{}, err
+	}
+	section, err := q.GetProjectSectionByID(ctx, sqlc.GetProjectSectionByIDParams{ID: toPgUUID(sectionID), UserID: toPgUUID(uid)})
+	if err != nil {
+		return pgtype.UUID{}, s.wrapDBError(err, "section not found")
+	}
+	if fromPgUUID(section.ProjectID) != projectID {
+		return pgtype.UUID{}, NewBadInput("section must belong to the same project")
+	}
+	return section.ID, nil
+}
+
+// reorderShift clamps a requested position into [0, count) and works out how
+// the siblings must move for the item at current to land there: those in
+// [from, to] shift by delta. delta is 0 when the item stays where it is.
+// Sections and statuses share this so both reorder the same way.
+func reorderShift(current int32, requested int, count int64) (position, delta, from, to int32) {
+	position = int32(min(max(requested, 0), int(count)-1))
+	switch {
+	case position > current:
+		return position, -1, current + 1, position
+	case position < current:
+		return position, 1, position, current - 1
+	default:
+		return position, 0, 0, 0
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +22,8 @@ import (
 const (
 	defaultTaskStatus   = "TODO"
 	defaultTaskPriority = "P3"
+	// maxProjectTasks caps unpaginated listings of a whole project.
+	maxProjectTasks = 5000
 )
 
 var validStatuses = map[string]struct{}{
@@ -37,11 +42,16 @@ var validPriorities = map[string]struct{}{
 }
 
 type Service struct {
-	store          *repo.Store
-	queryTimeout   time.Duration
-	defaultUser    UpsertMeInput
-	defaultUserID  uuid.UUID
-	defaultUserSet bool
+	store                *repo.Store
+	cursors              cursorCodec
+	tasks                *taskHub
+	queryTimeout         time.Duration
+	publicBaseURL        string
+	exportLinkTTL        time.Duration
+	allowPrivateWebhooks bool
+	defaultUser          UpsertMeInput
+	defaultUserID        uuid.UUID
+	defaultUserSet       bool
 }
 
 func New(store *repo.Store, cfg config.Config) *Service {
@@ -52,8 +62,13 @@ func New(store *repo.Store, cfg config.Config) *Service {
 	}
 
 	return &Service{
-		store:        store,
-		queryTimeout: cfg.QueryTimeout,
+		store:                store,
+		cursors:              newCursorCodec(cfg.CursorSecret),
+		tasks:                newTaskHub(),
+		queryTimeout:         cfg.QueryTimeout,
+		publicBaseURL:        cfg.PublicBaseURL,
+		exportLinkTTL:        cfg.ExportLinkTTL,
+		allowPrivateWebhooks: cfg.WebhookAllowPrivate,
 		defaultUser: UpsertMeInput{
 			Name:      strings.TrimSpace(cfg.DefaultUserName),
 			Email:     strings.TrimSpace(strings.ToLower(cfg.DefaultUserEmail)),
@@ -111,6 +126,84 @@ func (s *Service) Me(ctx context.Context) (sqlc.User, error) {
 	return user, nil
 }
 
+// userLocation is the user's timezone, or UTC when it is not a known zone.
+func (s *Service) userLocation(ctx context.Context) (*time.Location, error) {
+	user, err := s.Me(ctx)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return time.UTC, nil
+	}
+	return loc, nil
+}
+
+// Node resolves a Relay global ID. It returns nil when no live entity owned by
+// the current user has that ID; otherwise the value is the sqlc row of the
+// entity, or a Template.
+func (s *Service) Node(ctx context.Context, id string) (any, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nodeID, err := parseUUID(id, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	nodeType, err := s.store.Queries().GetNodeType(tctx, sqlc.GetNodeTypeParams{
+		ID:     toPgUUID(nodeID),
+		UserID: toPgUUID(uid),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, s.wrapDBError(err, "failed to resolve node")
+	}
+
+	var node any
+	switch nodeType {
+	case "User":
+		node, err = s.Me(ctx)
+	case "Project":
+		node, err = derefNode(s.Project(ctx, id))
+	case "Label":
+		node, err = derefNode(s.Label(ctx, id))
+	case "Task":
+		node, err = derefNode(s.Task(ctx, id))
+	case "ProjectSection":
+		node, err = derefNode(s.ProjectSection(ctx, id))
+	case "ProjectStatus":
+		node, err = derefNode(s.ProjectStatus(ctx, id))
+	case "CustomField":
+		node, err = derefNode(s.CustomField(ctx, id))
+	case "TimeEntry":
+		node, err = derefNode(s.TimeEntry(ctx, id))
+	case "Template":
+		node, err = derefNode(s.Template(ctx, id))
+	case "SavedFilter":
+		node, err = derefNode(s.SavedFilter(ctx, id))
+	case "WebhookSubscription":
+		node, err = derefNode(s.WebhookSubscription(ctx, id))
+	case "WebhookDelivery":
+		node, err = derefNode(s.WebhookDelivery(ctx, id))
+	case "CalendarFeed":
+		node, err = derefNode(s.CalendarFeedByID(ctx, id))
+	default:
+		return nil, NewInternal("unknown node type", fmt.Errorf("node type %q", nodeType))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
 func (s *Service) UpsertMe(ctx context.Context, in UpsertMeInput) (sqlc.User, error) {
 	in.Name = strings.TrimSpace(in.Name)
 	in.Email = strings.TrimSpace(strings.ToLower(in.Email))
@@ -149,6 +242,23 @@ func (s *Service) CreateProject(ctx context.Context, in CreateProjectInput) (sql
 		return sqlc.Project{}, err
 	}
 
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var project sqlc.Project
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		project, err = s.createProject(tctx, q, uid, in)
+		return err
+	})
+	if err != nil {
+		return sqlc.Project{}, err
+	}
+	return project, nil
+}
+
+// createProject validates in and creates the project with its default
+// statuses in the caller's transaction.
+func (s *Service) createProject(ctx context.Context, q *sqlc.Queries, uid uuid.UUID, in CreateProjectInput) (sqlc.Project, error) {
 	in.Title = strings.TrimSpace(in.Title)
 	if in.Title == "" {
 		return sqlc.Project{}, NewBadInput("project title is required")
@@ -157,18 +267,25 @@ func (s *Service) CreateProject(ctx context.Context, in CreateProjectInput) (sql
 		return sqlc.Project{}, err
 	}
 
-	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
-	defer cancel()
-
-	project, err := s.store.Queries().CreateProject(tctx, sqlc.CreateProjectParams{
-		UserID:      toPgUUID(uid),
-		Title:       in.Title,
-		Description: in.Description,
-		Color:       in.Color,
+	project, err := q.CreateProject(ctx, sqlc.CreateProjectParams{
+		UserID:              toPgUUID(uid),
+		Title:               in.Title,
+		Description:         in.Description,
+		Color:               in.Color,
+		AutoCompleteParents: in.AutoCompleteParents,
 	})
 	if err != nil {
 		return sqlc.Project{}, s.wrapDBError(err, "failed to create project")
 	}
+	if _, err := q.CreateDefaultProjectStatuses(ctx, sqlc.CreateDefaultProjectStatusesParams{
+		UserID:    toPgUUID(uid),
+		ProjectID: project.ID,
+	}); err != nil {
+		return sqlc.Project{}, s.wrapDBError(err, "failed to create project statuses")
+	}
+	if err := s.recordEvent(ctx, q, uid, EventProjectCreated, projectPayload(project)); err != nil {
+		return sqlc.Project{}, err
+	}
 	return project, nil
 }
 
@@ -194,15 +311,65 @@ func (s *Service) UpdateProject(ctx context.Context, in UpdateProjectInput) (sql
 	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
 	defer cancel()
 
-	project, err := s.store.Queries().UpdateProject(tctx, sqlc.UpdateProjectParams{
-		ID:          toPgUUID(id),
-		UserID:      toPgUUID(uid),
-		Title:       in.Title,
-		Description: in.Description,
-		Color:       in.Color,
+	var project sqlc.Project
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		project, err = q.UpdateProject(tctx, sqlc.UpdateProjectParams{
+			ID:                  toPgUUID(id),
+			UserID:              toPgUUID(uid),
+			Title:               in.Title,
+			Description:         in.Description,
+			Color:               in.Color,
+			AutoCompleteParents: in.AutoCompleteParents,
+		})
+		if err != nil {
+			return s.wrapDBError(err, "project not found")
+		}
+		return s.recordEvent(tctx, q, uid, EventProjectUpdated, projectPayload(project))
 	})
 	if err != nil {
-		return sqlc.Project{}, s.wrapDBError(err, "project not found")
+		return sqlc.Project{}, err
+	}
+	return project, nil
+}
+
+// ArchiveProject hides a project from ListProjects and stops new tasks being
+// added to it. Its tasks are kept and can still be read and updated.
+func (s *Service) ArchiveProject(ctx context.Context, id string) (sqlc.Project, error) {
+	return s.setProjectArchived(ctx, id, true)
+}
+
+func (s *Service) UnarchiveProject(ctx context.Context, id string) (sqlc.Project, error) {
+	return s.setProjectArchived(ctx, id, false)
+}
+
+func (s *Service) setProjectArchived(ctx context.Context, id string, archived bool) (sqlc.Project, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.Project{}, err
+	}
+
+	projectID, err := parseUUID(id, "project id")
+	if err != nil {
+		return sqlc.Project{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var project sqlc.Project
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		if archived {
+			project, err = q.ArchiveProject(tctx, sqlc.ArchiveProjectParams{ID: toPgUUID(projectID), UserID: toPgUUID(uid)})
+		} else {
+			project, err = q.UnarchiveProject(tctx, sqlc.UnarchiveProjectParams{ID: toPgUUID(projectID), UserID: toPgUUID(uid)})
+		}
+		if err != nil {
+			return s.wrapDBError(err, "project not found")
+		}
+		return s.recordEvent(tctx, q, uid, EventProjectUpdated, projectPayload(project))
+	})
+	if err != nil {
+		return sqlc.Project{}, err
 	}
 	return project, nil
 }
@@ -221,7 +388,10 @@ func (s *Service) DeleteProject(ctx context.Context, id string) (DeleteResult, e
 	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
 	defer cancel()
 
-	var result DeleteResult
+	var (
+		result  DeleteResult
+		removed []sqlc.Task
+	)
 	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
 		deleted, err := q.SoftDeleteProject(tctx, sqlc.SoftDeleteProjectParams{
 			ID:     toPgUUID(projectID),
@@ -231,22 +401,47 @@ func (s *Service) DeleteProject(ctx context.Context, id string) (DeleteResult, e
 			return s.wrapDBError(err, "project not found")
 		}
 
-		if _, err := q.SoftDeleteTasksByProject(tctx, sqlc.SoftDeleteTasksByProjectParams{
+		removed, err = q.SoftDeleteTasksByProject(tctx, sqlc.SoftDeleteTasksByProjectParams{
 			ProjectID: toPgUUID(projectID),
 			UserID:    toPgUUID(uid),
-		}); err != nil {
+		})
+		if err != nil {
 			return s.wrapDBError(err, "failed to delete project tasks")
 		}
+		if _, err := q.SoftDeleteSectionsByProject(tctx, sqlc.SoftDeleteSectionsByProjectParams{
+			ProjectID: toPgUUID(projectID),
+			UserID:    toPgUUID(uid),
+		}); err != nil {
+			return s.wrapDBError(err, "failed to delete project sections")
+		}
+		if _, err := q.SoftDeleteStatusesByProject(tctx, sqlc.SoftDeleteStatusesByProjectParams{
+			ProjectID: toPgUUID(projectID),
+			UserID:    toPgUUID(uid),
+		}); err != nil {
+			return s.wrapDBError(err, "failed to delete project statuses")
+		}
+		if _, err := q.SoftDeleteCustomFieldsByProject(tctx, sqlc.SoftDeleteCustomFieldsByProjectParams{
+			ProjectID: toPgUUID(projectID),
+			UserID:    toPgUUID(uid),
+		}); err != nil {
+			return s.wrapDBError(err, "failed to delete project custom fields")
+		}
 
+		if err := s.recordTasksDeleted(tctx, q, uid, removed); err != nil {
+			return err
+		}
 		result = DeleteResult{
 			ID:        fromPgUUID(deleted.ID),
 			DeletedAt: deleted.DeletedAt.Time.UTC(),
 		}
-		return nil
+		return s.recordEvent(tctx, q, uid, EventProjectDeleted, deletedPayload(deleted.ID, deleted.DeletedAt))
 	})
 	if err != nil {
 		return DeleteResult{}, err
 	}
+	for _, t := range removed {
+		s.publishTask(TaskDeleted, t)
+	}
 	return result, nil
 }
 
@@ -277,44 +472,70 @@ func (s *Service) Project(ctx context.Context, id string) (*sqlc.Project, error)
 	return &project, nil
 }
 
-func (s *Service) ListProjects(ctx context.Context, first int, after *string) (PageResult[sqlc.Project], error) {
+// checkAcceptsTasks rejects new tasks in an archived project.
+func checkAcceptsTasks(project sqlc.Project) error {
+	if project.ArchivedAt.Valid {
+		return NewBadInput("project is archived; unarchive it to add tasks")
+	}
+	return nil
+}
+
+// ListProjects lists live projects, leaving out archived ones unless
+// includeArchived is set.
+func (s *Service) ListProjects(ctx context.Context, includeArchived bool, args PageArgs) (PageResult[sqlc.Project], error) {
 	uid, err := s.userID(ctx)
 	if err != nil {
 		return PageResult[sqlc.Project]{}, err
 	}
 
-	limit := normalizePageSize(first, 20, 100)
-
-	useCursor := false
-	cursorTime := pgtype.Timestamptz{Valid: false}
-	cursorID := pgtype.UUID{Valid: false}
-	if after != nil && strings.TrimSpace(*after) != "" {
-		c, err := decodeCursor(*after)
-		if err != nil {
-			return PageResult[sqlc.Project]{}, err
-		}
-		useCursor = true
-		cursorTime = toPgTime(&c.CreatedAt)
-		cursorID = toPgUUID(c.ID)
+	var filters map[string][]string
+	if includeArchived {
+		filters = map[string][]string{"archived": {"include"}}
+	}
+	fingerprint := queryFingerprint("projects", sortCreatedDesc, filters)
+	page, err := s.resolvePage(args, fingerprint, 20, 100)
+	if err != nil {
+		return PageResult[sqlc.Project]{}, err
 	}
 
 	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
 	defer cancel()
 
-	rows, err := s.store.Queries().ListProjects(tctx, sqlc.ListProjectsParams{
-		UserID:  toPgUUID(uid),
-		Column2: useCursor,
-		Column3: cursorTime,
-		Column4: cursorID,
-		Limit:   int32(limit + 1),
-	})
+	var rows []sqlc.Project
+	if page.backward {
+		rows, err = s.store.Queries().ListProjectsBefore(tctx, sqlc.ListProjectsBeforeParams{
+			UserID:  toPgUUID(uid),
+			Column2: page.useCursor,
+			Column3: page.cursorTime,
+			Column4: page.cursorID,
+			Limit:   int32(page.limit + 1),
+			Column6: includeArchived,
+		})
+	} else {
+		rows, err = s.store.Queries().ListProjects(tctx, sqlc.ListProjectsParams{
+			UserID:  toPgUUID(uid),
+			Column2: page.useCursor,
+			Column3: page.cursorTime,
+			Column4: page.cursorID,
+			Limit:   int32(page.limit + 1),
+			Column6: includeArchived,
+		})
+	}
 	if err != nil {
 		return PageResult[sqlc.Project]{}, s.wrapDBError(err, "failed to list projects")
 	}
 
-	return paginateRows(rows, limit, func(p sqlc.Project) string {
-		return encodeCursor(p.CreatedAt.Time.UTC(), fromPgUUID(p.ID))
-	}), nil
+	result := paginateRows(rows, page, func(p sqlc.Project) string {
+		return s.cursors.encode(fingerprint, p.CreatedAt.Time, fromPgUUID(p.ID))
+	})
+	if args.WithTotal {
+		total, err := s.store.Queries().CountProjects(tctx, sqlc.CountProjectsParams{UserID: toPgUUID(uid), Column2: includeArchived})
+		if err != nil {
+			return PageResult[sqlc.Project]{}, s.wrapDBError(err, "failed to count projects")
+		}
+		result.TotalCount = intPtr(total)
+	}
+	return result, nil
 }
 
 func (s *Service) CreateLabel(ctx context.Context, in CreateLabelInput) (sqlc.Label, error) {
@@ -323,18 +544,34 @@ func (s *Service) CreateLabel(ctx context.Context, in CreateLabelInput) (sqlc.La
 		return sqlc.Label{}, err
 	}
 
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var label sqlc.Label
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		label, err = s.createLabel(tctx, q, uid, in)
+		return err
+	})
+	if err != nil {
+		return sqlc.Label{}, err
+	}
+	return label, nil
+}
+
+// createLabel validates in and creates the label in the caller's transaction.
+func (s *Service) createLabel(ctx context.Context, q *sqlc.Queries, uid uuid.UUID, in CreateLabelInput) (sqlc.Label, error) {
 	name := strings.TrimSpace(in.Name)
 	if name == "" {
 		return sqlc.Label{}, NewBadInput("label name is required")
 	}
 
-	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
-	defer cancel()
-
-	label, err := s.store.Queries().CreateLabel(tctx, sqlc.CreateLabelParams{UserID: toPgUUID(uid), Name: name})
+	label, err := q.CreateLabel(ctx, sqlc.CreateLabelParams{UserID: toPgUUID(uid), Name: name})
 	if err != nil {
 		return sqlc.Label{}, s.wrapDBError(err, "failed to create label")
 	}
+	if err := s.recordEvent(ctx, q, uid, EventLabelCreated, labelPayload(label)); err != nil {
+		return sqlc.Label{}, err
+	}
 	return label, nil
 }
 
@@ -356,13 +593,20 @@ func (s *Service) UpdateLabel(ctx context.Context, in UpdateLabelInput) (sqlc.La
 	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
 	defer cancel()
 
-	label, err := s.store.Queries().UpdateLabel(tctx, sqlc.UpdateLabelParams{
-		ID:     toPgUUID(labelID),
-		UserID: toPgUUID(uid),
-		Name:   name,
+	var label sqlc.Label
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		label, err = q.UpdateLabel(tctx, sqlc.UpdateLabelParams{
+			ID:     toPgUUID(labelID),
+			UserID: toPgUUID(uid),
+			Name:   name,
+		})
+		if err != nil {
+			return s.wrapDBError(err, "label not found")
+		}
+		return s.recordEvent(tctx, q, uid, EventLabelUpdated, labelPayload(label))
 	})
 	if err != nil {
-		return sqlc.Label{}, s.wrapDBError(err, "label not found")
+		return sqlc.Label{}, err
 	}
 	return label, nil
 }
@@ -396,7 +640,7 @@ func (s *Service) DeleteLabel(ctx context.Context, id string) (DeleteResult, err
 		}
 
 		result = DeleteResult{ID: fromPgUUID(deleted.ID), DeletedAt: deleted.DeletedAt.Time.UTC()}
-		return nil
+		return s.recordEvent(tctx, q, uid, EventLabelDeleted, deletedPayload(deleted.ID, deleted.DeletedAt))
 	})
 	if err != nil {
 		return DeleteResult{}, err
@@ -404,43 +648,81 @@ func (s *Service) DeleteLabel(ctx context.Context, id string) (DeleteResult, err
 	return result, nil
 }
 
-func (s *Service) ListLabels(ctx context.Context, first int, after *string) (PageResult[sqlc.Label], error) {
+func (s *Service) Label(ctx context.Context, id string) (*sqlc.Label, error) {
 	uid, err := s.userID(ctx)
 	if err != nil {
-		return PageResult[sqlc.Label]{}, err
+		return nil, err
 	}
 
-	limit := normalizePageSize(first, 50, 200)
-	useCursor := false
-	cursorTime := pgtype.Timestamptz{Valid: false}
-	cursorID := pgtype.UUID{Valid: false}
-	if after != nil && strings.TrimSpace(*after) != "" {
-		c, err := decodeCursor(*after)
-		if err != nil {
-			return PageResult[sqlc.Label]{}, err
-		}
-		useCursor = true
-		cursorTime = toPgTime(&c.CreatedAt)
-		cursorID = toPgUUID(c.ID)
+	labelID, err := parseUUID(id, "label id")
+	if err != nil {
+		return nil, err
 	}
 
 	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
 	defer cancel()
 
-	rows, err := s.store.Queries().ListLabels(tctx, sqlc.ListLabelsParams{
-		UserID:  toPgUUID(uid),
-		Column2: useCursor,
-		Column3: cursorTime,
-		Column4: cursorID,
-		Limit:   int32(limit + 1),
+	label, err := s.store.Queries().GetLabelByID(tctx, sqlc.GetLabelByIDParams{
+		ID:     toPgUUID(labelID),
+		UserID: toPgUUID(uid),
 	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, s.wrapDBError(err, "failed to fetch label")
+	}
+	return &label, nil
+}
+
+func (s *Service) ListLabels(ctx context.Context, args PageArgs) (PageResult[sqlc.Label], error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return PageResult[sqlc.Label]{}, err
+	}
+
+	fingerprint := queryFingerprint("labels", sortCreatedDesc, nil)
+	page, err := s.resolvePage(args, fingerprint, 50, 200)
+	if err != nil {
+		return PageResult[sqlc.Label]{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var rows []sqlc.Label
+	if page.backward {
+		rows, err = s.store.Queries().ListLabelsBefore(tctx, sqlc.ListLabelsBeforeParams{
+			UserID:  toPgUUID(uid),
+			Column2: page.useCursor,
+			Column3: page.cursorTime,
+			Column4: page.cursorID,
+			Limit:   int32(page.limit + 1),
+		})
+	} else {
+		rows, err = s.store.Queries().ListLabels(tctx, sqlc.ListLabelsParams{
+			UserID:  toPgUUID(uid),
+			Column2: page.useCursor,
+			Column3: page.cursorTime,
+			Column4: page.cursorID,
+			Limit:   int32(page.limit + 1),
+		})
+	}
 	if err != nil {
 		return PageResult[sqlc.Label]{}, s.wrapDBError(err, "failed to list labels")
 	}
 
-	return paginateRows(rows, limit, func(l sqlc.Label) string {
-		return encodeCursor(l.CreatedAt.Time.UTC(), fromPgUUID(l.ID))
-	}), nil
+	result := paginateRows(rows, page, func(l sqlc.Label) string {
+		return s.cursors.encode(fingerprint, l.CreatedAt.Time, fromPgUUID(l.ID))
+	})
+	if args.WithTotal {
+		total, err := s.store.Queries().CountLabels(tctx, toPgUUID(uid))
+		if err != nil {
+			return PageResult[sqlc.Label]{}, s.wrapDBError(err, "failed to count labels")
+		}
+		result.TotalCount = intPtr(total)
+	}
+	return result, nil
 }
 
 func (s *Service) Task(ctx context.Context, id string) (*sqlc.Task, error) {
@@ -476,11 +758,38 @@ func (s *Service) CreateTask(ctx context.Context, in CreateTaskInput) (sqlc.Task
 		return sqlc.Task{}, err
 	}
 
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var created sqlc.Task
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		created, err = s.createTask(tctx, q, uid, in)
+		return err
+	})
+	if err != nil {
+		return sqlc.Task{}, err
+	}
+	s.publishTask(TaskCreated, created)
+	return created, nil
+}
+
+// createTask validates in and creates the task in the caller's transaction.
+// The caller publishes the created task once the transaction commits.
+func (s *Service) createTask(ctx context.Context, q *sqlc.Queries, uid uuid.UUID, in CreateTaskInput) (sqlc.Task, error) {
 	projectID, err := parseUUID(in.ProjectID, "project id")
 	if err != nil {
 		return sqlc.Task{}, err
 	}
 
+	taskPg := pgtype.UUID{Valid: false}
+	if in.ID != nil {
+		taskID, err := parseUUID(*in.ID, "task id")
+		if err != nil {
+			return sqlc.Task{}, err
+		}
+		taskPg = toPgUUID(taskID)
+	}
+
 	var parentID *uuid.UUID
 	if in.ParentTaskID != nil && strings.TrimSpace(*in.ParentTaskID) != "" {
 		pID, err := parseUUID(*in.ParentTaskID, "parent task id")
@@ -506,64 +815,128 @@ func (s *Service) CreateTask(ctx context.Context, in CreateTaskInput) (sqlc.Task
 	if err := validateSchedule(in.StartAt, in.DueAt); err != nil {
 		return sqlc.Task{}, err
 	}
+	recurrence, err := normalizeRecurrence(in.Recurrence)
+	if err != nil {
+		return sqlc.Task{}, err
+	}
 
 	labelIDs, err := parseUUIDList(in.LabelIDs, "labelIds")
 	if err != nil {
 		return sqlc.Task{}, err
 	}
+	blockedReason := trimmedOrNil(in.BlockedReason)
+	estimate, err := normalizeEstimate(in.EstimateMinutes)
+	if err != nil {
+		return sqlc.Task{}, err
+	}
 
-	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
-	defer cancel()
+	project, err := q.GetProjectByID(ctx, sqlc.GetProjectByIDParams{ID: toPgUUID(projectID), UserID: toPgUUID(uid)})
+	if err != nil {
+		return sqlc.Task{}, s.wrapDBError(err, "project not found")
+	}
+	if err := checkAcceptsTasks(project); err != nil {
+		return sqlc.Task{}, err
+	}
 
-	var created sqlc.Task
-	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
-		if _, err := q.GetProjectByID(tctx, sqlc.GetProjectByIDParams{ID: toPgUUID(projectID), UserID: toPgUUID(uid)}); err != nil {
-			return s.wrapDBError(err, "project not found")
+	parentPg := pgtype.UUID{Valid: false}
+	if parentID != nil {
+		parentPg = toPgUUID(*parentID)
+		parentTask, err := q.GetTaskByID(ctx, sqlc.GetTaskByIDParams{ID: parentPg, UserID: toPgUUID(uid)})
+		if err != nil {
+			return sqlc.Task{}, s.wrapDBError(err, "parent task not found")
 		}
-
-		parentPg := pgtype.UUID{Valid: false}
-		if parentID != nil {
-			parentPg = toPgUUID(*parentID)
-			parentTask, err := q.GetTaskByID(tctx, sqlc.GetTaskByIDParams{ID: parentPg, UserID: toPgUUID(uid)})
-			if err != nil {
-				return s.wrapDBError(err, "parent task not found")
-			}
-			if parentTask.ParentTaskID.Valid {
-				return NewBadInput("only one level of subtasks is supported")
-			}
-			if fromPgUUID(parentTask.ProjectID) != projectID {
-				return NewBadInput("parent task must belong to the same project")
-			}
+		if parentTask.ParentTaskID.Valid {
+			return sqlc.Task{}, NewBadInput("only one level of subtasks is supported")
 		}
-
-		completedAt := pgtype.Timestamptz{Valid: false}
-		if status == "DONE" {
-			now := time.Now().UTC()
-			completedAt = toPgTime(&now)
+		if fromPgUUID(parentTask.ProjectID) != projectID {
+			return sqlc.Task{}, NewBadInput("parent task must belong to the same project")
 		}
+	}
 
-		created, err = q.CreateTask(tctx, sqlc.CreateTaskParams{
-			UserID:       toPgUUID(uid),
-			ProjectID:    toPgUUID(projectID),
-			ParentTaskID: parentPg,
-			Title:        title,
-			Description:  in.Description,
-			Status:       status,
-			Priority:     priority,
-			StartAt:      toPgTime(in.StartAt),
-			DueAt:        toPgTime(in.DueAt),
-			CompletedAt:  completedAt,
-		})
+	sectionPg := pgtype.UUID{Valid: false}
+	if in.SectionID != nil && strings.TrimSpace(*in.SectionID) != "" {
+		if parentID != nil {
+			return sqlc.Task{}, NewBadInput("subtasks cannot have a section")
+		}
+		sectionPg, err = s.resolveSection(ctx, q, uid, *in.SectionID, projectID)
 		if err != nil {
-			return s.wrapDBError(err, "failed to create task")
+			return sqlc.Task{}, err
 		}
+	}
 
-		if err := s.replaceTaskLabels(tctx, q, uid, fromPgUUID(created.ID), labelIDs); err != nil {
-			return err
+	var workflow sqlc.ProjectStatus
+	if in.StatusID != nil && strings.TrimSpace(*in.StatusID) != "" {
+		workflow, err = s.resolveStatus(ctx, q, uid, *in.StatusID, projectID)
+	} else {
+		workflow, err = s.statusForCategory(ctx, q, uid, project.ID, status)
+	}
+	if err != nil {
+		return sqlc.Task{}, err
+	}
+	status = workflow.Category
+	if status != "BLOCKED" {
+		blockedReason = nil
+	}
+	policy, err := s.loadTransitionPolicy(ctx, q, uid, project.ID)
+	if err != nil {
+		return sqlc.Task{}, err
+	}
+	if err := policy.check("", status, statusFields{
+		BlockedReason: blockedReason,
+		Description:   in.Description,
+		StartAt:       in.StartAt,
+		DueAt:         in.DueAt,
+	}); err != nil {
+		return sqlc.Task{}, err
+	}
+	if parentID == nil {
+		if err := s.checkWipLimit(ctx, q, uid, workflow.ID); err != nil {
+			return sqlc.Task{}, err
 		}
-		return nil
+	}
+
+	completedAt := pgtype.Timestamptz{Valid: false}
+	if status == "DONE" {
+		now := time.Now().UTC()
+		completedAt = toPgTime(&now)
+	}
+
+	created, err := q.CreateTask(ctx, sqlc.CreateTaskParams{
+		UserID:          toPgUUID(uid),
+		ProjectID:       toPgUUID(projectID),
+		ParentTaskID:    parentPg,
+		Title:           title,
+		Description:     in.Description,
+		Status:          status,
+		Priority:        priority,
+		StartAt:         toPgTime(in.StartAt),
+		DueAt:           toPgTime(in.DueAt),
+		CompletedAt:     completedAt,
+		SectionID:       sectionPg,
+		StatusID:        workflow.ID,
+		BlockedReason:   blockedReason,
+		EstimateMinutes: estimate,
+		ID:              taskPg,
 	})
 	if err != nil {
+		return sqlc.Task{}, s.wrapDBError(err, "failed to create task")
+	}
+
+	if err := s.recordStatusChange(ctx, q, created, ""); err != nil {
+		return sqlc.Task{}, err
+	}
+	if err := s.replaceTaskLabels(ctx, q, uid, fromPgUUID(created.ID), labelIDs); err != nil {
+		return sqlc.Task{}, err
+	}
+	if err := s.applyCustomFieldValues(ctx, q, uid, created, in.CustomFields, true); err != nil {
+		return sqlc.Task{}, err
+	}
+	if recurrence != "" {
+		if _, err := q.UpsertTaskRecurrence(ctx, sqlc.UpsertTaskRecurrenceParams{TaskID: created.ID, Rule: recurrence}); err != nil {
+			return sqlc.Task{}, s.wrapDBError(err, "failed to save recurrence")
+		}
+	}
+	if err := s.recordEvent(ctx, q, uid, EventTaskCreated, taskPayload(created)); err != nil {
 		return sqlc.Task{}, err
 	}
 	return created, nil
@@ -583,7 +956,11 @@ func (s *Service) UpdateTask(ctx context.Context, in UpdateTaskInput) (sqlc.Task
 	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
 	defer cancel()
 
-	var updated sqlc.Task
+	var (
+		updated sqlc.Task
+		// parent is set when the update rolled up onto the parent task.
+		parent *sqlc.Task
+	)
 	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
 		existing, err := q.GetTaskByID(tctx, sqlc.GetTaskByIDParams{
 			ID:     toPgUUID(taskID),
@@ -592,6 +969,9 @@ func (s *Service) UpdateTask(ctx context.Context, in UpdateTaskInput) (sqlc.Task
 		if err != nil {
 			return s.wrapDBError(err, "task not found")
 		}
+		if in.ExpectedUpdatedAt != nil && !existing.UpdatedAt.Time.Equal(*in.ExpectedUpdatedAt) {
+			return NewConflict("task has been modified", nil)
+		}
 
 		title := existing.Title
 		if in.Title != nil {
@@ -606,13 +986,38 @@ func (s *Service) UpdateTask(ctx context.Context, in UpdateTaskInput) (sqlc.Task
 			description = in.Description
 		}
 
-		status := existing.Status
-		if in.Status != nil {
+		status, statusID := existing.Status, existing.StatusID
+		if in.StatusID != nil && strings.TrimSpace(*in.StatusID) != "" {
+			workflow, err := s.resolveStatus(tctx, q, uid, *in.StatusID, fromPgUUID(existing.ProjectID))
+			if err != nil {
+				return err
+			}
+			status, statusID = workflow.Category, workflow.ID
+		} else if in.Status != nil {
 			normalized, err := normalizeStatus(*in.Status)
 			if err != nil {
 				return err
 			}
-			status = normalized
+			if normalized != existing.Status {
+				workflow, err := s.statusForCategory(tctx, q, uid, existing.ProjectID, normalized)
+				if err != nil {
+					return err
+				}
+				status, statusID = workflow.Category, workflow.ID
+			}
+		}
+
+		blockedReason := existing.BlockedReason
+		if in.BlockedReason != nil {
+			blockedReason = trimmedOrNil(in.BlockedReason)
+		}
+		if status != "BLOCKED" {
+			blockedReason = nil
+		}
+		if statusID != existing.StatusID && !existing.ParentTaskID.Valid {
+			if err := s.checkWipLimit(tctx, q, uid, statusID); err != nil {
+				return err
+			}
 		}
 
 		priority := existing.Priority
@@ -628,16 +1033,67 @@ func (s *Service) UpdateTask(ctx context.Context, in UpdateTaskInput) (sqlc.Task
 		if in.StartAt != nil {
 			startAt = in.StartAt
 		}
+		if in.ClearStartAt {
+			startAt = nil
+		}
 
 		dueAt := fromPgTime(existing.DueAt)
 		if in.DueAt != nil {
 			dueAt = in.DueAt
 		}
+		if in.ClearDueAt {
+			dueAt = nil
+		}
 
 		if err := validateSchedule(startAt, dueAt); err != nil {
 			return err
 		}
 
+		if status != existing.Status {
+			policy, err := s.loadTransitionPolicy(tctx, q, uid, existing.ProjectID)
+			if err != nil {
+				return err
+			}
+			if err := policy.check(existing.Status, status, statusFields{
+				BlockedReason: blockedReason,
+				Description:   description,
+				StartAt:       startAt,
+				DueAt:         dueAt,
+			}); err != nil {
+				return err
+			}
+		}
+
+		sectionID := existing.SectionID
+		if in.SectionID != nil && strings.TrimSpace(*in.SectionID) != "" {
+			if existing.ParentTaskID.Valid {
+				return NewBadInput("subtasks cannot have a section")
+			}
+			sectionID, err = s.resolveSection(tctx, q, uid, *in.SectionID, fromPgUUID(existing.ProjectID))
+			if err != nil {
+				return err
+			}
+		}
+		if in.ClearSection {
+			sectionID = pgtype.UUID{Valid: false}
+		}
+
+		estimate := existing.EstimateMinutes
+		if in.EstimateMinutes != nil {
+			estimate, err = normalizeEstimate(in.EstimateMinutes)
+			if err != nil {
+				return err
+			}
+		}
+		if in.ClearEstimate {
+			estimate = nil
+		}
+
+		recurrence, err := normalizeRecurrence(in.Recurrence)
+		if err != nil {
+			return err
+		}
+
 		completedAt := fromPgTime(existing.CompletedAt)
 		if status == "DONE" {
 			if existing.Status != "DONE" || completedAt == nil {
@@ -649,15 +1105,19 @@ func (s *Service) UpdateTask(ctx context.Context, in UpdateTaskInput) (sqlc.Task
 		}
 
 		updated, err = q.UpdateTask(tctx, sqlc.UpdateTaskParams{
-			ID:          toPgUUID(taskID),
-			UserID:      toPgUUID(uid),
-			Title:       title,
-			Description: description,
-			Status:      status,
-			Priority:    priority,
-			StartAt:     toPgTime(startAt),
-			DueAt:       toPgTime(dueAt),
-			CompletedAt: toPgTime(completedAt),
+			ID:              toPgUUID(taskID),
+			UserID:          toPgUUID(uid),
+			Title:           title,
+			Description:     description,
+			Status:          status,
+			Priority:        priority,
+			StartAt:         toPgTime(startAt),
+			DueAt:           toPgTime(dueAt),
+			CompletedAt:     toPgTime(completedAt),
+			SectionID:       sectionID,
+			StatusID:        statusID,
+			BlockedReason:   blockedReason,
+			EstimateMinutes: estimate,
 		})
 		if err != nil {
 			return s.wrapDBError(err, "failed to update task")
@@ -672,13 +1132,37 @@ func (s *Service) UpdateTask(ctx context.Context, in UpdateTaskInput) (sqlc.Task
 				return err
 			}
 		}
+		if err := s.applyCustomFieldValues(tctx, q, uid, updated, in.CustomFields, false); err != nil {
+			return err
+		}
+		switch {
+		case in.ClearRecurrence || (in.Recurrence != nil && recurrence == ""):
+			if err := q.DeleteTaskRecurrence(tctx, sqlc.DeleteTaskRecurrenceParams{TaskID: updated.ID, UserID: toPgUUID(uid)}); err != nil {
+				return s.wrapDBError(err, "failed to clear recurrence")
+			}
+		case recurrence != "":
+			if _, err := q.UpsertTaskRecurrence(tctx, sqlc.UpsertTaskRecurrenceParams{TaskID: updated.ID, Rule: recurrence}); err != nil {
+				return s.wrapDBError(err, "failed to save recurrence")
+			}
+		}
+		if err := s.recordStatusChange(tctx, q, updated, existing.Status); err != nil {
+			return err
+		}
 
-		return nil
+		parent, err = s.rollUpParent(tctx, q, uid, updated, existing.Status)
+		if err != nil {
+			return err
+		}
+		return s.recordEvent(tctx, q, uid, EventTaskUpdated, taskPayload(updated))
 	})
 	if err != nil {
 		return sqlc.Task{}, err
 	}
 
+	s.publishTask(TaskUpdated, updated)
+	if parent != nil {
+		s.publishTask(TaskUpdated, *parent)
+	}
 	return updated, nil
 }
 
@@ -696,7 +1180,11 @@ func (s *Service) DeleteTask(ctx context.Context, id string) (DeleteResult, erro
 	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
 	defer cancel()
 
-	var result DeleteResult
+	var (
+		result   DeleteResult
+		removed  sqlc.Task
+		subtasks []sqlc.Task
+	)
 	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
 		deleted, err := q.SoftDeleteTask(tctx, sqlc.SoftDeleteTaskParams{
 			ID:     toPgUUID(taskID),
@@ -705,23 +1193,32 @@ func (s *Service) DeleteTask(ctx context.Context, id string) (DeleteResult, erro
 		if err != nil {
 			return s.wrapDBError(err, "task not found")
 		}
-		if _, err := q.SoftDeleteDirectSubtasks(tctx, sqlc.SoftDeleteDirectSubtasksParams{
+		subtasks, err = q.SoftDeleteDirectSubtasks(tctx, sqlc.SoftDeleteDirectSubtasksParams{
 			ParentTaskID: toPgUUID(taskID),
 			UserID:       toPgUUID(uid),
-		}); err != nil {
+		})
+		if err != nil {
 			return s.wrapDBError(err, "failed to delete subtasks")
 		}
 
 		result = DeleteResult{ID: fromPgUUID(deleted.ID), DeletedAt: deleted.DeletedAt.Time.UTC()}
-		return nil
+		removed = deleted
+		if err := s.recordEvent(tctx, q, uid, EventTaskDeleted, deletedPayload(deleted.ID, deleted.DeletedAt)); err != nil {
+			return err
+		}
+		return s.recordTasksDeleted(tctx, q, uid, subtasks)
 	})
 	if err != nil {
 		return DeleteResult{}, err
 	}
+	s.publishTask(TaskDeleted, removed)
+	for _, t := range subtasks {
+		s.publishTask(TaskDeleted, t)
+	}
 	return result, nil
 }
 
-func (s *Service) ListTasks(ctx context.Context, projectID string, parentTaskID *string, statuses []string, priorities []string, first int, after *string) (PageResult[sqlc.Task], error) {
+func (s *Service) ListTasks(ctx context.Context, projectID string, parentTaskID *string, filter TaskFilter, args PageArgs) (PageResult[sqlc.Task], error) {
 	uid, err := s.userID(ctx)
 	if err != nil {
 		return PageResult[sqlc.Task]{}, err
@@ -732,11 +1229,7 @@ func (s *Service) ListTasks(ctx context.Context, projectID string, parentTaskID
 		return PageResult[sqlc.Task]{}, err
 	}
 
-	statuses, err = normalizeFilters(statuses, normalizeStatus)
-	if err != nil {
-		return PageResult[sqlc.Task]{}, err
-	}
-	priorities, err = normalizeFilters(priorities, normalizePriority)
+	f, fingerprintFilters, err := normalizeTaskFilter(filter)
 	if err != nil {
 		return PageResult[sqlc.Task]{}, err
 	}
@@ -750,18 +1243,20 @@ func (s *Service) ListTasks(ctx context.Context, projectID string, parentTaskID
 		parentUUID = &pid
 	}
 
-	limit := normalizePageSize(first, 20, 100)
-	useCursor := false
-	cursorTime := pgtype.Timestamptz{Valid: false}
-	cursorID := pgtype.UUID{Valid: false}
-	if after != nil && strings.TrimSpace(*after) != "" {
-		c, err := decodeCursor(*after)
-		if err != nil {
-			return PageResult[sqlc.Task]{}, err
-		}
-		useCursor = true
-		cursorTime = toPgTime(&c.CreatedAt)
-		cursorID = toPgUUID(c.ID)
+	parentFilter := "root"
+	if parentUUID != nil {
+		parentFilter = parentUUID.String()
+	}
+	fingerprintFilters["project"] = []string{projectUUID.String()}
+	fingerprintFilters["parent"] = []string{parentFilter}
+	sortKey := sortCreatedDesc
+	if f.sort != nil {
+		sortKey = f.sort.key()
+	}
+	fingerprint := queryFingerprint("tasks", sortKey, fingerprintFilters)
+	page, err := s.resolvePage(args, fingerprint, 20, 100)
+	if err != nil {
+		return PageResult[sqlc.Task]{}, err
 	}
 
 	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
@@ -770,46 +1265,298 @@ func (s *Service) ListTasks(ctx context.Context, projectID string, parentTaskID
 	if _, err := s.store.Queries().GetProjectByID(tctx, sqlc.GetProjectByIDParams{ID: toPgUUID(projectUUID), UserID: toPgUUID(uid)}); err != nil {
 		return PageResult[sqlc.Task]{}, s.wrapDBError(err, "project not found")
 	}
+	if len(f.customFields) > 0 || f.sort != nil {
+		return s.listTasksByCustomFields(tctx, uid, projectUUID, parentUUID, f, page, fingerprint, args.WithTotal)
+	}
+
+	taskCursor := func(t sqlc.Task) string {
+		return s.cursors.encode(fingerprint, t.CreatedAt.Time, fromPgUUID(t.ID))
+	}
 
 	if parentUUID == nil {
-		rows, err := s.store.Queries().ListRootTasks(tctx, sqlc.ListRootTasksParams{
-			UserID:    toPgUUID(uid),
-			ProjectID: toPgUUID(projectUUID),
-			Column3:   statuses,
-			Column4:   priorities,
-			Column5:   useCursor,
-			Column6:   cursorTime,
-			Column7:   cursorID,
-			Limit:     int32(limit + 1),
-		})
+		params := sqlc.ListRootTasksParams{
+			UserID:          toPgUUID(uid),
+			ProjectID:       toPgUUID(projectUUID),
+			Statuses:        f.statuses,
+			Priorities:      f.priorities,
+			LabelIds:        f.labelIDs,
+			MatchAllLabels:  f.matchAllLabels,
+			DueBefore:       f.dueBefore,
+			DueAfter:        f.dueAfter,
+			StartBefore:     f.startBefore,
+			StartAfter:      f.startAfter,
+			CompletedFrom:   f.completedFrom,
+			CompletedTo:     f.completedTo,
+			HasDueDate:      f.hasDueDate,
+			UpdatedSince:    f.updatedSince,
+			TitlePattern:    f.titlePattern,
+			SectionID:       f.sectionID,
+			StatusID:        f.statusID,
+			UseCursor:       page.useCursor,
+			CursorCreatedAt: page.cursorTime,
+			CursorID:        page.cursorID,
+			RowLimit:        int32(page.limit + 1),
+		}
+		var rows []sqlc.Task
+		if page.backward {
+			rows, err = s.store.Queries().ListRootTasksBefore(tctx, sqlc.ListRootTasksBeforeParams(params))
+		} else {
+			rows, err = s.store.Queries().ListRootTasks(tctx, params)
+		}
 		if err != nil {
 			return PageResult[sqlc.Task]{}, s.wrapDBError(err, "failed to list tasks")
 		}
-		return paginateRows(rows, limit, func(t sqlc.Task) string {
-			return encodeCursor(t.CreatedAt.Time.UTC(), fromPgUUID(t.ID))
-		}), nil
-	}
 
-	rows, err := s.store.Queries().ListSubtasks(tctx, sqlc.ListSubtasksParams{
-		UserID:       toPgUUID(uid),
-		ProjectID:    toPgUUID(projectUUID),
-		ParentTaskID: toPgUUID(*parentUUID),
-		Column4:      statuses,
-		Column5:      priorities,
-		Column6:      useCursor,
-		Column7:      cursorTime,
-		Column8:      cursorID,
-		Limit:        int32(limit + 1),
-	})
+		result := paginateRows(rows, page, taskCursor)
+		if args.WithTotal {
+			total, err := s.store.Queries().CountRootTasks(tctx, sqlc.CountRootTasksParams{
+				UserID:         params.UserID,
+				ProjectID:      params.ProjectID,
+				Statuses:       params.Statuses,
+				Priorities:     params.Priorities,
+				LabelIds:       params.LabelIds,
+				MatchAllLabels: params.MatchAllLabels,
+				DueBefore:      params.DueBefore,
+				DueAfter:       params.DueAfter,
+				StartBefore:    params.StartBefore,
+				StartAfter:     params.StartAfter,
+				CompletedFrom:  params.CompletedFrom,
+				CompletedTo:    params.CompletedTo,
+				HasDueDate:     params.HasDueDate,
+				UpdatedSince:   params.UpdatedSince,
+				TitlePattern:   params.TitlePattern,
+				SectionID:      params.SectionID,
+				StatusID:       params.StatusID,
+			})
+			if err != nil {
+				return PageResult[sqlc.Task]{}, s.wrapDBError(err, "failed to count tasks")
+			}
+			result.TotalCount = intPtr(total)
+		}
+		return result, nil
+	}
+
+	params := sqlc.ListSubtasksParams{
+		UserID:          toPgUUID(uid),
+		ProjectID:       toPgUUID(projectUUID),
+		ParentTaskID:    toPgUUID(*parentUUID),
+		Statuses:        f.statuses,
+		Priorities:      f.priorities,
+		LabelIds:        f.labelIDs,
+		MatchAllLabels:  f.matchAllLabels,
+		DueBefore:       f.dueBefore,
+		DueAfter:        f.dueAfter,
+		StartBefore:     f.startBefore,
+		StartAfter:      f.startAfter,
+		CompletedFrom:   f.completedFrom,
+		CompletedTo:     f.completedTo,
+		HasDueDate:      f.hasDueDate,
+		UpdatedSince:    f.updatedSince,
+		TitlePattern:    f.titlePattern,
+		SectionID:       f.sectionID,
+		StatusID:        f.statusID,
+		UseCursor:       page.useCursor,
+		CursorCreatedAt: page.cursorTime,
+		CursorID:        page.cursorID,
+		RowLimit:        int32(page.limit + 1),
+	}
+	var rows []sqlc.Task
+	if page.backward {
+		rows, err = s.store.Queries().ListSubtasksBefore(tctx, sqlc.ListSubtasksBeforeParams(params))
+	} else {
+		rows, err = s.store.Queries().ListSubtasks(tctx, params)
+	}
 	if err != nil {
 		return PageResult[sqlc.Task]{}, s.wrapDBError(err, "failed to list subtasks")
 	}
 
-	return paginateRows(rows, limit, func(t sqlc.Task) string {
-		return encodeCursor(t.CreatedAt.Time.UTC(), fromPgUUID(t.ID))
-	}), nil
+	result := paginateRows(rows, page, taskCursor)
+	if args.WithTotal {
+		total, err := s.store.Queries().CountSubtasks(tctx, sqlc.CountSubtasksParams{
+			UserID:         params.UserID,
+			ProjectID:      params.ProjectID,
+			ParentTaskID:   params.ParentTaskID,
+			Statuses:       params.Statuses,
+			Priorities:     params.Priorities,
+			LabelIds:       params.LabelIds,
+			MatchAllLabels: params.MatchAllLabels,
+			DueBefore:      params.DueBefore,
+			DueAfter:       params.DueAfter,
+			StartBefore:    params.StartBefore,
+			StartAfter:     params.StartAfter,
+			CompletedFrom:  params.CompletedFrom,
+			CompletedTo:    params.CompletedTo,
+			HasDueDate:     params.HasDueDate,
+			UpdatedSince:   params.UpdatedSince,
+			TitlePattern:   params.TitlePattern,
+			SectionID:      params.SectionID,
+			StatusID:       params.StatusID,
+		})
+		if err != nil {
+			return PageResult[sqlc.Task]{}, s.wrapDBError(err, "failed to count subtasks")
+		}
+		result.TotalCount = intPtr(total)
+	}
+	return result, nil
+}
+
+// taskFilterParams is a TaskFilter in query-parameter form.
+type taskFilterParams struct {
+	statuses       []string
+	priorities     []string
+	labelIDs       []pgtype.UUID
+	matchAllLabels bool
+	dueBefore      pgtype.Timestamptz
+	dueAfter       pgtype.Timestamptz
+	startBefore    pgtype.Timestamptz
+	startAfter     pgtype.Timestamptz
+	completedFrom  pgtype.Timestamptz
+	completedTo    pgtype.Timestamptz
+	hasDueDate     *bool
+	updatedSince   pgtype.Timestamptz
+	titlePattern   *string
+	sectionID      pgtype.UUID
+	statusID       pgtype.UUID
+	customFields   []customFieldMatch
+	sort           *customFieldSort
 }
 
+// normalizeTaskFilter validates f and also returns the filters in the form
+// queryFingerprint expects, so a cursor only works with the filters it was
+// issued for.
+func normalizeTaskFilter(f TaskFilter) (taskFilterParams, map[string][]string, error) {
+	var (
+		out taskFilterParams
+		err error
+	)
+	out.statuses, err = normalizeFilters(f.Statuses, normalizeStatus)
+	if err != nil {
+		return taskFilterParams{}, nil, err
+	}
+	out.priorities, err = normalizeFilters(f.Priorities, normalizePriority)
+	if err != nil {
+		return taskFilterParams{}, nil, err
+	}
+	fingerprint := map[string][]string{
+		"statuses":   out.statuses,
+		"priorities": out.priorities,
+	}
+
+	// Duplicates would make "all labels" unsatisfiable.
+	seen := map[uuid.UUID]bool{}
+	labelKeys := []string{}
+	for _, raw := range f.LabelIDs {
+		id, err := parseUUID(raw, "label id")
+		if err != nil {
+			return taskFilterParams{}, nil, err
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		out.labelIDs = append(out.labelIDs, toPgUUID(id))
+		labelKeys = append(labelKeys, id.String())
+	}
+	if out.labelIDs == nil {
+		out.labelIDs = []pgtype.UUID{}
+	}
+	if len(labelKeys) > 0 {
+		out.matchAllLabels = f.MatchAllLabels
+		match := "any"
+		if f.MatchAllLabels {
+			match = "all"
+		}
+		fingerprint["labels"] = labelKeys
+		fingerprint["labelMatch"] = []string{match}
+	}
+
+	ranges := []struct {
+		name     string
+		from, to *time.Time
+		fromOut  *pgtype.Timestamptz
+		toOut    *pgtype.Timestamptz
+	}{
+		{"due", f.DueAfter, f.DueBefore, &out.dueAfter, &out.dueBefore},
+		{"start", f.StartAfter, f.StartBefore, &out.startAfter, &out.startBefore},
+		{"completed", f.CompletedFrom, f.CompletedTo, &out.completedFrom, &out.completedTo},
+	}
+	for _, r := range ranges {
+		if r.from != nil && r.to != nil && !r.from.Before(*r.to) {
+			return taskFilterParams{}, nil, NewBadInput(fmt.Sprintf("%s range is empty: the start must be before the end", r.name))
+		}
+		*r.fromOut = toPgTime(r.from)
+		*r.toOut = toPgTime(r.to)
+		if r.from != nil {
+			fingerprint[r.name+"From"] = []string{r.from.UTC().Format(time.RFC3339Nano)}
+		}
+		if r.to != nil {
+			fingerprint[r.name+"To"] = []string{r.to.UTC().Format(time.RFC3339Nano)}
+		}
+	}
+
+	if f.HasDueDate != nil {
+		v := *f.HasDueDate
+		out.hasDueDate = &v
+		fingerprint["hasDueDate"] = []string{strconv.FormatBool(v)}
+	}
+	out.updatedSince = toPgTime(f.UpdatedSince)
+	if f.UpdatedSince != nil {
+		fingerprint["updatedSince"] = []string{f.UpdatedSince.UTC().Format(time.RFC3339Nano)}
+	}
+	if f.TitleContains != nil {
+		if text := strings.TrimSpace(*f.TitleContains); text != "" {
+			pattern := "%" + likeEscaper.Replace(text) + "%"
+			out.titlePattern = &pattern
+			fingerprint["title"] = []string{text}
+		}
+	}
+	if f.SectionID != nil && strings.TrimSpace(*f.SectionID) != "" {
+		id, err := parseUUID(*f.SectionID, "section id")
+		if err != nil {
+			return taskFilterParams{}, nil, err
+		}
+		out.sectionID = toPgUUID(id)
+		fingerprint["section"] = []string{id.String()}
+	}
+	if f.StatusID != nil && strings.TrimSpace(*f.StatusID) != "" {
+		id, err := parseUUID(*f.StatusID, "status id")
+		if err != nil {
+			return taskFilterParams{}, nil, err
+		}
+		out.statusID = toPgUUID(id)
+		fingerprint["statusId"] = []string{id.String()}
+	}
+	for _, cf := range f.CustomFields {
+		id, err := parseUUID(cf.FieldID, "custom field id")
+		if err != nil {
+			return taskFilterParams{}, nil, err
+		}
+		key := "customField." + id.String()
+		if _, ok := fingerprint[key]; ok {
+			return taskFilterParams{}, nil, NewBadInput("custom field " + id.String() + " is filtered twice")
+		}
+		match := customFieldMatch{fieldID: id}
+		fingerprint[key] = []string{"unset"}
+		if cf.Value != nil && strings.TrimSpace(*cf.Value) != "" {
+			v := strings.TrimSpace(*cf.Value)
+			match.value = &v
+			fingerprint[key] = []string{"=" + v}
+		}
+		out.customFields = append(out.customFields, match)
+	}
+	if f.SortByCustomField != nil {
+		id, err := parseUUID(f.SortByCustomField.FieldID, "custom field id")
+		if err != nil {
+			return taskFilterParams{}, nil, err
+		}
+		out.sort = &customFieldSort{fieldID: id, descending: f.SortByCustomField.Descending}
+	}
+	return out, fingerprint, nil
+}
+
+// likeEscaper escapes LIKE wildcards so user text matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 func (s *Service) LabelsForTask(ctx context.Context, taskID string) ([]sqlc.Label, error) {
 	uid, err := s.userID(ctx)
 	if err != nil {
@@ -834,6 +1581,43 @@ func (s *Service) LabelsForTask(ctx context.Context, taskID string) ([]sqlc.Labe
 	return labels, nil
 }
 
+// TaskRecurrenceBatch returns the RRULE of each task in taskIDs that
+// repeats, keyed by the canonical task ID, in one query.
+func (s *Service) TaskRecurrenceBatch(ctx context.Context, taskIDs []string) (map[string]string, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]pgtype.UUID, 0, len(taskIDs))
+	for _, raw := range taskIDs {
+		id, err := parseUUID(raw, "task id")
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, toPgUUID(id))
+	}
+	out := make(map[string]string, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.store.Queries().ListTaskRecurrences(tctx, sqlc.ListTaskRecurrencesParams{
+		TaskIds: ids,
+		UserID:  toPgUUID(uid),
+	})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to load recurrence")
+	}
+	for _, row := range rows {
+		out[fromPgUUID(row.TaskID).String()] = row.Rule
+	}
+	return out, nil
+}
+
 func (s *Service) SubtasksForTask(ctx context.Context, taskID string) ([]sqlc.Task, error) {
 	uid, err := s.userID(ctx)
 	if err != nil {
@@ -858,6 +1642,33 @@ func (s *Service) SubtasksForTask(ctx context.Context, taskID string) ([]sqlc.Ta
 	return tasks, nil
 }
 
+// ProjectTasks returns every live task in a project, subtasks included,
+// oldest first.
+func (s *Service) ProjectTasks(ctx context.Context, projectID string) ([]sqlc.Task, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pid, err := parseUUID(projectID, "project id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	tasks, err := s.store.Queries().ListProjectTasks(tctx, sqlc.ListProjectTasksParams{
+		UserID:    toPgUUID(uid),
+		ProjectID: toPgUUID(pid),
+		Limit:     maxProjectTasks,
+	})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to list tasks")
+	}
+	return tasks, nil
+}
+
 func (s *Service) replaceTaskLabels(ctx context.Context, q *sqlc.Queries, userID uuid.UUID, taskID uuid.UUID, labelIDs []uuid.UUID) error {
 	if err := q.DeleteTaskLabelsForTask(ctx, toPgUUID(taskID)); err != nil {
 		return s.wrapDBError(err, "failed to reset task labels")
@@ -928,6 +1739,17 @@ func validateColor(color *string) error {
 	return nil
 }
 
+func trimmedOrNil(v *string) *string {
+	if v == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*v)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
 func normalizeStatus(v string) (string, error) {
 	v = strings.TrimSpace(strings.ToUpper(v))
 	if v == "" {
@@ -950,6 +1772,23 @@ func normalizePriority(v string) (string, error) {
 	return v, nil
 }
 
+// recurrencePattern mirrors the task_recurrences check constraint.
+var recurrencePattern = regexp.MustCompile(`^FREQ=(DAILY|WEEKLY|MONTHLY|YEARLY)(;[A-Z]+=[A-Z0-9,]+)*$`)
+
+func normalizeRecurrence(v *string) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	rule := strings.TrimSpace(strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(*v)), "RRULE:"))
+	if rule == "" {
+		return "", nil
+	}
+	if !recurrencePattern.MatchString(rule) {
+		return "", NewBadInput(fmt.Sprintf("invalid recurrence %q", *v))
+	}
+	return rule, nil
+}
+
 func normalizeFilters(input []string, normalize func(string) (string, error)) ([]string, error) {
 	if len(input) == 0 {
 		return []string{}, nil
@@ -1010,22 +1849,96 @@ func normalizePageSize(first int, defaultValue int, maxValue int) int {
 	return first
 }
 
-func paginateRows[T any](rows []T, limit int, cursorFn func(T) string) PageResult[T] {
-	hasNext := false
-	if len(rows) > limit {
-		hasNext = true
-		rows = rows[:limit]
+type pageQuery struct {
+	limit      int
+	backward   bool
+	useCursor  bool
+	cursorTime pgtype.Timestamptz
+	cursorID   pgtype.UUID
+	cursorSort *cursorSortValue
+}
+
+func (s *Service) resolvePage(args PageArgs, fingerprint string, defaultSize int, maxSize int) (pageQuery, error) {
+	forward := args.First != nil || hasCursor(args.After)
+	backward := args.Last != nil || hasCursor(args.Before)
+	if forward && backward {
+		return pageQuery{}, NewBadInput("first/after cannot be combined with last/before")
+	}
+
+	size, raw := args.First, args.After
+	if backward {
+		size, raw = args.Last, args.Before
 	}
 
-	var endCursor *string
-	if len(rows) > 0 {
-		cursor := cursorFn(rows[len(rows)-1])
-		endCursor = &cursor
+	requested := 0
+	if size != nil {
+		requested = *size
 	}
 
-	return PageResult[T]{
-		Items:       rows,
-		EndCursor:   endCursor,
-		HasNextPage: hasNext,
+	page := pageQuery{
+		limit:      normalizePageSize(requested, defaultSize, maxSize),
+		backward:   backward,
+		cursorTime: pgtype.Timestamptz{Valid: false},
+		cursorID:   pgtype.UUID{Valid: false},
 	}
+	if hasCursor(raw) {
+		c, err := s.cursors.decode(fingerprint, *raw)
+		if err != nil {
+			return pageQuery{}, err
+		}
+		page.useCursor = true
+		page.cursorTime = toPgTime(&c.CreatedAt)
+		page.cursorID = toPgUUID(c.ID)
+		page.cursorSort = c.Sort
+	}
+	return page, nil
+}
+
+func hasCursor(raw *string) bool {
+	return raw != nil && strings.TrimSpace(*raw) != ""
+}
+
+// paginateRows trims the look-ahead row and fills page info. Backward pages are
+// fetched in ascending order and reversed so edges keep the default ordering.
+func paginateRows[T any](rows []T, page pageQuery, cursorFn func(T) string) PageResult[T] {
+	hasMore := false
+	if len(rows) > page.limit {
+		hasMore = true
+		rows = rows[:page.limit]
+	}
+	if page.backward {
+		slices.Reverse(rows)
+	}
+
+	edges := make([]Edge[T], 0, len(rows))
+	for _, row := range rows {
+		edges = append(edges, Edge[T]{Cursor: cursorFn(row), Node: row})
+	}
+
+	result := PageResult[T]{Edges: edges}
+	if page.backward {
+		result.HasPreviousPage = hasMore
+		result.HasNextPage = page.useCursor
+	} else {
+		result.HasNextPage = hasMore
+		result.HasPreviousPage = page.useCursor
+	}
+
+	if len(edges) > 0 {
+		result.StartCursor = &edges[0].Cursor
+		result.EndCursor = &edges[len(edges)-1].Cursor
+	}
+	return result
+}
+
+func derefNode[T any](v *T, err error) (any, error) {
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return *v, nil
+}
+
+func intPtr(v int64) *int {
+	i := int(v)
+	return &i
 }
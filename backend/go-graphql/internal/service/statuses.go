@@ -0,0 +1,511 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Board is a project's workflow statuses in order, one column each.
+type Board struct {
+	Project sqlc.Project
+	Columns []BoardColumn
+}
+
+// BoardColumn is one workflow status with the number of root tasks in it.
+type BoardColumn struct {
+	Status    sqlc.ProjectStatus
+	TaskCount int
+}
+
+// OverWipLimit reports whether the column holds more tasks than its limit,
+// which happens when the limit is lowered below the current count.
+func (c BoardColumn) OverWipLimit() bool {
+	return exceedsWipLimit(c.Status.WipLimit, int64(c.TaskCount))
+}
+
+func (s *Service) CreateProjectStatus(ctx context.Context, in CreateProjectStatusInput) (sqlc.ProjectStatus, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.ProjectStatus{}, err
+	}
+
+	projectID, err := parseUUID(in.ProjectID, "project id")
+	if err != nil {
+		return sqlc.ProjectStatus{}, err
+	}
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return sqlc.ProjectStatus{}, NewBadInput("status name is required")
+	}
+	category, err := normalizeStatus(in.Category)
+	if err != nil {
+		return sqlc.ProjectStatus{}, err
+	}
+	if err := validateColor(in.Color); err != nil {
+		return sqlc.ProjectStatus{}, err
+	}
+	wipLimit, err := normalizeWipLimit(in.WipLimit)
+	if err != nil {
+		return sqlc.ProjectStatus{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var status sqlc.ProjectStatus
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		if _, err := q.GetProjectByID(tctx, sqlc.GetProjectByIDParams{ID: toPgUUID(projectID), UserID: toPgUUID(uid)}); err != nil {
+			return s.wrapDBError(err, "project not found")
+		}
+		if err := q.LockProjectStatuses(tctx, toPgUUID(projectID)); err != nil {
+			return s.wrapDBError(err, "failed to lock statuses")
+		}
+		status, err = q.CreateProjectStatus(tctx, sqlc.CreateProjectStatusParams{
+			UserID:    toPgUUID(uid),
+			ProjectID: toPgUUID(projectID),
+			Name:      name,
+			Category:  category,
+			Color:     in.Color,
+			WipLimit:  wipLimit,
+		})
+		if err != nil {
+			return s.wrapDBError(err, "failed to create status")
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.ProjectStatus{}, err
+	}
+	return status, nil
+}
+
+func (s *Service) UpdateProjectStatus(ctx context.Context, in UpdateProjectStatusInput) (sqlc.ProjectStatus, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.ProjectStatus{}, err
+	}
+
+	statusID, err := parseUUID(in.ID, "status id")
+	if err != nil {
+		return sqlc.ProjectStatus{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var (
+		status  sqlc.ProjectStatus
+		changed []sqlc.Task
+	)
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		existing, err := q.GetProjectStatusByID(tctx, sqlc.GetProjectStatusByIDParams{ID: toPgUUID(statusID), UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "status not found")
+		}
+		if err := q.LockProjectStatuses(tctx, existing.ProjectID); err != nil {
+			return s.wrapDBError(err, "failed to lock statuses")
+		}
+		// Re-read under the lock; a concurrent move may have shifted it.
+		existing, err = q.GetProjectStatusByID(tctx, sqlc.GetProjectStatusByIDParams{ID: toPgUUID(statusID), UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "status not found")
+		}
+
+		name := existing.Name
+		if in.Name != nil {
+			name = strings.TrimSpace(*in.Name)
+			if name == "" {
+				return NewBadInput("status name cannot be empty")
+			}
+		}
+
+		category := existing.Category
+		if in.Category != nil {
+			category, err = normalizeStatus(*in.Category)
+			if err != nil {
+				return err
+			}
+		}
+
+		color := existing.Color
+		if in.Color != nil {
+			if err := validateColor(in.Color); err != nil {
+				return err
+			}
+			color = in.Color
+		}
+		if in.ClearColor {
+			color = nil
+		}
+
+		wipLimit := existing.WipLimit
+		if in.WipLimit != nil {
+			wipLimit, err = normalizeWipLimit(in.WipLimit)
+			if err != nil {
+				return err
+			}
+		}
+		if in.ClearWipLimit {
+			wipLimit = nil
+		}
+
+		position := existing.Position
+		if in.Position != nil {
+			count, err := q.CountProjectStatuses(tctx, sqlc.CountProjectStatusesParams{ProjectID: existing.ProjectID, UserID: toPgUUID(uid)})
+			if err != nil {
+				return s.wrapDBError(err, "failed to count statuses")
+			}
+			var delta, from, to int32
+			position, delta, from, to = reorderShift(existing.Position, *in.Position, count)
+			if delta != 0 {
+				shift := sqlc.ShiftProjectStatusesParams{ProjectID: existing.ProjectID, UserID: toPgUUID(uid), Delta: delta, FromPosition: from, ToPosition: to}
+				if err := q.ShiftProjectStatuses(tctx, shift); err != nil {
+					return s.wrapDBError(err, "failed to reorder statuses")
+				}
+			}
+		}
+
+		status, err = q.UpdateProjectStatus(tctx, sqlc.UpdateProjectStatusParams{
+			ID:       existing.ID,
+			UserID:   toPgUUID(uid),
+			Name:     name,
+			Category: category,
+			Color:    color,
+			WipLimit: wipLimit,
+			Position: position,
+		})
+		if err != nil {
+			return s.wrapDBError(err, "failed to update status")
+		}
+
+		if category != existing.Category {
+			rows, err := q.SetTaskCategoryForStatus(tctx, sqlc.SetTaskCategoryForStatusParams{
+				Category: category,
+				StatusID: existing.ID,
+				UserID:   toPgUUID(uid),
+			})
+			if err != nil {
+				return s.wrapDBError(err, "failed to update tasks in status")
+			}
+			for _, row := range rows {
+				t := sqlc.Task(row)
+				if err := s.recordEvent(tctx, q, uid, EventTaskUpdated, taskPayload(t)); err != nil {
+					return err
+				}
+				changed = append(changed, t)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.ProjectStatus{}, err
+	}
+	for _, t := range changed {
+		s.publishTask(TaskUpdated, t)
+	}
+	return status, nil
+}
+
+// DeleteProjectStatus removes a workflow status. Its tasks move to
+// moveTasksTo, a status of the same project, or when that is nil to the first
+// other status with the same category. It fails when the moved root tasks
+// would take the target past its WIP limit. A project always keeps at least
+// one status.
+func (s *Service) DeleteProjectStatus(ctx context.Context, id string, moveTasksTo *string) (DeleteResult, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	statusID, err := parseUUID(id, "status id")
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var (
+		result DeleteResult
+		moved  []sqlc.Task
+	)
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		existing, err := q.GetProjectStatusByID(tctx, sqlc.GetProjectStatusByIDParams{ID: toPgUUID(statusID), UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "status not found")
+		}
+		if err := q.LockProjectStatuses(tctx, existing.ProjectID); err != nil {
+			return s.wrapDBError(err, "failed to lock statuses")
+		}
+
+		var target sqlc.ProjectStatus
+		if moveTasksTo != nil && strings.TrimSpace(*moveTasksTo) != "" {
+			target, err = s.resolveStatus(tctx, q, uid, *moveTasksTo, fromPgUUID(existing.ProjectID))
+			if err != nil {
+				return err
+			}
+			if target.ID == existing.ID {
+				return NewBadInput("cannot move tasks to the status being deleted")
+			}
+		} else {
+			statuses, err := q.ListProjectStatuses(tctx, sqlc.ListProjectStatusesParams{ProjectID: existing.ProjectID, UserID: toPgUUID(uid)})
+			if err != nil {
+				return s.wrapDBError(err, "failed to list statuses")
+			}
+			if len(statuses) <= 1 {
+				return NewBadInput("a project needs at least one status")
+			}
+			found := false
+			for _, st := range statuses {
+				if st.ID != existing.ID && st.Category == existing.Category {
+					target, found = st, true
+					break
+				}
+			}
+			if !found {
+				return NewBadInput(fmt.Sprintf("no other %s status to move tasks to; set moveTasksToStatusId", existing.Category))
+			}
+		}
+		if err := s.checkWipLimitForMove(tctx, q, uid, existing.ID, target.ID); err != nil {
+			return err
+		}
+
+		rows, err := q.MoveTasksToStatus(tctx, sqlc.MoveTasksToStatusParams{
+			NewStatusID: target.ID,
+			Category:    target.Category,
+			StatusID:    existing.ID,
+			UserID:      toPgUUID(uid),
+		})
+		if err != nil {
+			return s.wrapDBError(err, "failed to move status tasks")
+		}
+		for _, row := range rows {
+			t := sqlc.Task(row)
+			if err := s.recordEvent(tctx, q, uid, EventTaskUpdated, taskPayload(t)); err != nil {
+				return err
+			}
+			moved = append(moved, t)
+		}
+
+		deleted, err := q.SoftDeleteProjectStatus(tctx, sqlc.SoftDeleteProjectStatusParams{ID: existing.ID, UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "status not found")
+		}
+		if err := q.ShiftProjectStatuses(tctx, sqlc.ShiftProjectStatusesParams{
+			Delta:        -1,
+			ProjectID:    deleted.ProjectID,
+			UserID:       toPgUUID(uid),
+			FromPosition: deleted.Position + 1,
+			ToPosition:   math.MaxInt32,
+		}); err != nil {
+			return s.wrapDBError(err, "failed to reorder statuses")
+		}
+
+		result = DeleteResult{ID: fromPgUUID(deleted.ID), DeletedAt: deleted.DeletedAt.Time.UTC()}
+		return nil
+	})
+	if err != nil {
+		return DeleteResult{}, err
+	}
+	for _, t := range moved {
+		s.publishTask(TaskUpdated, t)
+	}
+	return result, nil
+}
+
+// ProjectStatuses lists a project's workflow statuses in order.
+func (s *Service) ProjectStatuses(ctx context.Context, projectID string) ([]sqlc.ProjectStatus, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pid, err := parseUUID(projectID, "project id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	statuses, err := s.store.Queries().ListProjectStatuses(tctx, sqlc.ListProjectStatusesParams{ProjectID: toPgUUID(pid), UserID: toPgUUID(uid)})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to list statuses")
+	}
+	return statuses, nil
+}
+
+// ProjectStatus returns a workflow status, or nil when it does not exist.
+func (s *Service) ProjectStatus(ctx context.Context, statusID string) (*sqlc.ProjectStatus, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := parseUUID(statusID, "status id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	status, err := s.store.Queries().GetProjectStatusByID(tctx, sqlc.GetProjectStatusByIDParams{ID: toPgUUID(id), UserID: toPgUUID(uid)})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, s.wrapDBError(err, "failed to fetch status")
+	}
+	return &status, nil
+}
+
+// Board returns the project's statuses as columns with their root task
+// counts, or nil when the project does not exist.
+func (s *Service) Board(ctx context.Context, projectID string) (*Board, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pid, err := parseUUID(projectID, "project id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var board *Board
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		project, err := q.GetProjectByID(tctx, sqlc.GetProjectByIDParams{ID: toPgUUID(pid), UserID: toPgUUID(uid)})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			return s.wrapDBError(err, "failed to fetch project")
+		}
+		statuses, err := q.ListProjectStatuses(tctx, sqlc.ListProjectStatusesParams{ProjectID: project.ID, UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "failed to list statuses")
+		}
+		counts, err := q.CountRootTasksByStatus(tctx, sqlc.CountRootTasksByStatusParams{ProjectID: project.ID, UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "failed to count tasks")
+		}
+		byStatus := make(map[pgtype.UUID]int, len(counts))
+		for _, c := range counts {
+			byStatus[c.StatusID] = int(c.TaskCount)
+		}
+
+		board = &Board{Project: project, Columns: make([]BoardColumn, 0, len(statuses))}
+		for _, st := range statuses {
+			board.Columns = append(board.Columns, BoardColumn{Status: st, TaskCount: byStatus[st.ID]})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+// resolveStatus checks that raw names a live status of projectID.
+func (s *Service) resolveStatus(ctx context.Context, q *sqlc.Queries, uid uuid.UUID, raw string, projectID uuid.UUID) (sqlc.ProjectStatus, error) {
+	statusID, err := parseUUID(raw, "status id")
+	if err != nil {
+		return sqlc.ProjectStatus{}, err
+	}
+	status, err := q.GetProjectStatusByID(ctx, sqlc.GetProjectStatusByIDParams{ID: toPgUUID(statusID), UserID: toPgUUID(uid)})
+	if err != nil {
+		return sqlc.ProjectStatus{}, s.wrapDBError(err, "status not found")
+	}
+	if fromPgUUID(status.ProjectID) != projectID {
+		return sqlc.ProjectStatus{}, NewBadInput("status must belong to the same project")
+	}
+	return status, nil
+}
+
+// statusForCategory returns the first workflow status of projectID in
+// category, which is where tasks land when only a category is given.
+func (s *Service) statusForCategory(ctx context.Context, q *sqlc.Queries, uid uuid.UUID, projectID pgtype.UUID, category string) (sqlc.ProjectStatus, error) {
+	status, err := q.FirstProjectStatusInCategory(ctx, sqlc.FirstProjectStatusInCategoryParams{ProjectID: projectID, UserID: toPgUUID(uid), Category: category})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.ProjectStatus{}, NewBadInput(fmt.Sprintf("project has no %s status; pick a statusId", category))
+		}
+		return sqlc.ProjectStatus{}, s.wrapDBError(err, "failed to resolve status")
+	}
+	return status, nil
+}
+
+// checkWipLimit fails with CONFLICT when one more root task would exceed the
+// status's WIP limit. It locks the status row so that concurrent moves into
+// the same column are counted one after another.
+func (s *Service) checkWipLimit(ctx context.Context, q *sqlc.Queries, uid uuid.UUID, statusID pgtype.UUID) error {
+	status, err := q.GetProjectStatusForUpdate(ctx, sqlc.GetProjectStatusForUpdateParams{ID: statusID, UserID: toPgUUID(uid)})
+	if err != nil {
+		return s.wrapDBError(err, "status not found")
+	}
+	if status.WipLimit == nil {
+		return nil
+	}
+	count, err := q.CountRootTasksInStatus(ctx, sqlc.CountRootTasksInStatusParams{StatusID: statusID, UserID: toPgUUID(uid)})
+	if err != nil {
+		return s.wrapDBError(err, "failed to count tasks in status")
+	}
+	if exceedsWipLimit(status.WipLimit, count+1) {
+		return NewConflict(fmt.Sprintf("status %q is at its WIP limit of %d", status.Name, *status.WipLimit), nil)
+	}
+	return nil
+}
+
+// checkWipLimitForMove fails with BAD_USER_INPUT when moving every root task
+// of status from into status to would exceed to's WIP limit. Like
+// checkWipLimit it locks the target status row.
+func (s *Service) checkWipLimitForMove(ctx context.Context, q *sqlc.Queries, uid uuid.UUID, from, to pgtype.UUID) error {
+	status, err := q.GetProjectStatusForUpdate(ctx, sqlc.GetProjectStatusForUpdateParams{ID: to, UserID: toPgUUID(uid)})
+	if err != nil {
+		return s.wrapDBError(err, "status not found")
+	}
+	if status.WipLimit == nil {
+		return nil
+	}
+	current, err := q.CountRootTasksInStatus(ctx, sqlc.CountRootTasksInStatusParams{StatusID: to, UserID: toPgUUID(uid)})
+	if err != nil {
+		return s.wrapDBError(err, "failed to count tasks in status")
+	}
+	moving, err := q.CountRootTasksInStatus(ctx, sqlc.CountRootTasksInStatusParams{StatusID: from, UserID: toPgUUID(uid)})
+	if err != nil {
+		return s.wrapDBError(err, "failed to count tasks in status")
+	}
+	if exceedsWipLimit(status.WipLimit, current+moving) {
+		return NewBadInput(fmt.Sprintf("moving %d tasks into status %q would exceed its WIP limit of %d", moving, status.Name, *status.WipLimit))
+	}
+	return nil
+}
+
+// exceedsWipLimit reports whether count root tasks are more than limit
+// allows; a nil limit allows any number.
+func exceedsWipLimit(limit *int32, count int64) bool {
+	return limit != nil && count > int64(*limit)
+}
+
+func normalizeWipLimit(limit *int) (*int32, error) {
+	if limit == nil {
+		return nil, nil
+	}
+	if *limit < 1 || *limit > math.MaxInt32 {
+		return nil, NewBadInput("wipLimit must be a positive number")
+	}
+	v := int32(*limit)
+	return &v, nil
+}
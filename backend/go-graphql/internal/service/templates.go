@@ -0,0 +1,561 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	TemplateKindProject = "PROJECT"
+	TemplateKindTask    = "TASK"
+	// maxTemplateTasks bounds the tasks saved into one template.
+	maxTemplateTasks = 500
+	// templateTasksPerTimeout is how many tasks instantiation may create per
+	// queryTimeout; each task takes about ten queries.
+	templateTasksPerTimeout = 25
+)
+
+// Template is a saved template with its content decoded.
+type Template struct {
+	ID   uuid.UUID
+	Name string
+	Kind string
+	// Project is set for PROJECT templates.
+	Project   *TemplateProject
+	Tasks     []TemplateTask
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// templateContent is the JSON stored in templates.content.
+type templateContent struct {
+	Project *TemplateProject `json:"project,omitempty"`
+	Tasks   []TemplateTask   `json:"tasks"`
+}
+
+type TemplateProject struct {
+	Title               string  `json:"title"`
+	Description         *string `json:"description,omitempty"`
+	Color               *string `json:"color,omitempty"`
+	AutoCompleteParents bool    `json:"autoCompleteParents,omitempty"`
+}
+
+// TemplateTask is a task to create, with its subtasks. Labels that were
+// deleted since the template was saved are skipped.
+type TemplateTask struct {
+	Title           string         `json:"title"`
+	Description     *string        `json:"description,omitempty"`
+	Priority        string         `json:"priority"`
+	EstimateMinutes *int           `json:"estimateMinutes,omitempty"`
+	LabelIDs        []string       `json:"labelIds,omitempty"`
+	StartAt         *TemplateDate  `json:"startAt,omitempty"`
+	DueAt           *TemplateDate  `json:"dueAt,omitempty"`
+	Subtasks        []TemplateTask `json:"subtasks,omitempty"`
+}
+
+// TemplateDate is a time of day, Minute after local midnight, Days after the
+// day a template is instantiated on.
+type TemplateDate struct {
+	Days   int `json:"days"`
+	Minute int `json:"minute"`
+}
+
+// on resolves d against start, a local midnight.
+func (d TemplateDate) on(start time.Time) time.Time {
+	y, m, day := start.Date()
+	return time.Date(y, m, day+d.Days, 0, d.Minute, 0, 0, start.Location())
+}
+
+// SaveTemplateInput names exactly one of ProjectID and TaskID. Name defaults
+// to the project's or task's title.
+type SaveTemplateInput struct {
+	ProjectID *string
+	TaskID    *string
+	Name      *string
+}
+
+// InstantiateTemplateInput creates a template's tasks with their dates
+// counted from StartDate's day. Title renames the new project, or the root
+// task of a TASK template; ProjectID is where a TASK template's tasks go.
+type InstantiateTemplateInput struct {
+	TemplateID string
+	StartDate  time.Time
+	Title      *string
+	ProjectID  *string
+}
+
+// TemplateInstance is what instantiating a template created: the project
+// (or, for a TASK template, the project it was added to) and its tasks,
+// parents before their subtasks.
+type TemplateInstance struct {
+	Project sqlc.Project
+	Tasks   []sqlc.Task
+}
+
+// SaveAsTemplate snapshots a project's live tasks, or one task and its
+// subtasks, with descriptions, priorities, estimates and labels. Dates are
+// kept relative to the earliest start or due date among the tasks.
+func (s *Service) SaveAsTemplate(ctx context.Context, in SaveTemplateInput) (Template, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return Template{}, err
+	}
+
+	hasProject := in.ProjectID != nil && strings.TrimSpace(*in.ProjectID) != ""
+	hasTask := in.TaskID != nil && strings.TrimSpace(*in.TaskID) != ""
+	if hasProject == hasTask {
+		return Template{}, NewBadInput("pass exactly one of projectId and taskId")
+	}
+
+	loc, err := s.userLocation(ctx)
+	if err != nil {
+		return Template{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	q := s.store.Queries()
+	var (
+		kind, name string
+		content    templateContent
+		tasks      []sqlc.Task
+	)
+	if hasProject {
+		projectID, err := parseUUID(*in.ProjectID, "project id")
+		if err != nil {
+			return Template{}, err
+		}
+		project, err := q.GetProjectByID(tctx, sqlc.GetProjectByIDParams{ID: toPgUUID(projectID), UserID: toPgUUID(uid)})
+		if err != nil {
+			return Template{}, s.wrapDBError(err, "project not found")
+		}
+		tasks, err = q.ListProjectTasks(tctx, sqlc.ListProjectTasksParams{
+			UserID:    toPgUUID(uid),
+			ProjectID: project.ID,
+			Limit:     maxTemplateTasks + 1,
+		})
+		if err != nil {
+			return Template{}, s.wrapDBError(err, "failed to load project tasks")
+		}
+		kind, name = TemplateKindProject, project.Title
+		content.Project = &TemplateProject{
+			Title:               project.Title,
+			Description:         project.Description,
+			Color:               project.Color,
+			AutoCompleteParents: project.AutoCompleteParents,
+		}
+	} else {
+		taskID, err := parseUUID(*in.TaskID, "task id")
+		if err != nil {
+			return Template{}, err
+		}
+		root, err := q.GetTaskByID(tctx, sqlc.GetTaskByIDParams{ID: toPgUUID(taskID), UserID: toPgUUID(uid)})
+		if err != nil {
+			return Template{}, s.wrapDBError(err, "task not found")
+		}
+		subtasks, err := q.ListSubtasksByParentID(tctx, sqlc.ListSubtasksByParentIDParams{UserID: toPgUUID(uid), ParentTaskID: root.ID})
+		if err != nil {
+			return Template{}, s.wrapDBError(err, "failed to load subtasks")
+		}
+		// Oldest first, like a project's tasks.
+		slices.Reverse(subtasks)
+		tasks = append([]sqlc.Task{root}, subtasks...)
+		kind, name = TemplateKindTask, root.Title
+	}
+	if len(tasks) > maxTemplateTasks {
+		return Template{}, NewBadInput(fmt.Sprintf("a template holds at most %d tasks", maxTemplateTasks))
+	}
+	if in.Name != nil {
+		name = strings.TrimSpace(*in.Name)
+	}
+	if name == "" {
+		return Template{}, NewBadInput("template name is required")
+	}
+
+	taskIDs := make([]pgtype.UUID, 0, len(tasks))
+	for _, t := range tasks {
+		taskIDs = append(taskIDs, t.ID)
+	}
+	labelRows, err := q.ListLabelsForTasks(tctx, sqlc.ListLabelsForTasksParams{TaskIds: taskIDs, UserID: toPgUUID(uid)})
+	if err != nil {
+		return Template{}, s.wrapDBError(err, "failed to load task labels")
+	}
+	labels := map[pgtype.UUID][]string{}
+	for _, row := range labelRows {
+		labels[row.TaskID] = append(labels[row.TaskID], fromPgUUID(row.ID).String())
+	}
+	content.Tasks = buildTemplateTasks(tasks, labels, loc)
+
+	raw, err := json.Marshal(content)
+	if err != nil {
+		return Template{}, NewInternal("failed to encode template", err)
+	}
+	row, err := q.CreateTemplate(tctx, sqlc.CreateTemplateParams{
+		UserID:  toPgUUID(uid),
+		Name:    name,
+		Kind:    kind,
+		Content: raw,
+	})
+	if err != nil {
+		return Template{}, s.wrapDBError(err, "failed to save template")
+	}
+	return templateFromRow(row)
+}
+
+// InstantiateTemplate creates a template's project and tasks, or a TASK
+// template's tasks in an existing project, in one transaction. Every task
+// goes through the same validation as CreateTask.
+func (s *Service) InstantiateTemplate(ctx context.Context, in InstantiateTemplateInput) (TemplateInstance, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return TemplateInstance{}, err
+	}
+
+	templateID, err := parseUUID(in.TemplateID, "template id")
+	if err != nil {
+		return TemplateInstance{}, err
+	}
+	hasProject := in.ProjectID != nil && strings.TrimSpace(*in.ProjectID) != ""
+
+	loc, err := s.userLocation(ctx)
+	if err != nil {
+		return TemplateInstance{}, err
+	}
+	start := localDay(in.StartDate, loc)
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	row, err := s.store.Queries().GetTemplateByID(tctx, sqlc.GetTemplateByIDParams{ID: toPgUUID(templateID), UserID: toPgUUID(uid)})
+	if err != nil {
+		return TemplateInstance{}, s.wrapDBError(err, "template not found")
+	}
+	tmpl, err := templateFromRow(row)
+	if err != nil {
+		return TemplateInstance{}, err
+	}
+	cancel()
+
+	// A large template runs far more queries than one request normally does,
+	// so the transaction gets a deadline sized to it. The request's own
+	// deadline (REQUEST_TIMEOUT) still applies on top.
+	tctx, cancel = context.WithTimeout(ctx, s.instantiateTimeout(tmpl.Tasks))
+	defer cancel()
+
+	var instance TemplateInstance
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		tasks := tmpl.Tasks
+		switch tmpl.Kind {
+		case TemplateKindProject:
+			if hasProject {
+				return NewBadInput("projectId only applies to task templates")
+			}
+			p := TemplateProject{Title: tmpl.Name}
+			if tmpl.Project != nil {
+				p = *tmpl.Project
+			}
+			if in.Title != nil {
+				p.Title = *in.Title
+			}
+			instance.Project, err = s.createProject(tctx, q, uid, CreateProjectInput{
+				Title:               p.Title,
+				Description:         p.Description,
+				Color:               p.Color,
+				AutoCompleteParents: p.AutoCompleteParents,
+			})
+			if err != nil {
+				return err
+			}
+		default:
+			if !hasProject {
+				return NewBadInput("projectId is required to instantiate a task template")
+			}
+			projectID, err := parseUUID(*in.ProjectID, "project id")
+			if err != nil {
+				return err
+			}
+			instance.Project, err = q.GetProjectByID(tctx, sqlc.GetProjectByIDParams{ID: toPgUUID(projectID), UserID: toPgUUID(uid)})
+			if err != nil {
+				return s.wrapDBError(err, "project not found")
+			}
+			if in.Title != nil && len(tasks) > 0 {
+				tasks = slices.Clone(tasks)
+				tasks[0].Title = *in.Title
+			}
+		}
+
+		liveLabels, err := s.liveTemplateLabels(tctx, q, uid, tasks)
+		if err != nil {
+			return err
+		}
+		projectID := fromPgUUID(instance.Project.ID).String()
+		var create func(t TemplateTask, parentID *string) error
+		create = func(t TemplateTask, parentID *string) error {
+			task, err := s.createTask(tctx, q, uid, templateTaskInput(t, projectID, parentID, start, liveLabels))
+			if err != nil {
+				return err
+			}
+			instance.Tasks = append(instance.Tasks, task)
+			id := fromPgUUID(task.ID).String()
+			for _, sub := range t.Subtasks {
+				if err := create(sub, &id); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		for _, t := range tasks {
+			if err := create(t, nil); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return TemplateInstance{}, err
+	}
+
+	for _, t := range instance.Tasks {
+		s.publishTask(TaskCreated, t)
+	}
+	return instance, nil
+}
+
+// instantiateTimeout allows one queryTimeout per templateTasksPerTimeout
+// tasks, subtasks included.
+func (s *Service) instantiateTimeout(tasks []TemplateTask) time.Duration {
+	return s.queryTimeout * time.Duration(1+countTemplateTasks(tasks)/templateTasksPerTimeout)
+}
+
+func countTemplateTasks(tasks []TemplateTask) int {
+	n := len(tasks)
+	for _, t := range tasks {
+		n += countTemplateTasks(t.Subtasks)
+	}
+	return n
+}
+
+func (s *Service) Templates(ctx context.Context) ([]Template, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.store.Queries().ListTemplates(tctx, toPgUUID(uid))
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to list templates")
+	}
+	out := make([]Template, 0, len(rows))
+	for _, row := range rows {
+		t, err := templateFromRow(row)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func (s *Service) Template(ctx context.Context, id string) (*Template, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templateID, err := parseUUID(id, "template id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	row, err := s.store.Queries().GetTemplateByID(tctx, sqlc.GetTemplateByIDParams{ID: toPgUUID(templateID), UserID: toPgUUID(uid)})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, s.wrapDBError(err, "failed to fetch template")
+	}
+	t, err := templateFromRow(row)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (s *Service) DeleteTemplate(ctx context.Context, id string) (DeleteResult, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	templateID, err := parseUUID(id, "template id")
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	deleted, err := s.store.Queries().SoftDeleteTemplate(tctx, sqlc.SoftDeleteTemplateParams{
+		ID:     toPgUUID(templateID),
+		UserID: toPgUUID(uid),
+	})
+	if err != nil {
+		return DeleteResult{}, s.wrapDBError(err, "template not found")
+	}
+	return DeleteResult{ID: fromPgUUID(deleted.ID), DeletedAt: deleted.DeletedAt.Time.UTC()}, nil
+}
+
+// liveTemplateLabels returns the labels referenced by tasks that still exist.
+func (s *Service) liveTemplateLabels(ctx context.Context, q *sqlc.Queries, uid uuid.UUID, tasks []TemplateTask) (map[string]bool, error) {
+	var ids []pgtype.UUID
+	var collect func([]TemplateTask)
+	collect = func(tasks []TemplateTask) {
+		for _, t := range tasks {
+			for _, raw := range t.LabelIDs {
+				if id, err := uuid.Parse(raw); err == nil {
+					ids = append(ids, toPgUUID(id))
+				}
+			}
+			collect(t.Subtasks)
+		}
+	}
+	collect(tasks)
+
+	live := map[string]bool{}
+	if len(ids) == 0 {
+		return live, nil
+	}
+	labels, err := q.GetLabelsByIDs(ctx, sqlc.GetLabelsByIDsParams{UserID: toPgUUID(uid), Column2: ids})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to load labels")
+	}
+	for _, l := range labels {
+		live[fromPgUUID(l.ID).String()] = true
+	}
+	return live, nil
+}
+
+func templateTaskInput(t TemplateTask, projectID string, parentID *string, start time.Time, liveLabels map[string]bool) CreateTaskInput {
+	in := CreateTaskInput{
+		ProjectID:       projectID,
+		ParentTaskID:    parentID,
+		Title:           t.Title,
+		Description:     t.Description,
+		Priority:        t.Priority,
+		EstimateMinutes: t.EstimateMinutes,
+		LabelIDs:        []string{},
+	}
+	if t.StartAt != nil {
+		v := t.StartAt.on(start)
+		in.StartAt = &v
+	}
+	if t.DueAt != nil {
+		v := t.DueAt.on(start)
+		in.DueAt = &v
+	}
+	for _, id := range t.LabelIDs {
+		if liveLabels[id] {
+			in.LabelIDs = append(in.LabelIDs, id)
+		}
+	}
+	return in
+}
+
+// buildTemplateTasks nests tasks under their parents, keeping their order. A
+// task whose parent is not among tasks is a root. Dates become days after
+// the earliest start or due date, in loc.
+func buildTemplateTasks(tasks []sqlc.Task, labels map[pgtype.UUID][]string, loc *time.Location) []TemplateTask {
+	var anchor time.Time
+	for _, t := range tasks {
+		for _, d := range []pgtype.Timestamptz{t.StartAt, t.DueAt} {
+			if !d.Valid {
+				continue
+			}
+			if day := localDay(d.Time, loc); anchor.IsZero() || day.Before(anchor) {
+				anchor = day
+			}
+		}
+	}
+	relative := func(d pgtype.Timestamptz) *TemplateDate {
+		if !d.Valid {
+			return nil
+		}
+		local := d.Time.In(loc)
+		days := math.Round(localDay(local, loc).Sub(anchor).Hours() / 24)
+		return &TemplateDate{Days: int(days), Minute: local.Hour()*60 + local.Minute()}
+	}
+
+	present := make(map[pgtype.UUID]bool, len(tasks))
+	for _, t := range tasks {
+		present[t.ID] = true
+	}
+	children := map[pgtype.UUID][]sqlc.Task{}
+	var roots []sqlc.Task
+	for _, t := range tasks {
+		if t.ParentTaskID.Valid && present[t.ParentTaskID] {
+			children[t.ParentTaskID] = append(children[t.ParentTaskID], t)
+		} else {
+			roots = append(roots, t)
+		}
+	}
+
+	var convert func([]sqlc.Task) []TemplateTask
+	convert = func(tasks []sqlc.Task) []TemplateTask {
+		out := make([]TemplateTask, 0, len(tasks))
+		for _, t := range tasks {
+			tt := TemplateTask{
+				Title:       t.Title,
+				Description: t.Description,
+				Priority:    t.Priority,
+				LabelIDs:    labels[t.ID],
+				StartAt:     relative(t.StartAt),
+				DueAt:       relative(t.DueAt),
+				Subtasks:    convert(children[t.ID]),
+			}
+			if t.EstimateMinutes != nil {
+				v := int(*t.EstimateMinutes)
+				tt.EstimateMinutes = &v
+			}
+			out = append(out, tt)
+		}
+		return out
+	}
+	return convert(roots)
+}
+
+func templateFromRow(row sqlc.Template) (Template, error) {
+	var content templateContent
+	if err := json.Unmarshal(row.Content, &content); err != nil {
+		return Template{}, NewInternal("failed to decode template", err)
+	}
+	if content.Tasks == nil {
+		content.Tasks = []TemplateTask{}
+	}
+	return Template{
+		ID:        fromPgUUID(row.ID),
+		Name:      row.Name,
+		Kind:      row.Kind,
+		Project:   content.Project,
+		Tasks:     content.Tasks,
+		CreatedAt: row.CreatedAt.Time.UTC(),
+		UpdatedAt: row.UpdatedAt.Time.UTC(),
+	}, nil
+}
@@ -0,0 +1,503 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Groupings of a time report.
+const (
+	ReportByProject = "PROJECT"
+	ReportByLabel   = "LABEL"
+	ReportByDay     = "DAY"
+)
+
+// maxReportSpan bounds a time report so DAY reports stay small.
+const maxReportSpan = 366 * 24 * time.Hour
+
+const timerRunningMessage = "a timer is already running; stop it first"
+
+// TimeReport totals tracked time over [From, To). Entries are clipped to the
+// range and running timers count up to now.
+type TimeReport struct {
+	From    time.Time
+	To      time.Time
+	GroupBy string
+	// TotalSeconds counts each entry once, even when it falls in several
+	// LABEL buckets.
+	TotalSeconds int64
+	Buckets      []TimeReportBucket
+}
+
+// TimeReportBucket is one group of a report. Key is the project or label ID,
+// or the day as YYYY-MM-DD; it is nil for the bucket of unlabelled tasks.
+type TimeReportBucket struct {
+	Key     *string
+	Name    string
+	Seconds int64
+}
+
+// StartTimer starts a timer on a task. Only one timer runs per user; starting
+// another while one runs fails with CONFLICT.
+func (s *Service) StartTimer(ctx context.Context, taskID string, note *string) (sqlc.TimeEntry, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.TimeEntry{}, err
+	}
+
+	tid, err := parseUUID(taskID, "task id")
+	if err != nil {
+		return sqlc.TimeEntry{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var entry sqlc.TimeEntry
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		if _, err := q.GetTaskByID(tctx, sqlc.GetTaskByIDParams{ID: toPgUUID(tid), UserID: toPgUUID(uid)}); err != nil {
+			return s.wrapDBError(err, "task not found")
+		}
+		if _, err := q.GetRunningTimeEntry(tctx, toPgUUID(uid)); err == nil {
+			return NewConflict(timerRunningMessage, nil)
+		} else if !errors.Is(err, pgx.ErrNoRows) {
+			return s.wrapDBError(err, "failed to check running timer")
+		}
+
+		entry, err = q.StartTimeEntry(tctx, sqlc.StartTimeEntryParams{
+			UserID: toPgUUID(uid),
+			TaskID: toPgUUID(tid),
+			Note:   trimmedOrNil(note),
+		})
+		if err != nil {
+			// A timer started concurrently trips the one-running-timer index.
+			if wrapped := s.wrapDBError(err, "failed to start timer"); IsAppErrorCode(wrapped, CodeConflict) {
+				return NewConflict(timerRunningMessage, err)
+			}
+			return s.wrapDBError(err, "failed to start timer")
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+// StopTimer stops the user's running timer and returns the finished entry.
+func (s *Service) StopTimer(ctx context.Context) (sqlc.TimeEntry, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.TimeEntry{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	entry, err := s.store.Queries().StopRunningTimeEntry(tctx, toPgUUID(uid))
+	if err != nil {
+		return sqlc.TimeEntry{}, s.wrapDBError(err, "no timer is running")
+	}
+	return entry, nil
+}
+
+// RunningTimer returns the user's running timer, or nil when none runs.
+func (s *Service) RunningTimer(ctx context.Context) (*sqlc.TimeEntry, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	entry, err := s.store.Queries().GetRunningTimeEntry(tctx, toPgUUID(uid))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, s.wrapDBError(err, "failed to fetch running timer")
+	}
+	return &entry, nil
+}
+
+func (s *Service) CreateTimeEntry(ctx context.Context, in CreateTimeEntryInput) (sqlc.TimeEntry, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.TimeEntry{}, err
+	}
+
+	tid, err := parseUUID(in.TaskID, "task id")
+	if err != nil {
+		return sqlc.TimeEntry{}, err
+	}
+	if err := validateTimeEntry(in.StartedAt, &in.EndedAt, time.Now()); err != nil {
+		return sqlc.TimeEntry{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var entry sqlc.TimeEntry
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		if _, err := q.GetTaskByID(tctx, sqlc.GetTaskByIDParams{ID: toPgUUID(tid), UserID: toPgUUID(uid)}); err != nil {
+			return s.wrapDBError(err, "task not found")
+		}
+		entry, err = q.CreateTimeEntry(tctx, sqlc.CreateTimeEntryParams{
+			UserID:    toPgUUID(uid),
+			TaskID:    toPgUUID(tid),
+			StartedAt: toPgTime(&in.StartedAt),
+			EndedAt:   toPgTime(&in.EndedAt),
+			Note:      trimmedOrNil(in.Note),
+		})
+		if err != nil {
+			return s.wrapDBError(err, "failed to create time entry")
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+func (s *Service) UpdateTimeEntry(ctx context.Context, in UpdateTimeEntryInput) (sqlc.TimeEntry, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.TimeEntry{}, err
+	}
+
+	entryID, err := parseUUID(in.ID, "time entry id")
+	if err != nil {
+		return sqlc.TimeEntry{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var entry sqlc.TimeEntry
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		existing, err := q.GetTimeEntryByID(tctx, sqlc.GetTimeEntryByIDParams{ID: toPgUUID(entryID), UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "time entry not found")
+		}
+
+		taskID := existing.TaskID
+		if in.TaskID != nil {
+			tid, err := parseUUID(*in.TaskID, "task id")
+			if err != nil {
+				return err
+			}
+			task, err := q.GetTaskByID(tctx, sqlc.GetTaskByIDParams{ID: toPgUUID(tid), UserID: toPgUUID(uid)})
+			if err != nil {
+				return s.wrapDBError(err, "task not found")
+			}
+			taskID = task.ID
+		}
+
+		startedAt := existing.StartedAt.Time
+		if in.StartedAt != nil {
+			startedAt = *in.StartedAt
+		}
+		endedAt := fromPgTime(existing.EndedAt)
+		if in.EndedAt != nil {
+			endedAt = in.EndedAt
+		}
+		if err := validateTimeEntry(startedAt, endedAt, time.Now()); err != nil {
+			return err
+		}
+
+		note := existing.Note
+		if in.Note != nil {
+			note = trimmedOrNil(in.Note)
+		}
+
+		entry, err = q.UpdateTimeEntry(tctx, sqlc.UpdateTimeEntryParams{
+			ID:        existing.ID,
+			UserID:    toPgUUID(uid),
+			TaskID:    taskID,
+			StartedAt: toPgTime(&startedAt),
+			EndedAt:   toPgTime(endedAt),
+			Note:      note,
+		})
+		if err != nil {
+			return s.wrapDBError(err, "failed to update time entry")
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.TimeEntry{}, err
+	}
+	return entry, nil
+}
+
+func (s *Service) DeleteTimeEntry(ctx context.Context, id string) (DeleteResult, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	entryID, err := parseUUID(id, "time entry id")
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	deleted, err := s.store.Queries().SoftDeleteTimeEntry(tctx, sqlc.SoftDeleteTimeEntryParams{ID: toPgUUID(entryID), UserID: toPgUUID(uid)})
+	if err != nil {
+		return DeleteResult{}, s.wrapDBError(err, "time entry not found")
+	}
+	return DeleteResult{ID: fromPgUUID(deleted.ID), DeletedAt: deleted.DeletedAt.Time.UTC()}, nil
+}
+
+// TaskTimeEntries lists a task's own time entries, latest first.
+func (s *Service) TaskTimeEntries(ctx context.Context, taskID string) ([]sqlc.TimeEntry, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tid, err := parseUUID(taskID, "task id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	entries, err := s.store.Queries().ListTimeEntriesByTask(tctx, sqlc.ListTimeEntriesByTaskParams{TaskID: toPgUUID(tid), UserID: toPgUUID(uid)})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to list time entries")
+	}
+	return entries, nil
+}
+
+// TimeEntry returns a time entry, or nil when it does not exist.
+func (s *Service) TimeEntry(ctx context.Context, id string) (*sqlc.TimeEntry, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entryID, err := parseUUID(id, "time entry id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	entry, err := s.store.Queries().GetTimeEntryByID(tctx, sqlc.GetTimeEntryByIDParams{ID: toPgUUID(entryID), UserID: toPgUUID(uid)})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, s.wrapDBError(err, "failed to fetch time entry")
+	}
+	return &entry, nil
+}
+
+// TaskTimeSpentBatch returns the seconds tracked on each task in taskIDs and
+// its live subtasks, keyed by the canonical task ID, in one query.
+func (s *Service) TaskTimeSpentBatch(ctx context.Context, taskIDs []string) (map[string]int64, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]pgtype.UUID, 0, len(taskIDs))
+	out := make(map[string]int64, len(taskIDs))
+	for _, raw := range taskIDs {
+		id, err := parseUUID(raw, "task id")
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, toPgUUID(id))
+		out[id.String()] = 0
+	}
+	if len(ids) == 0 {
+		return out, nil
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.store.Queries().TaskTimeSpent(tctx, sqlc.TaskTimeSpentParams{TaskIds: ids, UserID: toPgUUID(uid)})
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to load time spent")
+	}
+	for _, row := range rows {
+		out[fromPgUUID(row.TaskID).String()] = row.Seconds
+	}
+	return out, nil
+}
+
+// TimeReport totals the user's tracked time over [from, to) by project, by
+// label or by day. Days are calendar days in the user's timezone and every
+// day of the range gets a bucket; entries spanning midnight are split.
+func (s *Service) TimeReport(ctx context.Context, from, to time.Time, groupBy string) (TimeReport, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return TimeReport{}, err
+	}
+
+	if !from.Before(to) {
+		return TimeReport{}, NewBadInput("report range is empty: from must be before to")
+	}
+	if to.Sub(from) > maxReportSpan {
+		return TimeReport{}, NewBadInput("report range cannot exceed 366 days")
+	}
+	switch groupBy {
+	case ReportByProject, ReportByLabel, ReportByDay:
+	default:
+		return TimeReport{}, NewBadInput("invalid report grouping")
+	}
+
+	user, err := s.Me(ctx)
+	if err != nil {
+		return TimeReport{}, err
+	}
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	rows, err := s.store.Queries().ListTimeEntriesForReport(tctx, sqlc.ListTimeEntriesForReportParams{
+		UserID:    toPgUUID(uid),
+		RangeFrom: toPgTime(&from),
+		RangeTo:   toPgTime(&to),
+	})
+	if err != nil {
+		return TimeReport{}, s.wrapDBError(err, "failed to load time entries")
+	}
+
+	var labels []sqlc.ListLabelsForTasksRow
+	if groupBy == ReportByLabel && len(rows) > 0 {
+		taskIDs := make([]pgtype.UUID, 0, len(rows))
+		for _, row := range rows {
+			taskIDs = append(taskIDs, row.TaskID)
+		}
+		labels, err = s.store.Queries().ListLabelsForTasks(tctx, sqlc.ListLabelsForTasksParams{TaskIds: taskIDs, UserID: toPgUUID(uid)})
+		if err != nil {
+			return TimeReport{}, s.wrapDBError(err, "failed to load task labels")
+		}
+	}
+	return buildTimeReport(rows, labels, from, to, groupBy, loc), nil
+}
+
+func buildTimeReport(rows []sqlc.ListTimeEntriesForReportRow, labels []sqlc.ListLabelsForTasksRow, from, to time.Time, groupBy string, loc *time.Location) TimeReport {
+	report := TimeReport{From: from, To: to, GroupBy: groupBy}
+	totals := map[string]time.Duration{}
+	names := map[string]string{}
+	var unlabelled time.Duration
+
+	labelsByTask := map[uuid.UUID][]sqlc.ListLabelsForTasksRow{}
+	for _, l := range labels {
+		task := fromPgUUID(l.TaskID)
+		labelsByTask[task] = append(labelsByTask[task], l)
+	}
+
+	var total time.Duration
+	for _, row := range rows {
+		start, end := maxTime(row.StartedAt.Time, from), minTime(row.EndedAt.Time, to)
+		if !start.Before(end) {
+			continue
+		}
+		total += end.Sub(start)
+
+		switch groupBy {
+		case ReportByProject:
+			key := fromPgUUID(row.ProjectID).String()
+			totals[key] += end.Sub(start)
+			names[key] = row.ProjectTitle
+		case ReportByLabel:
+			taskLabels := labelsByTask[fromPgUUID(row.TaskID)]
+			if len(taskLabels) == 0 {
+				unlabelled += end.Sub(start)
+			}
+			for _, l := range taskLabels {
+				key := fromPgUUID(l.ID).String()
+				totals[key] += end.Sub(start)
+				names[key] = l.Name
+			}
+		case ReportByDay:
+			for start.Before(end) {
+				local := start.In(loc)
+				midnight := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+				segEnd := minTime(midnight, end)
+				totals[local.Format(time.DateOnly)] += segEnd.Sub(start)
+				start = segEnd
+			}
+		}
+	}
+	report.TotalSeconds = int64(total / time.Second)
+
+	if groupBy == ReportByDay {
+		first := from.In(loc)
+		day := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc)
+		for day.Before(to) {
+			key := day.Format(time.DateOnly)
+			report.Buckets = append(report.Buckets, TimeReportBucket{Key: &key, Name: key, Seconds: int64(totals[key] / time.Second)})
+			day = time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, loc)
+		}
+		return report
+	}
+
+	for key, d := range totals {
+		report.Buckets = append(report.Buckets, TimeReportBucket{Key: &key, Name: names[key], Seconds: int64(d / time.Second)})
+	}
+	// Largest first; the unlabelled bucket goes last.
+	slices.SortFunc(report.Buckets, func(a, b TimeReportBucket) int {
+		return cmp.Or(cmp.Compare(b.Seconds, a.Seconds), cmp.Compare(a.Name, b.Name), cmp.Compare(*a.Key, *b.Key))
+	})
+	if unlabelled > 0 {
+		report.Buckets = append(report.Buckets, TimeReportBucket{Name: "No label", Seconds: int64(unlabelled / time.Second)})
+	}
+	return report
+}
+
+// validateTimeEntry checks an entry's span. A nil endedAt is a running timer,
+// which must not start in the future; finished entries must end after they
+// start and no later than now.
+func validateTimeEntry(startedAt time.Time, endedAt *time.Time, now time.Time) error {
+	if startedAt.After(now) {
+		return NewBadInput("time entries cannot start in the future")
+	}
+	if endedAt == nil {
+		return nil
+	}
+	if !endedAt.After(startedAt) {
+		return NewBadInput("time entries must end after they start")
+	}
+	if endedAt.After(now) {
+		return NewBadInput("time entries cannot end in the future")
+	}
+	return nil
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
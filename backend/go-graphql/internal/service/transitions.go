@@ -0,0 +1,327 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Fields a transition policy can require a task to have when it enters a
+// status category.
+const (
+	RequireBlockedReason = "BLOCKED_REASON"
+	RequireDescription   = "DESCRIPTION"
+	RequireStartAt       = "START_AT"
+	RequireDueAt         = "DUE_AT"
+)
+
+var requirementNames = map[string]string{
+	RequireBlockedReason: "a blocked reason",
+	RequireDescription:   "a description",
+	RequireStartAt:       "a start date",
+	RequireDueAt:         "a due date",
+}
+
+// statusOrder is the order categories are listed in policies.
+var statusOrder = []string{"TODO", "IN_PROGRESS", "BLOCKED", "DONE"}
+
+type StatusTransition struct {
+	From string
+	To   string
+}
+
+// StatusRequirement lists the fields a task needs to enter Status.
+type StatusRequirement struct {
+	Status string
+	Fields []string
+}
+
+// TransitionPolicy governs how a project's tasks move between status
+// categories. When Restricted is false every move is allowed and Transitions
+// lists all of them, so clients can treat both cases alike. Moves between
+// workflow statuses of the same category are always allowed.
+type TransitionPolicy struct {
+	ProjectID    uuid.UUID
+	Restricted   bool
+	Transitions  []StatusTransition
+	Requirements []StatusRequirement
+}
+
+// SetTransitionPolicyInput replaces a project's policy. No transitions lifts
+// the restriction on moves; requirements apply either way.
+type SetTransitionPolicyInput struct {
+	ProjectID    string
+	Transitions  []StatusTransition
+	Requirements []StatusRequirement
+}
+
+// statusFields is the part of a task that requirements look at.
+type statusFields struct {
+	BlockedReason *string
+	Description   *string
+	StartAt       *time.Time
+	DueAt         *time.Time
+}
+
+// Allows reports whether a task may move from one category to another.
+func (p TransitionPolicy) Allows(from, to string) bool {
+	if from == to || !p.Restricted {
+		return true
+	}
+	return slices.Contains(p.Transitions, StatusTransition{From: from, To: to})
+}
+
+// check validates a task entering category to. from is empty for new tasks,
+// which are only held to the requirements.
+func (p TransitionPolicy) check(from, to string, t statusFields) error {
+	if from != "" && !p.Allows(from, to) {
+		return NewBadInput(fmt.Sprintf("moving a task from %s to %s is not allowed in this project", from, to))
+	}
+	for _, req := range p.Requirements {
+		if req.Status != to {
+			continue
+		}
+		for _, field := range req.Fields {
+			var ok bool
+			switch field {
+			case RequireBlockedReason:
+				ok = t.BlockedReason != nil && strings.TrimSpace(*t.BlockedReason) != ""
+			case RequireDescription:
+				ok = t.Description != nil && strings.TrimSpace(*t.Description) != ""
+			case RequireStartAt:
+				ok = t.StartAt != nil
+			case RequireDueAt:
+				ok = t.DueAt != nil
+			}
+			if !ok {
+				return NewBadInput(fmt.Sprintf("tasks need %s to enter %s", requirementNames[field], to))
+			}
+		}
+	}
+	return nil
+}
+
+// TransitionPolicy returns the project's policy, or nil when the project
+// does not exist.
+func (s *Service) TransitionPolicy(ctx context.Context, projectID string) (*TransitionPolicy, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pid, err := parseUUID(projectID, "project id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var policy *TransitionPolicy
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		project, err := q.GetProjectByID(tctx, sqlc.GetProjectByIDParams{ID: toPgUUID(pid), UserID: toPgUUID(uid)})
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil
+			}
+			return s.wrapDBError(err, "failed to fetch project")
+		}
+		p, err := s.loadTransitionPolicy(tctx, q, uid, project.ID)
+		if err != nil {
+			return err
+		}
+		policy = &p
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+func (s *Service) SetTransitionPolicy(ctx context.Context, in SetTransitionPolicyInput) (TransitionPolicy, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return TransitionPolicy{}, err
+	}
+
+	pid, err := parseUUID(in.ProjectID, "project id")
+	if err != nil {
+		return TransitionPolicy{}, err
+	}
+	transitions, err := normalizeTransitions(in.Transitions)
+	if err != nil {
+		return TransitionPolicy{}, err
+	}
+	requirements, err := normalizeRequirements(in.Requirements)
+	if err != nil {
+		return TransitionPolicy{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var policy TransitionPolicy
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		project, err := q.GetProjectByID(tctx, sqlc.GetProjectByIDParams{ID: toPgUUID(pid), UserID: toPgUUID(uid)})
+		if err != nil {
+			return s.wrapDBError(err, "project not found")
+		}
+
+		if err := q.DeleteStatusTransitions(tctx, sqlc.DeleteStatusTransitionsParams{ProjectID: project.ID, UserID: toPgUUID(uid)}); err != nil {
+			return s.wrapDBError(err, "failed to replace transitions")
+		}
+		if len(transitions) > 0 {
+			params := sqlc.InsertStatusTransitionsParams{ProjectID: project.ID, UserID: toPgUUID(uid)}
+			for _, t := range transitions {
+				params.FromStatuses = append(params.FromStatuses, t.From)
+				params.ToStatuses = append(params.ToStatuses, t.To)
+			}
+			if err := q.InsertStatusTransitions(tctx, params); err != nil {
+				return s.wrapDBError(err, "failed to save transitions")
+			}
+		}
+
+		if err := q.DeleteStatusRequirements(tctx, sqlc.DeleteStatusRequirementsParams{ProjectID: project.ID, UserID: toPgUUID(uid)}); err != nil {
+			return s.wrapDBError(err, "failed to replace requirements")
+		}
+		if len(requirements) > 0 {
+			params := sqlc.InsertStatusRequirementsParams{ProjectID: project.ID, UserID: toPgUUID(uid)}
+			for _, r := range requirements {
+				for _, field := range r.Fields {
+					params.Statuses = append(params.Statuses, r.Status)
+					params.Fields = append(params.Fields, field)
+				}
+			}
+			if err := q.InsertStatusRequirements(tctx, params); err != nil {
+				return s.wrapDBError(err, "failed to save requirements")
+			}
+		}
+
+		policy, err = s.loadTransitionPolicy(tctx, q, uid, project.ID)
+		return err
+	})
+	if err != nil {
+		return TransitionPolicy{}, err
+	}
+	return policy, nil
+}
+
+func (s *Service) loadTransitionPolicy(ctx context.Context, q *sqlc.Queries, uid uuid.UUID, projectID pgtype.UUID) (TransitionPolicy, error) {
+	policy := TransitionPolicy{ProjectID: fromPgUUID(projectID)}
+
+	rows, err := q.ListStatusTransitions(ctx, sqlc.ListStatusTransitionsParams{ProjectID: projectID, UserID: toPgUUID(uid)})
+	if err != nil {
+		return TransitionPolicy{}, s.wrapDBError(err, "failed to load transitions")
+	}
+	for _, row := range rows {
+		policy.Transitions = append(policy.Transitions, StatusTransition{From: row.FromStatus, To: row.ToStatus})
+	}
+	policy.Restricted = len(policy.Transitions) > 0
+	if !policy.Restricted {
+		for _, from := range statusOrder {
+			for _, to := range statusOrder {
+				if from != to {
+					policy.Transitions = append(policy.Transitions, StatusTransition{From: from, To: to})
+				}
+			}
+		}
+	}
+	sortTransitions(policy.Transitions)
+
+	reqs, err := q.ListStatusRequirements(ctx, sqlc.ListStatusRequirementsParams{ProjectID: projectID, UserID: toPgUUID(uid)})
+	if err != nil {
+		return TransitionPolicy{}, s.wrapDBError(err, "failed to load requirements")
+	}
+	for _, row := range reqs {
+		i := slices.IndexFunc(policy.Requirements, func(r StatusRequirement) bool { return r.Status == row.Status })
+		if i < 0 {
+			policy.Requirements = append(policy.Requirements, StatusRequirement{Status: row.Status})
+			i = len(policy.Requirements) - 1
+		}
+		policy.Requirements[i].Fields = append(policy.Requirements[i].Fields, row.Field)
+	}
+	slices.SortFunc(policy.Requirements, func(a, b StatusRequirement) int {
+		return slices.Index(statusOrder, a.Status) - slices.Index(statusOrder, b.Status)
+	})
+	return policy, nil
+}
+
+func normalizeTransitions(in []StatusTransition) ([]StatusTransition, error) {
+	out := make([]StatusTransition, 0, len(in))
+	for _, t := range in {
+		from, err := strictStatus(t.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := strictStatus(t.To)
+		if err != nil {
+			return nil, err
+		}
+		if from == to {
+			return nil, NewBadInput(fmt.Sprintf("transition from %s to itself is always allowed; leave it out", from))
+		}
+		pair := StatusTransition{From: from, To: to}
+		if !slices.Contains(out, pair) {
+			out = append(out, pair)
+		}
+	}
+	sortTransitions(out)
+	return out, nil
+}
+
+func normalizeRequirements(in []StatusRequirement) ([]StatusRequirement, error) {
+	out := make([]StatusRequirement, 0, len(in))
+	for _, r := range in {
+		status, err := strictStatus(r.Status)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(out, func(o StatusRequirement) bool { return o.Status == status }) {
+			return nil, NewBadInput(fmt.Sprintf("requirements for %s are listed twice", status))
+		}
+		fields := make([]string, 0, len(r.Fields))
+		for _, f := range r.Fields {
+			f = strings.TrimSpace(strings.ToUpper(f))
+			if _, ok := requirementNames[f]; !ok {
+				return nil, NewBadInput(fmt.Sprintf("invalid required field %q", f))
+			}
+			if f == RequireBlockedReason && status != "BLOCKED" {
+				return nil, NewBadInput("a blocked reason can only be required for BLOCKED")
+			}
+			if !slices.Contains(fields, f) {
+				fields = append(fields, f)
+			}
+		}
+		if len(fields) > 0 {
+			slices.Sort(fields)
+			out = append(out, StatusRequirement{Status: status, Fields: fields})
+		}
+	}
+	return out, nil
+}
+
+// strictStatus is normalizeStatus without the default for empty values.
+func strictStatus(v string) (string, error) {
+	if strings.TrimSpace(v) == "" {
+		return "", NewBadInput("status is required")
+	}
+	return normalizeStatus(v)
+}
+
+func sortTransitions(ts []StatusTransition) {
+	slices.SortFunc(ts, func(a, b StatusTransition) int {
+		if d := slices.Index(statusOrder, a.From) - slices.Index(statusOrder, b.From); d != 0 {
+			return d
+		}
+		return slices.Index(statusOrder, a.To) - slices.Index(statusOrder, b.To)
+	})
+}
@@ -1,7 +1,6 @@
 package service
 
 import (
-	"encoding/base64"
 	"fmt"
 	"regexp"
 	"strings"
@@ -13,36 +12,6 @@ import (
 
 var colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
 
-type cursor struct {
-	CreatedAt time.Time
-	ID        uuid.UUID
-}
-
-func encodeCursor(createdAt time.Time, id uuid.UUID) string {
-	raw := fmt.Sprintf("%s|%s", createdAt.UTC().Format(time.RFC3339Nano), id.String())
-	return base64.StdEncoding.EncodeToString([]byte(raw))
-}
-
-func decodeCursor(raw string) (cursor, error) {
-	decoded, err := base64.StdEncoding.DecodeString(raw)
-	if err != nil {
-		return cursor{}, NewBadInput("invalid cursor")
-	}
-	parts := strings.Split(string(decoded), "|")
-	if len(parts) != 2 {
-		return cursor{}, NewBadInput("invalid cursor")
-	}
-	t, err := time.Parse(time.RFC3339Nano, parts[0])
-	if err != nil {
-		return cursor{}, NewBadInput("invalid cursor")
-	}
-	id, err := uuid.Parse(parts[1])
-	if err != nil {
-		return cursor{}, NewBadInput("invalid cursor")
-	}
-	return cursor{CreatedAt: t.UTC(), ID: id}, nil
-}
-
 func parseUUID(input string, fieldName string) (uuid.UUID, error) {
 	id, err := uuid.Parse(strings.TrimSpace(input))
 	if err != nil {
@@ -84,10 +53,28 @@ func fromPgTime(v pgtype.Timestamptz) *time.Time {
 	return &t
 }
 
+// PageArgs carries Relay connection arguments. First/After page forward and
+// Last/Before page backward; the two directions cannot be mixed.
+type PageArgs struct {
+	First     *int
+	After     *string
+	Last      *int
+	Before    *string
+	WithTotal bool
+}
+
+type Edge[T any] struct {
+	Cursor string
+	Node   T
+}
+
 type PageResult[T any] struct {
-	Items       []T
-	EndCursor   *string
-	HasNextPage bool
+	Edges           []Edge[T]
+	StartCursor     *string
+	EndCursor       *string
+	HasPreviousPage bool
+	HasNextPage     bool
+	TotalCount      *int
 }
 
 type UpsertMeInput struct {
@@ -101,13 +88,19 @@ type CreateProjectInput struct {
 	Title       string
 	Description *string
 	Color       *string
+	// AutoCompleteParents completes a parent task when its last open subtask
+	// is done and reopens it when a subtask is reopened.
+	AutoCompleteParents bool
 }
 
+// UpdateProjectInput replaces the title, description and color. A nil
+// AutoCompleteParents keeps the current setting.
 type UpdateProjectInput struct {
-	ID          string
-	Title       string
-	Description *string
-	Color       *string
+	ID                  string
+	Title               string
+	Description         *string
+	Color               *string
+	AutoCompleteParents *bool
 }
 
 type CreateLabelInput struct {
@@ -119,7 +112,132 @@ type UpdateLabelInput struct {
 	Name string
 }
 
+// TaskFilter narrows ListTasks. Empty fields do not filter. Each range is
+// half-open: the After/From bound is inclusive and the Before/To bound
+// exclusive.
+type TaskFilter struct {
+	Statuses   []string
+	Priorities []string
+	LabelIDs   []string
+	// MatchAllLabels requires every label in LabelIDs rather than any one.
+	MatchAllLabels bool
+	DueBefore      *time.Time
+	DueAfter       *time.Time
+	StartBefore    *time.Time
+	StartAfter     *time.Time
+	CompletedFrom  *time.Time
+	CompletedTo    *time.Time
+	// HasDueDate keeps only tasks with (true) or without (false) a due date.
+	HasDueDate   *bool
+	UpdatedSince *time.Time
+	// TitleContains is a case-insensitive substring of the title.
+	TitleContains *string
+	SectionID     *string
+	StatusID      *string
+	// CustomFields keeps tasks matching every entry.
+	CustomFields []CustomFieldFilter
+	// SortByCustomField orders by a custom field instead of newest first.
+	SortByCustomField *CustomFieldSort
+}
+
+// CustomFieldFilter matches tasks whose value for FieldID equals Value, read
+// the way the field type reads task values. A nil Value matches tasks without
+// a value.
+type CustomFieldFilter struct {
+	FieldID string
+	Value   *string
+}
+
+// CustomFieldSort orders tasks by their value for FieldID. Tasks without a
+// value come last either way; ties fall back to creation time in the same
+// direction.
+type CustomFieldSort struct {
+	FieldID    string
+	Descending bool
+}
+
+type CreateProjectSectionInput struct {
+	ProjectID string
+	Name      string
+}
+
+// UpdateProjectSectionInput leaves nil fields unchanged. Position is clamped
+// to the project's sections; the others shift to make room.
+type UpdateProjectSectionInput struct {
+	ID       string
+	Name     *string
+	Position *int
+}
+
+// CreateProjectStatusInput adds a workflow status. Category is one of the
+// task statuses (TODO, IN_PROGRESS, BLOCKED, DONE) and is what Task.status
+// reports for tasks in it. A nil WipLimit means no limit.
+type CreateProjectStatusInput struct {
+	ProjectID string
+	Name      string
+	Category  string
+	Color     *string
+	WipLimit  *int
+}
+
+// UpdateProjectStatusInput leaves nil fields unchanged. ClearColor and
+// ClearWipLimit take precedence over Color and WipLimit. Changing Category
+// re-categorises the tasks already in the status.
+type UpdateProjectStatusInput struct {
+	ID            string
+	Name          *string
+	Category      *string
+	Color         *string
+	ClearColor    bool
+	WipLimit      *int
+	ClearWipLimit bool
+	Position      *int
+}
+
+// CreateCustomFieldInput adds a custom field. Type is one of TEXT, NUMBER,
+// DATE, SELECT, URL or CHECKBOX; Options are the choices of a SELECT field.
+// Required fields must be set on new tasks and cannot be cleared.
+type CreateCustomFieldInput struct {
+	ProjectID string
+	Name      string
+	Type      string
+	Options   []string
+	Required  bool
+}
+
+// UpdateCustomFieldInput leaves nil fields unchanged. A field's type is fixed
+// once created. Options replaces the choices of a SELECT field and cannot
+// drop a choice live tasks still use.
+type UpdateCustomFieldInput struct {
+	ID       string
+	Name     *string
+	Options  []string
+	Required *bool
+	Position *int
+}
+
+// CustomFieldValueInput sets a task's value for one field. A nil or blank
+// Value clears it.
+type CustomFieldValueInput struct {
+	FieldID string
+	Value   *string
+}
+
+type CreateSavedFilterInput struct {
+	Name       string
+	Expression string
+}
+
+type UpdateSavedFilterInput struct {
+	ID         string
+	Name       string
+	Expression string
+}
+
 type CreateTaskInput struct {
+	// ID is normally left empty. CalDAV clients name new tasks themselves, and
+	// a task created with an ID that is already taken fails with CONFLICT.
+	ID           *string
 	ProjectID    string
 	ParentTaskID *string
 	Title        string
@@ -129,17 +247,90 @@ type CreateTaskInput struct {
 	StartAt      *time.Time
 	DueAt        *time.Time
 	LabelIDs     []string
+	// Recurrence is an RRULE value such as "FREQ=WEEKLY;BYDAY=MO".
+	Recurrence *string
+	// SectionID must be a section of the same project. Subtasks have no
+	// section of their own.
+	SectionID *string
+	// StatusID picks a workflow status of the project and overrides Status.
+	// Without it the task goes to the first status in Status's category.
+	StatusID *string
+	// BlockedReason is kept only while the task is BLOCKED.
+	BlockedReason *string
+	CustomFields  []CustomFieldValueInput
+	// EstimateMinutes is the expected effort; capacity planning spreads it
+	// over the task's schedule.
+	EstimateMinutes *int
 }
 
+// UpdateTaskInput leaves nil fields unchanged. ClearStartAt, ClearDueAt,
+// ClearSection and ClearEstimate remove the value and take precedence over the
+// matching field.
+// StatusID overrides Status; a Status alone moves the task to the first
+// workflow status of that category unless it is already in one. Moves between
+// categories must be allowed by the project's transition policy. BlockedReason
+// is cleared when the task leaves BLOCKED. When
+// ExpectedUpdatedAt is set the update fails with CONFLICT if the task has
+// changed since then.
 type UpdateTaskInput struct {
-	ID          string
-	Title       *string
-	Description *string
-	Status      *string
-	Priority    *string
-	StartAt     *time.Time
-	DueAt       *time.Time
-	LabelIDs    []string
+	ID            string
+	Title         *string
+	Description   *string
+	Status        *string
+	Priority      *string
+	StartAt       *time.Time
+	DueAt         *time.Time
+	ClearStartAt  bool
+	ClearDueAt    bool
+	SectionID     *string
+	ClearSection  bool
+	StatusID      *string
+	BlockedReason *string
+	LabelIDs      []string
+	// CustomFields sets or clears the listed fields only.
+	CustomFields      []CustomFieldValueInput
+	EstimateMinutes   *int
+	ClearEstimate     bool
+	ExpectedUpdatedAt *time.Time
+	// Recurrence replaces the RRULE; an empty rule, like ClearRecurrence,
+	// stops the task repeating.
+	Recurrence      *string
+	ClearRecurrence bool
+}
+
+// CreateTimeEntryInput records finished work on a task; use StartTimer for
+// time still running.
+type CreateTimeEntryInput struct {
+	TaskID    string
+	StartedAt time.Time
+	EndedAt   time.Time
+	Note      *string
+}
+
+// UpdateTimeEntryInput leaves nil fields unchanged. Setting EndedAt on a
+// running timer stops it.
+type UpdateTimeEntryInput struct {
+	ID        string
+	TaskID    *string
+	StartedAt *time.Time
+	EndedAt   *time.Time
+	Note      *string
+}
+
+type CreateWebhookSubscriptionInput struct {
+	URL        string
+	Secret     string
+	EventTypes []string
+}
+
+// UpdateWebhookSubscriptionInput leaves nil fields unchanged. An empty,
+// non-nil EventTypes subscribes to every event.
+type UpdateWebhookSubscriptionInput struct {
+	ID         string
+	URL        *string
+	Secret     *string
+	EventTypes []string
+	Active     *bool
 }
 
 type DeleteResult struct {
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// WebhookPayloadVersion is sent as "version" in every webhook body. Fields
+// may be added within a version; renaming, retyping or removing one needs a
+// new version.
+const WebhookPayloadVersion = 1
+
+// webhookPayload is implemented by the types below, the "data" of webhook
+// events. Events are mapped into them so that database rows never become
+// part of the webhook contract.
+type webhookPayload interface {
+	webhookPayload()
+}
+
+// TaskPayload is the data of task.created and task.updated.
+type TaskPayload struct {
+	ID              string     `json:"id"`
+	ProjectID       string     `json:"projectId"`
+	ParentTaskID    *string    `json:"parentTaskId"`
+	SectionID       *string    `json:"sectionId"`
+	StatusID        *string    `json:"statusId"`
+	Title           string     `json:"title"`
+	Description     *string    `json:"description"`
+	Status          string     `json:"status"`
+	BlockedReason   *string    `json:"blockedReason"`
+	Priority        string     `json:"priority"`
+	EstimateMinutes *int32     `json:"estimateMinutes"`
+	StartAt         *time.Time `json:"startAt"`
+	DueAt           *time.Time `json:"dueAt"`
+	CompletedAt     *time.Time `json:"completedAt"`
+	CreatedAt       time.Time  `json:"createdAt"`
+	UpdatedAt       time.Time  `json:"updatedAt"`
+}
+
+// ProjectPayload is the data of project.created and project.updated.
+type ProjectPayload struct {
+	ID                  string     `json:"id"`
+	Title               string     `json:"title"`
+	Description         *string    `json:"description"`
+	Color               *string    `json:"color"`
+	AutoCompleteParents bool       `json:"autoCompleteParents"`
+	ArchivedAt          *time.Time `json:"archivedAt"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+}
+
+// LabelPayload is the data of label.created and label.updated.
+type LabelPayload struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// DeletedPayload is the data of the *.deleted events.
+type DeletedPayload struct {
+	ID        string    `json:"id"`
+	DeletedAt time.Time `json:"deletedAt"`
+}
+
+func (TaskPayload) webhookPayload()    {}
+func (ProjectPayload) webhookPayload() {}
+func (LabelPayload) webhookPayload()   {}
+func (DeletedPayload) webhookPayload() {}
+
+func taskPayload(t sqlc.Task) TaskPayload {
+	return TaskPayload{
+		ID:              fromPgUUID(t.ID).String(),
+		ProjectID:       fromPgUUID(t.ProjectID).String(),
+		ParentTaskID:    payloadID(t.ParentTaskID),
+		SectionID:       payloadID(t.SectionID),
+		StatusID:        payloadID(t.StatusID),
+		Title:           t.Title,
+		Description:     t.Description,
+		Status:          t.Status,
+		BlockedReason:   t.BlockedReason,
+		Priority:        t.Priority,
+		EstimateMinutes: t.EstimateMinutes,
+		StartAt:         fromPgTime(t.StartAt),
+		DueAt:           fromPgTime(t.DueAt),
+		CompletedAt:     fromPgTime(t.CompletedAt),
+		CreatedAt:       t.CreatedAt.Time.UTC(),
+		UpdatedAt:       t.UpdatedAt.Time.UTC(),
+	}
+}
+
+func projectPayload(p sqlc.Project) ProjectPayload {
+	return ProjectPayload{
+		ID:                  fromPgUUID(p.ID).String(),
+		Title:               p.Title,
+		Description:         p.Description,
+		Color:               p.Color,
+		AutoCompleteParents: p.AutoCompleteParents,
+		ArchivedAt:          fromPgTime(p.ArchivedAt),
+		CreatedAt:           p.CreatedAt.Time.UTC(),
+		UpdatedAt:           p.UpdatedAt.Time.UTC(),
+	}
+}
+
+func labelPayload(l sqlc.Label) LabelPayload {
+	return LabelPayload{
+		ID:        fromPgUUID(l.ID).String(),
+		Name:      l.Name,
+		CreatedAt: l.CreatedAt.Time.UTC(),
+		UpdatedAt: l.UpdatedAt.Time.UTC(),
+	}
+}
+
+func deletedPayload(id pgtype.UUID, deletedAt pgtype.Timestamptz) DeletedPayload {
+	return DeletedPayload{ID: fromPgUUID(id).String(), DeletedAt: deletedAt.Time.UTC()}
+}
+
+func payloadID(v pgtype.UUID) *string {
+	if !v.Valid {
+		return nil
+	}
+	id := fromPgUUID(v).String()
+	return &id
+}
@@ -0,0 +1,483 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"strings"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Webhook event types. They are stored as-is in outbox_events.event_type and
+// sent in the X-Zenlist-Event header.
+const (
+	EventTaskCreated    = "task.created"
+	EventTaskUpdated    = "task.updated"
+	EventTaskDeleted    = "task.deleted"
+	EventProjectCreated = "project.created"
+	EventProjectUpdated = "project.updated"
+	EventProjectDeleted = "project.deleted"
+	EventLabelCreated   = "label.created"
+	EventLabelUpdated   = "label.updated"
+	EventLabelDeleted   = "label.deleted"
+)
+
+var validEventTypes = map[string]struct{}{
+	EventTaskCreated:    {},
+	EventTaskUpdated:    {},
+	EventTaskDeleted:    {},
+	EventProjectCreated: {},
+	EventProjectUpdated: {},
+	EventProjectDeleted: {},
+	EventLabelCreated:   {},
+	EventLabelUpdated:   {},
+	EventLabelDeleted:   {},
+}
+
+// Webhook delivery states. PENDING deliveries are retried until they succeed
+// or run out of attempts and move to DEAD. Deleting or deactivating a
+// subscription also moves its PENDING deliveries to DEAD.
+const (
+	DeliveryPending   = "PENDING"
+	DeliveryDelivered = "DELIVERED"
+	DeliveryDead      = "DEAD"
+)
+
+var validDeliveryStatuses = map[string]struct{}{
+	DeliveryPending:   {},
+	DeliveryDelivered: {},
+	DeliveryDead:      {},
+}
+
+const minWebhookSecretLen = 16
+
+// nonPublicPrefixes are reserved IPv4 ranges that netip.Addr has no
+// predicate for.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+}
+
+// IsPublicAddr reports whether webhooks may be sent to addr. Loopback,
+// link-local, private and other reserved addresses are refused so that a
+// subscription cannot point the worker at internal services.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, p := range nonPublicPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// recordEvent appends an event to the outbox. It must run on the transaction
+// that makes the change so the event is published if and only if it commits.
+func (s *Service) recordEvent(ctx context.Context, q *sqlc.Queries, userID uuid.UUID, eventType string, data webhookPayload) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return NewInternal("failed to encode event", err)
+	}
+	if err := q.InsertOutboxEvent(ctx, sqlc.InsertOutboxEventParams{
+		UserID:    toPgUUID(userID),
+		EventType: eventType,
+		Payload:   payload,
+	}); err != nil {
+		return s.wrapDBError(err, "failed to record event")
+	}
+	return nil
+}
+
+// recordTasksDeleted records task.deleted for tasks removed along with their
+// parent or project, which subscribers would otherwise never hear about.
+func (s *Service) recordTasksDeleted(ctx context.Context, q *sqlc.Queries, userID uuid.UUID, tasks []sqlc.Task) error {
+	for _, t := range tasks {
+		if err := s.recordEvent(ctx, q, userID, EventTaskDeleted, deletedPayload(t.ID, t.DeletedAt)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Service) ListWebhookSubscriptions(ctx context.Context) ([]sqlc.WebhookSubscription, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	subs, err := s.store.Queries().ListWebhookSubscriptions(tctx, toPgUUID(uid))
+	if err != nil {
+		return nil, s.wrapDBError(err, "failed to list webhook subscriptions")
+	}
+	return subs, nil
+}
+
+// WebhookSubscription returns a webhook subscription, or nil when it does not exist.
+func (s *Service) WebhookSubscription(ctx context.Context, id string) (*sqlc.WebhookSubscription, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	subID, err := parseUUID(id, "subscription id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	sub, err := s.store.Queries().GetWebhookSubscriptionByID(tctx, sqlc.GetWebhookSubscriptionByIDParams{ID: toPgUUID(subID), UserID: toPgUUID(uid)})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, s.wrapDBError(err, "failed to fetch subscription")
+	}
+	return &sub, nil
+}
+
+func (s *Service) CreateWebhookSubscription(ctx context.Context, in CreateWebhookSubscriptionInput) (sqlc.WebhookSubscription, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.WebhookSubscription{}, err
+	}
+
+	target, err := normalizeWebhookURL(in.URL, s.allowPrivateWebhooks)
+	if err != nil {
+		return sqlc.WebhookSubscription{}, err
+	}
+	secret, err := normalizeWebhookSecret(in.Secret)
+	if err != nil {
+		return sqlc.WebhookSubscription{}, err
+	}
+	eventTypes, err := normalizeFilters(in.EventTypes, normalizeEventType)
+	if err != nil {
+		return sqlc.WebhookSubscription{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	sub, err := s.store.Queries().CreateWebhookSubscription(tctx, sqlc.CreateWebhookSubscriptionParams{
+		UserID:     toPgUUID(uid),
+		Url:        target,
+		Secret:     secret,
+		EventTypes: eventTypes,
+	})
+	if err != nil {
+		return sqlc.WebhookSubscription{}, s.wrapDBError(err, "failed to create webhook subscription")
+	}
+	return sub, nil
+}
+
+func (s *Service) UpdateWebhookSubscription(ctx context.Context, in UpdateWebhookSubscriptionInput) (sqlc.WebhookSubscription, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.WebhookSubscription{}, err
+	}
+
+	id, err := parseUUID(in.ID, "webhook subscription id")
+	if err != nil {
+		return sqlc.WebhookSubscription{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var updated sqlc.WebhookSubscription
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		existing, err := q.GetWebhookSubscriptionByID(tctx, sqlc.GetWebhookSubscriptionByIDParams{
+			ID:     toPgUUID(id),
+			UserID: toPgUUID(uid),
+		})
+		if err != nil {
+			return s.wrapDBError(err, "webhook subscription not found")
+		}
+
+		params := sqlc.UpdateWebhookSubscriptionParams{
+			ID:         existing.ID,
+			UserID:     existing.UserID,
+			Url:        existing.Url,
+			Secret:     existing.Secret,
+			EventTypes: existing.EventTypes,
+			Active:     existing.Active,
+		}
+		if in.URL != nil {
+			if params.Url, err = normalizeWebhookURL(*in.URL, s.allowPrivateWebhooks); err != nil {
+				return err
+			}
+		}
+		if in.Secret != nil {
+			if params.Secret, err = normalizeWebhookSecret(*in.Secret); err != nil {
+				return err
+			}
+		}
+		if in.EventTypes != nil {
+			if params.EventTypes, err = normalizeFilters(in.EventTypes, normalizeEventType); err != nil {
+				return err
+			}
+		}
+		if in.Active != nil {
+			params.Active = *in.Active
+		}
+
+		updated, err = q.UpdateWebhookSubscription(tctx, params)
+		if err != nil {
+			return s.wrapDBError(err, "failed to update webhook subscription")
+		}
+		if existing.Active && !updated.Active {
+			return s.cancelPendingDeliveries(tctx, q, updated.ID, uid, "subscription deactivated")
+		}
+		return nil
+	})
+	if err != nil {
+		return sqlc.WebhookSubscription{}, err
+	}
+	return updated, nil
+}
+
+func (s *Service) DeleteWebhookSubscription(ctx context.Context, id string) (DeleteResult, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	subID, err := parseUUID(id, "webhook subscription id")
+	if err != nil {
+		return DeleteResult{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var result DeleteResult
+	err = s.store.WithTx(tctx, func(q *sqlc.Queries) error {
+		deleted, err := q.SoftDeleteWebhookSubscription(tctx, sqlc.SoftDeleteWebhookSubscriptionParams{
+			ID:     toPgUUID(subID),
+			UserID: toPgUUID(uid),
+		})
+		if err != nil {
+			return s.wrapDBError(err, "webhook subscription not found")
+		}
+		result = DeleteResult{ID: fromPgUUID(deleted.ID), DeletedAt: deleted.DeletedAt.Time.UTC()}
+		return s.cancelPendingDeliveries(tctx, q, deleted.ID, uid, "subscription deleted")
+	})
+	if err != nil {
+		return DeleteResult{}, err
+	}
+	return result, nil
+}
+
+// cancelPendingDeliveries dead-letters the deliveries a subscription still
+// has queued, so they are not sent once it is deleted or deactivated.
+func (s *Service) cancelPendingDeliveries(ctx context.Context, q *sqlc.Queries, subscriptionID pgtype.UUID, uid uuid.UUID, reason string) error {
+	if _, err := q.CancelPendingWebhookDeliveries(ctx, sqlc.CancelPendingWebhookDeliveriesParams{
+		Reason:         reason,
+		SubscriptionID: subscriptionID,
+		UserID:         toPgUUID(uid),
+	}); err != nil {
+		return s.wrapDBError(err, "failed to cancel pending webhook deliveries")
+	}
+	return nil
+}
+
+func (s *Service) ListWebhookDeliveries(ctx context.Context, subscriptionID *string, statuses []string, args PageArgs) (PageResult[sqlc.WebhookDelivery], error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return PageResult[sqlc.WebhookDelivery]{}, err
+	}
+
+	filterSub := false
+	subPg := pgtype.UUID{Valid: false}
+	subFilter := []string{}
+	if subscriptionID != nil && strings.TrimSpace(*subscriptionID) != "" {
+		id, err := parseUUID(*subscriptionID, "webhook subscription id")
+		if err != nil {
+			return PageResult[sqlc.WebhookDelivery]{}, err
+		}
+		filterSub = true
+		subPg = toPgUUID(id)
+		subFilter = []string{id.String()}
+	}
+
+	normalizedStatuses, err := normalizeFilters(statuses, normalizeDeliveryStatus)
+	if err != nil {
+		return PageResult[sqlc.WebhookDelivery]{}, err
+	}
+
+	fingerprint := queryFingerprint("webhookDeliveries", sortCreatedDesc, map[string][]string{
+		"subscription": subFilter,
+		"statuses":     normalizedStatuses,
+	})
+	page, err := s.resolvePage(args, fingerprint, 20, 100)
+	if err != nil {
+		return PageResult[sqlc.WebhookDelivery]{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	var rows []sqlc.WebhookDelivery
+	if page.backward {
+		rows, err = s.store.Queries().ListWebhookDeliveriesBefore(tctx, sqlc.ListWebhookDeliveriesBeforeParams{
+			UserID:  toPgUUID(uid),
+			Column2: filterSub,
+			Column3: subPg,
+			Column4: normalizedStatuses,
+			Column5: page.useCursor,
+			Column6: page.cursorTime,
+			Column7: page.cursorID,
+			Limit:   int32(page.limit + 1),
+		})
+	} else {
+		rows, err = s.store.Queries().ListWebhookDeliveries(tctx, sqlc.ListWebhookDeliveriesParams{
+			UserID:  toPgUUID(uid),
+			Column2: filterSub,
+			Column3: subPg,
+			Column4: normalizedStatuses,
+			Column5: page.useCursor,
+			Column6: page.cursorTime,
+			Column7: page.cursorID,
+			Limit:   int32(page.limit + 1),
+		})
+	}
+	if err != nil {
+		return PageResult[sqlc.WebhookDelivery]{}, s.wrapDBError(err, "failed to list webhook deliveries")
+	}
+
+	result := paginateRows(rows, page, func(d sqlc.WebhookDelivery) string {
+		return s.cursors.encode(fingerprint, d.CreatedAt.Time, fromPgUUID(d.ID))
+	})
+	if args.WithTotal {
+		total, err := s.store.Queries().CountWebhookDeliveries(tctx, sqlc.CountWebhookDeliveriesParams{
+			UserID:  toPgUUID(uid),
+			Column2: filterSub,
+			Column3: subPg,
+			Column4: normalizedStatuses,
+		})
+		if err != nil {
+			return PageResult[sqlc.WebhookDelivery]{}, s.wrapDBError(err, "failed to count webhook deliveries")
+		}
+		result.TotalCount = intPtr(total)
+	}
+	return result, nil
+}
+
+// WebhookDelivery returns a webhook delivery, or nil when it does not exist.
+func (s *Service) WebhookDelivery(ctx context.Context, id string) (*sqlc.WebhookDelivery, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryID, err := parseUUID(id, "delivery id")
+	if err != nil {
+		return nil, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	delivery, err := s.store.Queries().GetWebhookDeliveryByID(tctx, sqlc.GetWebhookDeliveryByIDParams{ID: toPgUUID(deliveryID), UserID: toPgUUID(uid)})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, s.wrapDBError(err, "failed to fetch delivery")
+	}
+	return &delivery, nil
+}
+
+// RetryWebhookDelivery moves a dead-lettered delivery of an active
+// subscription back to PENDING with a fresh attempt budget.
+func (s *Service) RetryWebhookDelivery(ctx context.Context, id string) (sqlc.WebhookDelivery, error) {
+	uid, err := s.userID(ctx)
+	if err != nil {
+		return sqlc.WebhookDelivery{}, err
+	}
+
+	deliveryID, err := parseUUID(id, "webhook delivery id")
+	if err != nil {
+		return sqlc.WebhookDelivery{}, err
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, s.queryTimeout)
+	defer cancel()
+
+	delivery, err := s.store.Queries().RetryWebhookDelivery(tctx, sqlc.RetryWebhookDeliveryParams{
+		ID:     toPgUUID(deliveryID),
+		UserID: toPgUUID(uid),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return sqlc.WebhookDelivery{}, NewNotFound("dead-lettered webhook delivery not found")
+		}
+		return sqlc.WebhookDelivery{}, s.wrapDBError(err, "failed to retry webhook delivery")
+	}
+	return delivery, nil
+}
+
+// normalizeWebhookURL validates a subscription URL. Unless allowPrivate is
+// set, localhost and non-public IP literals are refused; hostnames are checked
+// again by the worker once resolved, as DNS can change after subscribing.
+func normalizeWebhookURL(raw string, allowPrivate bool) (string, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", NewBadInput("webhook url must be an absolute http or https URL")
+	}
+	if u.User != nil {
+		return "", NewBadInput("webhook url must not contain credentials")
+	}
+	if !allowPrivate {
+		host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+		if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+			return "", NewBadInput("webhook url must not point at localhost")
+		}
+		if addr, err := netip.ParseAddr(host); err == nil && !IsPublicAddr(addr) {
+			return "", NewBadInput("webhook url must not point at a private or reserved address")
+		}
+	}
+	return u.String(), nil
+}
+
+func normalizeWebhookSecret(secret string) (string, error) {
+	secret = strings.TrimSpace(secret)
+	if len(secret) < minWebhookSecretLen {
+		return "", NewBadInput(fmt.Sprintf("webhook secret must be at least %d characters", minWebhookSecretLen))
+	}
+	return secret, nil
+}
+
+func normalizeEventType(v string) (string, error) {
+	v = strings.TrimSpace(strings.ToLower(v))
+	if _, ok := validEventTypes[v]; !ok {
+		return "", NewBadInput(fmt.Sprintf("invalid webhook event type %q", v))
+	}
+	return v, nil
+}
+
+func normalizeDeliveryStatus(v string) (string, error) {
+	v = strings.TrimSpace(strings.ToUpper(v))
+	if _, ok := validDeliveryStatuses[v]; !ok {
+		return "", NewBadInput(fmt.Sprintf("invalid webhook delivery status %q", v))
+	}
+	return v, nil
+}
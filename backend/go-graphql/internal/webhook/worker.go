@@ -0,0 +1,308 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/repo"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	platformlogger "github.com/faizp/zenlist/backend/go-graphql/internal/platform/logger"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	batchSize    = 32
+	concurrency  = 8
+	baseBackoff  = 30 * time.Second
+	maxBackoff   = 6 * time.Hour
+	maxErrorLen  = 500
+	maxBodyDrain = 64 << 10
+)
+
+// Headers sent with every delivery. The signature is
+// "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)).
+const (
+	HeaderEvent     = "X-Zenlist-Event"
+	HeaderDelivery  = "X-Zenlist-Delivery"
+	HeaderTimestamp = "X-Zenlist-Timestamp"
+	HeaderSignature = "X-Zenlist-Signature"
+)
+
+type Config struct {
+	PollInterval   time.Duration
+	RequestTimeout time.Duration
+	MaxAttempts    int
+	// AllowPrivate lets deliveries reach loopback and private addresses.
+	AllowPrivate bool
+}
+
+// Worker moves outbox events into per-subscription deliveries and sends them.
+// Several workers may run against the same database; rows are claimed with
+// SKIP LOCKED and leased, so each delivery is attempted by one worker at a time.
+type Worker struct {
+	store  *repo.Store
+	cfg    Config
+	client *http.Client
+	log    *platformlogger.Logger
+}
+
+func NewWorker(store *repo.Store, cfg Config, log *platformlogger.Logger) *Worker {
+	dialer := &net.Dialer{Timeout: cfg.RequestTimeout}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if !cfg.AllowPrivate {
+		dialer.Control = refuseNonPublic
+		// A proxy would dial the target itself, past the address check.
+		transport.Proxy = nil
+	}
+	transport.DialContext = dialer.DialContext
+
+	return &Worker{
+		store: store,
+		cfg:   cfg,
+		client: &http.Client{
+			Timeout:   cfg.RequestTimeout,
+			Transport: transport,
+			// A redirect is reported as a failed attempt rather than followed, so a
+			// subscription cannot be used to reach a different host.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		log: log,
+	}
+}
+
+// Run polls until ctx is cancelled.
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := w.dispatch(ctx); err != nil && ctx.Err() == nil {
+			w.log.Error("webhook_dispatch_failed", "error", err)
+		}
+		if err := w.deliver(ctx); err != nil && ctx.Err() == nil {
+			w.log.Error("webhook_deliver_failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch fans pending outbox events out into one delivery per matching
+// subscription and marks them dispatched in the same transaction.
+func (w *Worker) dispatch(ctx context.Context) error {
+	return w.store.WithTx(ctx, func(q *sqlc.Queries) error {
+		events, err := q.ClaimOutboxEvents(ctx, batchSize)
+		if err != nil {
+			return fmt.Errorf("claim outbox events: %w", err)
+		}
+		for _, ev := range events {
+			if _, err := q.EnqueueWebhookDeliveries(ctx, sqlc.EnqueueWebhookDeliveriesParams{
+				EventID:   ev.ID,
+				EventType: ev.EventType,
+				UserID:    ev.UserID,
+			}); err != nil {
+				return fmt.Errorf("enqueue deliveries: %w", err)
+			}
+			if err := q.MarkOutboxEventDispatched(ctx, ev.ID); err != nil {
+				return fmt.Errorf("mark event dispatched: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (w *Worker) deliver(ctx context.Context) error {
+	// The lease covers the worst case of every request in the batch timing out.
+	lease := w.cfg.RequestTimeout * time.Duration(batchSize/concurrency+1)
+	due, err := w.store.Queries().ClaimDueWebhookDeliveries(ctx, sqlc.ClaimDueWebhookDeliveriesParams{
+		Lease:     pgtype.Interval{Microseconds: lease.Microseconds(), Valid: true},
+		BatchSize: batchSize,
+	})
+	if err != nil {
+		return fmt.Errorf("claim deliveries: %w", err)
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, d := range due {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(d sqlc.ClaimDueWebhookDeliveriesRow) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			w.attempt(ctx, d)
+		}(d)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (w *Worker) attempt(ctx context.Context, d sqlc.ClaimDueWebhookDeliveriesRow) {
+	deliveryID := uuid.UUID(d.ID.Bytes).String()
+	statusCode, sendErr := w.send(ctx, d, deliveryID)
+	if ctx.Err() != nil {
+		// Shutting down: leave the lease to expire so the attempt is not counted.
+		return
+	}
+
+	var code *int32
+	if statusCode != 0 {
+		c := int32(statusCode)
+		code = &c
+	}
+
+	var err error
+	if sendErr == nil {
+		err = w.store.Queries().MarkWebhookDeliverySucceeded(ctx, sqlc.MarkWebhookDeliverySucceededParams{
+			ID:             d.ID,
+			LastStatusCode: code,
+		})
+	} else {
+		attempt := int(d.Attempts) + 1
+		status := service.DeliveryPending
+		next := time.Now().Add(jitter(backoff(attempt)))
+		if attempt >= w.cfg.MaxAttempts {
+			status = service.DeliveryDead
+			next = time.Now()
+		}
+		msg := truncate(sendErr.Error(), maxErrorLen)
+		err = w.store.Queries().MarkWebhookDeliveryFailed(ctx, sqlc.MarkWebhookDeliveryFailedParams{
+			ID:             d.ID,
+			Status:         status,
+			NextAttemptAt:  pgtype.Timestamptz{Time: next.UTC(), Valid: true},
+			LastStatusCode: code,
+			LastError:      &msg,
+		})
+		w.log.Info("webhook_delivery_failed",
+			"delivery_id", deliveryID,
+			"attempt", attempt,
+			"status", status,
+			"error", msg,
+		)
+	}
+	if err != nil {
+		w.log.Error("webhook_delivery_update_failed", "delivery_id", deliveryID, "error", err)
+	}
+}
+
+func (w *Worker) send(ctx context.Context, d sqlc.ClaimDueWebhookDeliveriesRow, deliveryID string) (int, error) {
+	body, err := json.Marshal(envelope{
+		ID:        uuid.UUID(d.EventID.Bytes).String(),
+		Type:      d.EventType,
+		Version:   service.WebhookPayloadVersion,
+		CreatedAt: d.EventCreatedAt.Time.UTC(),
+		Data:      json.RawMessage(d.Payload),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("encode payload: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Zenlist-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, d.EventType)
+	req.Header.Set(HeaderDelivery, deliveryID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(d.Secret, timestamp, body))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxBodyDrain))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// refuseNonPublic runs for every address dialled, after DNS resolution, so a
+// hostname that resolves to an internal address is refused even if it did
+// not when the subscription was created.
+func refuseNonPublic(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("parse dialled address: %w", err)
+	}
+	if !service.IsPublicAddr(addr) {
+		return fmt.Errorf("refusing to deliver to non-public address %s", addr)
+	}
+	return nil
+}
+
+type envelope struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Version   int             `json:"version"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Sign returns the X-Zenlist-Signature value for body sent at timestamp.
+// Receivers should recompute it and compare with hmac.Equal.
+func Sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns the delay before retry number attempt (1-based): 30s, 1m,
+// 2m, ... capped at maxBackoff.
+func backoff(attempt int) time.Duration {
+	d := baseBackoff
+	for i := 1; i < attempt; i++ {
+		d *= 2
+		if d >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return d
+}
+
+// jitter spreads retries by up to 10% so failures do not retry in lockstep.
+func jitter(d time.Duration) time.Duration {
+	return d + rand.N(d/10+1)
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
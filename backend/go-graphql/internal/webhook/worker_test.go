@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/faizp/zenlist/backend/go-graphql/internal/db/sqlc"
+	"github.com/faizp/zenlist/backend/go-graphql/internal/service"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 30 * time.Second},
+		{attempt: 2, want: time.Minute},
+		{attempt: 5, want: 8 * time.Minute},
+		{attempt: 12, want: maxBackoff},
+		{attempt: 100, want: maxBackoff},
+	}
+	for _, tc := range tests {
+		if got := backoff(tc.attempt); got != tc.want {
+			t.Fatalf("backoff(%d): got %s want %s", tc.attempt, got, tc.want)
+		}
+	}
+
+	for i := 0; i < 100; i++ {
+		if got := jitter(time.Minute); got < time.Minute || got > time.Minute+6*time.Second {
+			t.Fatalf("jitter out of range: %s", got)
+		}
+	}
+}
+
+func TestSendSignsPayload(t *testing.T) {
+	const secret = "super-secret-value-123"
+	eventID := uuid.New()
+	payload := []byte(`{"id":"abc","title":"Ship it"}`)
+
+	var gotBody []byte
+	var gotHeader http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Clone()
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	w := NewWorker(nil, Config{RequestTimeout: time.Second, AllowPrivate: true}, nil)
+	code, err := w.send(context.Background(), sqlc.ClaimDueWebhookDeliveriesRow{
+		EventType:      "task.created",
+		EventID:        pgtype.UUID{Bytes: eventID, Valid: true},
+		Payload:        payload,
+		EventCreatedAt: pgtype.Timestamptz{Time: time.Now(), Valid: true},
+		Url:            srv.URL,
+		Secret:         secret,
+	}, "delivery-1")
+	if err != nil || code != http.StatusNoContent {
+		t.Fatalf("send: code=%d err=%v", code, err)
+	}
+
+	if gotHeader.Get(HeaderEvent) != "task.created" || gotHeader.Get(HeaderDelivery) != "delivery-1" {
+		t.Fatalf("unexpected headers: %v", gotHeader)
+	}
+	want := Sign(secret, gotHeader.Get(HeaderTimestamp), gotBody)
+	if !hmac.Equal([]byte(gotHeader.Get(HeaderSignature)), []byte(want)) {
+		t.Fatalf("signature mismatch: got %s want %s", gotHeader.Get(HeaderSignature), want)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(gotBody, &env); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if env.ID != eventID.String() || env.Type != "task.created" || env.Version != service.WebhookPayloadVersion || string(env.Data) != string(payload) {
+		t.Fatalf("unexpected envelope: %+v", env)
+	}
+}
+
+func TestSendTreatsRedirectAsFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "http://example.com/", http.StatusFound)
+	}))
+	defer srv.Close()
+
+	w := NewWorker(nil, Config{RequestTimeout: time.Second, AllowPrivate: true}, nil)
+	code, err := w.send(context.Background(), sqlc.ClaimDueWebhookDeliveriesRow{
+		EventType: "task.created",
+		Payload:   []byte(`{}`),
+		Url:       srv.URL,
+		Secret:    "super-secret-value-123",
+	}, "delivery-2")
+	if err == nil || code != http.StatusFound {
+		t.Fatalf("expected failed attempt with 302, got code=%d err=%v", code, err)
+	}
+}
+
+func TestSendRefusesNonPublicAddresses(t *testing.T) {
+	var hit bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	w := NewWorker(nil, Config{RequestTimeout: time.Second}, nil)
+	code, err := w.send(context.Background(), sqlc.ClaimDueWebhookDeliveriesRow{
+		EventType: "task.created",
+		Payload:   []byte(`{}`),
+		Url:       srv.URL,
+		Secret:    "super-secret-value-123",
+	}, "delivery-3")
+	if err == nil || code != 0 || hit {
+		t.Fatalf("expected loopback delivery to be refused, got code=%d err=%v hit=%v", code, err, hit)
+	}
+}
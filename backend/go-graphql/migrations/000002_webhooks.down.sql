@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS webhook_deliveries_user_created_idx;
+DROP INDEX IF EXISTS webhook_deliveries_due_idx;
+DROP TABLE IF EXISTS webhook_deliveries;
+
+DROP INDEX IF EXISTS outbox_events_pending_idx;
+DROP TABLE IF EXISTS outbox_events;
+
+DROP INDEX IF EXISTS webhook_subscriptions_user_created_idx;
+DROP TABLE IF EXISTS webhook_subscriptions;
@@ -0,0 +1,55 @@
+CREATE TABLE webhook_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX webhook_subscriptions_user_created_idx
+ON webhook_subscriptions (user_id, created_at DESC, id DESC)
+WHERE deleted_at IS NULL;
+
+-- Written in the same transaction as the change it describes, then fanned out
+-- into webhook_deliveries by the delivery worker.
+CREATE TABLE outbox_events (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    event_type TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    dispatched_at TIMESTAMPTZ
+);
+
+CREATE INDEX outbox_events_pending_idx
+ON outbox_events (created_at, id)
+WHERE dispatched_at IS NULL;
+
+CREATE TABLE webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id),
+    event_id UUID NOT NULL REFERENCES outbox_events(id),
+    event_type TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'PENDING',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_status_code INTEGER,
+    last_error TEXT,
+    delivered_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT webhook_deliveries_status_check CHECK (status IN ('PENDING', 'DELIVERED', 'DEAD')),
+    CONSTRAINT webhook_deliveries_subscription_event_key UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX webhook_deliveries_due_idx
+ON webhook_deliveries (next_attempt_at)
+WHERE status = 'PENDING';
+
+CREATE INDEX webhook_deliveries_user_created_idx
+ON webhook_deliveries (user_id, created_at DESC, id DESC);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tasks_user_scheduled_idx;
+DROP INDEX IF EXISTS calendar_feeds_user_created_idx;
+DROP TABLE IF EXISTS calendar_feeds;
@@ -0,0 +1,19 @@
+-- Only a SHA-256 hash of each feed token is stored; the token itself is shown
+-- once, when the feed is created.
+CREATE TABLE calendar_feeds (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    project_id UUID REFERENCES projects(id),
+    token_hash BYTEA NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMPTZ
+);
+
+CREATE INDEX calendar_feeds_user_created_idx
+ON calendar_feeds (user_id, created_at DESC, id DESC)
+WHERE revoked_at IS NULL;
+
+CREATE INDEX tasks_user_scheduled_idx
+ON tasks (user_id, project_id)
+WHERE deleted_at IS NULL
+  AND (start_at IS NOT NULL OR due_at IS NOT NULL);
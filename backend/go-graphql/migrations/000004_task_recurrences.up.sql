@@ -0,0 +1,10 @@
+-- Recurrence rules are kept beside tasks rather than as a tasks column so
+-- the many queries that select whole task rows are unaffected. rule is an
+-- RFC 5545 RRULE value such as FREQ=WEEKLY;BYDAY=MO.
+CREATE TABLE task_recurrences (
+    task_id UUID PRIMARY KEY REFERENCES tasks(id),
+    rule TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT task_recurrences_rule_check CHECK (rule ~ '^FREQ=(DAILY|WEEKLY|MONTHLY|YEARLY)(;[A-Z]+=[A-Z0-9,]+)*$')
+);
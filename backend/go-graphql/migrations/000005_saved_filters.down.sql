@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS tasks_user_created_idx;
+DROP TABLE IF EXISTS saved_filters;
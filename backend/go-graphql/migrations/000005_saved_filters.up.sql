@@ -0,0 +1,18 @@
+CREATE TABLE saved_filters (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    name TEXT NOT NULL,
+    expression TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX saved_filters_user_name_active_idx
+ON saved_filters (user_id, LOWER(name))
+WHERE deleted_at IS NULL;
+
+-- tasksByFilter pages over all of a user's tasks, not one project's.
+CREATE INDEX tasks_user_created_idx
+ON tasks (user_id, created_at DESC, id DESC)
+WHERE deleted_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS tasks_title_trgm_idx;
+DROP INDEX IF EXISTS tasks_project_updated_idx;
+DROP INDEX IF EXISTS tasks_project_completed_idx;
+DROP INDEX IF EXISTS tasks_project_start_idx;
+DROP INDEX IF EXISTS tasks_project_due_idx;
@@ -0,0 +1,23 @@
+-- Supports the date, completion and title filters on Query.tasks. pg_trgm is
+-- a trusted extension, so the database owner can create it.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX tasks_project_due_idx
+ON tasks (project_id, due_at)
+WHERE deleted_at IS NULL AND due_at IS NOT NULL;
+
+CREATE INDEX tasks_project_start_idx
+ON tasks (project_id, start_at)
+WHERE deleted_at IS NULL AND start_at IS NOT NULL;
+
+CREATE INDEX tasks_project_completed_idx
+ON tasks (project_id, completed_at)
+WHERE deleted_at IS NULL AND completed_at IS NOT NULL;
+
+CREATE INDEX tasks_project_updated_idx
+ON tasks (project_id, updated_at)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX tasks_title_trgm_idx
+ON tasks USING GIN (title gin_trgm_ops)
+WHERE deleted_at IS NULL;
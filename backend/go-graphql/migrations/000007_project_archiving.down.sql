@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS projects_user_active_created_idx;
+ALTER TABLE projects DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,7 @@
+-- Archived projects stay readable but are hidden from Query.projects by
+-- default and accept no new tasks. Unlike deletion, their tasks are untouched.
+ALTER TABLE projects ADD COLUMN archived_at TIMESTAMPTZ;
+
+CREATE INDEX projects_user_active_created_idx
+ON projects (user_id, created_at DESC, id DESC)
+WHERE deleted_at IS NULL AND archived_at IS NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tasks_section_created_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS section_id;
+DROP TABLE IF EXISTS project_sections;
@@ -0,0 +1,22 @@
+-- Sections group a project's root tasks. position orders sections within a
+-- project and is kept dense (0, 1, 2, ...) by the service.
+CREATE TABLE project_sections (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    name TEXT NOT NULL,
+    position INTEGER NOT NULL CHECK (position >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX project_sections_project_position_idx
+ON project_sections (project_id, position)
+WHERE deleted_at IS NULL;
+
+ALTER TABLE tasks ADD COLUMN section_id UUID REFERENCES project_sections(id);
+
+CREATE INDEX tasks_section_created_idx
+ON tasks (section_id, created_at DESC, id DESC)
+WHERE deleted_at IS NULL AND section_id IS NOT NULL;
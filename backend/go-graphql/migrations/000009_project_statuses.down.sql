@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tasks_status_id_created_idx;
+ALTER TABLE tasks DROP COLUMN IF EXISTS status_id;
+DROP TABLE IF EXISTS project_statuses;
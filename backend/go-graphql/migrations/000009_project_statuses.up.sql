@@ -0,0 +1,53 @@
+-- Workflow statuses are per project. category is one of the fixed task
+-- statuses; tasks.status keeps holding it so filters, feeds and integrations
+-- that only know the four categories keep working.
+CREATE TABLE project_statuses (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    name TEXT NOT NULL,
+    category TEXT NOT NULL,
+    color TEXT,
+    wip_limit INTEGER,
+    position INTEGER NOT NULL CHECK (position >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    CONSTRAINT project_statuses_category_check CHECK (category IN ('TODO', 'IN_PROGRESS', 'BLOCKED', 'DONE')),
+    CONSTRAINT project_statuses_wip_limit_check CHECK (wip_limit IS NULL OR wip_limit > 0)
+);
+
+CREATE INDEX project_statuses_project_position_idx
+ON project_statuses (project_id, position)
+WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX project_statuses_project_name_unique_idx
+ON project_statuses (project_id, lower(name))
+WHERE deleted_at IS NULL;
+
+-- Every existing project gets one status per category, matching the old
+-- fixed workflow.
+INSERT INTO project_statuses (user_id, project_id, name, category, position, deleted_at)
+SELECT p.user_id, p.id, d.name, d.category, d.position, p.deleted_at
+FROM projects p
+CROSS JOIN (
+    VALUES
+        ('To Do', 'TODO', 0),
+        ('In Progress', 'IN_PROGRESS', 1),
+        ('Blocked', 'BLOCKED', 2),
+        ('Done', 'DONE', 3)
+) AS d (name, category, position);
+
+ALTER TABLE tasks ADD COLUMN status_id UUID REFERENCES project_statuses(id);
+
+UPDATE tasks t
+SET status_id = ps.id
+FROM project_statuses ps
+WHERE ps.project_id = t.project_id
+  AND ps.category = t.status;
+
+ALTER TABLE tasks ALTER COLUMN status_id SET NOT NULL;
+
+CREATE INDEX tasks_status_id_created_idx
+ON tasks (status_id, created_at DESC, id DESC)
+WHERE deleted_at IS NULL;
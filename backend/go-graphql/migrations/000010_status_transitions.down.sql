@@ -0,0 +1,3 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS blocked_reason;
+DROP TABLE IF EXISTS project_status_requirements;
+DROP TABLE IF EXISTS project_status_transitions;
@@ -0,0 +1,28 @@
+-- A project's transition policy is expressed on status categories. With no
+-- rows in project_status_transitions every move is allowed; otherwise only
+-- the listed from -> to pairs are (moves within one category always are).
+CREATE TABLE project_status_transitions (
+    user_id UUID NOT NULL REFERENCES users(id),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    from_status TEXT NOT NULL,
+    to_status TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (project_id, from_status, to_status),
+    CONSTRAINT project_status_transitions_from_check CHECK (from_status IN ('TODO', 'IN_PROGRESS', 'BLOCKED', 'DONE')),
+    CONSTRAINT project_status_transitions_to_check CHECK (to_status IN ('TODO', 'IN_PROGRESS', 'BLOCKED', 'DONE')),
+    CONSTRAINT project_status_transitions_distinct_check CHECK (from_status <> to_status)
+);
+
+-- Fields a task must have set when it enters a status category.
+CREATE TABLE project_status_requirements (
+    user_id UUID NOT NULL REFERENCES users(id),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    status TEXT NOT NULL,
+    field TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (project_id, status, field),
+    CONSTRAINT project_status_requirements_status_check CHECK (status IN ('TODO', 'IN_PROGRESS', 'BLOCKED', 'DONE')),
+    CONSTRAINT project_status_requirements_field_check CHECK (field IN ('BLOCKED_REASON', 'DESCRIPTION', 'START_AT', 'DUE_AT'))
+);
+
+ALTER TABLE tasks ADD COLUMN blocked_reason TEXT;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS tasks_parent_status_idx;
+ALTER TABLE projects DROP COLUMN IF EXISTS auto_complete_parents;
@@ -0,0 +1,8 @@
+-- Opt-in: completing a parent's last open subtask completes the parent, and
+-- reopening a subtask reopens a completed parent.
+ALTER TABLE projects ADD COLUMN auto_complete_parents BOOLEAN NOT NULL DEFAULT false;
+
+-- Subtask progress is read for many parents at once, across projects.
+CREATE INDEX tasks_parent_status_idx
+ON tasks (parent_task_id, status)
+WHERE deleted_at IS NULL AND parent_task_id IS NOT NULL;
@@ -0,0 +1,41 @@
+-- Custom fields are per project. field_type fixes how values are validated
+-- and compared; options lists the choices of a SELECT field in order.
+CREATE TABLE custom_fields (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    project_id UUID NOT NULL REFERENCES projects(id),
+    name TEXT NOT NULL,
+    field_type TEXT NOT NULL,
+    options TEXT[] NOT NULL DEFAULT '{}',
+    required BOOLEAN NOT NULL DEFAULT false,
+    position INTEGER NOT NULL CHECK (position >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    CONSTRAINT custom_fields_type_check CHECK (field_type IN ('TEXT', 'NUMBER', 'DATE', 'SELECT', 'URL', 'CHECKBOX'))
+);
+
+CREATE INDEX custom_fields_project_position_idx
+ON custom_fields (project_id, position)
+WHERE deleted_at IS NULL;
+
+CREATE UNIQUE INDEX custom_fields_project_name_unique_idx
+ON custom_fields (project_id, lower(name))
+WHERE deleted_at IS NULL;
+
+-- value holds the canonical text form of every value and is what filters
+-- match. The typed columns are set for the matching field types so sorting
+-- compares numbers and dates rather than text.
+CREATE TABLE task_custom_field_values (
+    task_id UUID NOT NULL REFERENCES tasks(id),
+    field_id UUID NOT NULL REFERENCES custom_fields(id),
+    value TEXT NOT NULL,
+    number_value DOUBLE PRECISION,
+    date_value DATE,
+    bool_value BOOLEAN,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, field_id)
+);
+
+CREATE INDEX task_custom_field_values_field_value_idx
+ON task_custom_field_values (field_id, value);
@@ -0,0 +1,26 @@
+-- A time entry with no ended_at is a running timer; each user has at most
+-- one.
+CREATE TABLE time_entries (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    task_id UUID NOT NULL REFERENCES tasks(id),
+    started_at TIMESTAMPTZ NOT NULL,
+    ended_at TIMESTAMPTZ,
+    note TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    CONSTRAINT time_entries_range_check CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE UNIQUE INDEX time_entries_running_unique_idx
+ON time_entries (user_id)
+WHERE ended_at IS NULL AND deleted_at IS NULL;
+
+CREATE INDEX time_entries_task_idx
+ON time_entries (task_id, started_at DESC)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX time_entries_user_started_idx
+ON time_entries (user_id, started_at)
+WHERE deleted_at IS NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS tasks_user_estimated_schedule_idx;
+DROP TABLE IF EXISTS user_capacity;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_estimate_minutes_check;
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate_minutes;
@@ -0,0 +1,17 @@
+ALTER TABLE tasks ADD COLUMN estimate_minutes INTEGER;
+ALTER TABLE tasks ADD CONSTRAINT tasks_estimate_minutes_check CHECK (estimate_minutes IS NULL OR estimate_minutes > 0);
+
+-- Users without a row work the default week (40 hours, Monday to Friday).
+-- work_days holds ISO weekdays, 1 = Monday.
+CREATE TABLE user_capacity (
+    user_id UUID PRIMARY KEY REFERENCES users(id),
+    weekly_minutes INTEGER NOT NULL CHECK (weekly_minutes >= 0),
+    work_days SMALLINT[] NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT user_capacity_work_days_check CHECK (work_days <@ ARRAY[1, 2, 3, 4, 5, 6, 7]::SMALLINT[])
+);
+
+-- Capacity planning reads open, estimated tasks by schedule.
+CREATE INDEX tasks_user_estimated_schedule_idx
+ON tasks (user_id, COALESCE(start_at, due_at))
+WHERE deleted_at IS NULL AND estimate_minutes IS NOT NULL AND status <> 'DONE';
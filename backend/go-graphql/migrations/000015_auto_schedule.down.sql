@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS tasks_user_unscheduled_idx;
+ALTER TABLE user_capacity DROP CONSTRAINT IF EXISTS user_capacity_workday_check;
+ALTER TABLE user_capacity DROP COLUMN IF EXISTS workday_end;
+ALTER TABLE user_capacity DROP COLUMN IF EXISTS workday_start;
@@ -0,0 +1,11 @@
+-- Working hours are minutes after local midnight; the default is 09:00 to
+-- 17:00.
+ALTER TABLE user_capacity
+    ADD COLUMN workday_start SMALLINT NOT NULL DEFAULT 540,
+    ADD COLUMN workday_end SMALLINT NOT NULL DEFAULT 1020,
+    ADD CONSTRAINT user_capacity_workday_check CHECK (0 <= workday_start AND workday_start < workday_end AND workday_end <= 1440);
+
+-- Auto-scheduling reads open, unscheduled tasks.
+CREATE INDEX tasks_user_unscheduled_idx
+ON tasks (user_id, due_at)
+WHERE deleted_at IS NULL AND start_at IS NULL AND status <> 'DONE';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tasks_user_due_idx;
+DROP INDEX IF EXISTS tasks_user_completed_idx;
+DROP TABLE IF EXISTS task_status_changes;
@@ -0,0 +1,22 @@
+-- One row each time a task enters a status category; it stays there until
+-- its next row. Existing tasks start with their current status, entered when
+-- they were completed or last updated.
+CREATE TABLE task_status_changes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id UUID NOT NULL REFERENCES tasks(id),
+    user_id UUID NOT NULL REFERENCES users(id),
+    status TEXT NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT task_status_changes_status_check CHECK (status IN ('TODO', 'IN_PROGRESS', 'BLOCKED', 'DONE'))
+);
+
+CREATE INDEX task_status_changes_task_changed_idx ON task_status_changes (task_id, changed_at, id);
+CREATE INDEX task_status_changes_user_changed_idx ON task_status_changes (user_id, changed_at);
+
+INSERT INTO task_status_changes (task_id, user_id, status, changed_at)
+SELECT id, user_id, status, CASE WHEN status = 'DONE' THEN COALESCE(completed_at, updated_at) ELSE updated_at END
+FROM tasks;
+
+-- Analytics read completions and due dates per user.
+CREATE INDEX tasks_user_completed_idx ON tasks (user_id, completed_at) WHERE deleted_at IS NULL AND completed_at IS NOT NULL;
+CREATE INDEX tasks_user_due_idx ON tasks (user_id, due_at) WHERE deleted_at IS NULL AND due_at IS NOT NULL;
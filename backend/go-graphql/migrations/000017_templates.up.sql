@@ -0,0 +1,18 @@
+-- A template is a snapshot of a project's tasks (kind PROJECT) or of one task
+-- and its subtasks (kind TASK). content holds the task tree as JSON, with
+-- dates relative to the day the template is instantiated on.
+CREATE TABLE templates (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id),
+    name TEXT NOT NULL,
+    kind TEXT NOT NULL,
+    content JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ,
+    CONSTRAINT templates_kind_check CHECK (kind IN ('PROJECT', 'TASK'))
+);
+
+CREATE UNIQUE INDEX templates_user_name_active_idx
+ON templates (user_id, LOWER(name))
+WHERE deleted_at IS NULL;